@@ -43,7 +43,7 @@ func NewDeleteCommand() *cobra.Command {
 			}
 			namespace := client.Namespace(ctx)
 			for _, identifier := range args {
-				uid, err := resolveUID(ctx, serviceClient, identifier, namespace, forceUID, forceName)
+				uid, err := ResolveUID(ctx, serviceClient, identifier, namespace, forceUID, forceName)
 				if err != nil {
 					return fmt.Errorf("resolve UID: %w", err)
 				}
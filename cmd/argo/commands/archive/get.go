@@ -56,7 +56,7 @@ func NewGetCommand() *cobra.Command {
 			}
 
 			namespace := client.Namespace(ctx)
-			uid, err := resolveUID(ctx, serviceClient, identifier, namespace, forceUID, forceName)
+			uid, err := ResolveUID(ctx, serviceClient, identifier, namespace, forceUID, forceName)
 			if err != nil {
 				return fmt.Errorf("resolve UID: %w", err)
 			}
@@ -132,7 +132,7 @@ func resubmitArchivedWorkflows(ctx context.Context, archiveServiceClient workflo
 	// Add workflows from args - auto-detect UID vs NAME
 	for _, identifier := range args {
 		var uid string
-		uid, err = resolveUID(ctx, archiveServiceClient, identifier, resubmitOpts.namespace, resubmitOpts.forceUID, resubmitOpts.forceName)
+		uid, err = ResolveUID(ctx, archiveServiceClient, identifier, resubmitOpts.namespace, resubmitOpts.forceUID, resubmitOpts.forceName)
 		if err != nil {
 			return fmt.Errorf("resolve UID: %w", err)
 		}
@@ -28,7 +28,8 @@ func isUID(s string, forceUID bool, forceName bool) bool {
 	return uuidRegex.MatchString(s)
 }
 
-func resolveUID(ctx context.Context, serviceClient workflowarchivepkg.ArchivedWorkflowServiceClient, identifier string, namespace string, forceUID bool, forceName bool) (string, error) {
+// ResolveUID returns the UID of the archived workflow identified by the name or UID in identifier
+func ResolveUID(ctx context.Context, serviceClient workflowarchivepkg.ArchivedWorkflowServiceClient, identifier string, namespace string, forceUID bool, forceName bool) (string, error) {
 	if isUID(identifier, forceUID, forceName) {
 		return identifier, nil
 	}
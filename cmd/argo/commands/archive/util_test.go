@@ -174,7 +174,7 @@ func TestResolveUID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			m := &mockArchivedWorkflowServiceClient{}
 			tt.mockSetup(m)
-			got, err := resolveUID(context.Background(), m, tt.identifier, "default", tt.forceUID, tt.forceName)
+			got, err := ResolveUID(context.Background(), m, tt.identifier, "default", tt.forceUID, tt.forceName)
 			if tt.wantErr != "" {
 				assert.ErrorContains(t, err, tt.wantErr)
 			} else {
@@ -0,0 +1,80 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v4/cmd/argo/commands/client"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+func NewGetCommand() *cobra.Command {
+	var archived bool
+	command := &cobra.Command{
+		Use:   "get WORKFLOW NODE_ID ARTIFACT [PATH]",
+		Short: "download an output artifact of a workflow from its repository",
+		Long:  "Download an output artifact of a workflow from its repository to a path, or to stdout if the path is omitted or \"-\". Directories can only be downloaded to a path.",
+		Args:  cobra.RangeArgs(3, 4),
+		Example: `# Download an artifact to a local file:
+  argo artifact get my-wf my-wf-123 main-logs main.log
+
+# Print an artifact of an archived workflow:
+  argo artifact get my-wf my-wf-123 result --archived
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			path := "-"
+			if len(args) == 4 {
+				path = args[3]
+			}
+			wf, err := getWorkflow(ctx, args[0], client.Namespace(ctx), archived)
+			if err != nil {
+				return err
+			}
+			repos, err := newRepositories(wf.Namespace)
+			if err != nil {
+				return err
+			}
+			return getArtifact(ctx, repos, wf, args[1], args[2], path, os.Stdout)
+		},
+	}
+	command.Flags().BoolVar(&archived, "archived", false, "get the workflow, by name or UID, from the workflow archive")
+	return command
+}
+
+// getArtifact loads the output artifact of the node to the path, or writes it to out if the path is "-"
+func getArtifact(ctx context.Context, repos *repositories, wf *wfv1.Workflow, nodeID, artifactName, path string, out io.Writer) error {
+	node, err := wf.Status.Nodes.Get(nodeID)
+	if err != nil {
+		return err
+	}
+	output := node.GetOutputs().GetArtifactByName(artifactName)
+	if output == nil {
+		return fmt.Errorf("node %s has no output artifact named %s", nodeID, artifactName)
+	}
+	if output.Deleted {
+		return fmt.Errorf("artifact %s of node %s has been deleted", artifactName, nodeID)
+	}
+	art, err := repos.locate(ctx, wf, nodeID, *output)
+	if err != nil {
+		return err
+	}
+	driver, err := repos.newDriver(ctx, art)
+	if err != nil {
+		return err
+	}
+	if path != "-" {
+		return driver.Load(ctx, art, path)
+	}
+	stream, err := driver.OpenStream(ctx, art)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	_, err = io.Copy(out, stream)
+	return err
+}
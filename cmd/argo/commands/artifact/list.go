@@ -0,0 +1,125 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v4/cmd/argo/commands/client"
+	argoerrors "github.com/argoproj/argo-workflows/v4/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/util"
+)
+
+// artifactInfo is a row of the artifact list
+type artifactInfo struct {
+	nodeID   string
+	template string
+	name     string
+	key      string
+	size     string
+}
+
+func NewListCommand() *cobra.Command {
+	var flags workflowFlags
+	command := &cobra.Command{
+		Use:     "ls WORKFLOW",
+		Aliases: []string{"list"},
+		Short:   "list the output artifacts of a workflow with their sizes",
+		Args:    cobra.ExactArgs(1),
+		Example: `# List the artifacts of a workflow:
+  argo artifact ls my-wf
+
+# List the artifacts of an archived workflow, by name or UID:
+  argo artifact ls my-wf --archived
+
+# List the artifacts of one template of a workflow:
+  argo artifact ls my-wf --template-name main
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			namespace := client.Namespace(ctx)
+			wf, err := getWorkflow(ctx, args[0], namespace, flags.archived)
+			if err != nil {
+				return err
+			}
+			repos, err := newRepositories(wf.Namespace)
+			if err != nil {
+				return err
+			}
+			infos, err := listArtifacts(ctx, repos, wf, flags.searchQuery())
+			if err != nil {
+				return err
+			}
+			printArtifacts(os.Stdout, infos)
+			return nil
+		},
+	}
+	flags.addFlags(command)
+	return command
+}
+
+// listArtifacts returns the output artifacts of the workflow matching the query. The size is only known if the
+// artifact is a file in a repository which can get its metadata.
+func listArtifacts(ctx context.Context, repos *repositories, wf *wfv1.Workflow, query *wfv1.ArtifactSearchQuery) ([]artifactInfo, error) {
+	var infos []artifactInfo
+	for _, result := range wf.SearchArtifacts(query) {
+		info := artifactInfo{nodeID: result.NodeID, name: result.Name, size: "-"}
+		if node, err := wf.Status.Nodes.Get(result.NodeID); err == nil {
+			info.template = util.GetTemplateFromNode(*node)
+		}
+		art, err := repos.locate(ctx, wf, result.NodeID, result.Artifact)
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate artifact %s of node %s: %w", result.Name, result.NodeID, err)
+		}
+		info.key, _ = art.GetKey()
+		if !art.Deleted {
+			size, err := statSize(ctx, repos, art)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get size of artifact %s of node %s: %w", result.Name, result.NodeID, err)
+			}
+			info.size = size
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].nodeID != infos[j].nodeID {
+			return infos[i].nodeID < infos[j].nodeID
+		}
+		return infos[i].name < infos[j].name
+	})
+	return infos, nil
+}
+
+// statSize returns the human-readable size of the artifact, or "-" if it is a directory or its size cannot be got
+func statSize(ctx context.Context, repos *repositories, art *wfv1.Artifact) (string, error) {
+	driver, err := repos.newDriver(ctx, art)
+	if err != nil {
+		return "", err
+	}
+	info, err := common.Stat(ctx, driver, art)
+	if errors.Is(err, common.ErrStatNotSupported) || argoerrors.IsCode(argoerrors.CodeNotFound, err) {
+		return "-", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return humanize.IBytes(uint64(info.Size)), nil
+}
+
+func printArtifacts(out io.Writer, infos []artifactInfo) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprint(w, "NODE ID\tTEMPLATE\tARTIFACT\tKEY\tSIZE\n")
+	for _, info := range infos {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", info.nodeID, info.template, info.name, info.key, info.size)
+	}
+	_ = w.Flush()
+}
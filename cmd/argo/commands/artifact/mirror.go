@@ -0,0 +1,114 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v4/cmd/argo/commands/client"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+func NewMirrorCommand() *cobra.Command {
+	var (
+		flags   workflowFlags
+		repoRef repositoryFlags
+		dryRun  bool // --dry-run
+	)
+	command := &cobra.Command{
+		Use:   "mirror WORKFLOW",
+		Short: "copy the output artifacts of a workflow to another artifact repository",
+		Long: `Copy the output artifacts of a workflow to another artifact repository, keeping their keys.
+
+The artifact repository is read from a config map in the workflow's namespace, in the same way as a workflow's artifactRepositoryRef, and the credentials of both repositories are read from secrets in that namespace.`,
+		Args: cobra.ExactArgs(1),
+		Example: `# Promote the outputs of a workflow from the staging bucket to the "prod" artifact repository:
+  argo artifact mirror my-wf --repository-key prod
+
+# Copy one artifact of an archived workflow to the repository in another config map:
+  argo artifact mirror my-wf --archived --artifact-name model --repository-config-map backup-repositories
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			wf, err := getWorkflow(ctx, args[0], client.Namespace(ctx), flags.archived)
+			if err != nil {
+				return err
+			}
+			repos, err := newRepositories(wf.Namespace)
+			if err != nil {
+				return err
+			}
+			return mirrorArtifacts(ctx, repos, wf, flags.searchQuery(), repoRef.ref(), dryRun, os.Stdout)
+		},
+	}
+	flags.addFlags(command)
+	repoRef.addFlags(command)
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "print the artifacts which would be copied without copying them")
+	return command
+}
+
+// mirrorArtifacts copies the output artifacts of the workflow matching the query to the same keys in the referenced
+// repository. Artifacts are copied as they are stored, so archived artifacts stay archived.
+func mirrorArtifacts(ctx context.Context, repos *repositories, wf *wfv1.Workflow, query *wfv1.ArtifactSearchQuery, ref *wfv1.ArtifactRepositoryRef, dryRun bool, out io.Writer) error {
+	location, err := repos.resolve(ctx, ref)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp("", "argo-artifact-mirror")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	for i, result := range wf.SearchArtifacts(query) {
+		if result.Deleted {
+			_, _ = fmt.Fprintf(out, "Skipped %s of node %s: deleted\n", result.Name, result.NodeID)
+			continue
+		}
+		if !result.HasKey() {
+			_, _ = fmt.Fprintf(out, "Skipped %s of node %s: not stored in an artifact repository\n", result.Name, result.NodeID)
+			continue
+		}
+		src, err := repos.locate(ctx, wf, result.NodeID, result.Artifact)
+		if err != nil {
+			return fmt.Errorf("failed to locate artifact %s of node %s: %w", result.Name, result.NodeID, err)
+		}
+		key, err := src.GetKey()
+		if err != nil {
+			return err
+		}
+		dst := src.DeepCopy()
+		dst.ArtifactLocation = *location.DeepCopy()
+		if err := dst.SetKey(key); err != nil {
+			return err
+		}
+		if !dryRun {
+			if err := copyArtifact(ctx, repos, src, dst, filepath.Join(tmpDir, strconv.Itoa(i))); err != nil {
+				return fmt.Errorf("failed to copy artifact %s of node %s: %w", result.Name, result.NodeID, err)
+			}
+		}
+		_, _ = fmt.Fprintf(out, "Mirrored %s of node %s to %s\n", result.Name, result.NodeID, key)
+	}
+	return nil
+}
+
+// copyArtifact copies the file or directory of src to dst through the local path
+func copyArtifact(ctx context.Context, repos *repositories, src, dst *wfv1.Artifact, path string) error {
+	srcDriver, err := repos.newDriver(ctx, src)
+	if err != nil {
+		return err
+	}
+	dstDriver, err := repos.newDriver(ctx, dst)
+	if err != nil {
+		return err
+	}
+	if err := srcDriver.Load(ctx, src, path); err != nil {
+		return err
+	}
+	defer os.RemoveAll(path)
+	return dstDriver.Save(ctx, path, dst)
+}
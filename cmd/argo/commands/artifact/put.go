@@ -0,0 +1,61 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v4/cmd/argo/commands/client"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+func NewPutCommand() *cobra.Command {
+	var flags repositoryFlags
+	command := &cobra.Command{
+		Use:   "put PATH KEY",
+		Short: "upload a file or directory to a key in an artifact repository",
+		Args:  cobra.ExactArgs(2),
+		Example: `# Upload a file to the default artifact repository of the namespace:
+  argo artifact put data.csv my-data/data.csv
+
+# Upload a directory to the "prod" artifact repository in the "artifact-repositories" config map:
+  argo artifact put ./models models/v2 --repository-key prod
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			repos, err := newRepositories(client.Namespace(ctx))
+			if err != nil {
+				return err
+			}
+			if err := putArtifact(ctx, repos, flags.ref(), args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Printf("Uploaded %s to %s\n", args[0], args[1])
+			return nil
+		},
+	}
+	flags.addFlags(command)
+	return command
+}
+
+// putArtifact saves the path to the key in the referenced repository
+func putArtifact(ctx context.Context, repos *repositories, ref *wfv1.ArtifactRepositoryRef, path, key string) error {
+	location, err := repos.resolve(ctx, ref)
+	if err != nil {
+		return err
+	}
+	art := &wfv1.Artifact{Name: filepath.Base(path), ArtifactLocation: *location}
+	if err := art.SetKey(key); err != nil {
+		return err
+	}
+	driver, err := repos.newDriver(ctx, art)
+	if err != nil {
+		return err
+	}
+	if err := driver.Save(ctx, path, art); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", path, err)
+	}
+	return nil
+}
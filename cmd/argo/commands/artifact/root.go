@@ -0,0 +1,22 @@
+package artifact
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewArtifactCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "artifact",
+		Short: "manage the artifacts of workflows in their repositories",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	command.AddCommand(NewListCommand())
+	command.AddCommand(NewGetCommand())
+	command.AddCommand(NewPutCommand())
+	command.AddCommand(NewMirrorCommand())
+
+	return command
+}
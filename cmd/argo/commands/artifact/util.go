@@ -0,0 +1,170 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v4/cmd/argo/commands/archive"
+	"github.com/argoproj/argo-workflows/v4/cmd/argo/commands/client"
+	workflowpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	workflowarchivepkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifactrepositories"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/util"
+)
+
+// workflowFlags select a workflow, or an archived workflow, and which of its output artifacts to use
+type workflowFlags struct {
+	archived     bool   // --archived
+	nodeID       string // --node-id
+	templateName string // --template-name
+	artifactName string // --artifact-name
+}
+
+func (f *workflowFlags) addFlags(command *cobra.Command) {
+	command.Flags().BoolVar(&f.archived, "archived", false, "get the workflow, by name or UID, from the workflow archive")
+	command.Flags().StringVar(&f.nodeID, "node-id", "", "only artifacts of the node with this ID")
+	command.Flags().StringVar(&f.templateName, "template-name", "", "only artifacts of nodes of this template")
+	command.Flags().StringVar(&f.artifactName, "artifact-name", "", "only artifacts with this name")
+}
+
+func (f *workflowFlags) searchQuery() *wfv1.ArtifactSearchQuery {
+	return &wfv1.ArtifactSearchQuery{ArtifactName: f.artifactName, TemplateName: f.templateName, NodeId: f.nodeID}
+}
+
+// repositoryFlags reference an artifact repository in a config map, in the same way as a workflow's artifactRepositoryRef
+type repositoryFlags struct {
+	configMap string // --repository-config-map
+	key       string // --repository-key
+}
+
+func (f *repositoryFlags) addFlags(command *cobra.Command) {
+	command.Flags().StringVar(&f.configMap, "repository-config-map", "", "config map of the artifact repository, defaults to \"artifact-repositories\"")
+	command.Flags().StringVar(&f.key, "repository-key", "", "key of the artifact repository in the config map, defaults to the key in its \"workflows.argoproj.io/default-artifact-repository\" annotation")
+}
+
+func (f *repositoryFlags) ref() *wfv1.ArtifactRepositoryRef {
+	return &wfv1.ArtifactRepositoryRef{ConfigMap: f.configMap, Key: f.key}
+}
+
+// getWorkflow gets the workflow from the Argo Server or the cluster, or with archived, the workflow with the name or
+// UID from the workflow archive
+func getWorkflow(ctx context.Context, identifier, namespace string, archived bool) (*wfv1.Workflow, error) {
+	ctx, apiClient, err := client.NewAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !archived {
+		wf, err := apiClient.NewWorkflowServiceClient(ctx).GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: identifier, Namespace: namespace})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workflow: %w", err)
+		}
+		return wf, nil
+	}
+	serviceClient, err := apiClient.NewArchivedWorkflowServiceClient()
+	if err != nil {
+		return nil, err
+	}
+	uid, err := archive.ResolveUID(ctx, serviceClient, identifier, namespace, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("resolve UID: %w", err)
+	}
+	wf, err := serviceClient.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: uid, Namespace: namespace})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived workflow: %w", err)
+	}
+	return wf, nil
+}
+
+// repositories reads artifact repositories and the credentials of their drivers in a namespace. The Argo Server
+// does not serve config maps or secrets, so they are read using the kubeconfig.
+type repositories struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	artifactrepositories.Interface
+}
+
+func newRepositories(namespace string) (*repositories, error) {
+	restConfig, err := client.GetConfig().ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &repositories{kubeClient, namespace, artifactrepositories.New(kubeClient, namespace, nil)}, nil
+}
+
+func (r *repositories) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := r.kubeClient.CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[key]), nil
+}
+
+func (r *repositories) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := r.kubeClient.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[key], nil
+}
+
+// newDriver returns the driver of the artifact, which must have a full location
+func (r *repositories) newDriver(ctx context.Context, art *wfv1.Artifact) (common.ArtifactDriver, error) {
+	return artifacts.NewDriver(ctx, art, r)
+}
+
+// locate returns a copy of the output artifact of the node with its full location. The location is defined by the
+// artifact itself, the archive location of the node's template or the artifact repository of the workflow, in
+// that order, as it is by the artifact server.
+func (r *repositories) locate(ctx context.Context, wf *wfv1.Workflow, nodeID string, art wfv1.Artifact) (*wfv1.Artifact, error) {
+	if art.HasLocation() {
+		return &art, nil
+	}
+	node, err := wf.Status.Nodes.Get(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	var location *wfv1.ArtifactLocation
+	if templateName := util.GetTemplateFromNode(*node); templateName != "" {
+		if tmpl := wf.GetTemplateByName(templateName); tmpl != nil {
+			location = tmpl.ArchiveLocation
+		}
+	}
+	if _, err := location.Get(); err != nil {
+		if wf.Status.ArtifactRepositoryRef == nil {
+			return nil, fmt.Errorf("workflow %s has no artifact repository", wf.Name)
+		}
+		repo, err := r.Get(ctx, wf.Status.ArtifactRepositoryRef)
+		if err != nil {
+			return nil, err
+		}
+		location = repo.ToArtifactLocation()
+	}
+	if err := art.Relocate(location); err != nil {
+		return nil, err
+	}
+	return &art, nil
+}
+
+// resolve returns the location of the referenced artifact repository, looked up in the namespace
+func (r *repositories) resolve(ctx context.Context, ref *wfv1.ArtifactRepositoryRef) (*wfv1.ArtifactLocation, error) {
+	status, err := r.Resolve(ctx, ref, r.namespace)
+	if err != nil {
+		return nil, err
+	}
+	location := status.ArtifactRepository.ToArtifactLocation()
+	if _, err := location.Get(); err != nil {
+		return nil, fmt.Errorf("artifact repository %s#%s has no location: %w", status.GetConfigMapOr("artifact-repositories"), status.Key, err)
+	}
+	return location, nil
+}
@@ -0,0 +1,116 @@
+package artifact
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifactrepositories"
+)
+
+func newTestRepositories() *repositories {
+	kubeClient := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "artifact-repositories",
+			Namespace:   "my-ns",
+			Annotations: map[string]string{"workflows.argoproj.io/default-artifact-repository": "staging"},
+		},
+		Data: map[string]string{
+			"staging": `s3: {bucket: staging, endpoint: minio:9000}`,
+			"prod":    `s3: {bucket: prod, endpoint: minio:9000}`,
+		},
+	})
+	return &repositories{kubeClient, "my-ns", artifactrepositories.New(kubeClient, "my-ns", nil)}
+}
+
+func newTestWorkflow() *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"},
+		Spec: wfv1.WorkflowSpec{Templates: []wfv1.Template{
+			{Name: "main"},
+			{Name: "archived", ArchiveLocation: &wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "archive", Endpoint: "minio:9000"}}}},
+		}},
+		Status: wfv1.WorkflowStatus{
+			ArtifactRepositoryRef: &wfv1.ArtifactRepositoryRefStatus{Namespace: "my-ns", ArtifactRepositoryRef: wfv1.ArtifactRepositoryRef{Key: "staging"}},
+			Nodes: wfv1.Nodes{
+				"my-wf-1": {ID: "my-wf-1", TemplateName: "main", Outputs: &wfv1.Outputs{Artifacts: wfv1.Artifacts{
+					{Name: "result", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-wf/result.tgz"}}},
+					{Name: "raw", ArtifactLocation: wfv1.ArtifactLocation{Raw: &wfv1.RawArtifact{Data: "hello"}}},
+					{Name: "deleted", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-wf/deleted.tgz"}}, Deleted: true},
+				}}},
+				"my-wf-2": {ID: "my-wf-2", TemplateName: "archived", Outputs: &wfv1.Outputs{Artifacts: wfv1.Artifacts{
+					{Name: "model", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-wf/model.tgz"}}},
+				}}},
+			},
+		},
+	}
+}
+
+func TestLocate(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	repos := newTestRepositories()
+	wf := newTestWorkflow()
+
+	t.Run("Repository", func(t *testing.T) {
+		art, err := repos.locate(ctx, wf, "my-wf-1", *wf.Status.Nodes["my-wf-1"].Outputs.GetArtifactByName("result"))
+		require.NoError(t, err)
+		assert.Equal(t, "staging", art.S3.Bucket)
+		assert.Equal(t, "my-wf/result.tgz", art.S3.Key)
+	})
+	t.Run("ArchiveLocation", func(t *testing.T) {
+		art, err := repos.locate(ctx, wf, "my-wf-2", *wf.Status.Nodes["my-wf-2"].Outputs.GetArtifactByName("model"))
+		require.NoError(t, err)
+		assert.Equal(t, "archive", art.S3.Bucket)
+		assert.Equal(t, "my-wf/model.tgz", art.S3.Key)
+	})
+	t.Run("NoRepository", func(t *testing.T) {
+		wf := newTestWorkflow()
+		wf.Status.ArtifactRepositoryRef = nil
+		_, err := repos.locate(ctx, wf, "my-wf-1", *wf.Status.Nodes["my-wf-1"].Outputs.GetArtifactByName("result"))
+		require.EqualError(t, err, "workflow my-wf has no artifact repository")
+	})
+}
+
+func TestResolve(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	repos := newTestRepositories()
+
+	location, err := repos.resolve(ctx, (&repositoryFlags{}).ref())
+	require.NoError(t, err)
+	assert.Equal(t, "staging", location.S3.Bucket)
+
+	location, err = repos.resolve(ctx, (&repositoryFlags{key: "prod"}).ref())
+	require.NoError(t, err)
+	assert.Equal(t, "prod", location.S3.Bucket)
+
+	_, err = repos.resolve(ctx, (&repositoryFlags{configMap: "missing"}).ref())
+	require.Error(t, err)
+}
+
+func TestListArtifacts(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	infos, err := listArtifacts(ctx, newTestRepositories(), newTestWorkflow(), &wfv1.ArtifactSearchQuery{NodeId: "my-wf-1", ArtifactName: "raw"})
+	require.NoError(t, err)
+	assert.Equal(t, []artifactInfo{{nodeID: "my-wf-1", template: "main", name: "raw", size: "-"}}, infos)
+
+	var out bytes.Buffer
+	printArtifacts(&out, infos)
+	assert.Equal(t, "NODE ID   TEMPLATE   ARTIFACT   KEY   SIZE\nmy-wf-1   main       raw              -\n", out.String())
+}
+
+func TestMirrorArtifactsDryRun(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	var out bytes.Buffer
+	err := mirrorArtifacts(ctx, newTestRepositories(), newTestWorkflow(), &wfv1.ArtifactSearchQuery{NodeId: "my-wf-1"}, (&repositoryFlags{key: "prod"}).ref(), true, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Mirrored result of node my-wf-1 to my-wf/result.tgz\n")
+	assert.Contains(t, out.String(), "Skipped deleted of node my-wf-1: deleted\n")
+	assert.Contains(t, out.String(), "Skipped raw of node my-wf-1: not stored in an artifact repository\n")
+}
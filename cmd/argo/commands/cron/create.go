@@ -92,7 +92,7 @@ func CreateCronWorkflows(ctx context.Context, filePaths []string, cliOpts *cliCr
 		if err != nil {
 			return fmt.Errorf("failed to create cron workflow: %w", err)
 		}
-		fmt.Print(getCronWorkflowGet(ctx, created))
+		fmt.Print(getCronWorkflowGet(ctx, created, nil, 1))
 	}
 	return nil
 }
@@ -1,20 +1,37 @@
 package cron
 
 import (
+	"context"
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/argoproj/argo-workflows/v4/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v4/cmd/argo/commands/common"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/cron/exclusions"
 )
 
 func NewGetCommand() *cobra.Command {
-	var output = common.NewPrintWorkflowOutputValue("")
+	var (
+		output   = common.NewPrintWorkflowOutputValue("")
+		nextRuns int
+	)
 
 	command := &cobra.Command{
 		Use:   "get CRON_WORKFLOW...",
 		Short: "display details about a cron workflow",
-		Args:  cobra.MinimumNArgs(1),
+		Example: `# Print the details of a cron workflow, including its next scheduled time:
+  argo cron get my-cron-wf
+
+# Print the next 10 times the cron workflow will run, after its exclusions are applied:
+  argo cron get my-cron-wf --next-runs 10
+`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
 			if err != nil {
@@ -34,12 +51,38 @@ func NewGetCommand() *cobra.Command {
 				if err != nil {
 					return err
 				}
-				printCronWorkflow(ctx, cronWf, output.String())
+				holidays, err := getHolidays(ctx, cronWf)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Unable to read holidays, they are not excluded from the next scheduled times: %v\n", err)
+				}
+				printCronWorkflow(ctx, cronWf, output.String(), holidays, nextRuns)
 			}
 			return nil
 		},
 	}
 
 	command.Flags().VarP(&output, "output", "o", "Output format. "+output.Usage())
+	command.Flags().IntVar(&nextRuns, "next-runs", 1, "Number of next scheduled times to print, after exclusions are applied")
 	return command
 }
+
+// getHolidays reads the dates in the holidays ConfigMap of the CronWorkflow. The Argo Server does not serve ConfigMaps,
+// so it is read using the kubeconfig.
+func getHolidays(ctx context.Context, cronWf *v1alpha1.CronWorkflow) ([]string, error) {
+	if cronWf.Spec.Exclusions == nil || cronWf.Spec.Exclusions.HolidaysConfigMap == nil {
+		return nil, nil
+	}
+	restConfig, err := client.GetConfig().ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := kubeClient.CoreV1().ConfigMaps(cronWf.Namespace).Get(ctx, cronWf.Spec.Exclusions.HolidaysConfigMap.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return exclusions.ParseHolidays(cm.Data)
+}
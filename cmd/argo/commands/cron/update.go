@@ -90,7 +90,7 @@ func updateCronWorkflows(ctx context.Context, filePaths []string, cliOpts *cliUp
 		if err != nil {
 			return fmt.Errorf("failed to update workflow template: %w", err)
 		}
-		fmt.Print(getCronWorkflowGet(ctx, updated))
+		fmt.Print(getCronWorkflowGet(ctx, updated, nil, 1))
 	}
 	return nil
 }
@@ -8,33 +8,46 @@ import (
 	"strings"
 	"time"
 
-	"github.com/robfig/cron/v3"
 	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v4/util/humanize"
 	argoJson "github.com/argoproj/argo-workflows/v4/util/json"
 	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/cron/exclusions"
 	"github.com/argoproj/argo-workflows/v4/workflow/util"
 )
 
-// GetNextRuntime returns the next time the workflow should run in local time. It assumes the workflow-controller is in
-// UTC, but nevertheless returns the time in the local timezone.
+// GetNextRuntime returns the next time the workflow should run in local time, skipping its excluded dates and windows.
+// A workflow without a timezone is assumed to be in the local timezone, as the workflow-controller would be.
 func GetNextRuntime(ctx context.Context, cwf *v1alpha1.CronWorkflow) (time.Time, error) {
-	var nextRunTime time.Time
-	now := time.Now().UTC()
-	for _, schedule := range cwf.Spec.GetSchedulesWithTimezone() {
-		cronSchedule, err := cron.ParseStandard(schedule)
-		if err != nil {
-			return time.Time{}, err
-		}
-		next := cronSchedule.Next(now).Local()
-		if nextRunTime.IsZero() || next.Before(nextRunTime) {
-			nextRunTime = next
-		}
+	next, err := GetNextRuntimes(ctx, cwf, nil, 1)
+	if err != nil || len(next) == 0 {
+		return time.Time{}, err
 	}
+	return next[0], nil
+}
 
-	return nextRunTime, nil
+// GetNextRuntimes returns up to count of the next times the workflow should run in local time, skipping its excluded
+// dates and windows, and the given holidays.
+func GetNextRuntimes(ctx context.Context, cwf *v1alpha1.CronWorkflow, holidays []string, count int) ([]time.Time, error) {
+	cronExclusions, err := exclusions.New(&cwf.Spec, holidays)
+	if err != nil {
+		return nil, err
+	}
+	// schedules without a timezone run in the timezone of the time they are evaluated from
+	location, err := exclusions.Location(&cwf.Spec)
+	if err != nil {
+		return nil, err
+	}
+	next, err := exclusions.NextRuntimes(&cwf.Spec, cronExclusions, time.Now().In(location), count)
+	if err != nil {
+		return nil, err
+	}
+	for i := range next {
+		next[i] = next[i].Local()
+	}
+	return next, nil
 }
 
 func generateCronWorkflows(ctx context.Context, filePaths []string, strict bool) []v1alpha1.CronWorkflow {
@@ -75,7 +88,7 @@ func unmarshalCronWorkflows(ctx context.Context, wfBytes []byte, strict bool) []
 	return nil
 }
 
-func printCronWorkflow(ctx context.Context, wf *v1alpha1.CronWorkflow, outFmt string) {
+func printCronWorkflow(ctx context.Context, wf *v1alpha1.CronWorkflow, outFmt string, holidays []string, nextRuns int) {
 	switch outFmt {
 	case "name":
 		fmt.Println(wf.Name)
@@ -86,13 +99,14 @@ func printCronWorkflow(ctx context.Context, wf *v1alpha1.CronWorkflow, outFmt st
 		outBytes, _ := yaml.Marshal(wf)
 		fmt.Print(string(outBytes))
 	case "wide", "":
-		fmt.Print(getCronWorkflowGet(ctx, wf))
+		fmt.Print(getCronWorkflowGet(ctx, wf, holidays, nextRuns))
 	default:
 		log.Fatalf("Unknown output format: %s", outFmt)
 	}
 }
 
-func getCronWorkflowGet(ctx context.Context, cwf *v1alpha1.CronWorkflow) string {
+// getCronWorkflowGet describes the CronWorkflow, including its next nextRuns scheduled times
+func getCronWorkflowGet(ctx context.Context, cwf *v1alpha1.CronWorkflow, holidays []string, nextRuns int) string {
 	const fmtStr = "%-30s %v\n"
 
 	var out strings.Builder
@@ -114,9 +128,15 @@ func getCronWorkflowGet(ctx context.Context, cwf *v1alpha1.CronWorkflow) string
 		fmt.Fprintf(&out, fmtStr, "LastScheduledTime:", humanize.Timestamp(cwf.Status.LastScheduledTime.Time))
 	}
 
-	next, err := GetNextRuntime(ctx, cwf)
-	if err == nil {
-		fmt.Fprintf(&out, fmtStr, "NextScheduledTime:", humanize.Timestamp(next)+" (assumes workflow-controller is in UTC)")
+	next, err := GetNextRuntimes(ctx, cwf, holidays, max(nextRuns, 1))
+	if err == nil && len(next) > 0 {
+		fmt.Fprintf(&out, fmtStr, "NextScheduledTime:", humanize.Timestamp(next[0])+" (assumes workflow-controller is in UTC)")
+		if nextRuns > 1 {
+			fmt.Fprintf(&out, fmtStr, "NextScheduledTimes:", "")
+			for _, t := range next {
+				fmt.Fprintf(&out, fmtStr, "", t.Format(time.RFC3339))
+			}
+		}
 	}
 
 	if len(cwf.Status.Active) > 0 {
@@ -59,7 +59,7 @@ Conditions:
 func TestPrintCronWorkflow(t *testing.T) {
 	var cronWf = v1alpha1.MustUnmarshalCronWorkflow(invalidCwf)
 	ctx := logging.TestContext(t.Context())
-	out := getCronWorkflowGet(ctx, cronWf)
+	out := getCronWorkflowGet(ctx, cronWf, nil, 1)
 	assert.Contains(t, out, expectedOut)
 }
 
@@ -104,3 +104,45 @@ func TestNextRuntimeWithMultipleSchedules(t *testing.T) {
 	assert.LessOrEqual(t, next.Unix(), time.Now().Add(1*time.Minute).Unix())
 	assert.Greater(t, next.Unix(), time.Now().Unix())
 }
+
+func TestPrintCronWorkflowNextRuns(t *testing.T) {
+	var cronWf = v1alpha1.MustUnmarshalCronWorkflow(cronMultipleSchedules)
+	cronWf.Spec.Exclusions = &v1alpha1.CronWorkflowExclusions{
+		Windows: []v1alpha1.ExclusionWindow{{Schedule: "*/2 * * * *", Duration: "1m"}},
+	}
+	ctx := logging.TestContext(t.Context())
+
+	next, err := GetNextRuntimes(ctx, cronWf, nil, 3)
+	require.NoError(t, err)
+	require.Len(t, next, 3)
+	for _, runAt := range next {
+		assert.Equal(t, 1, runAt.Minute()%2, "even minutes are excluded")
+	}
+
+	out := getCronWorkflowGet(ctx, cronWf, nil, 3)
+	assert.Contains(t, out, "NextScheduledTimes:")
+	for _, runAt := range next {
+		assert.Contains(t, out, runAt.Format(time.RFC3339))
+	}
+}
+
+func TestNextRuntimeWithoutTimezone(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+	time.Local = time.FixedZone("UTC+5", 5*60*60)
+
+	var cronWf = v1alpha1.MustUnmarshalCronWorkflow(cronMultipleSchedules)
+	cronWf.Spec.Schedules = []string{"0 9 * * *"}
+	cronWf.Spec.Exclusions = &v1alpha1.CronWorkflowExclusions{
+		Windows: []v1alpha1.ExclusionWindow{{Schedule: "0 0 * * 6,0", Duration: "24h"}},
+	}
+	ctx := logging.TestContext(t.Context())
+	next, err := GetNextRuntimes(ctx, cronWf, nil, 3)
+	require.NoError(t, err)
+	require.Len(t, next, 3)
+	for _, runAt := range next {
+		// the schedule and the exclusions are both in the local timezone, as they are in the controller
+		assert.Equal(t, 9, runAt.Hour())
+		assert.NotContains(t, []time.Weekday{time.Saturday, time.Sunday}, runAt.Weekday())
+	}
+}
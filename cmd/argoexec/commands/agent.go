@@ -16,6 +16,7 @@ import (
 
 	"github.com/argoproj/argo-workflows/v4"
 	argoexecex "github.com/argoproj/argo-workflows/v4/cmd/argoexec/executor"
+	argoConfig "github.com/argoproj/argo-workflows/v4/config"
 	executorplugins "github.com/argoproj/argo-workflows/v4/pkg/plugins/executor"
 	"github.com/argoproj/argo-workflows/v4/util/logs"
 	"github.com/argoproj/argo-workflows/v4/workflow/common"
@@ -81,6 +82,17 @@ func getPluginAddresses(ctx context.Context) []string {
 	return addresses
 }
 
+func getAgentConfig(ctx context.Context) argoConfig.AgentConfig {
+	var agentConfig argoConfig.AgentConfig
+	if value, ok := os.LookupEnv(common.EnvAgentConfig); ok {
+		if err := json.Unmarshal([]byte(value), &agentConfig); err != nil {
+			logging.RequireLoggerFromContext(ctx).WithError(err).WithFatal().Error(ctx, "Failed to unmarshal agent config")
+			os.Exit(1)
+		}
+	}
+	return agentConfig
+}
+
 func NewAgentMainCommand() *cobra.Command {
 	return &cobra.Command{
 		Use: "main",
@@ -122,6 +134,7 @@ func initAgentExecutor(ctx context.Context) *executor.AgentExecutor {
 		os.Exit(1)
 	}
 
+	agentConfig := getAgentConfig(ctx)
 	addresses := getPluginAddresses(ctx)
 	names := getPluginNames(ctx)
 	var plugins []executorplugins.TemplateExecutor
@@ -136,8 +149,13 @@ func initAgentExecutor(ctx context.Context) *executor.AgentExecutor {
 			logger.WithError(err).WithFatal().Error(ctx, "Failed to read token file")
 			os.Exit(1)
 		}
-		plugins = append(plugins, rpc.New(address, string(data)))
+		plug, err := rpc.New(address, string(data), agentConfig.HTTPRetry)
+		if err != nil {
+			logger.WithError(err).WithFatal().Error(ctx, "Failed to create plugin")
+			os.Exit(1)
+		}
+		plugins = append(plugins, plug)
 	}
 
-	return executor.NewAgentExecutor(clientSet, restClient, config, namespace, workflowName, workflowUID, plugins)
+	return executor.NewAgentExecutor(clientSet, restClient, config, namespace, workflowName, workflowUID, plugins, agentConfig)
 }
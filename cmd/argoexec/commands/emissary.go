@@ -17,7 +17,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/otel/propagation"
-	"k8s.io/client-go/util/retry"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v4/util/archive"
@@ -213,12 +213,12 @@ func NewEmissaryCommand() *cobra.Command {
 				}
 			}
 
-			backoff, err := template.GetRetryStrategy()
+			backoff, err := template.ContainerSet.GetContainerRetryStrategy(containerName)
 			if err != nil {
 				return fmt.Errorf("failed to get retry strategy: %w", err)
 			}
 
-			cmdErr := retry.OnError(backoff, func(error) bool { return true }, func() error {
+			cmdErr := retryCommand(backoff, func() error {
 				command, closer, err := startCommand(ctx, name, args, template)
 				if err != nil {
 					return fmt.Errorf("failed to start command: %w", err)
@@ -275,23 +275,32 @@ func NewEmissaryCommand() *cobra.Command {
 
 			exitCode = exitCodeFromErr(cmdErr, exitCode)
 
+			// the main container saves the outputs of the template, and any container of a container set its own
+			var outputs []wfv1.Outputs
 			if containerName == common.MainContainerName {
-				for _, x := range template.Outputs.Parameters {
+				outputs = append(outputs, template.Outputs)
+			}
+			if ctr := template.ContainerSet.GetContainerNode(containerName); ctr != nil && ctr.Outputs != nil {
+				outputs = append(outputs, *ctr.Outputs)
+			}
+			if len(outputs) == 0 {
+				logger.Info(ctx, "not saving outputs - container has none")
+			}
+			for _, o := range outputs {
+				for _, x := range o.Parameters {
 					if x.ValueFrom != nil && x.ValueFrom.Path != "" {
 						if err := saveParameter(ctx, x.ValueFrom.Path); err != nil {
 							return err
 						}
 					}
 				}
-				for _, x := range template.Outputs.Artifacts {
+				for _, x := range o.Artifacts {
 					if x.Path != "" {
-						if err := saveArtifact(ctx, x.Path); err != nil {
+						if err := saveArtifact(ctx, x); err != nil {
 							return err
 						}
 					}
 				}
-			} else {
-				logger.Info(ctx, "not saving outputs - not main container")
 			}
 
 			return cmdErr // this is the error returned from cmd.Wait(), which maybe an exitError
@@ -592,6 +601,28 @@ func waitForDependencyExitCode(ctx context.Context, depExitPath string, signals
 	}
 }
 
+// retryCommand runs f until it succeeds or the steps of the backoff are exhausted, returning the last error.
+// Unlike wait.Backoff, which stops retrying once the cap is reached, the delay between retries is held at the cap.
+func retryCommand(backoff wait.Backoff, f func() error) error {
+	capDuration := backoff.Cap
+	backoff.Cap = 0
+	var err error
+	for backoff.Steps > 0 {
+		if err = f(); err == nil {
+			return nil
+		}
+		if backoff.Steps == 1 {
+			break
+		}
+		delay := backoff.Step()
+		if capDuration > 0 && backoff.Duration > capDuration {
+			backoff.Duration = capDuration
+		}
+		time.Sleep(delay)
+	}
+	return err
+}
+
 // exitCodeFromErr maps the result of waiting on a sub-process to a numeric exit
 // code: 0 on success, the process's own exit code when it exited normally, or
 // 137 when it was signalled with no usable code. For any other (non-exit) error
@@ -660,8 +691,9 @@ func startCommand(ctx context.Context, name string, args []string, template *wfv
 	return command, closer, nil
 }
 
-func saveArtifact(ctx context.Context, srcPath string) error {
+func saveArtifact(ctx context.Context, art wfv1.Artifact) error {
 	logger := logging.RequireLoggerFromContext(ctx)
+	srcPath := art.Path
 
 	if common.FindOverlappingVolume(template, srcPath) != nil {
 		logger.WithField("srcPath", srcPath).Info(ctx, "no need to save artifact - on overlapping volume")
@@ -671,7 +703,7 @@ func saveArtifact(ctx context.Context, srcPath string) error {
 		logger.WithField("srcPath", srcPath).WithError(err).Warn(ctx, "cannot save artifact")
 		return nil
 	}
-	dstPath := filepath.Join(varRunArgo, "/outputs/artifacts/", strings.TrimSuffix(srcPath, "/")+".tgz")
+	dstPath := filepath.Join(varRunArgo, common.OutputsDir(containerName), "artifacts", strings.TrimSuffix(srcPath, "/")+".tgz")
 	logger.WithFields(logging.Fields{
 		"src": srcPath,
 		"dst": dstPath,
@@ -685,7 +717,7 @@ func saveArtifact(ctx context.Context, srcPath string) error {
 		return fmt.Errorf("failed to create destination %s: %w", dstPath, err)
 	}
 	defer func() { _ = dst.Close() }()
-	if err = archive.TarGzToWriter(ctx, srcPath, gzip.DefaultCompression, dst); err != nil {
+	if err = tarArtifact(ctx, art, dst); err != nil {
 		return fmt.Errorf("failed to tarball the output %s to %s: %w", srcPath, dstPath, err)
 	}
 	if err = dst.Close(); err != nil {
@@ -694,6 +726,28 @@ func saveArtifact(ctx context.Context, srcPath string) error {
 	return nil
 }
 
+// tarArtifact tars the artifact for the wait container. The tarball is only gzipped when it is uploaded
+// as is, otherwise the wait container unpacks it and archives it with the artifact's archive strategy,
+// so compressing it here would be wasted effort.
+func tarArtifact(ctx context.Context, art wfv1.Artifact, w io.Writer) error {
+	if art.Deduplicate {
+		// tarballs of the same files have the same digest, so they are deduplicated
+		ctx = archive.WithNormalizedHeaders(ctx)
+	}
+	strategy := art.Archive
+	if strategy == nil {
+		strategy = &wfv1.ArchiveStrategy{Tar: &wfv1.TarStrategy{}}
+	}
+	if strategy.Tar == nil || strategy.Tar.IsUncompressed() {
+		return archive.TarToWriter(ctx, art.Path, w)
+	}
+	level := gzip.DefaultCompression
+	if strategy.Tar.CompressionLevel != nil {
+		level = int(*strategy.Tar.CompressionLevel)
+	}
+	return archive.TarGzToWriter(ctx, art.Path, level, w)
+}
+
 func saveParameter(ctx context.Context, srcPath string) error {
 	logger := logging.RequireLoggerFromContext(ctx)
 
@@ -710,7 +764,7 @@ func saveParameter(ctx context.Context, srcPath string) error {
 		return fmt.Errorf("failed to open %s: %w", srcPath, err)
 	}
 	defer func() { _ = src.Close() }()
-	dstPath := varRunArgo + "/outputs/parameters/" + srcPath
+	dstPath := filepath.Join(varRunArgo, common.OutputsDir(containerName), "parameters", srcPath)
 	logger.WithFields(logging.Fields{
 		"src": srcPath,
 		"dst": dstPath,
@@ -9,9 +9,11 @@ import (
 	"sync"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	cmdutil "github.com/argoproj/argo-workflows/v4/util/cmd"
 	"github.com/argoproj/argo-workflows/v4/util/errors"
@@ -205,6 +207,40 @@ func TestEmissary(t *testing.T) {
 	})
 }
 
+func TestRetryCommand(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		attempts := 0
+		err := retryCommand(wait.Backoff{Steps: 3}, func() error {
+			attempts++
+			if attempts < 2 {
+				return fmt.Errorf("attempt %d failed", attempts)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+	t.Run("Exhausted", func(t *testing.T) {
+		attempts := 0
+		err := retryCommand(wait.Backoff{Steps: 3}, func() error {
+			attempts++
+			return fmt.Errorf("attempt %d failed", attempts)
+		})
+		require.EqualError(t, err, "attempt 3 failed")
+		assert.Equal(t, 3, attempts)
+	})
+	t.Run("Cap", func(t *testing.T) {
+		// the delay is held at the cap rather than ending the retries
+		attempts := 0
+		err := retryCommand(wait.Backoff{Steps: 5, Duration: time.Millisecond, Factor: 10, Cap: 2 * time.Millisecond}, func() error {
+			attempts++
+			return fmt.Errorf("attempt %d failed", attempts)
+		})
+		require.EqualError(t, err, "attempt 5 failed")
+		assert.Equal(t, 5, attempts)
+	})
+}
+
 func run(script string) error {
 	cmd := NewEmissaryCommand()
 	_, _, err := cmdutil.ContextWithLogger(cmd, string(logging.Info), string(logging.Text))
@@ -23,6 +23,32 @@ type ResourceRateLimit struct {
 	Burst int `json:"burst"`
 }
 
+// AgentConfig configures the agent which runs the HTTP and Plugin templates of a workflow
+type AgentConfig struct {
+	// HTTPRetry retries the requests of HTTP templates which do not have their own retry
+	HTTPRetry *wfv1.HTTPRetry `json:"httpRetry,omitempty"`
+	// HTTPRateLimit limits the rate of requests to each host, across all the HTTP templates of a workflow
+	HTTPRateLimit *HTTPRateLimit `json:"httpRateLimit,omitempty"`
+}
+
+// HTTPRateLimit is a token bucket for each host which HTTP templates send requests to
+type HTTPRateLimit struct {
+	// Limit is the maximum rate of requests to each host, per second
+	Limit float64 `json:"limit"`
+	// Burst allows temporary spikes above the limit
+	Burst int `json:"burst"`
+	// Hosts overrides the limit for the named hosts, e.g. api.example.com
+	Hosts map[string]ResourceRateLimit `json:"hosts,omitempty"`
+}
+
+// GetLimit returns the rate limit for the host
+func (l HTTPRateLimit) GetLimit(host string) ResourceRateLimit {
+	if limit, ok := l.Hosts[host]; ok {
+		return limit
+	}
+	return ResourceRateLimit{Limit: l.Limit, Burst: l.Burst}
+}
+
 // Config contains the root of the configuration settings for the workflow controller
 // as read from the ConfigMap called workflow-controller-configmap
 type Config struct {
@@ -32,6 +58,9 @@ type Config struct {
 	// WorkflowEvents configures how workflow events are emitted
 	WorkflowEvents WorkflowEvents `json:"workflowEvents,omitzero"`
 
+	// Notifications publishes the phase transitions of workflows and their nodes as CloudEvents to webhooks
+	Notifications *Notifications `json:"notifications,omitempty"`
+
 	// Executor holds container customizations for the executor to use when running pods
 	Executor *apiv1.Container `json:"executor,omitempty"`
 
@@ -134,6 +163,9 @@ type Config struct {
 	// Note: when this is set to true, HTTP templates will not be reconciled and the controller will not attempt to create agent pods for them.
 	DisableAgentPodCreation bool `json:"disableAgentPodCreation,omitempty"`
 
+	// Agent configures the agent which runs HTTP and Plugin templates
+	Agent *AgentConfig `json:"agent,omitempty"`
+
 	// InitlessPod configures an opt-in pod layout that omits the argoexec init container.
 	// The argoexec binary is delivered to the main container via a Kubernetes image volume
 	// (KEP-4639 — Beta in K8s 1.33 behind a feature gate, GA in 1.36), and a new
@@ -141,6 +173,83 @@ type Config struct {
 	// (template write, script staging, input artifact download, readiness signaling) in
 	// addition to its existing post-main work.
 	InitlessPod *InitlessPodConfig `json:"initlessPod,omitempty"`
+
+	// DurationEstimation configures how the controller estimates the duration of workflows and nodes
+	DurationEstimation *DurationEstimationConfig `json:"durationEstimation,omitempty"`
+
+	// SemaphoreFairShare enables fair-share queueing for semaphores, so that tenants take turns acquiring them,
+	// rather than workflows only being served in priority and creation order.
+	// Requires a controller restart to take effect.
+	SemaphoreFairShare *FairShareConfig `json:"semaphoreFairShare,omitempty"`
+}
+
+// FairShareConfig configures fair-share queueing for semaphores
+type FairShareConfig struct {
+	// TenantLabelKey is the workflow label whose value is the tenant of the workflow.
+	// If not set, the tenant of a workflow is its namespace.
+	TenantLabelKey string `json:"tenantLabelKey,omitempty"`
+
+	// Weights of tenants, keyed by tenant. A tenant with weight 2 acquires semaphores twice as often as a tenant with weight 1
+	// while both are waiting. Tenants without a weight have weight 1.
+	Weights map[string]int `json:"weights,omitempty"`
+}
+
+// DurationEstimationStrategy selects how durations are estimated
+type DurationEstimationStrategy string
+
+const (
+	// DurationEstimationStrategyLatest uses the most recent successful workflow as the baseline
+	DurationEstimationStrategyLatest DurationEstimationStrategy = "Latest"
+	// DurationEstimationStrategyPercentile uses percentiles of the durations of recent successful archived workflows
+	DurationEstimationStrategyPercentile DurationEstimationStrategy = "Percentile"
+)
+
+// DurationEstimationConfig configures how the controller estimates the duration of workflows and nodes
+type DurationEstimationConfig struct {
+	// Strategy is either `Latest` (default) or `Percentile`.
+	// `Percentile` requires the workflow archive, and falls back to `Latest` when no archived workflows are found.
+	Strategy DurationEstimationStrategy `json:"strategy,omitempty"`
+
+	// HistorySize is the number of most recent successful archived workflows the `Percentile` strategy uses. Default is 10.
+	HistorySize int `json:"historySize,omitempty"`
+
+	// Percentile of the historical durations used as the estimated duration. Default is 50.
+	Percentile int `json:"percentile,omitempty"`
+
+	// UpperBoundPercentile is the percentile of the historical durations used as the upper bound of the estimated duration. Default is 90.
+	UpperBoundPercentile int `json:"upperBoundPercentile,omitempty"`
+}
+
+// GetStrategy returns the configured strategy or the default of Latest.
+func (c *DurationEstimationConfig) GetStrategy() DurationEstimationStrategy {
+	if c == nil || c.Strategy == "" {
+		return DurationEstimationStrategyLatest
+	}
+	return c.Strategy
+}
+
+// GetHistorySize returns the configured history size or the default value of 10.
+func (c *DurationEstimationConfig) GetHistorySize() int {
+	if c == nil || c.HistorySize <= 0 {
+		return 10
+	}
+	return c.HistorySize
+}
+
+// GetPercentile returns the configured percentile or the default value of 50.
+func (c *DurationEstimationConfig) GetPercentile() int {
+	if c == nil || c.Percentile <= 0 {
+		return 50
+	}
+	return min(c.Percentile, 100)
+}
+
+// GetUpperBoundPercentile returns the configured upper bound percentile or the default value of 90.
+func (c *DurationEstimationConfig) GetUpperBoundPercentile() int {
+	if c == nil || c.UpperBoundPercentile <= 0 {
+		return 90
+	}
+	return min(c.UpperBoundPercentile, 100)
 }
 
 // InitlessPodConfig configures the init-less pod layout.
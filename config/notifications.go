@@ -0,0 +1,113 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// Notifications configures the webhooks which the phase transitions of workflows and their nodes are published to as
+// CloudEvents
+type Notifications struct {
+	// Sinks are the webhooks which the transitions of all workflows are published to
+	Sinks []NotificationSink `json:"sinks,omitempty"`
+	// WorkflowSinkURLPrefixes allows workflows to add their own sinks, with the
+	// workflows.argoproj.io/notification-sinks annotation, whose URLs start with one of these prefixes,
+	// e.g. https://hooks.example.com/. Workflows cannot add sinks unless this is set.
+	WorkflowSinkURLPrefixes []string `json:"workflowSinkURLPrefixes,omitempty"`
+}
+
+// AllowsWorkflowSink returns whether workflows can add the sink, which must have an allowed URL and no secrets
+func (n Notifications) AllowsWorkflowSink(sink NotificationSink) bool {
+	if slices.ContainsFunc(sink.Headers, func(h NotificationHeader) bool { return h.SecretKeyRef != nil }) {
+		return false
+	}
+	return slices.ContainsFunc(n.WorkflowSinkURLPrefixes, func(prefix string) bool {
+		return prefix != "" && strings.HasPrefix(sink.URL, prefix)
+	})
+}
+
+// NotificationSink is a webhook which CloudEvents are POSTed to, in the structured content mode
+type NotificationSink struct {
+	// Name identifies the sink in logs
+	Name string `json:"name,omitempty"`
+	// URL is the URL of the webhook
+	URL string `json:"url"`
+	// Headers are added to the requests, e.g. for authorization
+	Headers []NotificationHeader `json:"headers,omitempty"`
+	// Filter selects the transitions which are published to the sink, all the workflow transitions by default
+	Filter NotificationFilter `json:"filter,omitzero"`
+	// Retry retries failed requests, by default 3 times for the status codes 429, 502, 503 and 504 and for network
+	// errors. Events which cannot be delivered are logged as dead letters.
+	Retry *wfv1.HTTPRetry `json:"retry,omitempty"`
+	// Timeout is the timeout of each request, 10s by default
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// GetName returns the name of the sink, or its URL if it does not have one
+func (s NotificationSink) GetName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.URL
+}
+
+// GetTimeout returns the timeout of each request
+func (s NotificationSink) GetTimeout() time.Duration {
+	if s.Timeout == nil {
+		return 10 * time.Second
+	}
+	return s.Timeout.Duration
+}
+
+// NotificationHeader is a header of the requests to a sink
+type NotificationHeader struct {
+	// Name is the name of the header
+	Name string `json:"name"`
+	// Value is the value of the header
+	Value string `json:"value,omitempty"`
+	// SecretKeyRef is a secret in the namespace of the controller which the value is read from. The sinks which
+	// workflows add cannot use secrets.
+	SecretKeyRef *apiv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// NotificationFilter selects the transitions published to a sink. A transition must match each field which is set.
+type NotificationFilter struct {
+	// Namespaces are the namespaces of the workflows
+	Namespaces []string `json:"namespaces,omitempty"`
+	// LabelSelector selects the workflows by their labels
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// Phases are the phases transitioned to, e.g. Succeeded, Failed or Error
+	Phases []string `json:"phases,omitempty"`
+	// Nodes publishes the transitions of the nodes of the workflows, as well as those of the workflows
+	Nodes bool `json:"nodes,omitempty"`
+}
+
+// Matches returns whether the transition of a workflow, or one of its nodes, to the phase matches the filter
+func (f NotificationFilter) Matches(namespace string, workflowLabels map[string]string, phase string, node bool) (bool, error) {
+	if node && !f.Nodes {
+		return false, nil
+	}
+	if len(f.Namespaces) > 0 && !slices.Contains(f.Namespaces, namespace) {
+		return false, nil
+	}
+	if len(f.Phases) > 0 && !slices.Contains(f.Phases, phase) {
+		return false, nil
+	}
+	if f.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(f.LabelSelector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(workflowLabels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNotificationsAllowsWorkflowSink(t *testing.T) {
+	n := Notifications{WorkflowSinkURLPrefixes: []string{"https://hooks.example.com/"}}
+	assert.True(t, n.AllowsWorkflowSink(NotificationSink{URL: "https://hooks.example.com/my-hook"}))
+	assert.False(t, n.AllowsWorkflowSink(NotificationSink{URL: "https://hooks.example.com.evil.io/my-hook"}))
+	assert.False(t, n.AllowsWorkflowSink(NotificationSink{
+		URL:     "https://hooks.example.com/my-hook",
+		Headers: []NotificationHeader{{Name: "Authorization", SecretKeyRef: &apiv1.SecretKeySelector{Key: "token"}}},
+	}))
+	assert.False(t, Notifications{}.AllowsWorkflowSink(NotificationSink{URL: "https://hooks.example.com/my-hook"}))
+}
+
+func TestNotificationFilterMatches(t *testing.T) {
+	labels := map[string]string{"team": "a"}
+	tests := []struct {
+		name   string
+		filter NotificationFilter
+		node   bool
+		want   bool
+	}{
+		{"Empty", NotificationFilter{}, false, true},
+		{"Node", NotificationFilter{}, true, false},
+		{"Nodes", NotificationFilter{Nodes: true}, true, true},
+		{"Namespace", NotificationFilter{Namespaces: []string{"my-ns"}}, false, true},
+		{"OtherNamespace", NotificationFilter{Namespaces: []string{"other"}}, false, false},
+		{"Phase", NotificationFilter{Phases: []string{"Failed", "Succeeded"}}, false, true},
+		{"OtherPhase", NotificationFilter{Phases: []string{"Failed"}}, false, false},
+		{"Labels", NotificationFilter{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}}, false, true},
+		{"OtherLabels", NotificationFilter{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := tt.filter.Matches("my-ns", labels, "Succeeded", tt.node)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, matches)
+		})
+	}
+	t.Run("InvalidSelector", func(t *testing.T) {
+		filter := NotificationFilter{LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Bad"}}}}
+		_, err := filter.Matches("my-ns", labels, "Succeeded", false)
+		require.Error(t, err)
+	})
+}
@@ -231,6 +231,33 @@ func MigrateChanges(clusterName, tableName string, dbType sqldb.DBType) []sqldb.
 			sqldb.Postgres: sqldb.AnsiSQLChange(`drop index argo_archived_workflows_i1`),
 		}),
 		sqldb.AnsiSQLChange(`create index argo_archived_workflows_i1 on argo_archived_workflows (clustername, instanceid, namespace, startedat DESC)`),
+		// add argo_memoization_cache table for database memoization caches
+		sqldb.ByType(dbType, sqldb.TypedChanges{
+			sqldb.MySQL: sqldb.AnsiSQLChange(`create table if not exists argo_memoization_cache (
+    clustername varchar(64) not null,
+    namespace varchar(256) not null,
+    name varchar(128) not null,
+    cachekey varchar(256) not null,
+    nodeid varchar(256) not null,
+    outputs longtext not null,
+    createdat timestamp not null default CURRENT_TIMESTAMP,
+    lasthitat timestamp not null default CURRENT_TIMESTAMP,
+    primary key (clustername, namespace, name, cachekey)
+)`),
+			sqldb.Postgres: sqldb.AnsiSQLChange(`create table if not exists argo_memoization_cache (
+    clustername varchar(64) not null,
+    namespace varchar(256) not null,
+    name varchar(128) not null,
+    cachekey varchar(256) not null,
+    nodeid varchar(256) not null,
+    outputs text not null,
+    createdat timestamp not null default CURRENT_TIMESTAMP,
+    lasthitat timestamp not null default CURRENT_TIMESTAMP,
+    primary key (clustername, namespace, name, cachekey)
+)`),
+		}),
+		// index to find entries that have not been hit recently for garbage collection
+		sqldb.AnsiSQLChange(`create index argo_memoization_cache_i1 on argo_memoization_cache (clustername, namespace, lasthitat)`),
 	}
 }
 
@@ -83,6 +83,8 @@ type WorkflowArchive interface {
 	HasMoreWorkflows(ctx context.Context, options sutils.ListOptions) (bool, error)
 	GetWorkflow(ctx context.Context, uid string, namespace string, name string) (*wfv1.Workflow, error)
 	GetWorkflowForEstimator(ctx context.Context, namespace string, requirements []labels.Requirement) (*wfv1.Workflow, error)
+	// ListWorkflowsForEstimator returns up to limit of the most recently started successful workflows, including their node statuses
+	ListWorkflowsForEstimator(ctx context.Context, namespace string, requirements []labels.Requirement, limit int) (wfv1.Workflows, error)
 	DeleteWorkflow(ctx context.Context, uid string) error
 	DeleteExpiredWorkflows(ctx context.Context, ttl time.Duration) error
 	IsEnabled() bool
@@ -723,6 +725,47 @@ func (r *workflowArchive) GetWorkflowForEstimator(ctx context.Context, namespace
 	return result, nil
 }
 
+func (r *workflowArchive) ListWorkflowsForEstimator(ctx context.Context, namespace string, requirements []labels.Requirement, limit int) (wfv1.Workflows, error) {
+	queryTimeoutSeconds := env.LookupEnvIntOr(ctx, "WORKFLOW_ESTIMATION_DB_QUERY_TIMEOUT_SECONDS", defaultEstimationDBQueryTimeoutSeconds)
+	queryCtx, cancel := context.WithTimeout(ctx, time.Duration(queryTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	var archivedWfs []archivedWorkflowRecord
+	err := r.sessionProxy.With(queryCtx, func(s db.Session) error {
+		selector := s.WithContext(queryCtx).SQL().
+			Select("workflow").
+			From(archiveTableName).
+			Where(r.clusterManagedNamespaceAndInstanceID()).
+			And(phaseEqual(string(wfv1.NodeSucceeded)))
+
+		var err error
+		selector, err = BuildArchivedWorkflowSelector(selector, archiveTableName, archiveLabelsTableName, r.dbType, sutils.ListOptions{
+			Namespace:         namespace,
+			LabelRequirements: requirements,
+			Limit:             limit,
+			Offset:            0,
+		}, false)
+		if err != nil {
+			return err
+		}
+		return selector.All(&archivedWfs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	wfs := make(wfv1.Workflows, len(archivedWfs))
+	for i, archivedWf := range archivedWfs {
+		if r.dbType == sqldb.Postgres {
+			archivedWf.Workflow = strings.ReplaceAll(archivedWf.Workflow, postgresNullReplacement, "\\u0000")
+		}
+		if err := json.Unmarshal([]byte(archivedWf.Workflow), &wfs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return wfs, nil
+}
+
 func (r *workflowArchive) DeleteWorkflow(ctx context.Context, uid string) error {
 	logger := logging.RequireLoggerFromContext(ctx)
 	return r.sessionProxy.With(ctx, func(s db.Session) error {
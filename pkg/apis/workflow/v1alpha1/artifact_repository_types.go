@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"path"
 	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -29,6 +31,98 @@ type ArtifactRepository struct {
 	Azure *AzureArtifactRepository `json:"azure,omitempty" protobuf:"bytes,7,opt,name=azure"`
 	// Plugin stores artifact in a plugin-specific artifact repository
 	Plugin *PluginArtifactRepository `json:"plugin,omitempty" protobuf:"bytes,8,opt,name=plugin"`
+	// Retention deletes the artifacts of completed workflows which use this repository by age or by count
+	Retention *ArtifactRetention `json:"retention,omitempty" protobuf:"bytes,9,opt,name=retention"`
+	// Redirect redirects downloads of artifacts from the Argo Server to pre-signed URLs of the repository, so they are not streamed through the server
+	Redirect *ArtifactRedirect `json:"redirect,omitempty" protobuf:"bytes,10,opt,name=redirect"`
+	// Fallbacks are the locations to save output artifacts to, in order, when saving them to this repository fails.
+	// The location an artifact is saved to is recorded on it, so it is read from there.
+	Fallbacks []ArtifactRepositoryLocation `json:"fallbacks,omitempty" protobuf:"bytes,11,rep,name=fallbacks"`
+	// Replicas are the locations the controller copies the output artifacts of completed workflows to, asynchronously.
+	// Replicas are not read from, and their artifacts are not garbage collected.
+	Replicas []ArtifactRepositoryLocation `json:"replicas,omitempty" protobuf:"bytes,12,rep,name=replicas"`
+}
+
+// ArtifactRepositoryLocation is a location of an artifact repository which artifacts are saved or copied to, as a
+// fallback or replica of it
+type ArtifactRepositoryLocation struct {
+	// S3 stores artifact in a S3-compliant object store
+	S3 *S3ArtifactRepository `json:"s3,omitempty" protobuf:"bytes,1,opt,name=s3"`
+	// Artifactory stores artifacts to JFrog Artifactory
+	Artifactory *ArtifactoryArtifactRepository `json:"artifactory,omitempty" protobuf:"bytes,2,opt,name=artifactory"`
+	// HDFS stores artifacts in HDFS
+	HDFS *HDFSArtifactRepository `json:"hdfs,omitempty" protobuf:"bytes,3,opt,name=hdfs"`
+	// OSS stores artifact in a OSS-compliant object store
+	OSS *OSSArtifactRepository `json:"oss,omitempty" protobuf:"bytes,4,opt,name=oss"`
+	// GCS stores artifact in a GCS object store
+	GCS *GCSArtifactRepository `json:"gcs,omitempty" protobuf:"bytes,5,opt,name=gcs"`
+	// Azure stores artifact in an Azure Storage account
+	Azure *AzureArtifactRepository `json:"azure,omitempty" protobuf:"bytes,6,opt,name=azure"`
+}
+
+func (l *ArtifactRepositoryLocation) Get() ArtifactRepositoryType {
+	switch {
+	case l == nil:
+		return nil
+	case l.Artifactory != nil:
+		return l.Artifactory
+	case l.Azure != nil:
+		return l.Azure
+	case l.GCS != nil:
+		return l.GCS
+	case l.HDFS != nil:
+		return l.HDFS
+	case l.OSS != nil:
+		return l.OSS
+	case l.S3 != nil:
+		return l.S3
+	default:
+		return nil
+	}
+}
+
+// ToArtifactLocation returns the artifact location set with the key format of the location
+func (l *ArtifactRepositoryLocation) ToArtifactLocation() *ArtifactLocation {
+	if l == nil {
+		return nil
+	}
+	a := &ArtifactLocation{}
+	if v := l.Get(); v != nil {
+		v.IntoArtifactLocation(a)
+	}
+	return a
+}
+
+// FallbackLocations returns the artifact locations of the fallbacks of the repository, in order
+func (a *ArtifactRepository) FallbackLocations() []ArtifactLocation {
+	if a == nil {
+		return nil
+	}
+	var locations []ArtifactLocation
+	for _, l := range a.Fallbacks {
+		locations = append(locations, *l.ToArtifactLocation())
+	}
+	return locations
+}
+
+// ArtifactRedirect redirects downloads of artifacts from the Argo Server to pre-signed URLs, for S3, GCS, Azure and OSS.
+// The URLs must be reachable by the clients of the Argo Server, and artifacts of other repositories are streamed through the server.
+type ArtifactRedirect struct {
+	// Expiry is how long the pre-signed URLs are valid for, defaults to "5m"
+	Expiry string `json:"expiry,omitempty" protobuf:"bytes,1,opt,name=expiry"`
+}
+
+// ArtifactRetention deletes the output artifacts of completed workflows by age or by count.
+// The artifacts are deleted in the same way as by artifact garbage collection, using the service account and pod metadata of the workflow's artifactGC, and artifacts with the "Never" artifactGC strategy are kept.
+// Only workflows which are still in the cluster are considered.
+type ArtifactRetention struct {
+	// MaxAge deletes the artifacts of workflows which completed longer ago than this duration, e.g. "720h"
+	MaxAge string `json:"maxAge,omitempty" protobuf:"bytes,1,opt,name=maxAge"`
+	// KeepLast keeps the artifacts of the last N successful workflows of each WorkflowTemplate and ClusterWorkflowTemplate,
+	// and deletes the artifacts of workflows of the template which completed before them
+	KeepLast *int32 `json:"keepLast,omitempty" protobuf:"varint,2,opt,name=keepLast"`
+	// KeepSelector keeps the artifacts of workflows with matching labels, e.g. `matchLabels: {keep: "true"}`
+	KeepSelector *metav1.LabelSelector `json:"keepSelector,omitempty" protobuf:"bytes,3,opt,name=keepSelector"`
 }
 
 func (a *ArtifactRepository) IsArchiveLogs() bool {
@@ -76,6 +170,28 @@ func (a *ArtifactRepository) ToArtifactLocation() *ArtifactLocation {
 	return l
 }
 
+// KeyPrefix returns the static prefix of the keys of the artifacts saved in the repository, i.e. the directories of
+// its key format which precede the first variable: "my-prefix" for "my-prefix/{{workflow.name}}/{{pod.name}}".
+func (a *ArtifactRepository) KeyPrefix() string {
+	l := a.ToArtifactLocation()
+	if l == nil {
+		return ""
+	}
+	key, err := l.GetKey()
+	if err != nil {
+		return ""
+	}
+	static, _, templated := strings.Cut(key, "{{")
+	if templated {
+		i := strings.LastIndex(static, "/")
+		if i < 0 {
+			return ""
+		}
+		static = static[:i]
+	}
+	return strings.Trim(static, "/")
+}
+
 // S3ArtifactRepository defines the controller configuration for an S3 artifact repository
 type S3ArtifactRepository struct {
 	S3Bucket `json:",inline" protobuf:"bytes,1,opt,name=s3Bucket"`
@@ -75,3 +75,31 @@ func TestArtifactRepository_IsArchiveLogs(t *testing.T) {
 	assert.False(t, (&ArtifactRepository{ArchiveLogs: new(false)}).IsArchiveLogs())
 	assert.True(t, (&ArtifactRepository{ArchiveLogs: new(true)}).IsArchiveLogs())
 }
+
+func TestArtifactRepository_KeyPrefix(t *testing.T) {
+	assert.Empty(t, (*ArtifactRepository)(nil).KeyPrefix())
+	assert.Empty(t, (&ArtifactRepository{S3: &S3ArtifactRepository{}}).KeyPrefix())
+	assert.Equal(t, "my-key-prefix", (&ArtifactRepository{S3: &S3ArtifactRepository{KeyPrefix: "my-key-prefix"}}).KeyPrefix())
+	assert.Equal(t, "team-a/artifacts", (&ArtifactRepository{GCS: &GCSArtifactRepository{KeyFormat: "team-a/artifacts/{{workflow.name}}/{{pod.name}}"}}).KeyPrefix())
+	assert.Equal(t, "team-a", (&ArtifactRepository{OSS: &OSSArtifactRepository{KeyFormat: "team-a/wf-{{workflow.name}}"}}).KeyPrefix())
+	assert.Empty(t, (&ArtifactRepository{Azure: &AzureArtifactRepository{BlobNameFormat: "wf-{{workflow.name}}"}}).KeyPrefix())
+	assert.Equal(t, "static/key", (&ArtifactRepository{S3: &S3ArtifactRepository{KeyFormat: "/static/key/"}}).KeyPrefix())
+}
+
+func TestArtifactRepository_FallbackLocations(t *testing.T) {
+	var r *ArtifactRepository
+	assert.Empty(t, r.FallbackLocations())
+	r = &ArtifactRepository{
+		S3: &S3ArtifactRepository{S3Bucket: S3Bucket{Bucket: "my-bucket"}},
+		Fallbacks: []ArtifactRepositoryLocation{
+			{S3: &S3ArtifactRepository{S3Bucket: S3Bucket{Endpoint: "my-endpoint", Bucket: "my-fallback"}, KeyFormat: "fallback/{{workflow.name}}"}},
+			{GCS: &GCSArtifactRepository{GCSBucket: GCSBucket{Bucket: "my-gcs-bucket"}}},
+		},
+	}
+	locations := r.FallbackLocations()
+	require.Len(t, locations, 2)
+	assert.Equal(t, "my-fallback", locations[0].S3.Bucket)
+	assert.Equal(t, "fallback/{{workflow.name}}", locations[0].S3.Key)
+	assert.Equal(t, "my-gcs-bucket", locations[1].GCS.Bucket)
+	assert.Equal(t, DefaultArchivePattern, locations[1].GCS.Key)
+}
@@ -25,20 +25,45 @@ type ContainerSetRetryStrategy struct {
 	// Retries is the maximum number of retry attempts for each container. It does not include the
 	// first, original attempt; the maximum number of total attempts will be `retries + 1`.
 	Retries *intstr.IntOrString `json:"retries" protobuf:"bytes,2,rep,name=retries"`
+	// Factor is a factor to multiply the duration by after each retry
+	Factor *intstr.IntOrString `json:"factor,omitempty" protobuf:"bytes,3,opt,name=factor"`
+	// Cap is a limit on the duration between retries, which it is not multiplied beyond, examples values are "1m" or "5m".
+	Cap string `json:"cap,omitempty" protobuf:"bytes,4,opt,name=cap"`
 }
 
+// GetRetryStrategy returns the backoff of the container set, which containers without a retry strategy of their own use
 func (in *ContainerSetTemplate) GetRetryStrategy() (wait.Backoff, error) {
-	if in == nil || in.RetryStrategy == nil || in.RetryStrategy.Retries == nil {
+	if in == nil {
+		return wait.Backoff{Steps: 1}, nil
+	}
+	return in.RetryStrategy.GetBackoff()
+}
+
+// GetContainerRetryStrategy returns the backoff of the named container, which is its own retry strategy if it has
+// one, and that of the container set otherwise
+func (in *ContainerSetTemplate) GetContainerRetryStrategy(name string) (wait.Backoff, error) {
+	if ctr := in.GetContainerNode(name); ctr != nil && ctr.RetryStrategy != nil {
+		return ctr.RetryStrategy.GetBackoff()
+	}
+	return in.GetRetryStrategy()
+}
+
+// GetBackoff returns the backoff of the retry strategy
+func (in *ContainerSetRetryStrategy) GetBackoff() (wait.Backoff, error) {
+	if in == nil || in.Retries == nil {
 		return wait.Backoff{Steps: 1}, nil
 	}
 
-	backoff := wait.Backoff{Steps: in.RetryStrategy.Retries.IntValue()}
+	backoff := wait.Backoff{Steps: in.Retries.IntValue()}
 
-	if in.RetryStrategy.Duration == "" {
+	if in.Duration == "" {
+		if in.Factor != nil || in.Cap != "" {
+			return wait.Backoff{}, fmt.Errorf("factor and cap require a duration")
+		}
 		return backoff, nil
 	}
 
-	baseDuration, err := time.ParseDuration(in.RetryStrategy.Duration)
+	baseDuration, err := time.ParseDuration(in.Duration)
 	if err != nil {
 		return wait.Backoff{}, err
 	}
@@ -48,6 +73,25 @@ func (in *ContainerSetTemplate) GetRetryStrategy() (wait.Backoff, error) {
 	}
 
 	backoff.Duration = baseDuration
+
+	if in.Factor != nil {
+		factor := in.Factor.IntValue()
+		if factor < 1 {
+			return wait.Backoff{}, fmt.Errorf("factor has to be at least 1, current factor: %d", factor)
+		}
+		backoff.Factor = float64(factor)
+	}
+
+	if in.Cap != "" {
+		capDuration, err := time.ParseDuration(in.Cap)
+		if err != nil {
+			return wait.Backoff{}, err
+		}
+		if capDuration < baseDuration {
+			return wait.Backoff{}, fmt.Errorf("cap has to be at least the duration, current cap: %v ", capDuration)
+		}
+		backoff.Cap = capDuration
+	}
 	return backoff, nil
 }
 
@@ -77,6 +121,31 @@ func (in *ContainerSetTemplate) GetGraph() []ContainerNode {
 	return in.Containers
 }
 
+// GetContainerNode returns the container node with the name, or nil if there is none
+func (in *ContainerSetTemplate) GetContainerNode(name string) *ContainerNode {
+	for i, ctr := range in.GetGraph() {
+		if ctr.Name == name {
+			return &in.Containers[i]
+		}
+	}
+	return nil
+}
+
+// GetContainerOutputs returns the outputs of the containers which have any, by container name
+func (in *ContainerSetTemplate) GetContainerOutputs() map[string]Outputs {
+	var outputs map[string]Outputs
+	for _, ctr := range in.GetGraph() {
+		if ctr.Outputs == nil || !ctr.Outputs.HasOutputs() {
+			continue
+		}
+		if outputs == nil {
+			outputs = make(map[string]Outputs)
+		}
+		outputs[ctr.Name] = *ctr.Outputs
+	}
+	return outputs
+}
+
 func (in *ContainerSetTemplate) HasSequencedContainers() bool {
 	for _, n := range in.GetGraph() {
 		if len(n.Dependencies) > 0 {
@@ -133,10 +202,30 @@ func (in *ContainerSetTemplate) Validate() error {
 	if err != nil {
 		return fmt.Errorf("containers %s", err.Error())
 	}
+
+	if _, err := in.RetryStrategy.GetBackoff(); err != nil {
+		return fmt.Errorf("retryStrategy %s", err.Error())
+	}
+	for _, ctr := range in.Containers {
+		if _, err := ctr.RetryStrategy.GetBackoff(); err != nil {
+			return fmt.Errorf("containers.%s.retryStrategy %s", ctr.Name, err.Error())
+		}
+	}
 	return nil
 }
 
 type ContainerNode struct {
 	corev1.Container `json:",inline" protobuf:"bytes,1,opt,name=container"`
 	Dependencies     []string `json:"dependencies,omitempty" protobuf:"bytes,2,rep,name=dependencies"`
+	// Outputs are the output parameters and artifacts of the container, which are saved when it exits. They are
+	// recorded on the node of the container, and can be referenced as `steps.<name>.containers.<container>.outputs`
+	// or `tasks.<name>.containers.<container>.outputs`.
+	// Note: the schema of outputs is large, and repeating it for every container would make the CRDs too large
+	// to apply, so we need "x-kubernetes-preserve-unknown-fields: true" in the validation schema.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Outputs *Outputs `json:"outputs,omitempty" protobuf:"bytes,3,opt,name=outputs"`
+	// RetryStrategy describes how to retry the container if it fails, in place of the retry strategy of the container
+	// set
+	RetryStrategy *ContainerSetRetryStrategy `json:"retryStrategy,omitempty" protobuf:"bytes,4,opt,name=retryStrategy"`
 }
@@ -52,6 +52,67 @@ func TestContainerSetGetRetryStrategy(t *testing.T) {
 	})
 }
 
+func TestContainerSetGetContainerRetryStrategy(t *testing.T) {
+	retries := intstr.FromInt(2)
+	ownRetries := intstr.FromInt(5)
+	factor := intstr.FromInt(2)
+	set := &ContainerSetTemplate{
+		Containers: []ContainerNode{
+			{Container: corev1.Container{Name: "a"}},
+			{Container: corev1.Container{Name: "b"}, RetryStrategy: &ContainerSetRetryStrategy{Retries: &ownRetries, Duration: "1s", Factor: &factor, Cap: "10s"}},
+		},
+		RetryStrategy: &ContainerSetRetryStrategy{Retries: &retries},
+	}
+
+	t.Run("ContainerSet", func(t *testing.T) {
+		strategy, err := set.GetContainerRetryStrategy("a")
+		require.NoError(t, err)
+		assert.Equal(t, wait.Backoff{Steps: 2}, strategy)
+	})
+
+	t.Run("Container", func(t *testing.T) {
+		strategy, err := set.GetContainerRetryStrategy("b")
+		require.NoError(t, err)
+		assert.Equal(t, wait.Backoff{Steps: 5, Duration: time.Second, Factor: 2, Cap: 10 * time.Second}, strategy)
+	})
+
+	t.Run("NoContainerSet", func(t *testing.T) {
+		var set *ContainerSetTemplate
+		strategy, err := set.GetContainerRetryStrategy("main")
+		require.NoError(t, err)
+		assert.Equal(t, wait.Backoff{Steps: 1}, strategy)
+	})
+
+	t.Run("CapBelowDuration", func(t *testing.T) {
+		_, err := (&ContainerSetRetryStrategy{Retries: &retries, Duration: "1m", Cap: "10s"}).GetBackoff()
+		require.EqualError(t, err, "cap has to be at least the duration, current cap: 10s ")
+	})
+
+	t.Run("FactorWithoutDuration", func(t *testing.T) {
+		_, err := (&ContainerSetRetryStrategy{Retries: &retries, Factor: &factor}).GetBackoff()
+		require.EqualError(t, err, "factor and cap require a duration")
+	})
+
+	t.Run("CapWithoutDuration", func(t *testing.T) {
+		_, err := (&ContainerSetRetryStrategy{Retries: &retries, Cap: "10s"}).GetBackoff()
+		require.EqualError(t, err, "factor and cap require a duration")
+	})
+}
+
+func TestContainerSetGetContainerOutputs(t *testing.T) {
+	set := &ContainerSetTemplate{
+		Containers: []ContainerNode{
+			{Container: corev1.Container{Name: "a"}},
+			{Container: corev1.Container{Name: "b"}, Outputs: &Outputs{}},
+			{Container: corev1.Container{Name: "c"}, Outputs: &Outputs{Parameters: []Parameter{{Name: "p", Value: AnyStringPtr("v")}}}},
+		},
+	}
+	assert.Equal(t, map[string]Outputs{"c": {Parameters: []Parameter{{Name: "p", Value: AnyStringPtr("v")}}}}, set.GetContainerOutputs())
+	assert.Equal(t, "c", set.GetContainerNode("c").Name)
+	assert.Nil(t, set.GetContainerNode("main"))
+	assert.Nil(t, (&ContainerSetTemplate{}).GetContainerOutputs())
+}
+
 func TestContainerSetTemplate(t *testing.T) {
 	t.Run("Empty", func(t *testing.T) {
 		x := &ContainerSetTemplate{}
@@ -156,6 +217,33 @@ containers:
 	require.ErrorContains(t, err, "containers dependency cycle detected: b->a->b")
 }
 
+func TestInvalidContainerSetContainerRetryStrategy(t *testing.T) {
+	invalidContainerSetContainerRetryStrategy := `
+containers:
+  - name: a
+    image: argoproj/argosay:v2
+    retryStrategy:
+      retries: 2
+      duration: 1s
+      factor: 0
+`
+	err := validateContainerSetTemplate(invalidContainerSetContainerRetryStrategy)
+	require.ErrorContains(t, err, "containers.a.retryStrategy factor has to be at least 1, current factor: 0")
+}
+
+func TestInvalidContainerSetRetryStrategyWithoutDuration(t *testing.T) {
+	invalidContainerSetRetryStrategy := `
+retryStrategy:
+  retries: 2
+  factor: 2
+containers:
+  - name: a
+    image: argoproj/argosay:v2
+`
+	err := validateContainerSetTemplate(invalidContainerSetRetryStrategy)
+	require.ErrorContains(t, err, "retryStrategy factor and cap require a duration")
+}
+
 func TestValidContainerSet(t *testing.T) {
 	validContainerSet := `
 volumeMounts:
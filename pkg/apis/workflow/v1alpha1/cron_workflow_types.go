@@ -70,6 +70,90 @@ type CronWorkflowSpec struct {
 	Schedules []string `json:"schedules" protobuf:"bytes,11,opt,name=schedules"`
 	// v3.6 and after: When is an expression that determines if a run should be scheduled.
 	When string `json:"when,omitempty" protobuf:"bytes,12,opt,name=when"`
+	// v4.1 and after: CatchUpPolicy defines which of the schedules missed while the controller was down, or while the
+	// CronWorkflow was suspended, are run when it is processed again. If unset, the most recent missed schedule is only
+	// run if it is within StartingDeadlineSeconds.
+	CatchUpPolicy *CatchUpPolicy `json:"catchUpPolicy,omitempty" protobuf:"bytes,13,opt,name=catchUpPolicy"`
+	// v4.1 and after: Exclusions are dates and recurring windows during which scheduled Workflows are not run
+	Exclusions *CronWorkflowExclusions `json:"exclusions,omitempty" protobuf:"bytes,14,opt,name=exclusions"`
+}
+
+// CronWorkflowExclusions are dates and recurring windows, in the CronWorkflow's timezone, during which scheduled
+// Workflows are not run. v4.1 and after
+type CronWorkflowExclusions struct {
+	// DateRanges are ranges of dates on which no Workflows are run
+	// +listType=atomic
+	DateRanges []ExclusionDateRange `json:"dateRanges,omitempty" protobuf:"bytes,1,rep,name=dateRanges"`
+	// Windows are recurring windows during which no Workflows are run
+	// +listType=atomic
+	Windows []ExclusionWindow `json:"windows,omitempty" protobuf:"bytes,2,rep,name=windows"`
+	// HolidaysConfigMap is a ConfigMap in the CronWorkflow's namespace on whose dates no Workflows are run. Each value
+	// of the ConfigMap is one or more dates formatted YYYY-MM-DD, one per line; the keys are only descriptive.
+	HolidaysConfigMap *v1.LocalObjectReference `json:"holidaysConfigMap,omitempty" protobuf:"bytes,3,opt,name=holidaysConfigMap"`
+}
+
+// ExclusionDateRange is an inclusive range of dates, formatted YYYY-MM-DD, e.g. "2026-12-20"
+type ExclusionDateRange struct {
+	// Start is the first excluded date
+	Start string `json:"start" protobuf:"bytes,1,opt,name=start"`
+	// End is the last excluded date. Defaults to Start.
+	End string `json:"end,omitempty" protobuf:"bytes,2,opt,name=end"`
+}
+
+// GetEnd returns the last excluded date of the range
+func (r ExclusionDateRange) GetEnd() string {
+	if r.End == "" {
+		return r.Start
+	}
+	return r.End
+}
+
+// ExclusionWindow is a window which opens on a cron schedule and stays open for a duration
+type ExclusionWindow struct {
+	// Schedule is the cron schedule on which the window opens, e.g. "0 22 * * 5" for 10pm on Fridays
+	Schedule string `json:"schedule" protobuf:"bytes,1,opt,name=schedule"`
+	// Duration is how long the window stays open, e.g. "56h"
+	Duration string `json:"duration" protobuf:"bytes,2,opt,name=duration"`
+}
+
+// CatchUpStrategy defines which missed schedules of a CronWorkflow are run
+// +kubebuilder:validation:Enum=None;Latest;All
+type CatchUpStrategy string
+
+const (
+	// CatchUpNone runs none of the missed schedules
+	CatchUpNone CatchUpStrategy = "None"
+	// CatchUpLatest runs the most recent missed schedule
+	CatchUpLatest CatchUpStrategy = "Latest"
+	// CatchUpAll runs every missed schedule, oldest first, up to MaxCount
+	CatchUpAll CatchUpStrategy = "All"
+)
+
+// DefaultCatchUpMaxCount is the default maximum number of missed schedules run by the All strategy
+const DefaultCatchUpMaxCount = 10
+
+// CatchUpPolicy defines how a CronWorkflow catches up on missed schedules. v4.1 and after
+type CatchUpPolicy struct {
+	// Strategy is which missed schedules are run: None, Latest or All
+	Strategy CatchUpStrategy `json:"strategy" protobuf:"bytes,1,opt,name=strategy,casttype=CatchUpStrategy"`
+	// MaxCount is the maximum number of missed schedules run by the All strategy. If more schedules were missed, only
+	// the most recent are run. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	MaxCount *int32 `json:"maxCount,omitempty" protobuf:"varint,2,opt,name=maxCount"`
+}
+
+// GetMaxCount returns the maximum number of missed schedules to run
+func (p *CatchUpPolicy) GetMaxCount() int {
+	switch {
+	case p == nil || p.Strategy == CatchUpNone:
+		return 0
+	case p.Strategy == CatchUpLatest:
+		return 1
+	case p.MaxCount != nil:
+		return int(*p.MaxCount)
+	default:
+		return DefaultCatchUpMaxCount
+	}
 }
 
 // StopStrategy defines if the CronWorkflow should stop scheduling based on an expression. v3.6 and after
@@ -1,6 +1,10 @@
 package v1alpha1
 
-import "context"
+import (
+	"context"
+
+	apiv1 "k8s.io/api/core/v1"
+)
 
 // Data is a data template
 type Data struct {
@@ -12,8 +16,11 @@ type Data struct {
 }
 
 func (ds *DataSource) GetArtifactIfNeeded() (*Artifact, bool) {
-	if ds.ArtifactPaths != nil {
+	switch {
+	case ds.ArtifactPaths != nil:
 		return &ds.ArtifactPaths.Artifact, true
+	case ds.ArtifactContents != nil:
+		return &ds.ArtifactContents.Artifact, true
 	}
 	return nil, false
 }
@@ -29,6 +36,10 @@ type TransformationStep struct {
 type DataSource struct {
 	// ArtifactPaths is a data transformation that collects a list of artifact paths
 	ArtifactPaths *ArtifactPaths `json:"artifactPaths,omitempty" protobuf:"bytes,1,opt,name=artifactPaths"`
+	// ArtifactContents sources the parsed contents of a JSON, YAML or CSV artifact
+	ArtifactContents *ArtifactContents `json:"artifactContents,omitempty" protobuf:"bytes,2,opt,name=artifactContents"`
+	// ConfigMap sources the data of a config map in the workflow's namespace, as a map of keys to values
+	ConfigMap *apiv1.LocalObjectReference `json:"configMap,omitempty" protobuf:"bytes,3,opt,name=configMap"`
 }
 
 // ArtifactPaths expands a step from a collection of artifacts
@@ -37,6 +48,42 @@ type ArtifactPaths struct {
 	Artifact `json:",inline" protobuf:"bytes,1,opt,name=artifact"`
 }
 
+// ArtifactContentsFormat is the format of the file of an artifact contents source
+// +kubebuilder:validation:Enum="";json;yaml;csv
+type ArtifactContentsFormat string
+
+const (
+	ArtifactContentsFormatJSON ArtifactContentsFormat = "json"
+	ArtifactContentsFormatYAML ArtifactContentsFormat = "yaml"
+	ArtifactContentsFormatCSV  ArtifactContentsFormat = "csv"
+)
+
+// ArtifactContents sources the contents of a file artifact, parsed into objects, lists and values
+type ArtifactContents struct {
+	// Artifact is the artifact location of the file to parse, it is unpacked if it is an archive of a single file
+	Artifact `json:",inline" protobuf:"bytes,1,opt,name=artifact"`
+	// Format of the file: json (default), yaml or csv. JSON files are also parsed as YAML.
+	Format ArtifactContentsFormat `json:"format,omitempty" protobuf:"bytes,2,opt,name=format,casttype=ArtifactContentsFormat"`
+	// CSV configures parsing of the file when the format is csv
+	CSV *CSVOptions `json:"csv,omitempty" protobuf:"bytes,3,opt,name=csv"`
+}
+
+// IsCSV returns whether the contents are CSV rows
+func (a *ArtifactContents) IsCSV() bool {
+	return a.Format == ArtifactContentsFormatCSV
+}
+
+// CSVOptions configures parsing of a CSV file
+type CSVOptions struct {
+	// Header indicates the first row holds the column names, in which case each row is an object
+	// keyed by column name. Otherwise each row is a list of values.
+	Header bool `json:"header,omitempty" protobuf:"varint,1,opt,name=header"`
+	// Delimiter is the character separating values, defaults to a comma
+	Delimiter string `json:"delimiter,omitempty" protobuf:"bytes,2,opt,name=delimiter"`
+}
+
 type DataSourceProcessor interface {
 	ProcessArtifactPaths(context.Context, *ArtifactPaths) (any, error)
+	ProcessArtifactContents(context.Context, *ArtifactContents) (any, error)
+	ProcessConfigMap(context.Context, *apiv1.LocalObjectReference) (any, error)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 func TestGetArtifactIfNeeded(t *testing.T) {
@@ -13,3 +14,12 @@ func TestGetArtifactIfNeeded(t *testing.T) {
 	require.True(t, needed)
 	assert.Equal(t, "foo", art.Name)
 }
+
+func TestGetArtifactIfNeededContents(t *testing.T) {
+	art, needed := (&DataSource{ArtifactContents: &ArtifactContents{Artifact: Artifact{Name: "foo"}}}).GetArtifactIfNeeded()
+	require.True(t, needed)
+	assert.Equal(t, "foo", art.Name)
+
+	_, needed = (&DataSource{ConfigMap: &apiv1.LocalObjectReference{Name: "baz"}}).GetArtifactIfNeeded()
+	assert.False(t, needed)
+}
@@ -13,6 +13,10 @@ func (*ArtGCStatus) ProtoMessage() {}
 
 func (*Artifact) ProtoMessage() {}
 
+func (*ArtifactCache) ProtoMessage() {}
+
+func (*ArtifactContents) ProtoMessage() {}
+
 func (*ArtifactGC) ProtoMessage() {}
 
 func (*ArtifactGCSpec) ProtoMessage() {}
@@ -25,8 +29,12 @@ func (*ArtifactNodeSpec) ProtoMessage() {}
 
 func (*ArtifactPaths) ProtoMessage() {}
 
+func (*ArtifactRedirect) ProtoMessage() {}
+
 func (*ArtifactRepository) ProtoMessage() {}
 
+func (*ArtifactRepositoryLocation) ProtoMessage() {}
+
 func (*ArtifactRepositoryRef) ProtoMessage() {}
 
 func (*ArtifactRepositoryRefStatus) ProtoMessage() {}
@@ -35,6 +43,8 @@ func (*ArtifactResult) ProtoMessage() {}
 
 func (*ArtifactResultNodeStatus) ProtoMessage() {}
 
+func (*ArtifactRetention) ProtoMessage() {}
+
 func (*ArtifactSearchQuery) ProtoMessage() {}
 
 func (*ArtifactSearchResult) ProtoMessage() {}
@@ -55,8 +65,12 @@ func (*Backoff) ProtoMessage() {}
 
 func (*BasicAuth) ProtoMessage() {}
 
+func (*CSVOptions) ProtoMessage() {}
+
 func (*Cache) ProtoMessage() {}
 
+func (*CatchUpPolicy) ProtoMessage() {}
+
 func (*ClientCertAuth) ProtoMessage() {}
 
 func (*ClusterWorkflowTemplate) ProtoMessage() {}
@@ -81,6 +95,8 @@ func (*CreateS3BucketOptions) ProtoMessage() {}
 
 func (*CronWorkflow) ProtoMessage() {}
 
+func (*CronWorkflowExclusions) ProtoMessage() {}
+
 func (*CronWorkflowList) ProtoMessage() {}
 
 func (*CronWorkflowSpec) ProtoMessage() {}
@@ -95,8 +111,14 @@ func (*Data) ProtoMessage() {}
 
 func (*DataSource) ProtoMessage() {}
 
+func (*DatabaseCache) ProtoMessage() {}
+
 func (*Event) ProtoMessage() {}
 
+func (*ExclusionDateRange) ProtoMessage() {}
+
+func (*ExclusionWindow) ProtoMessage() {}
+
 func (*ExecutorConfig) ProtoMessage() {}
 
 func (*ExecutorPlugin) ProtoMessage() {}
@@ -135,6 +157,12 @@ func (*HTTPHeader) ProtoMessage() {}
 
 func (*HTTPHeaderSource) ProtoMessage() {}
 
+func (*HTTPPoll) ProtoMessage() {}
+
+func (*HTTPPollStatus) ProtoMessage() {}
+
+func (*HTTPRetry) ProtoMessage() {}
+
 func (*Header) ProtoMessage() {}
 
 func (*Histogram) ProtoMessage() {}
@@ -175,6 +203,8 @@ func (*NodeFlag) ProtoMessage() {}
 
 func (*NodeResult) ProtoMessage() {}
 
+func (*NodeSoftTimeout) ProtoMessage() {}
+
 func (*NodeStatus) ProtoMessage() {}
 
 func (*NodeSynchronizationStatus) ProtoMessage() {}
@@ -213,12 +243,18 @@ func (*Prometheus) ProtoMessage() {}
 
 func (*RawArtifact) ProtoMessage() {}
 
+func (*ResourceEscalation) ProtoMessage() {}
+
+func (*ResourceEscalationPolicy) ProtoMessage() {}
+
 func (*ResourceTemplate) ProtoMessage() {}
 
 func (*RetryAffinity) ProtoMessage() {}
 
 func (*RetryNodeAntiAffinity) ProtoMessage() {}
 
+func (*RetryRule) ProtoMessage() {}
+
 func (*RetryStrategy) ProtoMessage() {}
 
 func (*S3Artifact) ProtoMessage() {}
@@ -253,6 +289,8 @@ func (*SyncDatabaseRef) ProtoMessage() {}
 
 func (*Synchronization) ProtoMessage() {}
 
+func (*SynchronizationLease) ProtoMessage() {}
+
 func (*SynchronizationStatus) ProtoMessage() {}
 
 func (*TTLStrategy) ProtoMessage() {}
@@ -263,6 +301,8 @@ func (*Template) ProtoMessage() {}
 
 func (*TemplateRef) ProtoMessage() {}
 
+func (*TimeoutPolicy) ProtoMessage() {}
+
 func (*TransformationStep) ProtoMessage() {}
 
 func (*UserContainer) ProtoMessage() {}
@@ -316,3 +356,5 @@ func (*WorkflowTemplateList) ProtoMessage() {}
 func (*WorkflowTemplateRef) ProtoMessage() {}
 
 func (*ZipStrategy) ProtoMessage() {}
+
+func (*ZstdStrategy) ProtoMessage() {}
@@ -2,8 +2,11 @@ package v1alpha1
 
 import (
 	"net/http"
+	"slices"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type HTTPHeaderSource struct {
@@ -52,6 +55,90 @@ type HTTP struct {
 	BodyFrom *HTTPBodySource `json:"bodyFrom,omitempty" protobuf:"bytes,8,opt,name=bodyFrom"`
 	// InsecureSkipVerify is a bool when if set to true will skip TLS verification for the HTTP client
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" protobuf:"bytes,7,opt,name=insecureSkipVerify"`
+	// v4.1 and after: Poll repeats a follow-up request until the operation started by this request completes, e.g. for
+	// APIs which reply 202 Accepted with the URL of the operation
+	Poll *HTTPPoll `json:"poll,omitempty" protobuf:"bytes,9,opt,name=poll"`
+	// v4.1 and after: Retry retries the request on network errors and retryable response codes. Defaults to the
+	// controller's agent.httpRetry
+	Retry *HTTPRetry `json:"retry,omitempty" protobuf:"bytes,10,opt,name=retry"`
+	// v4.1 and after: Auth authenticates the request with a client certificate, an OAuth2 client credentials token, or
+	// basic auth
+	Auth *HTTPAuth `json:"auth,omitempty" protobuf:"bytes,11,opt,name=auth"`
+	// v4.1 and after: CACertSecret is the secret selector to a PEM bundle of certificate authorities to trust, in
+	// addition to the system's
+	CACertSecret *v1.SecretKeySelector `json:"caCertSecret,omitempty" protobuf:"bytes,12,opt,name=caCertSecret"`
+}
+
+// DefaultHTTPPollMaxDuration is how long an HTTP template polls for if its backoff does not have a maxDuration
+const DefaultHTTPPollMaxDuration = time.Hour
+
+// HTTPPoll polls for the completion of an operation started by an HTTP request. The template's successCondition and
+// outputs apply to the response which completes it.
+type HTTPPoll struct {
+	// URLExpression is an expression which evaluates to the URL to poll, given the request and response which started
+	// the operation, e.g. `response.headers["Location"][0]`. Defaults to the URL of the request.
+	URLExpression string `json:"urlExpression,omitempty" protobuf:"bytes,1,opt,name=urlExpression"`
+	// Method is the HTTP method of the polling requests. Default is GET
+	Method string `json:"method,omitempty" protobuf:"bytes,2,opt,name=method"`
+	// CompletionCondition is an expression which, when it evaluates to true for a polling response, completes the
+	// operation
+	CompletionCondition string `json:"completionCondition" protobuf:"bytes,3,opt,name=completionCondition"`
+	// FailureCondition is an expression which, when it evaluates to true for a polling response, fails the template
+	FailureCondition string `json:"failureCondition,omitempty" protobuf:"bytes,4,opt,name=failureCondition"`
+	// Backoff is the interval between polls: its duration defaults to 10 seconds, and its maxDuration, how long to poll
+	// for before failing, to 1 hour
+	Backoff *Backoff `json:"backoff,omitempty" protobuf:"bytes,5,opt,name=backoff"`
+}
+
+// HTTPRetry retries the request of an HTTP template, within the agent. Unlike a retryStrategy, it does not create new
+// nodes.
+type HTTPRetry struct {
+	// Limit is the maximum number of retries of the request. Defaults to 3
+	Limit *intstr.IntOrString `json:"limit,omitempty" protobuf:"bytes,1,opt,name=limit"`
+	// StatusCodes are the response codes to retry. Defaults to 429, 502, 503 and 504
+	StatusCodes []int32 `json:"statusCodes,omitempty" protobuf:"varint,2,rep,name=statusCodes"`
+	// NetworkErrors retries errors which prevented a response, such as a refused connection. Defaults to true for the
+	// idempotent methods GET, HEAD, OPTIONS, TRACE, PUT and DELETE, and to false for others, as the request may have
+	// been processed
+	NetworkErrors *bool `json:"networkErrors,omitempty" protobuf:"varint,3,opt,name=networkErrors"`
+	// Backoff is the delay between retries, defaulting to 1 second doubling each retry. A Retry-After header in the
+	// response takes precedence, up to its cap. The retries stop at its maxDuration, which defaults to 5 minutes.
+	Backoff *Backoff `json:"backoff,omitempty" protobuf:"bytes,4,opt,name=backoff"`
+}
+
+// DefaultHTTPRetryMaxDuration is how long an HTTPRetry retries for if its backoff does not have a maxDuration
+const DefaultHTTPRetryMaxDuration = 5 * time.Minute
+
+// DefaultHTTPRetryStatusCodes are the response codes an HTTPRetry retries if it does not have statusCodes
+var DefaultHTTPRetryStatusCodes = []int32{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetriesStatusCode returns whether the response code is retried
+func (r *HTTPRetry) RetriesStatusCode(statusCode int) bool {
+	statusCodes := r.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = DefaultHTTPRetryStatusCodes
+	}
+	return slices.Contains(statusCodes, int32(statusCode))
+}
+
+// RetriesNetworkErrors returns whether errors which prevented a response to a request with the method are retried
+func (r *HTTPRetry) RetriesNetworkErrors(method string) bool {
+	if r.NetworkErrors != nil {
+		return *r.NetworkErrors
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// GetMethod returns the HTTP method of the polling requests
+func (p *HTTPPoll) GetMethod() string {
+	if p.Method == "" {
+		return http.MethodGet
+	}
+	return p.Method
 }
 
 func (h *HTTP) GetBodyBytes() []byte {
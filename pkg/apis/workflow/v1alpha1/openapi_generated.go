@@ -19,17 +19,22 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Arguments":                     schema_pkg_apis_workflow_v1alpha1_Arguments(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtGCStatus":                   schema_pkg_apis_workflow_v1alpha1_ArtGCStatus(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Artifact":                      schema_pkg_apis_workflow_v1alpha1_Artifact(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactCache":                 schema_pkg_apis_workflow_v1alpha1_ArtifactCache(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactContents":              schema_pkg_apis_workflow_v1alpha1_ArtifactContents(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactGC":                    schema_pkg_apis_workflow_v1alpha1_ArtifactGC(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactGCSpec":                schema_pkg_apis_workflow_v1alpha1_ArtifactGCSpec(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactGCStatus":              schema_pkg_apis_workflow_v1alpha1_ArtifactGCStatus(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactLocation":              schema_pkg_apis_workflow_v1alpha1_ArtifactLocation(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactNodeSpec":              schema_pkg_apis_workflow_v1alpha1_ArtifactNodeSpec(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactPaths":                 schema_pkg_apis_workflow_v1alpha1_ArtifactPaths(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRedirect":              schema_pkg_apis_workflow_v1alpha1_ArtifactRedirect(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRepository":            schema_pkg_apis_workflow_v1alpha1_ArtifactRepository(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRepositoryLocation":    schema_pkg_apis_workflow_v1alpha1_ArtifactRepositoryLocation(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRepositoryRef":         schema_pkg_apis_workflow_v1alpha1_ArtifactRepositoryRef(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRepositoryRefStatus":   schema_pkg_apis_workflow_v1alpha1_ArtifactRepositoryRefStatus(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactResult":                schema_pkg_apis_workflow_v1alpha1_ArtifactResult(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactResultNodeStatus":      schema_pkg_apis_workflow_v1alpha1_ArtifactResultNodeStatus(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRetention":             schema_pkg_apis_workflow_v1alpha1_ArtifactRetention(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactSearchQuery":           schema_pkg_apis_workflow_v1alpha1_ArtifactSearchQuery(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactSearchResult":          schema_pkg_apis_workflow_v1alpha1_ArtifactSearchResult(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactoryArtifact":           schema_pkg_apis_workflow_v1alpha1_ArtifactoryArtifact(ref),
@@ -40,7 +45,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureBlobContainer":            schema_pkg_apis_workflow_v1alpha1_AzureBlobContainer(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Backoff":                       schema_pkg_apis_workflow_v1alpha1_Backoff(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.BasicAuth":                     schema_pkg_apis_workflow_v1alpha1_BasicAuth(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CSVOptions":                    schema_pkg_apis_workflow_v1alpha1_CSVOptions(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Cache":                         schema_pkg_apis_workflow_v1alpha1_Cache(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CatchUpPolicy":                 schema_pkg_apis_workflow_v1alpha1_CatchUpPolicy(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ClientCertAuth":                schema_pkg_apis_workflow_v1alpha1_ClientCertAuth(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ClusterWorkflowTemplate":       schema_pkg_apis_workflow_v1alpha1_ClusterWorkflowTemplate(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ClusterWorkflowTemplateList":   schema_pkg_apis_workflow_v1alpha1_ClusterWorkflowTemplateList(ref),
@@ -53,6 +60,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Counter":                       schema_pkg_apis_workflow_v1alpha1_Counter(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CreateS3BucketOptions":         schema_pkg_apis_workflow_v1alpha1_CreateS3BucketOptions(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CronWorkflow":                  schema_pkg_apis_workflow_v1alpha1_CronWorkflow(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CronWorkflowExclusions":        schema_pkg_apis_workflow_v1alpha1_CronWorkflowExclusions(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CronWorkflowList":              schema_pkg_apis_workflow_v1alpha1_CronWorkflowList(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CronWorkflowSpec":              schema_pkg_apis_workflow_v1alpha1_CronWorkflowSpec(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CronWorkflowStatus":            schema_pkg_apis_workflow_v1alpha1_CronWorkflowStatus(ref),
@@ -60,7 +68,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.DAGTemplate":                   schema_pkg_apis_workflow_v1alpha1_DAGTemplate(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Data":                          schema_pkg_apis_workflow_v1alpha1_Data(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.DataSource":                    schema_pkg_apis_workflow_v1alpha1_DataSource(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.DatabaseCache":                 schema_pkg_apis_workflow_v1alpha1_DatabaseCache(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Event":                         schema_pkg_apis_workflow_v1alpha1_Event(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExclusionDateRange":            schema_pkg_apis_workflow_v1alpha1_ExclusionDateRange(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExclusionWindow":               schema_pkg_apis_workflow_v1alpha1_ExclusionWindow(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExecutorConfig":                schema_pkg_apis_workflow_v1alpha1_ExecutorConfig(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExecutorPlugin":                schema_pkg_apis_workflow_v1alpha1_ExecutorPlugin(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExecutorPluginSidecar":         schema_pkg_apis_workflow_v1alpha1_ExecutorPluginSidecar(ref),
@@ -80,6 +91,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPBodySource":                schema_pkg_apis_workflow_v1alpha1_HTTPBodySource(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPHeader":                    schema_pkg_apis_workflow_v1alpha1_HTTPHeader(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPHeaderSource":              schema_pkg_apis_workflow_v1alpha1_HTTPHeaderSource(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPPoll":                      schema_pkg_apis_workflow_v1alpha1_HTTPPoll(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPPollStatus":                schema_pkg_apis_workflow_v1alpha1_HTTPPollStatus(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPRetry":                     schema_pkg_apis_workflow_v1alpha1_HTTPRetry(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Header":                        schema_pkg_apis_workflow_v1alpha1_Header(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Histogram":                     schema_pkg_apis_workflow_v1alpha1_Histogram(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Inputs":                        schema_pkg_apis_workflow_v1alpha1_Inputs(ref),
@@ -100,6 +114,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.MutexStatus":                   schema_pkg_apis_workflow_v1alpha1_MutexStatus(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeFlag":                      schema_pkg_apis_workflow_v1alpha1_NodeFlag(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeResult":                    schema_pkg_apis_workflow_v1alpha1_NodeResult(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeSoftTimeout":               schema_pkg_apis_workflow_v1alpha1_NodeSoftTimeout(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeStatus":                    schema_pkg_apis_workflow_v1alpha1_NodeStatus(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeSynchronizationStatus":     schema_pkg_apis_workflow_v1alpha1_NodeSynchronizationStatus(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NoneStrategy":                  schema_pkg_apis_workflow_v1alpha1_NoneStrategy(ref),
@@ -119,9 +134,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.PodGC":                         schema_pkg_apis_workflow_v1alpha1_PodGC(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Prometheus":                    schema_pkg_apis_workflow_v1alpha1_Prometheus(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RawArtifact":                   schema_pkg_apis_workflow_v1alpha1_RawArtifact(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceEscalation":            schema_pkg_apis_workflow_v1alpha1_ResourceEscalation(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceEscalationPolicy":      schema_pkg_apis_workflow_v1alpha1_ResourceEscalationPolicy(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceTemplate":              schema_pkg_apis_workflow_v1alpha1_ResourceTemplate(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryAffinity":                 schema_pkg_apis_workflow_v1alpha1_RetryAffinity(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryNodeAntiAffinity":         schema_pkg_apis_workflow_v1alpha1_RetryNodeAntiAffinity(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryRule":                     schema_pkg_apis_workflow_v1alpha1_RetryRule(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryStrategy":                 schema_pkg_apis_workflow_v1alpha1_RetryStrategy(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3Artifact":                    schema_pkg_apis_workflow_v1alpha1_S3Artifact(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3ArtifactRepository":          schema_pkg_apis_workflow_v1alpha1_S3ArtifactRepository(ref),
@@ -139,11 +157,13 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SuspendTemplate":               schema_pkg_apis_workflow_v1alpha1_SuspendTemplate(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SyncDatabaseRef":               schema_pkg_apis_workflow_v1alpha1_SyncDatabaseRef(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Synchronization":               schema_pkg_apis_workflow_v1alpha1_Synchronization(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SynchronizationLease":          schema_pkg_apis_workflow_v1alpha1_SynchronizationLease(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SynchronizationStatus":         schema_pkg_apis_workflow_v1alpha1_SynchronizationStatus(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TTLStrategy":                   schema_pkg_apis_workflow_v1alpha1_TTLStrategy(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TarStrategy":                   schema_pkg_apis_workflow_v1alpha1_TarStrategy(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Template":                      schema_pkg_apis_workflow_v1alpha1_Template(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TemplateRef":                   schema_pkg_apis_workflow_v1alpha1_TemplateRef(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TimeoutPolicy":                 schema_pkg_apis_workflow_v1alpha1_TimeoutPolicy(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TransformationStep":            schema_pkg_apis_workflow_v1alpha1_TransformationStep(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.UserContainer":                 schema_pkg_apis_workflow_v1alpha1_UserContainer(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ValueFrom":                     schema_pkg_apis_workflow_v1alpha1_ValueFrom(ref),
@@ -171,6 +191,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.WorkflowTemplateList":          schema_pkg_apis_workflow_v1alpha1_WorkflowTemplateList(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.WorkflowTemplateRef":           schema_pkg_apis_workflow_v1alpha1_WorkflowTemplateRef(ref),
 		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ZipStrategy":                   schema_pkg_apis_workflow_v1alpha1_ZipStrategy(ref),
+		"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ZstdStrategy":                  schema_pkg_apis_workflow_v1alpha1_ZstdStrategy(ref),
 	}
 }
 
@@ -208,11 +229,16 @@ func schema_pkg_apis_workflow_v1alpha1_ArchiveStrategy(ref common.ReferenceCallb
 							Ref: ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ZipStrategy"),
 						},
 					},
+					"zstd": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ZstdStrategy"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NoneStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TarStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ZipStrategy"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NoneStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TarStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ZipStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ZstdStrategy"},
 	}
 }
 
@@ -480,6 +506,20 @@ func schema_pkg_apis_workflow_v1alpha1_Artifact(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"deduplicate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deduplicate saves an output artifact under a key derived from the SHA-256 digest of its contents, and skips the upload if an artifact with the same digest was already saved to the repository. Deduplicated artifacts may be shared between workflows, so they are never garbage collected. Only supported for files and archives saved to S3, GCS, Azure and OSS repositories.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Digest of the contents of the artifact, formatted as sha256:<hex>. It is recorded when the artifact is saved, and verified when the artifact is loaded.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -489,6 +529,221 @@ func schema_pkg_apis_workflow_v1alpha1_Artifact(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_workflow_v1alpha1_ArtifactCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ArtifactCache is a memoization cache whose entries are objects in the artifact repository",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the cache, entries are stored under the key prefix \"memoization/<name>/\"",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_ArtifactContents(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ArtifactContents sources the contents of a file artifact, parsed into objects, lists and values",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name of the artifact. must be unique within a template's inputs/outputs.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the container path to the artifact",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "mode bits to use on this file, must be a value between 0 and 0777. Set when loading input artifacts. It is recommended to set the mode value to ensure the artifact has the expected permissions in your container.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Description: "From allows an artifact to reference an artifact from a previous step",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"archiveLogs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ArchiveLogs indicates if the container logs should be archived",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"s3": {
+						SchemaProps: spec.SchemaProps{
+							Description: "S3 contains S3 artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3Artifact"),
+						},
+					},
+					"git": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Git contains git artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GitArtifact"),
+						},
+					},
+					"http": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTP contains HTTP artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPArtifact"),
+						},
+					},
+					"artifactory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Artifactory contains artifactory artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactoryArtifact"),
+						},
+					},
+					"hdfs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HDFS contains HDFS artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HDFSArtifact"),
+						},
+					},
+					"raw": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Raw contains raw artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RawArtifact"),
+						},
+					},
+					"oss": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OSS contains OSS artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.OSSArtifact"),
+						},
+					},
+					"gcs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GCS contains GCS artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GCSArtifact"),
+						},
+					},
+					"azure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Azure contains Azure Storage artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureArtifact"),
+						},
+					},
+					"plugin": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Plugin contains plugin artifact location details",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.PluginArtifact"),
+						},
+					},
+					"globalName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GlobalName exports an output artifact to the global scope, making it available as workflow.outputs.artifacts.XXXX and in workflow.status.outputs.artifacts",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"archive": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Archive controls how the artifact will be saved to the artifact repository.",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArchiveStrategy"),
+						},
+					},
+					"optional": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Make Artifacts optional, if Artifacts doesn't generate or exist",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"subPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SubPath allows an artifact to be sourced from a subpath within the specified source",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"recurseMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If mode is set, apply the permission recursively into the artifact if it is a folder",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"fromExpression": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FromExpression, if defined, is evaluated to specify the value for the artifact",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"artifactGC": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ArtifactGC describes the strategy to use when to deleting an artifact from completed or deleted workflows",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactGC"),
+						},
+					},
+					"deleted": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Has this been deleted?",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"deduplicate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deduplicate saves an output artifact under a key derived from the SHA-256 digest of its contents, and skips the upload if an artifact with the same digest was already saved to the repository. Deduplicated artifacts may be shared between workflows, so they are never garbage collected. Only supported for files and archives saved to S3, GCS, Azure and OSS repositories.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Digest of the contents of the artifact, formatted as sha256:<hex>. It is recorded when the artifact is saved, and verified when the artifact is loaded.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format of the file: json (default), yaml or csv. JSON files are also parsed as YAML.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"csv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CSV configures parsing of the file when the format is csv",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CSVOptions"),
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArchiveStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactGC", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactoryArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CSVOptions", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GCSArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GitArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HDFSArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.OSSArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.PluginArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RawArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3Artifact"},
+	}
+}
+
 func schema_pkg_apis_workflow_v1alpha1_ArtifactGC(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -852,36 +1107,167 @@ func schema_pkg_apis_workflow_v1alpha1_ArtifactPaths(ref common.ReferenceCallbac
 							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactGC"),
 						},
 					},
-					"deleted": {
+					"deleted": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Has this been deleted?",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"deduplicate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deduplicate saves an output artifact under a key derived from the SHA-256 digest of its contents, and skips the upload if an artifact with the same digest was already saved to the repository. Deduplicated artifacts may be shared between workflows, so they are never garbage collected. Only supported for files and archives saved to S3, GCS, Azure and OSS repositories.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Digest of the contents of the artifact, formatted as sha256:<hex>. It is recorded when the artifact is saved, and verified when the artifact is loaded.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArchiveStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactGC", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactoryArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GCSArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GitArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HDFSArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.OSSArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.PluginArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RawArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3Artifact"},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_ArtifactRedirect(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ArtifactRedirect redirects downloads of artifacts from the Argo Server to pre-signed URLs, for S3, GCS, Azure and OSS. The URLs must be reachable by the clients of the Argo Server, and artifacts of other repositories are streamed through the server.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"expiry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Expiry is how long the pre-signed URLs are valid for, defaults to \"5m\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_ArtifactRepository(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ArtifactRepository represents an artifact repository in which a controller will store its artifacts",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"archiveLogs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ArchiveLogs enables log archiving",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"s3": {
+						SchemaProps: spec.SchemaProps{
+							Description: "S3 stores artifact in a S3-compliant object store",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3ArtifactRepository"),
+						},
+					},
+					"artifactory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Artifactory stores artifacts to JFrog Artifactory",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactoryArtifactRepository"),
+						},
+					},
+					"hdfs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HDFS stores artifacts in HDFS",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HDFSArtifactRepository"),
+						},
+					},
+					"oss": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OSS stores artifact in a OSS-compliant object store",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.OSSArtifactRepository"),
+						},
+					},
+					"gcs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GCS stores artifact in a GCS object store",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GCSArtifactRepository"),
+						},
+					},
+					"azure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Azure stores artifact in an Azure Storage account",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureArtifactRepository"),
+						},
+					},
+					"plugin": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Plugin stores artifact in a plugin-specific artifact repository",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.PluginArtifactRepository"),
+						},
+					},
+					"retention": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retention deletes the artifacts of completed workflows which use this repository by age or by count",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRetention"),
+						},
+					},
+					"redirect": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Redirect redirects downloads of artifacts from the Argo Server to pre-signed URLs of the repository, so they are not streamed through the server",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRedirect"),
+						},
+					},
+					"fallbacks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Fallbacks are the locations to save output artifacts to, in order, when saving them to this repository fails. The location an artifact is saved to is recorded on it, so it is read from there.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRepositoryLocation"),
+									},
+								},
+							},
+						},
+					},
+					"replicas": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Has this been deleted?",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Replicas are the locations the controller copies the output artifacts of completed workflows to, asynchronously. Replicas are not read from, and their artifacts are not garbage collected.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRepositoryLocation"),
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArchiveStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactGC", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactoryArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GCSArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GitArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HDFSArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.OSSArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.PluginArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RawArtifact", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3Artifact"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRedirect", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRepositoryLocation", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactRetention", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactoryArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GCSArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HDFSArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.OSSArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.PluginArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3ArtifactRepository"},
 	}
 }
 
-func schema_pkg_apis_workflow_v1alpha1_ArtifactRepository(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_workflow_v1alpha1_ArtifactRepositoryLocation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ArtifactRepository represents an artifact repository in which a controller will store its artifacts",
+				Description: "ArtifactRepositoryLocation is a location of an artifact repository which artifacts are saved or copied to, as a fallback or replica of it",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"archiveLogs": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ArchiveLogs enables log archiving",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
 					"s3": {
 						SchemaProps: spec.SchemaProps{
 							Description: "S3 stores artifact in a S3-compliant object store",
@@ -918,17 +1304,11 @@ func schema_pkg_apis_workflow_v1alpha1_ArtifactRepository(ref common.ReferenceCa
 							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureArtifactRepository"),
 						},
 					},
-					"plugin": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Plugin stores artifact in a plugin-specific artifact repository",
-							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.PluginArtifactRepository"),
-						},
-					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactoryArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GCSArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HDFSArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.OSSArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.PluginArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3ArtifactRepository"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactoryArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.AzureArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.GCSArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HDFSArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.OSSArtifactRepository", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.S3ArtifactRepository"},
 	}
 }
 
@@ -1074,6 +1454,41 @@ func schema_pkg_apis_workflow_v1alpha1_ArtifactResultNodeStatus(ref common.Refer
 	}
 }
 
+func schema_pkg_apis_workflow_v1alpha1_ArtifactRetention(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ArtifactRetention deletes the output artifacts of completed workflows by age or by count. The artifacts are deleted in the same way as by artifact garbage collection, using the service account and pod metadata of the workflow's artifactGC, and artifacts with the \"Never\" artifactGC strategy are kept. Only workflows which are still in the cluster are considered.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxAge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxAge deletes the artifacts of workflows which completed longer ago than this duration, e.g. \"720h\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"keepLast": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepLast keeps the artifacts of the last N successful workflows of each WorkflowTemplate and ClusterWorkflowTemplate, and deletes the artifacts of workflows of the template which completed before them",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"keepSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepSelector keeps the artifacts of workflows with matching labels, e.g. `matchLabels: {keep: \"true\"}`",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
 func schema_pkg_apis_workflow_v1alpha1_ArtifactSearchQuery(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1491,11 +1906,38 @@ func schema_pkg_apis_workflow_v1alpha1_BasicAuth(ref common.ReferenceCallback) c
 	}
 }
 
+func schema_pkg_apis_workflow_v1alpha1_CSVOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CSVOptions configures parsing of a CSV file",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"header": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Header indicates the first row holds the column names, in which case each row is an object keyed by column name. Otherwise each row is a list of values.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"delimiter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Delimiter is the character separating values, defaults to a comma",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_workflow_v1alpha1_Cache(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Cache is the configuration for the type of cache to be used",
+				Description: "Cache is the configuration for the type of cache to be used. Exactly one of the caches must be set.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"configMap": {
@@ -1504,12 +1946,52 @@ func schema_pkg_apis_workflow_v1alpha1_Cache(ref common.ReferenceCallback) commo
 							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
 						},
 					},
+					"database": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Database sets a cache stored in the persistence database configured for the controller",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.DatabaseCache"),
+						},
+					},
+					"artifact": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Artifact sets a cache stored in the default artifact repository configured for the controller",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactCache"),
+						},
+					},
 				},
-				Required: []string{"configMap"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.LocalObjectReference"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactCache", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.DatabaseCache", "k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_CatchUpPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CatchUpPolicy defines how a CronWorkflow catches up on missed schedules. v4.1 and after",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"strategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Strategy is which missed schedules are run: None, Latest or All",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxCount is the maximum number of missed schedules run by the All strategy. If more schedules were missed, only the most recent are run. Defaults to 10.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"strategy"},
+			},
+		},
 	}
 }
 
@@ -2028,12 +2510,24 @@ func schema_pkg_apis_workflow_v1alpha1_ContainerNode(ref common.ReferenceCallbac
 							},
 						},
 					},
+					"outputs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Outputs are the output parameters and artifacts of the container, which are saved when it exits. They are recorded on the node of the container, and can be referenced as `steps.<name>.containers.<container>.outputs` or `tasks.<name>.containers.<container>.outputs`. Note: the schema of outputs is large, and repeating it for every container would make the CRDs too large to apply, so we need \"x-kubernetes-preserve-unknown-fields: true\" in the validation schema.",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs"),
+						},
+					},
+					"retryStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryStrategy describes how to retry the container if it fails, in place of the retry strategy of the container set",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ContainerSetRetryStrategy"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.ContainerResizePolicy", "k8s.io/api/core/v1.ContainerRestartRule", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.Probe", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ContainerSetRetryStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs", "k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.ContainerResizePolicy", "k8s.io/api/core/v1.ContainerRestartRule", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.Probe", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount"},
 	}
 }
 
@@ -2057,6 +2551,19 @@ func schema_pkg_apis_workflow_v1alpha1_ContainerSetRetryStrategy(ref common.Refe
 							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
 						},
 					},
+					"factor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Factor is a factor to multiply the duration by after each retry",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"cap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cap is a limit on the duration between retries, which it is not multiplied beyond, examples values are \"1m\" or \"5m\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"retries"},
 			},
@@ -2228,6 +2735,65 @@ func schema_pkg_apis_workflow_v1alpha1_CronWorkflow(ref common.ReferenceCallback
 	}
 }
 
+func schema_pkg_apis_workflow_v1alpha1_CronWorkflowExclusions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CronWorkflowExclusions are dates and recurring windows, in the CronWorkflow's timezone, during which scheduled Workflows are not run. v4.1 and after",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"dateRanges": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DateRanges are ranges of dates on which no Workflows are run",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExclusionDateRange"),
+									},
+								},
+							},
+						},
+					},
+					"windows": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Windows are recurring windows during which no Workflows are run",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExclusionWindow"),
+									},
+								},
+							},
+						},
+					},
+					"holidaysConfigMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HolidaysConfigMap is a ConfigMap in the CronWorkflow's namespace on whose dates no Workflows are run. Each value of the ConfigMap is one or more dates formatted YYYY-MM-DD, one per line; the keys are only descriptive.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExclusionDateRange", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExclusionWindow", "k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
 func schema_pkg_apis_workflow_v1alpha1_CronWorkflowList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2367,12 +2933,24 @@ func schema_pkg_apis_workflow_v1alpha1_CronWorkflowSpec(ref common.ReferenceCall
 							Format:      "",
 						},
 					},
+					"catchUpPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "v4.1 and after: CatchUpPolicy defines which of the schedules missed while the controller was down, or while the CronWorkflow was suspended, are run when it is processed again. If unset, the most recent missed schedule is only run if it is within StartingDeadlineSeconds.",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CatchUpPolicy"),
+						},
+					},
+					"exclusions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "v4.1 and after: Exclusions are dates and recurring windows during which scheduled Workflows are not run",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CronWorkflowExclusions"),
+						},
+					},
 				},
 				Required: []string{"workflowSpec", "schedules"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.StopStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.WorkflowSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CatchUpPolicy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.CronWorkflowExclusions", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.StopStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.WorkflowSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -2662,51 +3240,144 @@ func schema_pkg_apis_workflow_v1alpha1_Data(ref common.ReferenceCallback) common
 						},
 					},
 				},
-				Required: []string{"source", "transformation"},
+				Required: []string{"source", "transformation"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.DataSource", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TransformationStep"},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_DataSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataSource sources external data into a data template",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"artifactPaths": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ArtifactPaths is a data transformation that collects a list of artifact paths",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactPaths"),
+						},
+					},
+					"artifactContents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ArtifactContents sources the parsed contents of a JSON, YAML or CSV artifact",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactContents"),
+						},
+					},
+					"configMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigMap sources the data of a config map in the workflow's namespace, as a map of keys to values",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactContents", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactPaths", "k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_DatabaseCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatabaseCache is a memoization cache whose entries are rows in the persistence database",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the cache, entries with the same key in different caches are independent",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_Event(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector (https://github.com/expr-lang/expr) that we must must match the event. E.g. `payload.message == \"test\"`",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"selector"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.DataSource", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TransformationStep"},
 	}
 }
 
-func schema_pkg_apis_workflow_v1alpha1_DataSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_workflow_v1alpha1_ExclusionDateRange(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DataSource sources external data into a data template",
+				Description: "ExclusionDateRange is an inclusive range of dates, formatted YYYY-MM-DD, e.g. \"2026-12-20\"",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"artifactPaths": {
+					"start": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ArtifactPaths is a data transformation that collects a list of artifact paths",
-							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactPaths"),
+							Description: "Start is the first excluded date",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"end": {
+						SchemaProps: spec.SchemaProps{
+							Description: "End is the last excluded date. Defaults to Start.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"start"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactPaths"},
 	}
 }
 
-func schema_pkg_apis_workflow_v1alpha1_Event(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_workflow_v1alpha1_ExclusionWindow(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "ExclusionWindow is a window which opens on a cron schedule and stays open for a duration",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"selector": {
+					"schedule": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Selector (https://github.com/expr-lang/expr) that we must must match the event. E.g. `payload.message == \"test\"`",
+							Description: "Schedule is the cron schedule on which the window opens, e.g. \"0 22 * * 5\" for 10pm on Fridays",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is how long the window stays open, e.g. \"56h\"",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"selector"},
+				Required: []string{"schedule", "duration"},
 			},
 		},
 	}
@@ -3454,12 +4125,36 @@ func schema_pkg_apis_workflow_v1alpha1_HTTP(ref common.ReferenceCallback) common
 							Format:      "",
 						},
 					},
+					"poll": {
+						SchemaProps: spec.SchemaProps{
+							Description: "v4.1 and after: Poll repeats a follow-up request until the operation started by this request completes, e.g. for APIs which reply 202 Accepted with the URL of the operation",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPPoll"),
+						},
+					},
+					"retry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "v4.1 and after: Retry retries the request on network errors and retryable response codes. Defaults to the controller's agent.httpRetry",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPRetry"),
+						},
+					},
+					"auth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "v4.1 and after: Auth authenticates the request with a client certificate, an OAuth2 client credentials token, or basic auth",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPAuth"),
+						},
+					},
+					"caCertSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "v4.1 and after: CACertSecret is the secret selector to a PEM bundle of certificate authorities to trust, in addition to the system's",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
 				},
 				Required: []string{"url"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPBodySource", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPHeader"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPAuth", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPBodySource", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPHeader", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPPoll", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPRetry", "k8s.io/api/core/v1.SecretKeySelector"},
 	}
 }
 
@@ -3617,6 +4312,144 @@ func schema_pkg_apis_workflow_v1alpha1_HTTPHeaderSource(ref common.ReferenceCall
 	}
 }
 
+func schema_pkg_apis_workflow_v1alpha1_HTTPPoll(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HTTPPoll polls for the completion of an operation started by an HTTP request. The template's successCondition and outputs apply to the response which completes it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"urlExpression": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URLExpression is an expression which evaluates to the URL to poll, given the request and response which started the operation, e.g. `response.headers[\"Location\"][0]`. Defaults to the URL of the request.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"method": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Method is the HTTP method of the polling requests. Default is GET",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"completionCondition": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionCondition is an expression which, when it evaluates to true for a polling response, completes the operation",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"failureCondition": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureCondition is an expression which, when it evaluates to true for a polling response, fails the template",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"backoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backoff is the interval between polls: its duration defaults to 10 seconds, and its maxDuration, how long to poll for before failing, to 1 hour",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Backoff"),
+						},
+					},
+				},
+				Required: []string{"completionCondition"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Backoff"},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_HTTPPollStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HTTPPollStatus is the state of an HTTP template which is polling for the completion of its operation",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL is the URL polled",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"delay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Delay is the delay until the poll after the next one, e.g. 20s",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"deadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deadline is when polling stops, at the maxDuration of the poll's backoff",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_HTTPRetry(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HTTPRetry retries the request of an HTTP template, within the agent. Unlike a retryStrategy, it does not create new nodes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limit is the maximum number of retries of the request. Defaults to 3",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"statusCodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatusCodes are the response codes to retry. Defaults to 429, 502, 503 and 504",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int32",
+									},
+								},
+							},
+						},
+					},
+					"networkErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkErrors retries errors which prevented a response, such as a refused connection. Defaults to true for the idempotent methods GET, HEAD, OPTIONS, TRACE, PUT and DELETE, and to false for others, as the request may have been processed",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"backoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backoff is the delay between retries, defaulting to 1 second doubling each retry. A Retry-After header in the response takes precedence, up to its cap. The retries stop at its maxDuration, which defaults to 5 minutes.",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Backoff"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Backoff", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
 func schema_pkg_apis_workflow_v1alpha1_Header(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3971,6 +4804,13 @@ func schema_pkg_apis_workflow_v1alpha1_MemoizationStatus(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"cacheType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CacheType is the type of the cache that was used: ConfigMapCache, DatabaseCache or ArtifactCache. An empty value means ConfigMapCache.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"hit", "key", "cacheName"},
 			},
@@ -4144,6 +4984,13 @@ func schema_pkg_apis_workflow_v1alpha1_Mutex(ref common.ReferenceCallback) commo
 							Format:      "",
 						},
 					},
+					"leaseDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LeaseDuration is the longest time (e.g. \"30m\", \"2h\") the mutex may be held for. A hold which lasts longer is revoked, and the holding node or workflow fails.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -4285,11 +5132,69 @@ func schema_pkg_apis_workflow_v1alpha1_NodeResult(ref common.ReferenceCallback)
 							Format: "",
 						},
 					},
+					"containerOutputs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerOutputs are the outputs of the containers of a container set, by container name",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs"),
+									},
+								},
+							},
+						},
+					},
+					"httpPoll": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTPPoll is the state of an HTTP template which is polling for the completion of its operation, so that a restarted agent resumes polling rather than sending the request again",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPPollStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPPollStatus", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs"},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_NodeSoftTimeout(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeSoftTimeout is the soft timeout of the timeout policy of a node, which passed",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"at": {
+						SchemaProps: spec.SchemaProps{
+							Description: "At is when the soft timeout passed",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"hook": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hook is the name of the lifecycle hook the soft timeout runs",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"result": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Result is the phase the node completes with",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"at"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -4379,30 +5284,81 @@ func schema_pkg_apis_workflow_v1alpha1_NodeStatus(ref common.ReferenceCallback)
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"finishedAt": {
+					"finishedAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time at which this node completed",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"estimatedDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EstimatedDuration in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"estimatedDurationUpperBound": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EstimatedDurationUpperBound in seconds, a duration that recent runs have rarely exceeded. Only set when durations are estimated from the history of archived workflows.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"progress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Progress to completion",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourcesDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourcesDuration is indicative, but not accurate, resource duration. This is populated when the nodes completes.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int64",
+									},
+								},
+							},
+						},
+					},
+					"escalatedResources": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Time at which this node completed",
-							Default:     map[string]interface{}{},
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							Description: "EscalatedResources are the requests of the resources raised by the resource escalation of the retry strategy, summed over the containers of the pod. This is only set for retries which were escalated.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"estimatedDuration": {
+					"resourceFailure": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EstimatedDuration in seconds.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "ResourceFailure is the resource the pod of the node failed for lack of: memory if a main container was OOMKilled, or ephemeral-storage if the pod was evicted for it. It is recorded so that retries can escalate the resource once the pod has been deleted.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"progress": {
+					"softTimeout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Progress to completion",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "SoftTimeout records the soft timeout of the timeout policy of the node passing. This is only set for pod nodes.",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeSoftTimeout"),
 						},
 					},
-					"resourcesDuration": {
+					"retryRuleCounts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ResourcesDuration is indicative, but not accurate, resource duration. This is populated when the nodes completes.",
+							Description: "RetryRuleCounts are the numbers of failed attempts of the node which each of the rules of its retry strategy matched, by the names of the rules. This is only set for retry nodes whose strategy has rules.",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
@@ -4410,7 +5366,7 @@ func schema_pkg_apis_workflow_v1alpha1_NodeStatus(ref common.ReferenceCallback)
 									SchemaProps: spec.SchemaProps{
 										Default: 0,
 										Type:    []string{"integer"},
-										Format:  "int64",
+										Format:  "int32",
 									},
 								},
 							},
@@ -4523,7 +5479,7 @@ func schema_pkg_apis_workflow_v1alpha1_NodeStatus(ref common.ReferenceCallback)
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Inputs", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.MemoizationStatus", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeFlag", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeSynchronizationStatus", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TemplateRef", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Inputs", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.MemoizationStatus", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeFlag", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeSoftTimeout", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.NodeSynchronizationStatus", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TemplateRef", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -5272,6 +6228,63 @@ func schema_pkg_apis_workflow_v1alpha1_RawArtifact(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_workflow_v1alpha1_ResourceEscalation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceEscalation raises the requests and limits of the main containers of retries of pods which failed for lack of a resource, once for each such failure of the previous attempts",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"memory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Memory is escalated after a container of the pod is OOMKilled",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceEscalationPolicy"),
+						},
+					},
+					"ephemeralStorage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EphemeralStorage is escalated after the pod is evicted for exceeding its ephemeral storage",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceEscalationPolicy"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceEscalationPolicy"},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_ResourceEscalationPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceEscalationPolicy is how a resource is escalated",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"factor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Factor is a decimal, such as \"1.5\", which the request and limit of the resource are multiplied by for each failure. It must be at least 1.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"max": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Max is the most the request and limit of the resource are raised to",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+				Required: []string{"factor"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_pkg_apis_workflow_v1alpha1_ResourceTemplate(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5383,6 +6396,49 @@ func schema_pkg_apis_workflow_v1alpha1_RetryNodeAntiAffinity(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_workflow_v1alpha1_RetryRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RetryRule is the limit and backoff of the retries of the failures which match its expression",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the unique name of the rule, which the failures it matched are counted by in the retryRuleCounts of the node",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expression": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Expression is a condition expression, with the same variables as the expression of the retry strategy, which a failure must match. A rule without one matches all failures.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limit is the maximum number of retries of the failures which match the rule",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"backoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backoff is the backoff of the retries of the failures which match the rule, whose factor is raised to the number of its retries",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Backoff"),
+						},
+					},
+				},
+				Required: []string{"name", "limit"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Backoff", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
 func schema_pkg_apis_workflow_v1alpha1_RetryStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5422,11 +6478,31 @@ func schema_pkg_apis_workflow_v1alpha1_RetryStrategy(ref common.ReferenceCallbac
 							Format:      "",
 						},
 					},
+					"resourceEscalation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceEscalation raises the resources of retries of pods which failed for lack of them",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceEscalation"),
+						},
+					},
+					"rules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rules give different limits and backoffs to different failures. The first rule whose expression matches a failure applies to it, and failures which no rule matches are not retried. Rules cannot be used with limit, backoff or expression.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryRule"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Backoff", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryAffinity", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Backoff", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceEscalation", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryAffinity", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryRule", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
 	}
 }
 
@@ -6181,6 +7257,13 @@ func schema_pkg_apis_workflow_v1alpha1_SemaphoreRef(ref common.ReferenceCallback
 							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SyncDatabaseRef"),
 						},
 					},
+					"leaseDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LeaseDuration is the longest time (e.g. \"30m\", \"2h\") the semaphore may be held for. A hold which lasts longer is revoked, and the holding node or workflow fails.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -6541,6 +7624,45 @@ func schema_pkg_apis_workflow_v1alpha1_Synchronization(ref common.ReferenceCallb
 	}
 }
 
+func schema_pkg_apis_workflow_v1alpha1_SynchronizationLease(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SynchronizationLease records when the lease of a holder on a mutex or semaphore expires, so that the lease survives a restart of the controller",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lock": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Lock is the key of the mutex or semaphore",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"holder": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Holder is the key of the holder of the lock",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expiry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Expiry is when the lease expires",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"lock", "holder", "expiry"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_workflow_v1alpha1_SynchronizationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -6560,11 +7682,30 @@ func schema_pkg_apis_workflow_v1alpha1_SynchronizationStatus(ref common.Referenc
 							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.MutexStatus"),
 						},
 					},
+					"leases": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Leases stores when the leases on the mutexes and semaphores held by this workflow expire",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SynchronizationLease"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.MutexStatus", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SemaphoreStatus"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.MutexStatus", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SemaphoreStatus", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SynchronizationLease"},
 	}
 }
 
@@ -6616,6 +7757,13 @@ func schema_pkg_apis_workflow_v1alpha1_TarStrategy(ref common.ReferenceCallback)
 							Format:      "int32",
 						},
 					},
+					"compression": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Compression of the tarball when saving, either gzip (the default) or none for an uncompressed tarball. Loading a tarball detects its compression, so this is ignored for input artifacts.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -6987,11 +8135,17 @@ func schema_pkg_apis_workflow_v1alpha1_Template(ref common.ReferenceCallback) co
 							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
 						},
 					},
+					"timeoutPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutPolicy acts when the node has run for a soft timeout, before its timeout or activeDeadlineSeconds fails it, e.g. so that it can checkpoint and exit gracefully. It may only be applied to Container and Script templates.",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TimeoutPolicy"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactLocation", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ContainerSetTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.DAGTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Data", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExecutorConfig", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTP", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Inputs", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Memoize", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Metadata", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Metrics", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ParallelSteps", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Plugin", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ScriptTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SuspendTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Synchronization", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.UserContainer", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ArtifactLocation", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ContainerSetTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.DAGTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Data", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ExecutorConfig", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTP", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Inputs", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Memoize", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Metadata", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Metrics", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ParallelSteps", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Plugin", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ResourceTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.RetryStrategy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.ScriptTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.SuspendTemplate", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Synchronization", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.TimeoutPolicy", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.UserContainer", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
 	}
 }
 
@@ -7029,6 +8183,56 @@ func schema_pkg_apis_workflow_v1alpha1_TemplateRef(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_workflow_v1alpha1_TimeoutPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TimeoutPolicy is what happens when a node has run for its soft timeout",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"softTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SoftTimeout is the duration, counting from the node's start time, after which the policy acts, e.g. \"2h\"",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hook": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hook is the name of a lifecycle hook of the step or DAG task which is run when the soft timeout passes. The hook does not need an expression.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"terminate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Terminate sends SIGTERM to the main container when the soft timeout passes, and SIGKILL once the grace period has passed",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"gracePeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GracePeriod is how long the main container has to exit after SIGTERM, e.g. \"5m\". Defaults to 30s.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"result": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Result is the phase the node completes with after the soft timeout: Succeeded, Failed, or Continue (the default) for the phase it would otherwise complete with",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"softTimeout"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_workflow_v1alpha1_TransformationStep(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -8388,6 +9592,13 @@ func schema_pkg_apis_workflow_v1alpha1_WorkflowStatus(ref common.ReferenceCallba
 							Format:      "int32",
 						},
 					},
+					"estimatedDurationUpperBound": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EstimatedDurationUpperBound in seconds, a duration that recent runs have rarely exceeded. Only set when durations are estimated from the history of archived workflows.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"progress": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Progress to completion",
@@ -8704,12 +9915,33 @@ func schema_pkg_apis_workflow_v1alpha1_WorkflowTaskResult(ref common.ReferenceCa
 							Format: "",
 						},
 					},
+					"containerOutputs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerOutputs are the outputs of the containers of a container set, by container name",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs"),
+									},
+								},
+							},
+						},
+					},
+					"httpPoll": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTPPoll is the state of an HTTP template which is polling for the completion of its operation, so that a restarted agent resumes polling rather than sending the request again",
+							Ref:         ref("github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPPollStatus"),
+						},
+					},
 				},
 				Required: []string{"metadata"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.HTTPPollStatus", "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1.Outputs", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -9037,8 +10269,35 @@ func schema_pkg_apis_workflow_v1alpha1_ZipStrategy(ref common.ReferenceCallback)
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ZipStrategy will unzip zipped input artifacts",
+				Description: "ZipStrategy will zip the file or directory when saving, and unzip zipped input artifacts",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_workflow_v1alpha1_ZstdStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ZstdStrategy will tar the file or directory and compress it with Zstandard when saving, and untar Zstandard compressed input artifacts",
 				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"compressionLevel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompressionLevel specifies the zstd compression level to use for the artifact, from 1 (fastest) to 22 (best compression). Defaults to 3.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"concurrency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Concurrency is the number of goroutines compressing the artifact in parallel. Defaults to the number of CPUs available to the container.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -37,4 +37,19 @@ type NodeResult struct {
 	Message  string    `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
 	Outputs  *Outputs  `json:"outputs,omitempty" protobuf:"bytes,3,opt,name=outputs"`
 	Progress Progress  `json:"progress,omitempty" protobuf:"bytes,4,opt,name=progress,casttype=Progress"`
+	// ContainerOutputs are the outputs of the containers of a container set, by container name
+	ContainerOutputs map[string]Outputs `json:"containerOutputs,omitempty" protobuf:"bytes,5,rep,name=containerOutputs"`
+	// HTTPPoll is the state of an HTTP template which is polling for the completion of its operation, so that a
+	// restarted agent resumes polling rather than sending the request again
+	HTTPPoll *HTTPPollStatus `json:"httpPoll,omitempty" protobuf:"bytes,6,opt,name=httpPoll"`
+}
+
+// HTTPPollStatus is the state of an HTTP template which is polling for the completion of its operation
+type HTTPPollStatus struct {
+	// URL is the URL polled
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// Delay is the delay until the poll after the next one, e.g. 20s
+	Delay string `json:"delay,omitempty" protobuf:"bytes,2,opt,name=delay"`
+	// Deadline is when polling stops, at the maxDuration of the poll's backoff
+	Deadline metav1.Time `json:"deadline,omitempty" protobuf:"bytes,3,opt,name=deadline"`
 }
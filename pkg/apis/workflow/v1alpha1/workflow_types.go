@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"math"
 	"net/url"
 	"os"
 	"path"
@@ -14,6 +15,7 @@ import (
 	"runtime"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -124,6 +126,9 @@ const (
 	ArtifactGCOnWorkflowDeletion   ArtifactGCStrategy = "OnWorkflowDeletion"
 	ArtifactGCNever                ArtifactGCStrategy = "Never"
 	ArtifactGCStrategyUndefined    ArtifactGCStrategy = ""
+	// ArtifactGCOnRetentionExpiry is used by the controller to delete the artifacts of a workflow when the retention
+	// of its artifact repository has expired. It cannot be set on a workflow or an artifact.
+	ArtifactGCOnRetentionExpiry ArtifactGCStrategy = "OnRetentionExpiry"
 )
 
 var AnyArtifactGCStrategy = map[ArtifactGCStrategy]bool{
@@ -244,7 +249,11 @@ func (w *Workflow) GetExecSpec() *WorkflowSpec {
 
 // GetArtifactGCStrategy returns the ultimate ArtifactGCStrategy for the Artifact
 // (defined on the Workflow level but can be overridden on the Artifact level).
+// Deduplicated artifacts are never garbage collected, as they may be shared between workflows.
 func (w *Workflow) GetArtifactGCStrategy(a *Artifact) ArtifactGCStrategy {
+	if a.Deduplicate {
+		return ArtifactGCNever
+	}
 	artifactStrategy := a.GetArtifactGC().GetStrategy()
 	wfStrategy := w.Spec.GetArtifactGC().GetStrategy()
 	strategy := wfStrategy
@@ -857,6 +866,10 @@ type Template struct {
 	// Requires the PodLevelResources feature gate to be enabled on the cluster (beta since Kubernetes v1.34).
 	// +optional
 	PodResources *apiv1.ResourceRequirements `json:"podResources,omitempty" protobuf:"bytes,46,opt,name=podResources"`
+
+	// TimeoutPolicy acts when the node has run for a soft timeout, before its timeout or activeDeadlineSeconds fails it,
+	// e.g. so that it can checkpoint and exit gracefully. It may only be applied to Container and Script templates.
+	TimeoutPolicy *TimeoutPolicy `json:"timeoutPolicy,omitempty" protobuf:"bytes,47,opt,name=timeoutPolicy"`
 }
 
 // SetType will set the template object based on template type.
@@ -1177,6 +1190,16 @@ type Artifact struct {
 
 	// Has this been deleted?
 	Deleted bool `json:"deleted,omitempty" protobuf:"varint,13,opt,name=deleted"`
+
+	// Deduplicate saves an output artifact under a key derived from the SHA-256 digest of its contents,
+	// and skips the upload if an artifact with the same digest was already saved to the repository.
+	// Deduplicated artifacts may be shared between workflows, so they are never garbage collected.
+	// Only supported for files and archives saved to S3, GCS, Azure and OSS repositories.
+	Deduplicate bool `json:"deduplicate,omitempty" protobuf:"varint,14,opt,name=deduplicate"`
+
+	// Digest of the contents of the artifact, formatted as sha256:<hex>.
+	// It is recorded when the artifact is saved, and verified when the artifact is loaded.
+	Digest string `json:"digest,omitempty" protobuf:"bytes,15,opt,name=digest"`
 }
 
 // GetArtifactGC returns the ArtifactGC that was defined by the artifact. If none was provided, a default value is returned.
@@ -1334,18 +1357,63 @@ type ArchiveStrategy struct {
 	Tar  *TarStrategy  `json:"tar,omitempty" protobuf:"bytes,1,opt,name=tar"`
 	None *NoneStrategy `json:"none,omitempty" protobuf:"bytes,2,opt,name=none"`
 	Zip  *ZipStrategy  `json:"zip,omitempty" protobuf:"bytes,3,opt,name=zip"`
+	Zstd *ZstdStrategy `json:"zstd,omitempty" protobuf:"bytes,4,opt,name=zstd"`
 }
 
+// TarCompression is the compression applied to a tarball
+type TarCompression string
+
+const (
+	TarCompressionGzip TarCompression = "gzip"
+	TarCompressionNone TarCompression = "none"
+)
+
 // TarStrategy will tar and gzip the file or directory when saving
 type TarStrategy struct {
 	// CompressionLevel specifies the gzip compression level to use for the artifact.
 	// Defaults to gzip.DefaultCompression.
 	CompressionLevel *int32 `json:"compressionLevel,omitempty" protobuf:"varint,1,opt,name=compressionLevel"`
+	// Compression of the tarball when saving, either gzip (the default) or none for an uncompressed tarball.
+	// Loading a tarball detects its compression, so this is ignored for input artifacts.
+	// +kubebuilder:validation:Enum="";gzip;none
+	Compression TarCompression `json:"compression,omitempty" protobuf:"bytes,2,opt,name=compression,casttype=TarCompression"`
+}
+
+// IsUncompressed returns whether the tarball is saved without compression
+func (s *TarStrategy) IsUncompressed() bool {
+	return s != nil && s.Compression == TarCompressionNone
 }
 
-// ZipStrategy will unzip zipped input artifacts
+// ZipStrategy will zip the file or directory when saving, and unzip zipped input artifacts
 type ZipStrategy struct{}
 
+// ZstdStrategy will tar the file or directory and compress it with Zstandard when saving,
+// and untar Zstandard compressed input artifacts
+type ZstdStrategy struct {
+	// CompressionLevel specifies the zstd compression level to use for the artifact, from 1 (fastest) to 22 (best compression).
+	// Defaults to 3.
+	CompressionLevel *int32 `json:"compressionLevel,omitempty" protobuf:"varint,1,opt,name=compressionLevel"`
+	// Concurrency is the number of goroutines compressing the artifact in parallel.
+	// Defaults to the number of CPUs available to the container.
+	Concurrency *int32 `json:"concurrency,omitempty" protobuf:"varint,2,opt,name=concurrency"`
+}
+
+// GetCompressionLevel returns the zstd compression level, defaulting to 3
+func (s *ZstdStrategy) GetCompressionLevel() int {
+	if s == nil || s.CompressionLevel == nil {
+		return 3
+	}
+	return int(*s.CompressionLevel)
+}
+
+// GetConcurrency returns the number of goroutines compressing in parallel, or 0 for the number of CPUs
+func (s *ZstdStrategy) GetConcurrency() int {
+	if s == nil || s.Concurrency == nil {
+		return 0
+	}
+	return int(*s.Concurrency)
+}
+
 // NoneStrategy indicates to skip tar process and upload the files or directory tree as independent
 // files. Note that if the artifact is a directory, the artifact driver must support the ability to
 // save/load the directory appropriately.
@@ -1923,6 +1991,14 @@ type SemaphoreRef struct {
 	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
 	// SyncDatabaseRef is a database reference for Semaphore configuration
 	Database *SyncDatabaseRef `json:"database,omitempty" protobuf:"bytes,3,opt,name=database"`
+	// LeaseDuration is the longest time (e.g. "30m", "2h") the semaphore may be held for.
+	// A hold which lasts longer is revoked, and the holding node or workflow fails.
+	LeaseDuration string `json:"leaseDuration,omitempty" protobuf:"bytes,4,opt,name=leaseDuration"`
+}
+
+// GetLeaseDuration returns the lease duration of the semaphore, or zero if it may be held indefinitely
+func (s *SemaphoreRef) GetLeaseDuration() (time.Duration, error) {
+	return parseLeaseDuration(s.LeaseDuration)
 }
 
 // Mutex holds Mutex configuration
@@ -1933,6 +2009,28 @@ type Mutex struct {
 	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
 	// Database specifies this is database controlled if this is set true
 	Database bool `json:"database,omitempty" protobuf:"bytes,3,opt,name=database"`
+	// LeaseDuration is the longest time (e.g. "30m", "2h") the mutex may be held for.
+	// A hold which lasts longer is revoked, and the holding node or workflow fails.
+	LeaseDuration string `json:"leaseDuration,omitempty" protobuf:"bytes,4,opt,name=leaseDuration"`
+}
+
+// GetLeaseDuration returns the lease duration of the mutex, or zero if it may be held indefinitely
+func (m *Mutex) GetLeaseDuration() (time.Duration, error) {
+	return parseLeaseDuration(m.LeaseDuration)
+}
+
+func parseLeaseDuration(leaseDuration string) (time.Duration, error) {
+	if leaseDuration == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(leaseDuration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid leaseDuration %q: %w", leaseDuration, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("leaseDuration %q must be positive", leaseDuration)
+	}
+	return d, nil
 }
 
 // WorkflowTemplateRef is a reference to a WorkflowTemplate resource.
@@ -2177,6 +2275,10 @@ type WorkflowStatus struct {
 	// EstimatedDuration in seconds.
 	EstimatedDuration EstimatedDuration `json:"estimatedDuration,omitempty" protobuf:"varint,16,opt,name=estimatedDuration,casttype=EstimatedDuration"`
 
+	// EstimatedDurationUpperBound in seconds, a duration that recent runs have rarely exceeded.
+	// Only set when durations are estimated from the history of archived workflows.
+	EstimatedDurationUpperBound EstimatedDuration `json:"estimatedDurationUpperBound,omitempty" protobuf:"varint,21,opt,name=estimatedDurationUpperBound,casttype=EstimatedDuration"`
+
 	// Progress to completion
 	Progress Progress `json:"progress,omitempty" protobuf:"bytes,17,opt,name=progress,casttype=Progress"`
 
@@ -2366,18 +2468,128 @@ type RetryStrategy struct {
 	// Expression is a condition expression for when a node will be retried. If it evaluates to false, the node will not
 	// be retried and the retry strategy will be ignored
 	Expression string `json:"expression,omitempty" protobuf:"bytes,5,opt,name=expression"`
+
+	// ResourceEscalation raises the resources of retries of pods which failed for lack of them
+	ResourceEscalation *ResourceEscalation `json:"resourceEscalation,omitempty" protobuf:"bytes,6,opt,name=resourceEscalation"`
+
+	// Rules give different limits and backoffs to different failures. The first rule whose expression matches a failure
+	// applies to it, and failures which no rule matches are not retried. Rules cannot be used with limit, backoff or
+	// expression.
+	Rules []RetryRule `json:"rules,omitempty" protobuf:"bytes,7,rep,name=rules"`
+}
+
+// RetryRule is the limit and backoff of the retries of the failures which match its expression
+type RetryRule struct {
+	// Name is the unique name of the rule, which the failures it matched are counted by in the retryRuleCounts of
+	// the node
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+
+	// Expression is a condition expression, with the same variables as the expression of the retry strategy, which
+	// a failure must match. A rule without one matches all failures.
+	Expression string `json:"expression,omitempty" protobuf:"bytes,2,opt,name=expression"`
+
+	// Limit is the maximum number of retries of the failures which match the rule
+	Limit *intstr.IntOrString `json:"limit" protobuf:"varint,3,opt,name=limit"`
+
+	// Backoff is the backoff of the retries of the failures which match the rule, whose factor is raised to the
+	// number of its retries
+	Backoff *Backoff `json:"backoff,omitempty" protobuf:"bytes,4,opt,name=backoff"`
+}
+
+// ResourceEscalation raises the requests and limits of the main containers of retries of pods which failed for lack
+// of a resource, once for each such failure of the previous attempts
+type ResourceEscalation struct {
+	// Memory is escalated after a container of the pod is OOMKilled
+	Memory *ResourceEscalationPolicy `json:"memory,omitempty" protobuf:"bytes,1,opt,name=memory"`
+
+	// EphemeralStorage is escalated after the pod is evicted for exceeding its ephemeral storage
+	EphemeralStorage *ResourceEscalationPolicy `json:"ephemeralStorage,omitempty" protobuf:"bytes,2,opt,name=ephemeralStorage"`
+}
+
+// ResourceEscalationPolicy is how a resource is escalated
+type ResourceEscalationPolicy struct {
+	// Factor is a decimal, such as "1.5", which the request and limit of the resource are multiplied by for each
+	// failure. It must be at least 1.
+	Factor string `json:"factor" protobuf:"bytes,1,opt,name=factor"`
+
+	// Max is the most the request and limit of the resource are raised to
+	Max *resource.Quantity `json:"max,omitempty" protobuf:"bytes,2,opt,name=max"`
+}
+
+// GetFactor returns the factor the resource is multiplied by for each failure
+func (p ResourceEscalationPolicy) GetFactor() (float64, error) {
+	factor, err := strconv.ParseFloat(p.Factor, 64)
+	if err != nil || math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return 0, fmt.Errorf("must be a decimal, such as \"1.5\": %q", p.Factor)
+	}
+	if factor < 1 {
+		return 0, fmt.Errorf("must be at least 1: %q", p.Factor)
+	}
+	return factor, nil
+}
+
+// TimeoutResult is the phase a node completes with after its soft timeout
+type TimeoutResult string
+
+const (
+	TimeoutResultContinue  TimeoutResult = "Continue"
+	TimeoutResultSucceeded TimeoutResult = "Succeeded"
+	TimeoutResultFailed    TimeoutResult = "Failed"
+)
+
+// TimeoutPolicy is what happens when a node has run for its soft timeout
+type TimeoutPolicy struct {
+	// SoftTimeout is the duration, counting from the node's start time, after which the policy acts, e.g. "2h"
+	SoftTimeout string `json:"softTimeout" protobuf:"bytes,1,opt,name=softTimeout"`
+
+	// Hook is the name of a lifecycle hook of the step or DAG task which is run when the soft timeout passes. The hook
+	// does not need an expression.
+	Hook string `json:"hook,omitempty" protobuf:"bytes,2,opt,name=hook"`
+
+	// Terminate sends SIGTERM to the main container when the soft timeout passes, and SIGKILL once the grace period
+	// has passed
+	Terminate bool `json:"terminate,omitempty" protobuf:"varint,3,opt,name=terminate"`
+
+	// GracePeriod is how long the main container has to exit after SIGTERM, e.g. "5m". Defaults to 30s.
+	GracePeriod string `json:"gracePeriod,omitempty" protobuf:"bytes,4,opt,name=gracePeriod"`
+
+	// Result is the phase the node completes with after the soft timeout: Succeeded, Failed, or Continue (the
+	// default) for the phase it would otherwise complete with
+	Result TimeoutResult `json:"result,omitempty" protobuf:"bytes,5,opt,name=result,casttype=TimeoutResult"`
+}
+
+// GetSoftTimeout returns the duration after which the policy acts
+func (p TimeoutPolicy) GetSoftTimeout() (time.Duration, error) {
+	return ParseStringToDuration(p.SoftTimeout)
+}
+
+// GetGracePeriod returns how long the main container has to exit after SIGTERM
+func (p TimeoutPolicy) GetGracePeriod() (time.Duration, error) {
+	if p.GracePeriod == "" {
+		return 30 * time.Second, nil
+	}
+	return ParseStringToDuration(p.GracePeriod)
+}
+
+// GetResult returns the phase the node completes with after the soft timeout
+func (p TimeoutPolicy) GetResult() TimeoutResult {
+	if p.Result == "" {
+		return TimeoutResultContinue
+	}
+	return p.Result
 }
 
 // RetryPolicyActual gets the active retry policy for a strategy.
 // If the policy is explicit, use that.
 // If an expression is given, use a policy of Always so the
 // expression is all that controls the retry for 'least surprise'.
+// The same goes for rules.
 // Otherwise, if neither is given, default to retry OnFailure.
 func (s RetryStrategy) RetryPolicyActual() RetryPolicy {
 	if s.RetryPolicy != "" {
 		return s.RetryPolicy
 	}
-	if s.Expression == "" {
+	if s.Expression == "" && len(s.Rules) == 0 {
 		return RetryPolicyOnFailure
 	}
 	return RetryPolicyAlways
@@ -2568,12 +2780,32 @@ type NodeStatus struct {
 	// EstimatedDuration in seconds.
 	EstimatedDuration EstimatedDuration `json:"estimatedDuration,omitempty" protobuf:"varint,24,opt,name=estimatedDuration,casttype=EstimatedDuration"`
 
+	// EstimatedDurationUpperBound in seconds, a duration that recent runs have rarely exceeded.
+	// Only set when durations are estimated from the history of archived workflows.
+	EstimatedDurationUpperBound EstimatedDuration `json:"estimatedDurationUpperBound,omitempty" protobuf:"varint,31,opt,name=estimatedDurationUpperBound,casttype=EstimatedDuration"`
+
 	// Progress to completion
 	Progress Progress `json:"progress,omitempty" protobuf:"bytes,26,opt,name=progress,casttype=Progress"`
 
 	// ResourcesDuration is indicative, but not accurate, resource duration. This is populated when the nodes completes.
 	ResourcesDuration ResourcesDuration `json:"resourcesDuration,omitempty" protobuf:"bytes,21,opt,name=resourcesDuration"`
 
+	// EscalatedResources are the requests of the resources raised by the resource escalation of the retry strategy,
+	// summed over the containers of the pod. This is only set for retries which were escalated.
+	EscalatedResources apiv1.ResourceList `json:"escalatedResources,omitempty" protobuf:"bytes,32,rep,name=escalatedResources,casttype=k8s.io/api/core/v1.ResourceList,castkey=k8s.io/api/core/v1.ResourceName,castvalue=k8s.io/apimachinery/pkg/api/resource.Quantity"`
+
+	// ResourceFailure is the resource the pod of the node failed for lack of: memory if a main container was
+	// OOMKilled, or ephemeral-storage if the pod was evicted for it. It is recorded so that retries can escalate the
+	// resource once the pod has been deleted.
+	ResourceFailure apiv1.ResourceName `json:"resourceFailure,omitempty" protobuf:"bytes,35,opt,name=resourceFailure,casttype=k8s.io/api/core/v1.ResourceName"`
+
+	// SoftTimeout records the soft timeout of the timeout policy of the node passing. This is only set for pod nodes.
+	SoftTimeout *NodeSoftTimeout `json:"softTimeout,omitempty" protobuf:"bytes,34,opt,name=softTimeout"`
+
+	// RetryRuleCounts are the numbers of failed attempts of the node which each of the rules of its retry strategy
+	// matched, by the names of the rules. This is only set for retry nodes whose strategy has rules.
+	RetryRuleCounts map[string]int32 `json:"retryRuleCounts,omitempty" protobuf:"bytes,33,rep,name=retryRuleCounts"`
+
 	// PodIP captures the IP of the pod for daemoned steps
 	PodIP string `json:"podIP,omitempty" protobuf:"bytes,12,opt,name=podIP"`
 
@@ -2628,6 +2860,18 @@ type NodeStatus struct {
 	RestartingPodUID string `json:"restartingPodUID,omitempty" protobuf:"bytes,30,opt,name=restartingPodUID"`
 }
 
+// NodeSoftTimeout is the soft timeout of the timeout policy of a node, which passed
+type NodeSoftTimeout struct {
+	// At is when the soft timeout passed
+	At metav1.Time `json:"at" protobuf:"bytes,1,opt,name=at"`
+
+	// Hook is the name of the lifecycle hook the soft timeout runs
+	Hook string `json:"hook,omitempty" protobuf:"bytes,2,opt,name=hook"`
+
+	// Result is the phase the node completes with
+	Result TimeoutResult `json:"result,omitempty" protobuf:"bytes,3,opt,name=result,casttype=TimeoutResult"`
+}
+
 // Completed is used to determine if this node can proceed
 func (n *NodeStatus) Completed() bool {
 	synced := true
@@ -3562,6 +3806,20 @@ func (tmpl *Template) HasOutputs() bool {
 	return tmpl != nil && tmpl.Outputs.HasOutputs()
 }
 
+// GetOutputArtifacts returns the output artifacts of the template, and those of the containers of its container set
+func (tmpl *Template) GetOutputArtifacts() Artifacts {
+	if tmpl == nil {
+		return nil
+	}
+	artifacts := tmpl.Outputs.Artifacts
+	for _, ctr := range tmpl.ContainerSet.GetGraph() {
+		if ctr.Outputs != nil {
+			artifacts = slices.Concat(artifacts, ctr.Outputs.Artifacts)
+		}
+	}
+	return artifacts
+}
+
 // DAGTemplate is a template subtype for directed acyclic graph templates
 type DAGTemplate struct {
 	// Target are one or more names of targets to execute in a DAG
@@ -4163,12 +4421,51 @@ type MemoizationStatus struct {
 	Key string `json:"key" protobuf:"bytes,2,opt,name=key"`
 	// Cache is the name of the cache that was used
 	CacheName string `json:"cacheName" protobuf:"bytes,3,opt,name=cacheName"`
+	// CacheType is the type of the cache that was used: ConfigMapCache, DatabaseCache or ArtifactCache.
+	// An empty value means ConfigMapCache.
+	// +optional
+	CacheType string `json:"cacheType,omitempty" protobuf:"bytes,4,opt,name=cacheType"`
 }
 
-// Cache is the configuration for the type of cache to be used
+// Cache is the configuration for the type of cache to be used. Exactly one of the caches must be set.
 type Cache struct {
 	// ConfigMap sets a ConfigMap-based cache
-	ConfigMap *apiv1.LocalObjectReference `json:"configMap" protobuf:"bytes,1,opt,name=configMap"`
+	// +optional
+	ConfigMap *apiv1.LocalObjectReference `json:"configMap,omitempty" protobuf:"bytes,1,opt,name=configMap"`
+	// Database sets a cache stored in the persistence database configured for the controller
+	// +optional
+	Database *DatabaseCache `json:"database,omitempty" protobuf:"bytes,2,opt,name=database"`
+	// Artifact sets a cache stored in the default artifact repository configured for the controller
+	// +optional
+	Artifact *ArtifactCache `json:"artifact,omitempty" protobuf:"bytes,3,opt,name=artifact"`
+}
+
+// GetName returns the name of whichever cache is set
+func (c *Cache) GetName() string {
+	switch {
+	case c == nil:
+		return ""
+	case c.ConfigMap != nil:
+		return c.ConfigMap.Name
+	case c.Database != nil:
+		return c.Database.Name
+	case c.Artifact != nil:
+		return c.Artifact.Name
+	default:
+		return ""
+	}
+}
+
+// DatabaseCache is a memoization cache whose entries are rows in the persistence database
+type DatabaseCache struct {
+	// Name of the cache, entries with the same key in different caches are independent
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+}
+
+// ArtifactCache is a memoization cache whose entries are objects in the artifact repository
+type ArtifactCache struct {
+	// Name of the cache, entries are stored under the key prefix "memoization/<name>/"
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
 }
 
 type SynchronizationAction interface {
@@ -4366,6 +4663,20 @@ type SynchronizationStatus struct {
 	Semaphore *SemaphoreStatus `json:"semaphore,omitempty" protobuf:"bytes,1,opt,name=semaphore"`
 	// Mutex stores this workflow's mutex holder details
 	Mutex *MutexStatus `json:"mutex,omitempty" protobuf:"bytes,2,opt,name=mutex"`
+	// Leases stores when the leases on the mutexes and semaphores held by this workflow expire
+	// +listType=atomic
+	Leases []SynchronizationLease `json:"leases,omitempty" protobuf:"bytes,3,rep,name=leases"`
+}
+
+// SynchronizationLease records when the lease of a holder on a mutex or semaphore expires, so that the lease
+// survives a restart of the controller
+type SynchronizationLease struct {
+	// Lock is the key of the mutex or semaphore
+	Lock string `json:"lock" protobuf:"bytes,1,opt,name=lock"`
+	// Holder is the key of the holder of the lock
+	Holder string `json:"holder" protobuf:"bytes,2,opt,name=holder"`
+	// Expiry is when the lease expires
+	Expiry metav1.Time `json:"expiry" protobuf:"bytes,3,opt,name=expiry"`
 }
 
 type SynchronizationType string
@@ -4387,6 +4698,38 @@ func (ss *SynchronizationStatus) GetStatus(syncType SynchronizationType) Synchro
 	}
 }
 
+// GetLeaseExpiry returns when the lease of the holder on the lock expires, if it is recorded
+func (ss *SynchronizationStatus) GetLeaseExpiry(lockKey, holderKey string) (time.Time, bool) {
+	if ss == nil {
+		return time.Time{}, false
+	}
+	for _, lease := range ss.Leases {
+		if lease.Lock == lockKey && lease.Holder == holderKey {
+			return lease.Expiry.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// LeaseAcquired records when the lease of the holder on the lock expires, unless it is already recorded.
+// It returns whether the status was updated.
+func (ss *SynchronizationStatus) LeaseAcquired(lockKey, holderKey string, expiry time.Time) bool {
+	if _, ok := ss.GetLeaseExpiry(lockKey, holderKey); ok {
+		return false
+	}
+	ss.Leases = append(ss.Leases, SynchronizationLease{Lock: lockKey, Holder: holderKey, Expiry: metav1.NewTime(expiry)})
+	return true
+}
+
+// LeaseReleased removes the lease of the holder on the lock. It returns whether the status was updated.
+func (ss *SynchronizationStatus) LeaseReleased(lockKey, holderKey string) bool {
+	n := len(ss.Leases)
+	ss.Leases = slices.DeleteFunc(ss.Leases, func(lease SynchronizationLease) bool {
+		return lease.Lock == lockKey && lease.Holder == holderKey
+	})
+	return len(ss.Leases) != n
+}
+
 // NodeSynchronizationStatus stores the status of a node
 type NodeSynchronizationStatus struct {
 	// Waiting is the name of the lock that this node is waiting for
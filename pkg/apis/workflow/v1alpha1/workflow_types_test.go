@@ -162,6 +162,15 @@ func TestWorkflowGetArtifactGCStrategy(t *testing.T) {
                         strategy: ""`,
 			expectedStrategy: ArtifactGCNever,
 		},
+		{
+			name: "Deduplicated",
+			workflowArtGCStrategySpec: `
+              artifactGC:
+                strategy: OnWorkflowCompletion`,
+			artifactGCStrategySpec: `
+                      deduplicate: true`,
+			expectedStrategy: ArtifactGCNever,
+		},
 	}
 
 	for _, tt := range tests {
@@ -8,9 +8,9 @@ package v1alpha1
 import (
 	json "encoding/json"
 
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -49,6 +49,11 @@ func (in *ArchiveStrategy) DeepCopyInto(out *ArchiveStrategy) {
 		*out = new(ZipStrategy)
 		**out = **in
 	}
+	if in.Zstd != nil {
+		in, out := &in.Zstd, &out.Zstd
+		*out = new(ZstdStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -154,6 +159,44 @@ func (in *Artifact) DeepCopy() *Artifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactCache) DeepCopyInto(out *ArtifactCache) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactCache.
+func (in *ArtifactCache) DeepCopy() *ArtifactCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactContents) DeepCopyInto(out *ArtifactContents) {
+	*out = *in
+	in.Artifact.DeepCopyInto(&out.Artifact)
+	if in.CSV != nil {
+		in, out := &in.CSV, &out.CSV
+		*out = new(CSVOptions)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactContents.
+func (in *ArtifactContents) DeepCopy() *ArtifactContents {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactContents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactGC) DeepCopyInto(out *ArtifactGC) {
 	*out = *in
@@ -337,6 +380,22 @@ func (in *ArtifactPaths) DeepCopy() *ArtifactPaths {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactRedirect) DeepCopyInto(out *ArtifactRedirect) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactRedirect.
+func (in *ArtifactRedirect) DeepCopy() *ArtifactRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactRepository) DeepCopyInto(out *ArtifactRepository) {
 	*out = *in
@@ -380,6 +439,30 @@ func (in *ArtifactRepository) DeepCopyInto(out *ArtifactRepository) {
 		*out = new(PluginArtifactRepository)
 		**out = **in
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ArtifactRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(ArtifactRedirect)
+		**out = **in
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]ArtifactRepositoryLocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]ArtifactRepositoryLocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -393,6 +476,52 @@ func (in *ArtifactRepository) DeepCopy() *ArtifactRepository {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactRepositoryLocation) DeepCopyInto(out *ArtifactRepositoryLocation) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3ArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Artifactory != nil {
+		in, out := &in.Artifactory, &out.Artifactory
+		*out = new(ArtifactoryArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HDFS != nil {
+		in, out := &in.HDFS, &out.HDFS
+		*out = new(HDFSArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OSS != nil {
+		in, out := &in.OSS, &out.OSS
+		*out = new(OSSArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCSArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactRepositoryLocation.
+func (in *ArtifactRepositoryLocation) DeepCopy() *ArtifactRepositoryLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactRepositoryLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactRepositoryRef) DeepCopyInto(out *ArtifactRepositoryRef) {
 	*out = *in
@@ -475,6 +604,32 @@ func (in *ArtifactResultNodeStatus) DeepCopy() *ArtifactResultNodeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactRetention) DeepCopyInto(out *ArtifactRetention) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepSelector != nil {
+		in, out := &in.KeepSelector, &out.KeepSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactRetention.
+func (in *ArtifactRetention) DeepCopy() *ArtifactRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactSearchQuery) DeepCopyInto(out *ArtifactSearchQuery) {
 	*out = *in
@@ -588,12 +743,12 @@ func (in *ArtifactoryAuth) DeepCopyInto(out *ArtifactoryAuth) {
 	*out = *in
 	if in.UsernameSecret != nil {
 		in, out := &in.UsernameSecret, &out.UsernameSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PasswordSecret != nil {
 		in, out := &in.PasswordSecret, &out.PasswordSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -670,7 +825,7 @@ func (in *AzureBlobContainer) DeepCopyInto(out *AzureBlobContainer) {
 	*out = *in
 	if in.AccountKeySecret != nil {
 		in, out := &in.AccountKeySecret, &out.AccountKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -712,12 +867,12 @@ func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
 	if in.UsernameSecret != nil {
 		in, out := &in.UsernameSecret, &out.UsernameSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PasswordSecret != nil {
 		in, out := &in.PasswordSecret, &out.PasswordSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -733,12 +888,38 @@ func (in *BasicAuth) DeepCopy() *BasicAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSVOptions) DeepCopyInto(out *CSVOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSVOptions.
+func (in *CSVOptions) DeepCopy() *CSVOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CSVOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cache) DeepCopyInto(out *Cache) {
 	*out = *in
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(v1.LocalObjectReference)
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(DatabaseCache)
+		**out = **in
+	}
+	if in.Artifact != nil {
+		in, out := &in.Artifact, &out.Artifact
+		*out = new(ArtifactCache)
 		**out = **in
 	}
 	return
@@ -754,17 +935,38 @@ func (in *Cache) DeepCopy() *Cache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatchUpPolicy) DeepCopyInto(out *CatchUpPolicy) {
+	*out = *in
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatchUpPolicy.
+func (in *CatchUpPolicy) DeepCopy() *CatchUpPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CatchUpPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientCertAuth) DeepCopyInto(out *ClientCertAuth) {
 	*out = *in
 	if in.ClientCertSecret != nil {
 		in, out := &in.ClientCertSecret, &out.ClientCertSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ClientKeySecret != nil {
 		in, out := &in.ClientKeySecret, &out.ClientKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -923,6 +1125,16 @@ func (in *ContainerNode) DeepCopyInto(out *ContainerNode) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = new(Outputs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryStrategy != nil {
+		in, out := &in.RetryStrategy, &out.RetryStrategy
+		*out = new(ContainerSetRetryStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -944,6 +1156,11 @@ func (in *ContainerSetRetryStrategy) DeepCopyInto(out *ContainerSetRetryStrategy
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.Factor != nil {
+		in, out := &in.Factor, &out.Factor
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	return
 }
 
@@ -969,7 +1186,7 @@ func (in *ContainerSetTemplate) DeepCopyInto(out *ContainerSetTemplate) {
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1068,6 +1285,37 @@ func (in *CronWorkflow) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronWorkflowExclusions) DeepCopyInto(out *CronWorkflowExclusions) {
+	*out = *in
+	if in.DateRanges != nil {
+		in, out := &in.DateRanges, &out.DateRanges
+		*out = make([]ExclusionDateRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]ExclusionWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.HolidaysConfigMap != nil {
+		in, out := &in.HolidaysConfigMap, &out.HolidaysConfigMap
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronWorkflowExclusions.
+func (in *CronWorkflowExclusions) DeepCopy() *CronWorkflowExclusions {
+	if in == nil {
+		return nil
+	}
+	out := new(CronWorkflowExclusions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CronWorkflowList) DeepCopyInto(out *CronWorkflowList) {
 	*out = *in
@@ -1122,7 +1370,7 @@ func (in *CronWorkflowSpec) DeepCopyInto(out *CronWorkflowSpec) {
 	}
 	if in.WorkflowMetadata != nil {
 		in, out := &in.WorkflowMetadata, &out.WorkflowMetadata
-		*out = new(metav1.ObjectMeta)
+		*out = new(v1.ObjectMeta)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.StopStrategy != nil {
@@ -1135,6 +1383,16 @@ func (in *CronWorkflowSpec) DeepCopyInto(out *CronWorkflowSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CatchUpPolicy != nil {
+		in, out := &in.CatchUpPolicy, &out.CatchUpPolicy
+		*out = new(CatchUpPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exclusions != nil {
+		in, out := &in.Exclusions, &out.Exclusions
+		*out = new(CronWorkflowExclusions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1153,7 +1411,7 @@ func (in *CronWorkflowStatus) DeepCopyInto(out *CronWorkflowStatus) {
 	*out = *in
 	if in.Active != nil {
 		in, out := &in.Active, &out.Active
-		*out = make([]v1.ObjectReference, len(*in))
+		*out = make([]corev1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.LastScheduledTime != nil {
@@ -1292,6 +1550,16 @@ func (in *DataSource) DeepCopyInto(out *DataSource) {
 		*out = new(ArtifactPaths)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ArtifactContents != nil {
+		in, out := &in.ArtifactContents, &out.ArtifactContents
+		*out = new(ArtifactContents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -1305,6 +1573,22 @@ func (in *DataSource) DeepCopy() *DataSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseCache) DeepCopyInto(out *DatabaseCache) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseCache.
+func (in *DatabaseCache) DeepCopy() *DatabaseCache {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Event) DeepCopyInto(out *Event) {
 	*out = *in
@@ -1321,6 +1605,38 @@ func (in *Event) DeepCopy() *Event {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExclusionDateRange) DeepCopyInto(out *ExclusionDateRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExclusionDateRange.
+func (in *ExclusionDateRange) DeepCopy() *ExclusionDateRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ExclusionDateRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExclusionWindow) DeepCopyInto(out *ExclusionWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExclusionWindow.
+func (in *ExclusionWindow) DeepCopy() *ExclusionWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ExclusionWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecutorConfig) DeepCopyInto(out *ExecutorConfig) {
 	*out = *in
@@ -1428,7 +1744,7 @@ func (in *GCSBucket) DeepCopyInto(out *GCSBucket) {
 	*out = *in
 	if in.ServiceAccountKeySecret != nil {
 		in, out := &in.ServiceAccountKeySecret, &out.ServiceAccountKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -1480,17 +1796,17 @@ func (in *GitArtifact) DeepCopyInto(out *GitArtifact) {
 	}
 	if in.UsernameSecret != nil {
 		in, out := &in.UsernameSecret, &out.UsernameSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PasswordSecret != nil {
 		in, out := &in.PasswordSecret, &out.PasswordSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SSHPrivateKeySecret != nil {
 		in, out := &in.SSHPrivateKeySecret, &out.SSHPrivateKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -1567,17 +1883,17 @@ func (in *HDFSKrbConfig) DeepCopyInto(out *HDFSKrbConfig) {
 	*out = *in
 	if in.KrbCCacheSecret != nil {
 		in, out := &in.KrbCCacheSecret, &out.KrbCCacheSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.KrbKeytabSecret != nil {
 		in, out := &in.KrbKeytabSecret, &out.KrbKeytabSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.KrbConfigConfigMap != nil {
 		in, out := &in.KrbConfigConfigMap, &out.KrbConfigConfigMap
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -1613,6 +1929,26 @@ func (in *HTTP) DeepCopyInto(out *HTTP) {
 		*out = new(HTTPBodySource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Poll != nil {
+		in, out := &in.Poll, &out.Poll
+		*out = new(HTTPPoll)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(HTTPRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(HTTPAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CACertSecret != nil {
+		in, out := &in.CACertSecret, &out.CACertSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1718,7 +2054,7 @@ func (in *HTTPHeaderSource) DeepCopyInto(out *HTTPHeaderSource) {
 	*out = *in
 	if in.SecretKeyRef != nil {
 		in, out := &in.SecretKeyRef, &out.SecretKeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -1756,6 +2092,80 @@ func (in HTTPHeaders) DeepCopy() HTTPHeaders {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPPoll) DeepCopyInto(out *HTTPPoll) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPoll.
+func (in *HTTPPoll) DeepCopy() *HTTPPoll {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPPoll)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPPollStatus) DeepCopyInto(out *HTTPPollStatus) {
+	*out = *in
+	in.Deadline.DeepCopyInto(&out.Deadline)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPollStatus.
+func (in *HTTPPollStatus) DeepCopy() *HTTPPollStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPPollStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRetry) DeepCopyInto(out *HTTPRetry) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkErrors != nil {
+		in, out := &in.NetworkErrors, &out.NetworkErrors
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRetry.
+func (in *HTTPRetry) DeepCopy() *HTTPRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Header) DeepCopyInto(out *Header) {
 	*out = *in
@@ -2164,6 +2574,18 @@ func (in *NodeResult) DeepCopyInto(out *NodeResult) {
 		*out = new(Outputs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ContainerOutputs != nil {
+		in, out := &in.ContainerOutputs, &out.ContainerOutputs
+		*out = make(map[string]Outputs, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.HTTPPoll != nil {
+		in, out := &in.HTTPPoll, &out.HTTPPoll
+		*out = new(HTTPPollStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2177,6 +2599,23 @@ func (in *NodeResult) DeepCopy() *NodeResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSoftTimeout) DeepCopyInto(out *NodeSoftTimeout) {
+	*out = *in
+	in.At.DeepCopyInto(&out.At)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSoftTimeout.
+func (in *NodeSoftTimeout) DeepCopy() *NodeSoftTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSoftTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
@@ -2194,6 +2633,25 @@ func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.EscalatedResources != nil {
+		in, out := &in.EscalatedResources, &out.EscalatedResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.SoftTimeout != nil {
+		in, out := &in.SoftTimeout, &out.SoftTimeout
+		*out = new(NodeSoftTimeout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryRuleCounts != nil {
+		in, out := &in.RetryRuleCounts, &out.RetryRuleCounts
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Daemoned != nil {
 		in, out := &in.Daemoned, &out.Daemoned
 		*out = new(bool)
@@ -2311,17 +2769,17 @@ func (in *OAuth2Auth) DeepCopyInto(out *OAuth2Auth) {
 	*out = *in
 	if in.ClientIDSecret != nil {
 		in, out := &in.ClientIDSecret, &out.ClientIDSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ClientSecretSecret != nil {
 		in, out := &in.ClientSecretSecret, &out.ClientSecretSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.TokenURLSecret != nil {
 		in, out := &in.TokenURLSecret, &out.TokenURLSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Scopes != nil {
@@ -2402,12 +2860,12 @@ func (in *OSSBucket) DeepCopyInto(out *OSSBucket) {
 	*out = *in
 	if in.AccessKeySecret != nil {
 		in, out := &in.AccessKeySecret, &out.AccessKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SecretKeySecret != nil {
 		in, out := &in.SecretKeySecret, &out.SecretKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.LifecycleRule != nil {
@@ -2623,7 +3081,7 @@ func (in *PodGC) DeepCopyInto(out *PodGC) {
 	*out = *in
 	if in.LabelSelector != nil {
 		in, out := &in.LabelSelector, &out.LabelSelector
-		*out = new(metav1.LabelSelector)
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -2697,6 +3155,53 @@ func (in *RawArtifact) DeepCopy() *RawArtifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceEscalation) DeepCopyInto(out *ResourceEscalation) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(ResourceEscalationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EphemeralStorage != nil {
+		in, out := &in.EphemeralStorage, &out.EphemeralStorage
+		*out = new(ResourceEscalationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceEscalation.
+func (in *ResourceEscalation) DeepCopy() *ResourceEscalation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceEscalation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceEscalationPolicy) DeepCopyInto(out *ResourceEscalationPolicy) {
+	*out = *in
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceEscalationPolicy.
+func (in *ResourceEscalationPolicy) DeepCopy() *ResourceEscalationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceEscalationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceTemplate) DeepCopyInto(out *ResourceTemplate) {
 	*out = *in
@@ -2782,6 +3287,32 @@ func (in *RetryNodeAntiAffinity) DeepCopy() *RetryNodeAntiAffinity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryRule) DeepCopyInto(out *RetryRule) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryRule.
+func (in *RetryRule) DeepCopy() *RetryRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetryStrategy) DeepCopyInto(out *RetryStrategy) {
 	*out = *in
@@ -2800,6 +3331,18 @@ func (in *RetryStrategy) DeepCopyInto(out *RetryStrategy) {
 		*out = new(RetryAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceEscalation != nil {
+		in, out := &in.ResourceEscalation, &out.ResourceEscalation
+		*out = new(ResourceEscalation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RetryRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2857,17 +3400,17 @@ func (in *S3Bucket) DeepCopyInto(out *S3Bucket) {
 	}
 	if in.AccessKeySecret != nil {
 		in, out := &in.AccessKeySecret, &out.AccessKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SecretKeySecret != nil {
 		in, out := &in.SecretKeySecret, &out.SecretKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SessionTokenSecret != nil {
 		in, out := &in.SessionTokenSecret, &out.SessionTokenSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CreateBucketIfNotPresent != nil {
@@ -2882,7 +3425,7 @@ func (in *S3Bucket) DeepCopyInto(out *S3Bucket) {
 	}
 	if in.CASecret != nil {
 		in, out := &in.CASecret, &out.CASecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -2903,7 +3446,7 @@ func (in *S3EncryptionOptions) DeepCopyInto(out *S3EncryptionOptions) {
 	*out = *in
 	if in.ServerSideCustomerKeySecret != nil {
 		in, out := &in.ServerSideCustomerKeySecret, &out.ServerSideCustomerKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -2962,7 +3505,7 @@ func (in *SemaphoreRef) DeepCopyInto(out *SemaphoreRef) {
 	*out = *in
 	if in.ConfigMapKeyRef != nil {
 		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Database != nil {
@@ -3093,7 +3636,7 @@ func (in *SubmitOpts) DeepCopyInto(out *SubmitOpts) {
 	}
 	if in.OwnerReference != nil {
 		in, out := &in.OwnerReference, &out.OwnerReference
-		*out = new(metav1.OwnerReference)
+		*out = new(v1.OwnerReference)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Priority != nil {
@@ -3205,6 +3748,23 @@ func (in *Synchronization) DeepCopy() *Synchronization {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SynchronizationLease) DeepCopyInto(out *SynchronizationLease) {
+	*out = *in
+	in.Expiry.DeepCopyInto(&out.Expiry)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynchronizationLease.
+func (in *SynchronizationLease) DeepCopy() *SynchronizationLease {
+	if in == nil {
+		return nil
+	}
+	out := new(SynchronizationLease)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SynchronizationStatus) DeepCopyInto(out *SynchronizationStatus) {
 	*out = *in
@@ -3218,6 +3778,13 @@ func (in *SynchronizationStatus) DeepCopyInto(out *SynchronizationStatus) {
 		*out = new(MutexStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Leases != nil {
+		in, out := &in.Leases, &out.Leases
+		*out = make([]SynchronizationLease, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -3297,7 +3864,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	in.Metadata.DeepCopyInto(&out.Metadata)
@@ -3315,7 +3882,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.Container != nil {
 		in, out := &in.Container, &out.Container
-		*out = new(v1.Container)
+		*out = new(corev1.Container)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ContainerSet != nil {
@@ -3360,7 +3927,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3406,7 +3973,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3423,14 +3990,14 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.HostAliases != nil {
 		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]v1.HostAlias, len(*in))
+		*out = make([]corev1.HostAlias, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Metrics != nil {
@@ -3457,9 +4024,14 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.PodResources != nil {
 		in, out := &in.PodResources, &out.PodResources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TimeoutPolicy != nil {
+		in, out := &in.TimeoutPolicy, &out.TimeoutPolicy
+		*out = new(TimeoutPolicy)
+		**out = **in
+	}
 	return
 }
 
@@ -3489,6 +4061,22 @@ func (in *TemplateRef) DeepCopy() *TemplateRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeoutPolicy) DeepCopyInto(out *TimeoutPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeoutPolicy.
+func (in *TimeoutPolicy) DeepCopy() *TimeoutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeoutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Transformation) DeepCopyInto(out *Transformation) {
 	{
@@ -3557,7 +4145,7 @@ func (in *ValueFrom) DeepCopyInto(out *ValueFrom) {
 	}
 	if in.ConfigMapKeyRef != nil {
 		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Default != nil {
@@ -3891,14 +4479,14 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.VolumeClaimTemplates != nil {
 		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
+		*out = make([]corev1.PersistentVolumeClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3927,19 +4515,19 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.HostNetwork != nil {
@@ -3949,12 +4537,12 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	}
 	if in.DNSPolicy != nil {
 		in, out := &in.DNSPolicy, &out.DNSPolicy
-		*out = new(v1.DNSPolicy)
+		*out = new(corev1.DNSPolicy)
 		**out = **in
 	}
 	if in.DNSConfig != nil {
 		in, out := &in.DNSConfig, &out.DNSConfig
-		*out = new(v1.PodDNSConfig)
+		*out = new(corev1.PodDNSConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.TTLStrategy != nil {
@@ -3979,14 +4567,14 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	}
 	if in.HostAliases != nil {
 		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]v1.HostAlias, len(*in))
+		*out = make([]corev1.HostAlias, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodDisruptionBudget != nil {
@@ -4060,7 +4648,7 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	}
 	if in.PodResources != nil {
 		in, out := &in.PodResources, &out.PodResources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -4097,7 +4685,7 @@ func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 	}
 	if in.PersistentVolumeClaims != nil {
 		in, out := &in.PersistentVolumeClaims, &out.PersistentVolumeClaims
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4512,3 +5100,29 @@ func (in *ZipStrategy) DeepCopy() *ZipStrategy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZstdStrategy) DeepCopyInto(out *ZstdStrategy) {
+	*out = *in
+	if in.CompressionLevel != nil {
+		in, out := &in.CompressionLevel, &out.CompressionLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZstdStrategy.
+func (in *ZstdStrategy) DeepCopy() *ZstdStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ZstdStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
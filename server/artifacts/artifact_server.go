@@ -11,7 +11,9 @@ import (
 	"mime"
 	"net/http"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -53,6 +55,12 @@ const (
 	Inputs  Direction = "inputs"
 )
 
+// defaultRedirectExpiry is how long pre-signed URLs of artifacts are valid for, if their repository does not say
+const defaultRedirectExpiry = 5 * time.Minute
+
+// DigestHeader is the header holding the digest of a downloaded artifact, formatted as sha256:<hex>
+const DigestHeader = "X-Artifact-Digest"
+
 func NewArtifactServer(authN auth.Gatekeeper, hydrator hydrator.Interface, wfArchive sqldb.WorkflowArchive, instanceIDService instanceid.Service, artifactRepositories artifactrepositories.Interface, logger logging.Logger) *ArtifactServer {
 	return newArtifactServer(authN, hydrator, wfArchive, instanceIDService, artifacts.NewDriver, artifactRepositories, logger)
 }
@@ -455,7 +463,7 @@ func (a *ArtifactServer) GetArtifactFile(w http.ResponseWriter, r *http.Request)
 			"artifact": artifact,
 		}).Debug(ctx, "not a directory")
 
-		err = a.returnArtifact(ctx, w, artifact, driver)
+		err = a.returnArtifact(ctx, w, r, wf, artifact, driver)
 
 		if err != nil {
 			a.httpFromError(ctx, err, w)
@@ -535,7 +543,7 @@ func (a *ArtifactServer) getArtifact(w http.ResponseWriter, r *http.Request, isI
 		return
 	}
 
-	err = a.returnArtifact(ctx, w, art, driver)
+	err = a.returnArtifact(ctx, w, r, wf, art, driver)
 
 	if err != nil {
 		a.httpFromError(ctx, err, w)
@@ -599,7 +607,7 @@ func (a *ArtifactServer) getArtifactByUID(w http.ResponseWriter, r *http.Request
 		"isInput":      isInput,
 	}).Info(ctx, "Download artifact")
 
-	err = a.returnArtifact(ctx, w, art, driver)
+	err = a.returnArtifact(ctx, w, r, wf, art, driver)
 
 	if err != nil {
 		a.httpFromError(ctx, err, w)
@@ -725,6 +733,8 @@ func (a *ArtifactServer) getArtifactAndDriver(ctx context.Context, nodeID, artif
 		return art, nil, err
 	}
 	if fileName != nil {
+		// the digest is of the whole artifact, not of a file within it
+		art.Digest = ""
 		err = art.AppendToKey(*fileName)
 		if err != nil {
 			return art, nil, fmt.Errorf("error appending filename %s to key of artifact %+v: err: %w", *fileName, art, err)
@@ -756,9 +766,42 @@ func (a *ArtifactServer) setSecurityHeaders(w http.ResponseWriter) {
 	w.Header().Add("X-Frame-Options", env.GetString("ARGO_ARTIFACT_X_FRAME_OPTIONS", "SAMEORIGIN"))
 }
 
-func (a *ArtifactServer) returnArtifact(ctx context.Context, w http.ResponseWriter, art *wfv1.Artifact, driver common.ArtifactDriver) error {
+// returnArtifact redirects to a pre-signed URL of the artifact if the workflow's artifact repository redirects
+// downloads. Otherwise it returns the artifact, or the range of it requested by the Range header if the driver can get
+// its size, so an interrupted download can be resumed.
+func (a *ArtifactServer) returnArtifact(ctx context.Context, w http.ResponseWriter, r *http.Request, wf *wfv1.Workflow, art *wfv1.Artifact, driver common.ArtifactDriver) error {
 	logger := logging.RequireLoggerFromContext(ctx)
-	stream, err := driver.OpenStream(ctx, art)
+	if redirectURL := a.redirectURL(ctx, wf, art, driver); redirectURL != "" {
+		if art.Digest != "" {
+			w.Header().Add(DigestHeader, art.Digest)
+		}
+		// the URL expires, so the redirect must not be cached
+		w.Header().Set("Cache-Control", "no-store")
+		http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+		return nil
+	}
+	info, err := common.Stat(ctx, driver, art)
+	if err != nil && !errors.Is(err, common.ErrStatNotSupported) {
+		logger.WithError(err).Debug(ctx, "Unable to get the metadata of the artifact, so ranges are not supported")
+	}
+	var requestedRange *byteRange
+	if info != nil && ifRangeMatches(r.Header.Get("If-Range"), info) {
+		requestedRange, err = parseRange(r.Header.Get("Range"), info.Size)
+		if err != nil {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", info.Size))
+			http.Error(w, http.StatusText(http.StatusRequestedRangeNotSatisfiable), http.StatusRequestedRangeNotSatisfiable)
+			return nil
+		}
+	}
+
+	// whole artifacts with a digest are verified as they are streamed, but ranges are not, as that would mean reading
+	// the whole artifact
+	var stream io.ReadCloser
+	if requestedRange != nil {
+		stream, err = common.OpenStreamRange(ctx, driver, art, requestedRange.start, requestedRange.length())
+	} else {
+		stream, err = driver.OpenStream(ctx, art)
+	}
 	if err != nil {
 		return err
 	}
@@ -772,18 +815,81 @@ func (a *ArtifactServer) returnArtifact(ctx context.Context, w http.ResponseWrit
 	key, _ := art.GetKey()
 	w.Header().Add("Content-Disposition", fmt.Sprintf(`filename="%s"`, path.Base(key)))
 	w.Header().Add("Content-Type", mime.TypeByExtension(path.Ext(key)))
+	if art.Digest != "" {
+		// the digest is of the whole artifact, so a resumed download can be verified once it is complete
+		w.Header().Add(DigestHeader, art.Digest)
+	}
 	a.setSecurityHeaders(w)
 
+	statusCode := http.StatusOK
+	if info != nil {
+		w.Header().Set("Accept-Ranges", "bytes")
+		if info.ETag != "" {
+			w.Header().Set("ETag", quoteETag(info.ETag))
+		}
+		if !info.LastModified.IsZero() {
+			w.Header().Set("Last-Modified", info.LastModified.UTC().Format(http.TimeFormat))
+		}
+		w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	}
+	if requestedRange != nil {
+		w.Header().Set("Content-Range", requestedRange.contentRange(info.Size))
+		w.Header().Set("Content-Length", strconv.FormatInt(requestedRange.length(), 10))
+		statusCode = http.StatusPartialContent
+	}
+	w.WriteHeader(statusCode)
+
 	_, err = io.Copy(w, stream)
+	if errors.Is(err, common.ErrDigestMismatch) {
+		// the status has already been sent, so the connection is aborted to stop the client taking the corrupt
+		// artifact as complete
+		logger.WithError(err).Error(ctx, "Artifact failed verification")
+		panic(http.ErrAbortHandler)
+	}
 	if err != nil {
 		errStr := fmt.Sprintf("failed to stream artifact: %v", err)
 		http.Error(w, errStr, http.StatusInternalServerError)
 		return errors.New(errStr)
 	}
-	w.WriteHeader(http.StatusOK)
 	return nil
 }
 
+// redirectURL returns a pre-signed URL of the artifact if the workflow's artifact repository redirects downloads and
+// the driver can create one, or "" for the artifact to be streamed through the server
+func (a *ArtifactServer) redirectURL(ctx context.Context, wf *wfv1.Workflow, art *wfv1.Artifact, driver common.ArtifactDriver) string {
+	if wf.Status.ArtifactRepositoryRef == nil {
+		return ""
+	}
+	logger := logging.RequireLoggerFromContext(ctx)
+	// the repository is read again, rather than taken from the workflow's status, so redirects apply to all of its workflows
+	ref := wf.Status.ArtifactRepositoryRef.DeepCopy()
+	ref.ArtifactRepository = nil
+	repo, err := a.artifactRepositories.Get(ctx, ref)
+	if err != nil {
+		logger.WithError(err).Warn(ctx, "Unable to get the artifact repository, so the artifact is not redirected")
+		return ""
+	}
+	if repo == nil || repo.Redirect == nil {
+		return ""
+	}
+	expiry := defaultRedirectExpiry
+	if repo.Redirect.Expiry != "" {
+		expiry, err = wfv1.ParseStringToDuration(repo.Redirect.Expiry)
+		if err != nil {
+			logger.WithError(err).Warn(ctx, "Invalid expiry of artifact redirects, so the artifact is not redirected")
+			return ""
+		}
+	}
+	redirectURL, err := common.PresignedURL(ctx, driver, art, expiry)
+	if err != nil {
+		if !errors.Is(err, common.ErrPresignNotSupported) {
+			logger.WithError(err).Warn(ctx, "Unable to pre-sign the URL of the artifact, so it is not redirected")
+		}
+		return ""
+	}
+	return redirectURL
+}
+
 func (a *ArtifactServer) getWorkflowAndValidate(ctx context.Context, namespace string, workflowName string) (*wfv1.Workflow, error) {
 	wfClient := auth.GetWfClient(ctx)
 	wf, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, workflowName, metav1.GetOptions{})
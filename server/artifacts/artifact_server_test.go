@@ -16,6 +16,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	authorizationv1 "k8s.io/api/authorization/v1"
@@ -41,6 +42,7 @@ import (
 	"github.com/argoproj/argo-workflows/v4/util/logging"
 	armocks "github.com/argoproj/argo-workflows/v4/workflow/artifactrepositories/mocks"
 	artifactscommon "github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/integrity"
 	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/resource"
 	"github.com/argoproj/argo-workflows/v4/workflow/common"
 	hydratorfake "github.com/argoproj/argo-workflows/v4/workflow/hydrator/fake"
@@ -64,6 +66,29 @@ func (a *fakeArtifactDriver) Load(_ context.Context, _ *wfv1.Artifact, path stri
 	return os.WriteFile(path, a.data, 0o600)
 }
 
+// rangedArtifactDriver can get the metadata of artifacts and read part of them
+type rangedArtifactDriver struct {
+	fakeArtifactDriver
+}
+
+func (a *rangedArtifactDriver) Stat(_ context.Context, _ *wfv1.Artifact) (*artifactscommon.ArtifactInfo, error) {
+	return &artifactscommon.ArtifactInfo{Size: int64(len(a.data)), ETag: "v1"}, nil
+}
+
+func (a *rangedArtifactDriver) OpenStreamRange(_ context.Context, _ *wfv1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(a.data[offset : offset+length])), nil
+}
+
+// presigningArtifactDriver can create pre-signed URLs of artifacts
+type presigningArtifactDriver struct {
+	fakeArtifactDriver
+}
+
+func (a *presigningArtifactDriver) PresignedURL(_ context.Context, artifact *wfv1.Artifact, expiry time.Duration) (string, error) {
+	key, _ := artifact.GetKey()
+	return fmt.Sprintf("https://my-bucket.my-endpoint/%s?expiry=%s", key, expiry), nil
+}
+
 var bucketsOfKeys = map[string][]string{
 	"my-bucket": {
 		"my-wf/my-node-1/my-s3-input-artifact.tgz",
@@ -231,6 +256,7 @@ func newServer(t *testing.T) *ArtifactServer {
 										Key: "my-wf/my-node-1/my-s3-artifact.tgz",
 									},
 								},
+								Digest: "sha256:c0b8114a809d94b548e3f098b4b76b1589e8ea6297dc795b1377df2c99055385",
 							},
 							{
 								Name: "my-s3-artifact-directory",
@@ -585,10 +611,12 @@ func TestArtifactServer_GetOutputArtifact(t *testing.T) {
 	tests := []struct {
 		fileName     string
 		artifactName string
+		digest       string
 	}{
 		{
 			fileName:     "my-s3-artifact.tgz",
 			artifactName: "my-s3-artifact",
+			digest:       "sha256:c0b8114a809d94b548e3f098b4b76b1589e8ea6297dc795b1377df2c99055385",
 		},
 		{
 			fileName:     "my-gcs-artifact",
@@ -609,6 +637,7 @@ func TestArtifactServer_GetOutputArtifact(t *testing.T) {
 			s.GetOutputArtifact(recorder, r)
 			require.Equal(t, 200, recorder.Result().StatusCode)
 			assert.Equal(t, fmt.Sprintf(`filename="%s"`, tt.fileName), recorder.Header().Get("Content-Disposition"))
+			assert.Equal(t, tt.digest, recorder.Header().Get(DigestHeader))
 			all, err := io.ReadAll(recorder.Result().Body)
 			if err != nil {
 				panic(fmt.Sprintf("failed to read http body: %v", err))
@@ -618,6 +647,90 @@ func TestArtifactServer_GetOutputArtifact(t *testing.T) {
 	}
 }
 
+func TestArtifactServer_GetOutputArtifactDigestMismatch(t *testing.T) {
+	s := newServer(t)
+	s.artDriverFactory = func(_ context.Context, _ *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return integrity.New(&fakeArtifactDriver{data: []byte("my-corrupt-data")}), nil
+	}
+	r := &http.Request{}
+	r.URL = mustParse("/artifacts/my-ns/my-wf/my-node-1/my-s3-artifact")
+	recorder := httptest.NewRecorder()
+
+	// the artifact is verified as it is streamed, so the response is aborted once the mismatch is found
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { s.GetOutputArtifact(recorder, r) })
+}
+
+func TestArtifactServer_GetOutputArtifactRange(t *testing.T) {
+	s := newServer(t)
+	s.artDriverFactory = func(_ context.Context, _ *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return &rangedArtifactDriver{fakeArtifactDriver{data: []byte("my-data")}}, nil
+	}
+
+	tests := []struct {
+		name         string
+		header       http.Header
+		statusCode   int
+		contentRange string
+		body         string
+	}{
+		{name: "Whole", header: http.Header{}, statusCode: http.StatusOK, body: "my-data"},
+		{name: "Range", header: http.Header{"Range": {"bytes=3-"}}, statusCode: http.StatusPartialContent, contentRange: "bytes 3-6/7", body: "data"},
+		{name: "IfRangeMatch", header: http.Header{"Range": {"bytes=0-1"}, "If-Range": {`"v1"`}}, statusCode: http.StatusPartialContent, contentRange: "bytes 0-1/7", body: "my"},
+		{name: "IfRangeChanged", header: http.Header{"Range": {"bytes=3-"}, "If-Range": {`"v0"`}}, statusCode: http.StatusOK, body: "my-data"},
+		{name: "NotSatisfiable", header: http.Header{"Range": {"bytes=10-"}}, statusCode: http.StatusRequestedRangeNotSatisfiable, contentRange: "bytes */7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{Header: tt.header}
+			r.URL = mustParse("/artifacts/my-ns/my-wf/my-node-1/my-s3-artifact")
+			recorder := httptest.NewRecorder()
+
+			s.GetOutputArtifact(recorder, r)
+			require.Equal(t, tt.statusCode, recorder.Result().StatusCode)
+			assert.Equal(t, tt.contentRange, recorder.Header().Get("Content-Range"))
+			if tt.body != "" {
+				assert.Equal(t, "bytes", recorder.Header().Get("Accept-Ranges"))
+				assert.Equal(t, `"v1"`, recorder.Header().Get("ETag"))
+				assert.Equal(t, tt.body, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestArtifactServer_Redirect(t *testing.T) {
+	s := newServer(t)
+	ctx := logging.TestContext(t.Context())
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{ArtifactRepositoryRef: &wfv1.ArtifactRepositoryRefStatus{Default: true}}}
+	art := &wfv1.Artifact{Name: "my-s3-artifact", Digest: "sha256:my-digest", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-wf/my-s3-artifact.tgz"}}}
+	repo := func(redirect *wfv1.ArtifactRedirect) *armocks.Interface {
+		return armocks.DummyArtifactRepositories(&wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{}, Redirect: redirect})
+	}
+
+	t.Run("Redirect", func(t *testing.T) {
+		s.artifactRepositories = repo(&wfv1.ArtifactRedirect{Expiry: "1m"})
+		recorder := httptest.NewRecorder()
+		r := &http.Request{Method: http.MethodGet, URL: mustParse("/artifacts/my-ns/my-wf/my-node-1/my-s3-artifact")}
+
+		require.NoError(t, s.returnArtifact(ctx, recorder, r, wf, art, &presigningArtifactDriver{}))
+		assert.Equal(t, http.StatusTemporaryRedirect, recorder.Code)
+		assert.Equal(t, "https://my-bucket.my-endpoint/my-wf/my-s3-artifact.tgz?expiry=1m0s", recorder.Header().Get("Location"))
+		assert.Equal(t, "sha256:my-digest", recorder.Header().Get(DigestHeader))
+		assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+	})
+	t.Run("DefaultExpiry", func(t *testing.T) {
+		s.artifactRepositories = repo(&wfv1.ArtifactRedirect{})
+		assert.Equal(t, "https://my-bucket.my-endpoint/my-wf/my-s3-artifact.tgz?expiry=5m0s", s.redirectURL(ctx, wf, art, &presigningArtifactDriver{}))
+	})
+	t.Run("NotEnabled", func(t *testing.T) {
+		s.artifactRepositories = repo(nil)
+		assert.Empty(t, s.redirectURL(ctx, wf, art, &presigningArtifactDriver{}))
+	})
+	t.Run("NotSupported", func(t *testing.T) {
+		s.artifactRepositories = repo(&wfv1.ArtifactRedirect{})
+		assert.Empty(t, s.redirectURL(ctx, wf, art, &fakeArtifactDriver{}))
+	})
+}
+
 func TestArtifactServer_GetOutputArtifactWithTemplate(t *testing.T) {
 	s := newServer(t)
 
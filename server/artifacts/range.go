@@ -0,0 +1,86 @@
+package artifacts
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+)
+
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// byteRange is the range of bytes of an artifact from start to end, inclusive
+type byteRange struct {
+	start, end int64
+}
+
+func (r byteRange) length() int64 {
+	return r.end - r.start + 1
+}
+
+func (r byteRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.start, r.end, size)
+}
+
+// parseRange parses the Range header of a request for an artifact of the size. Only a single range of bytes is
+// supported, so nil is returned for requests without one, with several, or with an invalid header, and the whole
+// artifact is returned for them, as RFC 9110 allows.
+func parseRange(header string, size int64) (*byteRange, error) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return nil, nil
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil, nil
+	}
+	if first == "" {
+		// a suffix range, of the last bytes
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix < 0 {
+			return nil, nil
+		}
+		if suffix == 0 || size == 0 {
+			return nil, errRangeNotSatisfiable
+		}
+		return &byteRange{max(0, size-suffix), size - 1}, nil
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return nil, nil
+		}
+		end = min(end, size-1)
+	}
+	if start >= size {
+		return nil, errRangeNotSatisfiable
+	}
+	return &byteRange{start, end}, nil
+}
+
+// ifRangeMatches returns whether the artifact is unchanged according to the If-Range header, so the range can be
+// returned rather than the whole artifact. An entity tag must strongly match the artifact's, and a date must equal
+// its modification time.
+func ifRangeMatches(header string, info *common.ArtifactInfo) bool {
+	if header == "" {
+		return true
+	}
+	if strings.HasPrefix(header, `"`) || strings.HasPrefix(header, "W/") {
+		return info.ETag != "" && header == quoteETag(info.ETag)
+	}
+	t, err := http.ParseTime(header)
+	return err == nil && !info.LastModified.IsZero() && info.LastModified.Truncate(time.Second).Equal(t)
+}
+
+func quoteETag(etag string) string {
+	return `"` + strings.Trim(etag, `"`) + `"`
+}
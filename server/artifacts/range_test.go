@@ -0,0 +1,56 @@
+package artifacts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		header string
+		want   *byteRange
+		err    error
+	}{
+		{header: ""},
+		{header: "bytes=0-3", want: &byteRange{0, 3}},
+		{header: "bytes=3-", want: &byteRange{3, 6}},
+		{header: "bytes=3-100", want: &byteRange{3, 6}},
+		{header: "bytes=-2", want: &byteRange{5, 6}},
+		{header: "bytes=-100", want: &byteRange{0, 6}},
+		{header: "bytes=7-", err: errRangeNotSatisfiable},
+		{header: "bytes=-0", err: errRangeNotSatisfiable},
+		{header: "bytes=0-1,3-4"},
+		{header: "bytes=3-1"},
+		{header: "bytes=a-"},
+		{header: "lines=0-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, err := parseRange(tt.header, 7)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIfRangeMatches(t *testing.T) {
+	modified := time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC)
+	info := &common.ArtifactInfo{Size: 7, ETag: "v1", LastModified: modified}
+
+	assert.True(t, ifRangeMatches("", info))
+	assert.True(t, ifRangeMatches(`"v1"`, info))
+	assert.False(t, ifRangeMatches(`"v2"`, info))
+	assert.False(t, ifRangeMatches(`W/"v1"`, info))
+	assert.True(t, ifRangeMatches("Thu, 02 Jan 2025 03:04:05 GMT", info))
+	assert.False(t, ifRangeMatches("Thu, 02 Jan 2025 03:04:06 GMT", info))
+	assert.False(t, ifRangeMatches(`"v1"`, &common.ArtifactInfo{Size: 7}))
+}
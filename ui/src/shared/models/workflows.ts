@@ -145,6 +145,14 @@ export interface Artifact {
         strategy?: 'OnWorkflowCompletion' | 'OnWorkflowDeletion';
     };
     deleted?: boolean;
+    /**
+     * Deduplicate saves an output artifact under a key derived from the digest of its contents
+     */
+    deduplicate?: boolean;
+    /**
+     * Digest of the contents of the artifact, formatted as sha256:<hex>
+     */
+    digest?: string;
 }
 
 /**
@@ -618,6 +626,11 @@ export interface NodeStatus {
      */
     estimatedDuration?: number;
 
+    /**
+     * Upper bound of the estimated duration in seconds.
+     */
+    estimatedDurationUpperBound?: number;
+
     /**
      * Progress as numerator/denominator.
      */
@@ -728,6 +741,11 @@ export interface WorkflowStatus {
      */
     estimatedDuration?: number;
 
+    /**
+     * Upper bound of the estimated duration in seconds.
+     */
+    estimatedDurationUpperBound?: number;
+
     /**
      * Progress as numerator/denominator.
      */
@@ -1011,6 +1029,10 @@ export interface MemoizationStatus {
      * Cache name stores the identifier of the cache used for this node
      */
     cacheName: string;
+    /**
+     * Cache type is ConfigMapCache, DatabaseCache or ArtifactCache, unset means ConfigMapCache
+     */
+    cacheType?: string;
 }
 
 export type WorkflowPhase = 'Pending' | 'Running' | 'Succeeded' | 'Failed' | 'Error';
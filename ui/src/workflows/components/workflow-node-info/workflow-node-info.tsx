@@ -139,6 +139,10 @@ function WorkflowNodeSummary(props: Props) {
                                       left: <div> CACHE NAME </div>,
                                       right: <div> {props.node.memoizationStatus.cacheName} </div>
                                   },
+                                  {
+                                      left: <div> CACHE TYPE </div>,
+                                      right: <div> {props.node.memoizationStatus.cacheType || 'ConfigMapCache'} </div>
+                                  },
                                   {
                                       left: <div> HIT? </div>,
                                       right: <div> {props.node.memoizationStatus.hit ? 'YES' : 'NO'} </div>
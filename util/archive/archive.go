@@ -5,9 +5,13 @@ import (
 	"archive/zip"
 	"compress/gzip"
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
 
 	"github.com/argoproj/argo-workflows/v4/errors"
 	"github.com/argoproj/argo-workflows/v4/util"
@@ -18,8 +22,71 @@ type flusher interface {
 	Flush() error
 }
 
+type normalizedHeadersKey struct{}
+
+// WithNormalizedHeaders returns a context in which tarballs are written with normalized headers: without times, and
+// owned by root. Tarballs of the same files then have the same digest, wherever and whenever they were written.
+func WithNormalizedHeaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, normalizedHeadersKey{}, true)
+}
+
+// normalizeHeader normalizes the header, if the context asks for it
+func normalizeHeader(ctx context.Context, header *tar.Header) {
+	if normalize, _ := ctx.Value(normalizedHeadersKey{}).(bool); !normalize {
+		return
+	}
+	header.ModTime = time.Unix(0, 0)
+	header.AccessTime = time.Time{}
+	header.ChangeTime = time.Time{}
+	header.Uid, header.Gid = 0, 0
+	header.Uname, header.Gname = "", ""
+}
+
 // TarGzToWriter tar.gz's the source path to the supplied writer
 func TarGzToWriter(ctx context.Context, sourcePath string, level int, w io.Writer) error {
+	return tarToWriter(ctx, sourcePath, w, func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriterLevel(w, level)
+	})
+}
+
+// TarZstdToWriter tars the source path and compresses it with zstd to the supplied writer.
+// The level is a zstd level from 1 to 22, and concurrency is the number of goroutines compressing
+// in parallel, or 0 for the number of CPUs.
+func TarZstdToWriter(ctx context.Context, sourcePath string, level, concurrency int, w io.Writer) error {
+	return tarToWriter(ctx, sourcePath, w, func(w io.Writer) (io.WriteCloser, error) {
+		return NewZstdWriter(w, level, concurrency)
+	})
+}
+
+// NewZstdWriter returns a writer compressing to the supplied writer with zstd.
+// The level is a zstd level from 1 to 22, and concurrency is the number of goroutines compressing
+// in parallel, or 0 for the number of CPUs.
+func NewZstdWriter(w io.Writer, level, concurrency int) (io.WriteCloser, error) {
+	if level < 1 || level > 22 {
+		return nil, fmt.Errorf("zstd: invalid compression level: %d", level)
+	}
+	opts := []zstd.EOption{zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level))}
+	if concurrency > 0 {
+		opts = append(opts, zstd.WithEncoderConcurrency(concurrency))
+	}
+	return zstd.NewWriter(w, opts...)
+}
+
+// TarToWriter tars the source path to the supplied writer without compression
+func TarToWriter(ctx context.Context, sourcePath string, w io.Writer) error {
+	return tarToWriter(ctx, sourcePath, w, func(w io.Writer) (io.WriteCloser, error) {
+		return nopWriteCloser{w}, nil
+	})
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// tarToWriter tars the source path to the compressor created on the supplied writer
+func tarToWriter(ctx context.Context, sourcePath string, w io.Writer, newCompressor func(io.Writer) (io.WriteCloser, error)) error {
 	sourcePath, err := filepath.Abs(sourcePath)
 	if err != nil {
 		return errors.InternalErrorf("getting absolute path: %v", err)
@@ -39,18 +106,30 @@ func TarGzToWriter(ctx context.Context, sourcePath string, level int, w io.Write
 	if flush, ok := w.(flusher); ok {
 		defer func() { _ = flush.Flush() }()
 	}
-	gzw, err := gzip.NewWriterLevel(w, level)
+	cw, err := newCompressor(w)
 	if err != nil {
 		return errors.InternalWrapError(err)
 	}
-	defer util.Close(gzw)
-	tw := tar.NewWriter(gzw)
+	defer util.Close(cw)
+	tw := tar.NewWriter(cw)
 	defer util.Close(tw)
 
 	if sourceFi.IsDir() {
-		return tarDir(ctx, sourcePath, tw)
+		err = tarDir(ctx, sourcePath, tw)
+	} else {
+		err = tarFile(ctx, sourcePath, tw)
+	}
+	if err != nil {
+		return err
+	}
+	// close explicitly, as the compressor may only report write errors when flushing
+	if err := tw.Close(); err != nil {
+		return errors.InternalWrapError(err)
+	}
+	if err := cw.Close(); err != nil {
+		return errors.InternalWrapError(err)
 	}
-	return tarFile(sourcePath, tw)
+	return nil
 }
 
 // ZipToWriter zip the source path to the supplied writer
@@ -112,6 +191,7 @@ func tarDir(ctx context.Context, sourcePath string, tw *tar.Writer) error {
 			}
 		}
 		header.Name = nameInArchive
+		normalizeHeader(ctx, header)
 
 		err = tw.WriteHeader(header)
 		if err != nil {
@@ -143,7 +223,7 @@ func tarDir(ctx context.Context, sourcePath string, tw *tar.Writer) error {
 	return err
 }
 
-func tarFile(sourcePath string, tw *tar.Writer) error {
+func tarFile(ctx context.Context, sourcePath string, tw *tar.Writer) error {
 	f, err := os.Open(filepath.Clean(sourcePath))
 	if err != nil {
 		return errors.InternalWrapError(err)
@@ -158,6 +238,7 @@ func tarFile(sourcePath string, tw *tar.Writer) error {
 		return errors.InternalWrapError(err)
 	}
 	header.Name = filepath.Base(sourcePath)
+	normalizeHeader(ctx, header)
 	err = tw.WriteHeader(header)
 	if err != nil {
 		return errors.InternalWrapError(err)
@@ -220,7 +301,8 @@ func zipFile(sourcePath string, zw *zip.Writer) error {
 		return errors.InternalWrapError(err)
 	}
 	defer util.Close(f)
-	fileWriter, err := zw.Create(sourcePath)
+	// only the file name is used, so the file unzips to the same place regardless of where it was staged
+	fileWriter, err := zw.Create(filepath.Base(sourcePath))
 	if err != nil {
 		return errors.InternalWrapError(err)
 	}
@@ -1,15 +1,22 @@
 package archive
 
 import (
+	"archive/tar"
 	"archive/zip"
 	"bufio"
+	"bytes"
 	"compress/gzip"
+	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/argoproj/argo-workflows/v4/util/logging"
@@ -142,6 +149,89 @@ func TestTarFile(t *testing.T) {
 	}
 }
 
+func TestTarZstd(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		level       int
+		concurrency int
+		wantErr     bool
+	}{
+		{"dir_missing", "./fake/dir", 3, 0, true},
+		{"level_default", "../../test/e2e", 3, 0, false},
+		{"level_best", "../../test/e2e", 22, 0, false},
+		{"concurrency", "../../test/e2e", 3, 2, false},
+		{"level_out_of_range", "../../test/e2e", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logging.TestContext(t.Context())
+			var buf bytes.Buffer
+			err := TarZstdToWriter(ctx, tt.src, tt.level, tt.concurrency, &buf)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			zr, err := zstd.NewReader(&buf)
+			require.NoError(t, err)
+			defer zr.Close()
+			header, err := tar.NewReader(zr).Next()
+			require.NoError(t, err)
+			assert.Equal(t, "e2e", header.Name)
+		})
+	}
+}
+
+func TestTarUncompressed(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "my-file"), []byte("hello world"), 0o600))
+	var buf bytes.Buffer
+	require.NoError(t, TarToWriter(ctx, filepath.Join(dir, "my-file"), &buf))
+	tr := tar.NewReader(&buf)
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "my-file", header.Name)
+	data, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+}
+
+func TestTarNormalizedHeaders(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	tarball := func(ctx context.Context, mtime time.Time) []byte {
+		dir := filepath.Join(t.TempDir(), "my-dir")
+		require.NoError(t, os.MkdirAll(dir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "my-file"), []byte("hello world"), 0o600))
+		require.NoError(t, os.Chtimes(filepath.Join(dir, "my-file"), mtime, mtime))
+		require.NoError(t, os.Chtimes(dir, mtime, mtime))
+		var buf bytes.Buffer
+		require.NoError(t, TarGzToWriter(ctx, dir, gzip.DefaultCompression, &buf))
+		return buf.Bytes()
+	}
+	earlier, later := time.Unix(1000, 0), time.Unix(2000, 0)
+	assert.NotEqual(t, tarball(ctx, earlier), tarball(ctx, later))
+
+	ctx = WithNormalizedHeaders(ctx)
+	normalized := tarball(ctx, earlier)
+	assert.Equal(t, normalized, tarball(ctx, later))
+	zr, err := gzip.NewReader(bytes.NewReader(normalized))
+	require.NoError(t, err)
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), header.ModTime.Unix(), header.Name)
+		assert.Zero(t, header.Uid, header.Name)
+		assert.Zero(t, header.Gid, header.Name)
+		assert.Empty(t, header.Uname, header.Name)
+	}
+}
+
 func TestZipDirectory(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -197,8 +287,16 @@ func TestZipFile(t *testing.T) {
 
 		ctx := logging.TestContext(t.Context())
 
-		err = ZipToWriter(ctx, data.Name(), zip.NewWriter(f))
+		zw := zip.NewWriter(f)
+		err = ZipToWriter(ctx, data.Name(), zw)
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		zr, err := zip.OpenReader(dataZipPath)
 		require.NoError(t, err)
+		require.Len(t, zr.File, 1)
+		assert.Equal(t, filepath.Base(data.Name()), zr.File[0].Name)
+		require.NoError(t, zr.Close())
 
 		err = os.Remove(data.Name())
 		require.NoError(t, err)
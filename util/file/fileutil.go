@@ -2,6 +2,7 @@ package file
 
 import (
 	"archive/tar"
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"context"
@@ -106,6 +107,32 @@ func GetGzipReader(reader io.Reader) (io.ReadCloser, error) {
 	return gzipReader, nil
 }
 
+// GetDecompressingReader returns a reader of the decompressed content, and the compression algorithm
+// detected from the magic bytes of the content: GZipAlgorithm, ZStdAlgorithm, or "" if the content
+// is not compressed with either, in which case it is read as is.
+func GetDecompressingReader(reader io.Reader) (io.ReadCloser, string, error) {
+	br := bufio.NewReader(reader)
+	magic, err := br.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, "", err
+	}
+	switch {
+	case bytes.HasPrefix(magic, zstdMagic):
+		zstdReader, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, "", err
+		}
+		return zstdReader.IOReadCloser(), ZStdAlgorithm, nil
+	case bytes.HasPrefix(magic, gzipMagic):
+		gzipReader, err := GetGzipReader(br)
+		if err != nil {
+			return nil, "", err
+		}
+		return gzipReader, GZipAlgorithm, nil
+	}
+	return io.NopCloser(br), "", nil
+}
+
 // ExistsInTar return true if file or directory exists in tar
 func ExistsInTar(sourcePath string, tarReader TarReader) bool {
 	sourcePath = strings.Trim(sourcePath, "/")
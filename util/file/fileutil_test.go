@@ -148,6 +148,36 @@ func TestGetGzipReader(t *testing.T) {
 	}
 }
 
+// TestGetDecompressingReader checks the compression is detected from the content.
+func TestGetDecompressingReader(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	rawContent := "this is the content"
+	for _, algorithm := range []string{file.GZipAlgorithm, file.ZStdAlgorithm, ""} {
+		t.Run(fmt.Sprintf("algorithm=%q", algorithm), func(t *testing.T) {
+			content := []byte(rawContent)
+			if algorithm != "" {
+				t.Setenv(file.CompressionAlgorithmEnvVarKey, algorithm)
+				content = file.CompressContent(ctx, content)
+			}
+			reader, detected, err := file.GetDecompressingReader(bytes.NewReader(content))
+			require.NoError(t, err)
+			defer reader.Close()
+			assert.Equal(t, algorithm, detected)
+			res, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, rawContent, string(res))
+		})
+	}
+	t.Run("Empty", func(t *testing.T) {
+		reader, detected, err := file.GetDecompressingReader(bytes.NewReader(nil))
+		require.NoError(t, err)
+		assert.Empty(t, detected)
+		res, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
+
 func TestExistsInTar(t *testing.T) {
 	type fakeFile struct {
 		name, body string
@@ -46,6 +46,10 @@ func MigrateChanges(config *Config) []sqldb.Change {
     primary key(name)
 )`),
 		sqldb.AnsiSQLChange(`create unique index ilock_name on ` + config.LockTable + ` (name)`),
+		sqldb.AnsiSQLChange(`alter table ` + config.StateTable + ` add column tenant varchar(256) not null default ''`),
+		sqldb.AnsiSQLChange(`alter table ` + config.StateTable + ` add column fairsharetag double precision not null default 0`),
+		sqldb.AnsiSQLChange(`alter table ` + config.StateTable + ` add column queuedtime timestamp null`),
+		sqldb.AnsiSQLChange(`alter table ` + config.StateTable + ` add column leaseexpiry timestamp null`),
 	}
 }
 
@@ -41,16 +41,16 @@ func (_m *SyncQueries) EXPECT() *SyncQueries_Expecter {
 }
 
 // AddToQueue provides a mock function for the type SyncQueries
-func (_mock *SyncQueries) AddToQueue(ctx context.Context, record *db.StateRecord) error {
-	ret := _mock.Called(ctx, record)
+func (_mock *SyncQueries) AddToQueue(ctx context.Context, sessionProxy *sqldb.SessionProxy, record *db.StateRecord) error {
+	ret := _mock.Called(ctx, sessionProxy, record)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddToQueue")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *db.StateRecord) error); ok {
-		r0 = returnFunc(ctx, record)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sqldb.SessionProxy, *db.StateRecord) error); ok {
+		r0 = returnFunc(ctx, sessionProxy, record)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -64,24 +64,30 @@ type SyncQueries_AddToQueue_Call struct {
 
 // AddToQueue is a helper method to define mock.On call
 //   - ctx context.Context
+//   - sessionProxy *sqldb.SessionProxy
 //   - record *db.StateRecord
-func (_e *SyncQueries_Expecter) AddToQueue(ctx interface{}, record interface{}) *SyncQueries_AddToQueue_Call {
-	return &SyncQueries_AddToQueue_Call{Call: _e.mock.On("AddToQueue", ctx, record)}
+func (_e *SyncQueries_Expecter) AddToQueue(ctx interface{}, sessionProxy interface{}, record interface{}) *SyncQueries_AddToQueue_Call {
+	return &SyncQueries_AddToQueue_Call{Call: _e.mock.On("AddToQueue", ctx, sessionProxy, record)}
 }
 
-func (_c *SyncQueries_AddToQueue_Call) Run(run func(ctx context.Context, record *db.StateRecord)) *SyncQueries_AddToQueue_Call {
+func (_c *SyncQueries_AddToQueue_Call) Run(run func(ctx context.Context, sessionProxy *sqldb.SessionProxy, record *db.StateRecord)) *SyncQueries_AddToQueue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *db.StateRecord
+		var arg1 *sqldb.SessionProxy
 		if args[1] != nil {
-			arg1 = args[1].(*db.StateRecord)
+			arg1 = args[1].(*sqldb.SessionProxy)
+		}
+		var arg2 *db.StateRecord
+		if args[2] != nil {
+			arg2 = args[2].(*db.StateRecord)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -92,7 +98,7 @@ func (_c *SyncQueries_AddToQueue_Call) Return(err error) *SyncQueries_AddToQueue
 	return _c
 }
 
-func (_c *SyncQueries_AddToQueue_Call) RunAndReturn(run func(ctx context.Context, record *db.StateRecord) error) *SyncQueries_AddToQueue_Call {
+func (_c *SyncQueries_AddToQueue_Call) RunAndReturn(run func(ctx context.Context, sessionProxy *sqldb.SessionProxy, record *db.StateRecord) error) *SyncQueries_AddToQueue_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -716,6 +722,228 @@ func (_c *SyncQueries_GetExistingLocks_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// GetExpiredLeases provides a mock function for the type SyncQueries
+func (_mock *SyncQueries) GetExpiredLeases(ctx context.Context, semaphoreName string, controllerName string, now time.Time) ([]db.StateRecord, error) {
+	ret := _mock.Called(ctx, semaphoreName, controllerName, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredLeases")
+	}
+
+	var r0 []db.StateRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) ([]db.StateRecord, error)); ok {
+		return returnFunc(ctx, semaphoreName, controllerName, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) []db.StateRecord); ok {
+		r0 = returnFunc(ctx, semaphoreName, controllerName, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StateRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, semaphoreName, controllerName, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// SyncQueries_GetExpiredLeases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredLeases'
+type SyncQueries_GetExpiredLeases_Call struct {
+	*mock.Call
+}
+
+// GetExpiredLeases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - semaphoreName string
+//   - controllerName string
+//   - now time.Time
+func (_e *SyncQueries_Expecter) GetExpiredLeases(ctx interface{}, semaphoreName interface{}, controllerName interface{}, now interface{}) *SyncQueries_GetExpiredLeases_Call {
+	return &SyncQueries_GetExpiredLeases_Call{Call: _e.mock.On("GetExpiredLeases", ctx, semaphoreName, controllerName, now)}
+}
+
+func (_c *SyncQueries_GetExpiredLeases_Call) Run(run func(ctx context.Context, semaphoreName string, controllerName string, now time.Time)) *SyncQueries_GetExpiredLeases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SyncQueries_GetExpiredLeases_Call) Return(stateRecords []db.StateRecord, err error) *SyncQueries_GetExpiredLeases_Call {
+	_c.Call.Return(stateRecords, err)
+	return _c
+}
+
+func (_c *SyncQueries_GetExpiredLeases_Call) RunAndReturn(run func(ctx context.Context, semaphoreName string, controllerName string, now time.Time) ([]db.StateRecord, error)) *SyncQueries_GetExpiredLeases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFairShareState provides a mock function for the type SyncQueries
+func (_mock *SyncQueries) GetFairShareState(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string) ([]db.StateRecord, error) {
+	ret := _mock.Called(ctx, sessionProxy, semaphoreName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFairShareState")
+	}
+
+	var r0 []db.StateRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sqldb.SessionProxy, string) ([]db.StateRecord, error)); ok {
+		return returnFunc(ctx, sessionProxy, semaphoreName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sqldb.SessionProxy, string) []db.StateRecord); ok {
+		r0 = returnFunc(ctx, sessionProxy, semaphoreName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StateRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *sqldb.SessionProxy, string) error); ok {
+		r1 = returnFunc(ctx, sessionProxy, semaphoreName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// SyncQueries_GetFairShareState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFairShareState'
+type SyncQueries_GetFairShareState_Call struct {
+	*mock.Call
+}
+
+// GetFairShareState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionProxy *sqldb.SessionProxy
+//   - semaphoreName string
+func (_e *SyncQueries_Expecter) GetFairShareState(ctx interface{}, sessionProxy interface{}, semaphoreName interface{}) *SyncQueries_GetFairShareState_Call {
+	return &SyncQueries_GetFairShareState_Call{Call: _e.mock.On("GetFairShareState", ctx, sessionProxy, semaphoreName)}
+}
+
+func (_c *SyncQueries_GetFairShareState_Call) Run(run func(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string)) *SyncQueries_GetFairShareState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *sqldb.SessionProxy
+		if args[1] != nil {
+			arg1 = args[1].(*sqldb.SessionProxy)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *SyncQueries_GetFairShareState_Call) Return(stateRecords []db.StateRecord, err error) *SyncQueries_GetFairShareState_Call {
+	_c.Call.Return(stateRecords, err)
+	return _c
+}
+
+func (_c *SyncQueries_GetFairShareState_Call) RunAndReturn(run func(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string) ([]db.StateRecord, error)) *SyncQueries_GetFairShareState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLongestWaitsByController provides a mock function for the type SyncQueries
+func (_mock *SyncQueries) GetLongestWaitsByController(ctx context.Context, controllerName string) ([]db.WaitRecord, error) {
+	ret := _mock.Called(ctx, controllerName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLongestWaitsByController")
+	}
+
+	var r0 []db.WaitRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]db.WaitRecord, error)); ok {
+		return returnFunc(ctx, controllerName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []db.WaitRecord); ok {
+		r0 = returnFunc(ctx, controllerName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WaitRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, controllerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// SyncQueries_GetLongestWaitsByController_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLongestWaitsByController'
+type SyncQueries_GetLongestWaitsByController_Call struct {
+	*mock.Call
+}
+
+// GetLongestWaitsByController is a helper method to define mock.On call
+//   - ctx context.Context
+//   - controllerName string
+func (_e *SyncQueries_Expecter) GetLongestWaitsByController(ctx interface{}, controllerName interface{}) *SyncQueries_GetLongestWaitsByController_Call {
+	return &SyncQueries_GetLongestWaitsByController_Call{Call: _e.mock.On("GetLongestWaitsByController", ctx, controllerName)}
+}
+
+func (_c *SyncQueries_GetLongestWaitsByController_Call) Run(run func(ctx context.Context, controllerName string)) *SyncQueries_GetLongestWaitsByController_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *SyncQueries_GetLongestWaitsByController_Call) Return(waitRecords []db.WaitRecord, err error) *SyncQueries_GetLongestWaitsByController_Call {
+	_c.Call.Return(waitRecords, err)
+	return _c
+}
+
+func (_c *SyncQueries_GetLongestWaitsByController_Call) RunAndReturn(run func(ctx context.Context, controllerName string) ([]db.WaitRecord, error)) *SyncQueries_GetLongestWaitsByController_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOrderedQueue provides a mock function for the type SyncQueries
 func (_mock *SyncQueries) GetOrderedQueue(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string, inactiveTimeout time.Duration) ([]db.StateRecord, error) {
 	ret := _mock.Called(ctx, sessionProxy, semaphoreName, inactiveTimeout)
@@ -1327,6 +1555,87 @@ func (_c *SyncQueries_RemoveFromQueue_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// SetLeaseExpiry provides a mock function for the type SyncQueries
+func (_mock *SyncQueries) SetLeaseExpiry(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string, holderKey string, controllerName string, expiry time.Time) error {
+	ret := _mock.Called(ctx, sessionProxy, semaphoreName, holderKey, controllerName, expiry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLeaseExpiry")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sqldb.SessionProxy, string, string, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, sessionProxy, semaphoreName, holderKey, controllerName, expiry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// SyncQueries_SetLeaseExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLeaseExpiry'
+type SyncQueries_SetLeaseExpiry_Call struct {
+	*mock.Call
+}
+
+// SetLeaseExpiry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionProxy *sqldb.SessionProxy
+//   - semaphoreName string
+//   - holderKey string
+//   - controllerName string
+//   - expiry time.Time
+func (_e *SyncQueries_Expecter) SetLeaseExpiry(ctx interface{}, sessionProxy interface{}, semaphoreName interface{}, holderKey interface{}, controllerName interface{}, expiry interface{}) *SyncQueries_SetLeaseExpiry_Call {
+	return &SyncQueries_SetLeaseExpiry_Call{Call: _e.mock.On("SetLeaseExpiry", ctx, sessionProxy, semaphoreName, holderKey, controllerName, expiry)}
+}
+
+func (_c *SyncQueries_SetLeaseExpiry_Call) Run(run func(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string, holderKey string, controllerName string, expiry time.Time)) *SyncQueries_SetLeaseExpiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *sqldb.SessionProxy
+		if args[1] != nil {
+			arg1 = args[1].(*sqldb.SessionProxy)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 time.Time
+		if args[5] != nil {
+			arg5 = args[5].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *SyncQueries_SetLeaseExpiry_Call) Return(err error) *SyncQueries_SetLeaseExpiry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *SyncQueries_SetLeaseExpiry_Call) RunAndReturn(run func(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string, holderKey string, controllerName string, expiry time.Time) error) *SyncQueries_SetLeaseExpiry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateControllerTimestamp provides a mock function for the type SyncQueries
 func (_mock *SyncQueries) UpdateControllerTimestamp(ctx context.Context, controllerName string, timestamp time.Time) error {
 	ret := _mock.Called(ctx, controllerName, timestamp)
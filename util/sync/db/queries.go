@@ -22,6 +22,21 @@ type StateRecord struct {
 	Held       bool      `db:"held"`
 	Priority   int32     `db:"priority"` // higher number = higher priority in queue
 	Time       time.Time `db:"time"`     // timestamp of creation or last update
+	// Tenant of the workflow, for fair-share queueing and metrics
+	Tenant string `db:"tenant"`
+	// FairShareTag orders the queue when fair-share queueing is enabled, lower tags are served first
+	FairShareTag float64 `db:"fairsharetag"`
+	// QueuedTime is when the workflow started waiting for the lock
+	QueuedTime *time.Time `db:"queuedtime"`
+	// LeaseExpiry is when the hold is revoked, if the lock has a lease duration
+	LeaseExpiry *time.Time `db:"leaseexpiry"`
+}
+
+// WaitRecord is the longest wait of a tenant for a lock
+type WaitRecord struct {
+	Name       string     `db:"name"`       // semaphore name identifier (as stored, e.g. "sem/<ns>/<resource>")
+	Tenant     string     `db:"tenant"`     // tenant of the waiting workflows
+	QueuedTime *time.Time `db:"queuedtime"` // when the longest waiting workflow of the tenant started waiting
 }
 
 // StateCountRecord is an aggregate count of state rows for a lock, grouped by held/pending.
@@ -47,12 +62,16 @@ const (
 	LimitNameField = "name"
 	LimitSizeField = "sizelimit"
 
-	StateNameField       = "name"
-	StateKeyField        = "workflowkey"
-	StateControllerField = "controller"
-	StateHeldField       = "held"
-	StatePriorityField   = "priority"
-	StateTimeField       = "time"
+	StateNameField        = "name"
+	StateKeyField         = "workflowkey"
+	StateControllerField  = "controller"
+	StateHeldField        = "held"
+	StatePriorityField    = "priority"
+	StateTimeField        = "time"
+	StateTenantField      = "tenant"
+	StateFairShareField   = "fairsharetag"
+	StateQueuedTimeField  = "queuedtime"
+	StateLeaseExpiryField = "leaseexpiry"
 
 	ControllerNameField = "controller"
 	ControllerTimeField = "time"
@@ -71,14 +90,18 @@ type SyncQueries interface {
 	GetCurrentHolders(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string) ([]StateRecord, error)
 	GetCurrentPending(ctx context.Context, semaphoreName string) ([]StateRecord, error)
 	GetStateCountsByController(ctx context.Context, controllerName string) ([]StateCountRecord, error)
+	GetLongestWaitsByController(ctx context.Context, controllerName string) ([]WaitRecord, error)
+	GetFairShareState(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string) ([]StateRecord, error)
 	GetOrderedQueue(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string, inactiveTimeout time.Duration) ([]StateRecord, error)
-	AddToQueue(ctx context.Context, record *StateRecord) error
+	AddToQueue(ctx context.Context, sessionProxy *sqldb.SessionProxy, record *StateRecord) error
 	RemoveFromQueue(ctx context.Context, semaphoreName, holderKey string) error
 	CheckQueueExists(ctx context.Context, semaphoreName, holderKey, controllerName string) ([]StateRecord, error)
 	UpdateStateToHeld(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName, holderKey, controllerName string) error
 	InsertHeldState(ctx context.Context, sessionProxy *sqldb.SessionProxy, record *StateRecord) error
 	GetPendingInQueue(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName, holderKey, controllerName string) ([]StateRecord, error)
 	ReleaseHeld(ctx context.Context, semaphoreName, key, controllerName string) error
+	SetLeaseExpiry(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName, holderKey, controllerName string, expiry time.Time) error
+	GetExpiredLeases(ctx context.Context, semaphoreName, controllerName string, now time.Time) ([]StateRecord, error)
 
 	GetExistingLocks(ctx context.Context, lockName, controllerName string) ([]LockRecord, error)
 	InsertLock(ctx context.Context, record *LockRecord) error
@@ -201,6 +224,38 @@ func (q *syncQueries) GetStateCountsByController(ctx context.Context, controller
 	return counts, err
 }
 
+// GetLongestWaitsByController returns, for every lock this controller waits on, when the longest waiting
+// workflow of each tenant started waiting. Like GetStateCountsByController it reports only this controller's
+// waiters, and is used to drive the locks_longest_wait gauge.
+func (q *syncQueries) GetLongestWaitsByController(ctx context.Context, controllerName string) ([]WaitRecord, error) {
+	var waits []WaitRecord
+	err := q.sessionProxy.With(ctx, func(session db.Session) error {
+		waits = []WaitRecord{}
+		return session.SQL().
+			Select(StateNameField, StateTenantField, db.Raw("MIN("+StateQueuedTimeField+") AS "+StateQueuedTimeField)).
+			From(q.config.StateTable).
+			Where(db.Cond{StateControllerField: controllerName}).
+			And(db.Cond{StateHeldField: false}).
+			GroupBy(StateNameField, StateTenantField).
+			All(&waits)
+	})
+	return waits, err
+}
+
+// GetFairShareState returns the tenants and fair-share tags of all the holders and waiters of a semaphore
+func (q *syncQueries) GetFairShareState(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string) ([]StateRecord, error) {
+	var states []StateRecord
+	err := sessionProxy.With(ctx, func(session db.Session) error {
+		states = []StateRecord{}
+		return session.SQL().
+			Select(StateKeyField, StateHeldField, StateTenantField, StateFairShareField).
+			From(q.config.StateTable).
+			Where(db.Cond{StateNameField: semaphoreName}).
+			All(&states)
+	})
+	return states, err
+}
+
 func (q *syncQueries) GetOrderedQueue(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName string, inactiveTimeout time.Duration) ([]StateRecord, error) {
 	since := time.Now().Add(-inactiveTimeout)
 	var queue []StateRecord
@@ -212,7 +267,7 @@ func (q *syncQueries) GetOrderedQueue(ctx context.Context, sessionProxy *sqldb.S
 			And(db.Cond{ControllerTimeField + " >": since})
 
 		return session.SQL().
-			Select(StateKeyField, StateControllerField).
+			Select(StateKeyField, StateControllerField, StatePriorityField, StateTimeField, StateTenantField, StateFairShareField).
 			From(q.config.StateTable).
 			Where(db.Cond{StateNameField: semaphoreName}).
 			And(db.Cond{StateHeldField: false}).
@@ -225,8 +280,8 @@ func (q *syncQueries) GetOrderedQueue(ctx context.Context, sessionProxy *sqldb.S
 	return queue, err
 }
 
-func (q *syncQueries) AddToQueue(ctx context.Context, record *StateRecord) error {
-	return q.sessionProxy.With(ctx, func(session db.Session) error {
+func (q *syncQueries) AddToQueue(ctx context.Context, sessionProxy *sqldb.SessionProxy, record *StateRecord) error {
+	return sessionProxy.With(ctx, func(session db.Session) error {
 		_, err := session.Collection(q.config.StateTable).Insert(record)
 		return err
 	})
@@ -308,6 +363,38 @@ func (q *syncQueries) ReleaseHeld(ctx context.Context, semaphoreName, key, contr
 	})
 }
 
+// SetLeaseExpiry records when a hold expires, unless it already has an expiry
+func (q *syncQueries) SetLeaseExpiry(ctx context.Context, sessionProxy *sqldb.SessionProxy, semaphoreName, holderKey, controllerName string, expiry time.Time) error {
+	return sessionProxy.With(ctx, func(session db.Session) error {
+		_, err := session.SQL().Update(q.config.StateTable).
+			Set(StateLeaseExpiryField, expiry).
+			Where(db.Cond{StateNameField: semaphoreName}).
+			And(db.Cond{StateKeyField: holderKey}).
+			And(db.Cond{StateControllerField: controllerName}).
+			And(db.Cond{StateHeldField: true}).
+			And(db.Cond{StateLeaseExpiryField: db.IsNull()}).
+			Exec()
+		return err
+	})
+}
+
+// GetExpiredLeases returns this controller's holds of a lock whose leases expired before now
+func (q *syncQueries) GetExpiredLeases(ctx context.Context, semaphoreName, controllerName string, now time.Time) ([]StateRecord, error) {
+	var expired []StateRecord
+	err := q.sessionProxy.With(ctx, func(session db.Session) error {
+		expired = []StateRecord{}
+		return session.SQL().
+			Select(StateKeyField).
+			From(q.config.StateTable).
+			Where(db.Cond{StateNameField: semaphoreName}).
+			And(db.Cond{StateControllerField: controllerName}).
+			And(db.Cond{StateHeldField: true}).
+			And(db.Cond{StateLeaseExpiryField + " <": now}).
+			All(&expired)
+	})
+	return expired, err
+}
+
 // Lock operations
 func (q *syncQueries) GetExistingLocks(ctx context.Context, lockName, controllerName string) ([]LockRecord, error) {
 	var existingLocks []LockRecord
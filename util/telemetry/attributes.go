@@ -30,6 +30,7 @@ const (
 	AttribLockName            string = `lock_name`
 	AttribLockNamespace       string = `namespace`
 	AttribLockStorage         string = `storage`
+	AttribLockTenant          string = `tenant`
 	AttribLockType            string = `type`
 	AttribLogLevel            string = `level`
 	AttribMessage             string = `message`
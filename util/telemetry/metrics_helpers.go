@@ -137,6 +137,34 @@ func (m *Metrics) ObserveLocksHeld(ctx context.Context, o metric.Observer, val i
 	inst.ObserveInt(ctx, o, val, attribs)
 }
 
+// AddLocksLeaseExpiredTotal adds a value to the locks_lease_expired_total counter
+func (m *Metrics) AddLocksLeaseExpiredTotal(ctx context.Context, val int64, lockType string, lockStorage string, lockName string, lockNamespace string) {
+	attribs := Attributes{
+		{Name: AttribLockType, Value: lockType},
+		{Name: AttribLockStorage, Value: lockStorage},
+		{Name: AttribLockName, Value: lockName},
+		{Name: AttribLockNamespace, Value: lockNamespace},
+	}
+	m.AddInt(ctx, InstrumentLocksLeaseExpiredTotal.Name(), val, attribs)
+}
+
+// ObserveLocksLongestWait observes a value for the locks_longest_wait gauge
+// This is a helper method for use inside RegisterCallback functions
+func (m *Metrics) ObserveLocksLongestWait(ctx context.Context, o metric.Observer, val float64, lockType string, lockStorage string, lockName string, lockNamespace string, lockTenant string) {
+	inst := m.GetInstrument(InstrumentLocksLongestWait.Name())
+	if inst == nil {
+		return
+	}
+	attribs := Attributes{
+		{Name: AttribLockType, Value: lockType},
+		{Name: AttribLockStorage, Value: lockStorage},
+		{Name: AttribLockName, Value: lockName},
+		{Name: AttribLockNamespace, Value: lockNamespace},
+		{Name: AttribLockTenant, Value: lockTenant},
+	}
+	inst.ObserveFloat(ctx, o, val, attribs)
+}
+
 // ObserveLocksPending observes a value for the locks_pending gauge
 // This is a helper method for use inside RegisterCallback functions
 func (m *Metrics) ObserveLocksPending(ctx context.Context, o metric.Observer, val int64, lockType string, lockStorage string, lockName string, lockNamespace string) {
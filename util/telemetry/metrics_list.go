@@ -170,6 +170,51 @@ var InstrumentLocksHeld = BuiltinInstrument{
 	},
 }
 
+var InstrumentLocksLeaseExpiredTotal = BuiltinInstrument{
+	name:        "locks_lease_expired_total",
+	description: "A counter of the number of synchronization locks revoked because their lease duration expired",
+	unit:        "{lock}",
+	instType:    Int64Counter,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribLockType,
+		},
+		{
+			name: AttribLockStorage,
+		},
+		{
+			name: AttribLockName,
+		},
+		{
+			name: AttribLockNamespace,
+		},
+	},
+}
+
+var InstrumentLocksLongestWait = BuiltinInstrument{
+	name:        "locks_longest_wait",
+	description: "A gauge of the number of seconds that the longest waiting workflow of each tenant has been waiting to acquire a synchronization lock",
+	unit:        "s",
+	instType:    Float64ObservableGauge,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribLockType,
+		},
+		{
+			name: AttribLockStorage,
+		},
+		{
+			name: AttribLockName,
+		},
+		{
+			name: AttribLockNamespace,
+		},
+		{
+			name: AttribLockTenant,
+		},
+	},
+}
+
 var InstrumentLocksPending = BuiltinInstrument{
 	name:        "locks_pending",
 	description: "A gauge of the number of pending synchronization lock requests",
@@ -10,6 +10,8 @@ type NodeRefKeys struct {
 	OutputsResult                           *v.Key
 	OutputsParameterByName                  *v.Key
 	OutputsArtifactByName                   *v.Key
+	ContainerOutputsParameterByName         *v.Key
+	ContainerOutputsArtifactByName          *v.Key
 }
 
 var (
@@ -49,6 +51,16 @@ func nodeRef(pfx string, inKind v.TemplateKind) NodeRefKeys {
 			AppliesTo: applies, Phases: []v.LifecyclePhase{v.PhAfterNodeSucceeded},
 			Description: "Named output artifact of the referenced node",
 		}),
+		ContainerOutputsParameterByName: v.Define(v.Spec{
+			Template: pfx + ".<name>.containers.<c>.outputs.parameters.<p>", Kind: v.KindNodeRef, ValueType: "string",
+			AppliesTo: applies, Phases: []v.LifecyclePhase{v.PhAfterNodeSucceeded},
+			Description: "Named output parameter of a container of the referenced container set node",
+		}),
+		ContainerOutputsArtifactByName: v.Define(v.Spec{
+			Template: pfx + ".<name>.containers.<c>.outputs.artifacts.<a>", Kind: v.KindNodeRef, ValueType: "wfv1.Artifact",
+			AppliesTo: applies, Phases: []v.LifecyclePhase{v.PhAfterNodeSucceeded},
+			Description: "Named output artifact of a container of the referenced container set node",
+		}),
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/git"
 	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/hdfs"
 	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/http"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/integrity"
 	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/logging"
 	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/oss"
 	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/plugin"
@@ -31,7 +32,7 @@ func NewDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 	if err != nil {
 		return nil, err
 	}
-	return logging.New(drv), nil
+	return logging.New(integrity.New(drv)), nil
 }
 
 func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (common.ArtifactDriver, error) {
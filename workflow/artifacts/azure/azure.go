@@ -10,13 +10,16 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/argoproj/argo-workflows/v4/util/logging"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
 
 	argoerrors "github.com/argoproj/argo-workflows/v4/errors"
 	"github.com/argoproj/argo-workflows/v4/util/file"
@@ -35,6 +38,8 @@ type ArtifactDriver struct {
 
 var _ artifactscommon.ArtifactDriver = &ArtifactDriver{}
 
+var _ artifactscommon.ExistenceChecker = &ArtifactDriver{}
+
 // newAzureContainerClient creates a new container.Client for interacting with the specified Azure Blob Storage container
 // The container client is created with the default azblob.ClientOptions which does include retry behavior
 // for failed requests.
@@ -476,6 +481,92 @@ func (azblobDriver *ArtifactDriver) IsDirectory(ctx context.Context, artifact *w
 	return false, nil
 }
 
+// Exists returns whether a blob exists at the name of the artifact
+func (azblobDriver *ArtifactDriver) Exists(ctx context.Context, artifact *wfv1.Artifact) (bool, error) {
+	containerClient, err := azblobDriver.newAzureContainerClient(ctx)
+	if err != nil {
+		return false, fmt.Errorf("unable to create Azure Blob Container client: %w", err)
+	}
+	_, err = containerClient.NewBlobClient(artifact.Azure.Blob).GetProperties(ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("unable to get properties of blob %s in Azure Blob Storage container: %w", artifact.Azure.Blob, err)
+	}
+	return true, nil
+}
+
+// OpenStreamRange opens a stream reader for part of a file artifact from Azure Blob Storage
+func (azblobDriver *ArtifactDriver) OpenStreamRange(ctx context.Context, artifact *wfv1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{
+		"endpoint":  artifact.Azure.Endpoint,
+		"container": artifact.Azure.Container,
+		"blob":      artifact.Azure.Blob,
+		"offset":    offset,
+		"length":    length,
+	}).Info(ctx, "Streaming range from Azure Blob Storage")
+	if length == 0 {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	containerClient, err := azblobDriver.newAzureContainerClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Azure Blob Container client: %w", err)
+	}
+	// a count of 0 reads to the end of the blob
+	httpRange := blob.HTTPRange{Offset: offset}
+	if length > 0 {
+		httpRange.Count = length
+	}
+	response, err := containerClient.NewBlockBlobClient(artifact.Azure.Blob).DownloadStream(ctx, &azblob.DownloadStreamOptions{Range: httpRange})
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, argoerrors.New(argoerrors.CodeNotFound, fmt.Sprintf("no blob found of name %s", artifact.Azure.Blob))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open stream range for blob %s: %w", artifact.Azure.Blob, err)
+	}
+	return response.Body, nil
+}
+
+// PresignedURL returns a URL of the blob of the artifact with a read-only SAS. A SAS can only be signed with an account
+// key, and the repository's own SAS token must not be shared, so there are none for other credentials.
+func (azblobDriver *ArtifactDriver) PresignedURL(ctx context.Context, artifact *wfv1.Artifact, expiry time.Duration) (string, error) {
+	if azblobDriver.UseSDKCreds || isSASAccountKey(azblobDriver.AccountKey) {
+		return "", artifactscommon.ErrPresignNotSupported
+	}
+	containerClient, err := azblobDriver.newAzureContainerClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to create Azure Blob Container client: %w", err)
+	}
+	return containerClient.NewBlobClient(artifact.Azure.Blob).GetSASURL(sas.BlobPermissions{Read: true}, time.Now().Add(expiry), nil)
+}
+
+// Stat returns the size, ETag and modification time of the blob of the artifact
+func (azblobDriver *ArtifactDriver) Stat(ctx context.Context, artifact *wfv1.Artifact) (*artifactscommon.ArtifactInfo, error) {
+	containerClient, err := azblobDriver.newAzureContainerClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Azure Blob Container client: %w", err)
+	}
+	props, err := containerClient.NewBlobClient(artifact.Azure.Blob).GetProperties(ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, argoerrors.New(argoerrors.CodeNotFound, fmt.Sprintf("no blob found of name %s", artifact.Azure.Blob))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get properties of blob %s in Azure Blob Storage container: %w", artifact.Azure.Blob, err)
+	}
+	info := &artifactscommon.ArtifactInfo{}
+	if props.ContentLength != nil {
+		info.Size = *props.ContentLength
+	}
+	if props.ETag != nil {
+		info.ETag = strings.Trim(string(*props.ETag), `"`)
+	}
+	if props.LastModified != nil {
+		info.LastModified = *props.LastModified
+	}
+	return info, nil
+}
+
 type uploadTask struct {
 	blobName string
 	path     string
@@ -15,6 +15,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	artifactscommon "github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -174,3 +175,25 @@ func TestArtifactDriverMissingAccountKey(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "accountKey secret is required")
 }
+
+func TestPresignedURL(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Azure: &wfv1.AzureArtifact{Blob: "my-wf/result.tgz"}}}
+
+	driver := ArtifactDriver{
+		AccountKey: "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==", // default azurite key
+		Container:  "test",
+		Endpoint:   "http://127.0.0.1:10000/devstoreaccount1",
+	}
+	presignedURL, err := driver.PresignedURL(ctx, art, time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(presignedURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/devstoreaccount1/test/my-wf/result.tgz", u.Path)
+	assert.Equal(t, "r", u.Query().Get("sp"))
+	assert.NotEmpty(t, u.Query().Get("sig"))
+
+	driver.AccountKey = "sv=2021-06-08&ss=b&srt=sco&sp=rwdlacupiytfx&se=2030-01-01T00:00:00Z&sig=abc"
+	_, err = driver.PresignedURL(ctx, art, time.Minute)
+	require.ErrorIs(t, err, artifactscommon.ErrPresignNotSupported)
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// ContentAddressedKeyPrefix is the directory of deduplicated artifacts under the key prefix of the repository
+const ContentAddressedKeyPrefix = DigestAlgorithm
+
+// ExistenceChecker is implemented by drivers which can check whether an artifact exists without loading it
+type ExistenceChecker interface {
+	// Exists returns whether an object exists at the key of the artifact
+	Exists(ctx context.Context, artifact *v1alpha1.Artifact) (bool, error)
+}
+
+// ErrExistsNotSupported Sentinel error definition for drivers which cannot check whether an artifact exists
+var ErrExistsNotSupported = errors.New("checking whether an artifact exists is not supported for this artifact storage")
+
+// Exists returns whether the artifact exists, or ErrExistsNotSupported if the driver cannot check
+func Exists(ctx context.Context, driver ArtifactDriver, artifact *v1alpha1.Artifact) (bool, error) {
+	checker, ok := driver.(ExistenceChecker)
+	if !ok {
+		return false, ErrExistsNotSupported
+	}
+	return checker.Exists(ctx, artifact)
+}
+
+// ContentAddressedKey returns the key of a deduplicated artifact: <keyPrefix>/sha256/<hex><extension>.
+// The key depends only on the contents, so identical files saved under different names share it. The extension, e.g.
+// .tgz, is kept so the type of the artifact can be told from its key.
+func ContentAddressedKey(keyPrefix, digest, extension string) (string, error) {
+	sum, ok := strings.CutPrefix(digest, DigestAlgorithm+":")
+	if !ok || sum == "" {
+		return "", fmt.Errorf("invalid digest %q, expected %s:<hex>", digest, DigestAlgorithm)
+	}
+	return path.Join(keyPrefix, ContentAddressedKeyPrefix, sum+extension), nil
+}
+
+// FileExtension returns the extension of the file name, including the .tar of a compressed tarball, e.g. .tar.zst
+func FileExtension(fileName string) string {
+	ext := path.Ext(fileName)
+	if ext == "" {
+		return ""
+	}
+	if tarExt := path.Ext(strings.TrimSuffix(fileName, ext)); tarExt == ".tar" {
+		return tarExt + ext
+	}
+	return ext
+}
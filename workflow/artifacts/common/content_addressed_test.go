@@ -0,0 +1,73 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+const helloWorldDigest = "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+type existenceCheckingDriver struct {
+	fakeArtifactDriver
+	keys map[string]bool
+}
+
+func (d *existenceCheckingDriver) Exists(_ context.Context, a *wfv1.Artifact) (bool, error) {
+	key, err := a.GetKey()
+	return d.keys[key], err
+}
+
+func TestContentAddressedKey(t *testing.T) {
+	key, err := ContentAddressedKey("", helloWorldDigest, ".tgz")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256/b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9.tgz", key)
+
+	key, err = ContentAddressedKey("team-a/artifacts", helloWorldDigest, ".tgz")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a/artifacts/sha256/b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9.tgz", key)
+
+	key, err = ContentAddressedKey("", helloWorldDigest, "")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256/b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", key)
+
+	_, err = ContentAddressedKey("", "md5:5eb63bbbe01eeed093cb22bb8f5acdc3", ".tgz")
+	require.ErrorContains(t, err, "invalid digest")
+
+	_, err = ContentAddressedKey("", "sha256:", ".tgz")
+	require.ErrorContains(t, err, "invalid digest")
+}
+
+func TestFileExtension(t *testing.T) {
+	for fileName, want := range map[string]string{
+		"hello":         "",
+		"hello.txt":     ".txt",
+		"hello.tgz":     ".tgz",
+		"hello.tar":     ".tar",
+		"hello.tar.zst": ".tar.zst",
+		"hello.v1.zip":  ".zip",
+	} {
+		assert.Equal(t, want, FileExtension(fileName), fileName)
+	}
+}
+
+func TestExists(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-key"}}}
+
+	exists, err := Exists(ctx, &existenceCheckingDriver{keys: map[string]bool{"my-key": true}}, art)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = Exists(ctx, &existenceCheckingDriver{}, art)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = Exists(ctx, &fakeArtifactDriver{}, art)
+	require.ErrorIs(t, err, ErrExistsNotSupported)
+}
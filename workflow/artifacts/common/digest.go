@@ -0,0 +1,78 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// DigestAlgorithm is the algorithm of artifact digests, which are formatted as "sha256:<hex>"
+const DigestAlgorithm = "sha256"
+
+// ErrDigestMismatch Sentinel error definition for artifacts whose contents do not match their recorded digest
+var ErrDigestMismatch = errors.New("artifact digest mismatch")
+
+// Digest returns the digest of the contents of the reader
+func Digest(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return FormatDigest(h), nil
+}
+
+// FileDigest returns the digest of the contents of a file
+func FileDigest(filePath string) (string, error) {
+	f, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	return Digest(f)
+}
+
+// FormatDigest formats the sum of a SHA-256 hash as a digest
+func FormatDigest(h hash.Hash) string {
+	return DigestAlgorithm + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyDigest returns ErrDigestMismatch if the digest of the loaded contents differs from the digest recorded on the artifact
+func VerifyDigest(artifact *v1alpha1.Artifact, digest string) error {
+	if digest == artifact.Digest {
+		return nil
+	}
+	key, _ := artifact.GetKey()
+	return fmt.Errorf("%w: artifact %q (key %q) may be corrupt or truncated, its contents have digest %s but %s was recorded when it was saved",
+		ErrDigestMismatch, artifact.Name, key, digest, artifact.Digest)
+}
+
+// NewVerifiedStream returns a stream which computes the digest of the contents as they are read, and returns
+// ErrDigestMismatch instead of io.EOF if it differs from the digest recorded on the artifact. Nothing is buffered, so
+// callers must treat the contents as unverified until the end of the stream.
+func NewVerifiedStream(rc io.ReadCloser, artifact *v1alpha1.Artifact) io.ReadCloser {
+	return &verifiedStream{ReadCloser: rc, artifact: artifact, hash: sha256.New()}
+}
+
+type verifiedStream struct {
+	io.ReadCloser
+	artifact *v1alpha1.Artifact
+	hash     hash.Hash
+}
+
+func (s *verifiedStream) Read(p []byte) (int, error) {
+	n, err := s.ReadCloser.Read(p)
+	s.hash.Write(p[:n])
+	if errors.Is(err, io.EOF) {
+		if verifyErr := VerifyDigest(s.artifact, FormatDigest(s.hash)); verifyErr != nil {
+			return n, verifyErr
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+func TestDigest(t *testing.T) {
+	digest, err := Digest(strings.NewReader("hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, helloWorldDigest, digest)
+
+	filePath := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("hello world"), 0o600))
+	digest, err = FileDigest(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, helloWorldDigest, digest)
+
+	_, err = FileDigest(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestVerifyDigest(t *testing.T) {
+	art := &wfv1.Artifact{Name: "hello", Digest: helloWorldDigest, ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "hello.txt"}}}
+	require.NoError(t, VerifyDigest(art, helloWorldDigest))
+
+	err := VerifyDigest(art, "sha256:0000")
+	require.ErrorIs(t, err, ErrDigestMismatch)
+	require.EqualError(t, err, `artifact digest mismatch: artifact "hello" (key "hello.txt") may be corrupt or truncated, its contents have digest sha256:0000 but `+helloWorldDigest+` was recorded when it was saved`)
+}
+
+func TestNewVerifiedStream(t *testing.T) {
+	art := &wfv1.Artifact{Name: "hello", Digest: helloWorldDigest}
+
+	data, err := io.ReadAll(NewVerifiedStream(io.NopCloser(strings.NewReader("hello world")), art))
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+
+	data, err = io.ReadAll(NewVerifiedStream(io.NopCloser(strings.NewReader("hello")), art))
+	require.ErrorIs(t, err, ErrDigestMismatch)
+	assert.Equal(t, "hello", string(data))
+}
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// Presigner is implemented by drivers which can create pre-signed URLs, which download an artifact without credentials
+type Presigner interface {
+	// PresignedURL returns a URL which downloads the file artifact until it expires
+	PresignedURL(ctx context.Context, artifact *v1alpha1.Artifact, expiry time.Duration) (string, error)
+}
+
+// ErrPresignNotSupported Sentinel error definition for drivers which cannot create pre-signed URLs
+var ErrPresignNotSupported = errors.New("pre-signed URLs are not supported for this artifact storage")
+
+// PresignedURL returns a pre-signed URL of the artifact, or ErrPresignNotSupported if the driver cannot create one
+func PresignedURL(ctx context.Context, driver ArtifactDriver, artifact *v1alpha1.Artifact, expiry time.Duration) (string, error) {
+	presigner, ok := driver.(Presigner)
+	if !ok {
+		return "", ErrPresignNotSupported
+	}
+	return presigner.PresignedURL(ctx, artifact, expiry)
+}
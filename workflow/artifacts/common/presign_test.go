@@ -0,0 +1,33 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+type presignDriver struct {
+	fakeArtifactDriver
+}
+
+func (d *presignDriver) PresignedURL(_ context.Context, _ *wfv1.Artifact, expiry time.Duration) (string, error) {
+	return "https://my-bucket/my-key?expiry=" + expiry.String(), nil
+}
+
+func TestPresignedURL(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-key"}}}
+
+	url, err := PresignedURL(ctx, &presignDriver{}, art, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "https://my-bucket/my-key?expiry=1m0s", url)
+
+	_, err = PresignedURL(ctx, &fakeArtifactDriver{}, art, time.Minute)
+	require.ErrorIs(t, err, ErrPresignNotSupported)
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"context"
+	"io"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// RangedReader is implemented by drivers which can read part of a file artifact, so a download can be resumed
+// without reading the artifact from the start
+type RangedReader interface {
+	// OpenStreamRange opens length bytes of the file artifact from offset for reading, or the rest of it if length
+	// is negative
+	OpenStreamRange(ctx context.Context, a *v1alpha1.Artifact, offset, length int64) (io.ReadCloser, error)
+}
+
+// OpenStreamRange opens length bytes of the file artifact from offset for reading, or the rest of it if length is
+// negative. Drivers which cannot read part of an artifact open the whole artifact and skip to offset.
+func OpenStreamRange(ctx context.Context, driver ArtifactDriver, a *v1alpha1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	if r, ok := driver.(RangedReader); ok {
+		return r.OpenStreamRange(ctx, a, offset, length)
+	}
+	rc, err := driver.OpenStream(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	return SkipStream(rc, offset, length)
+}
+
+// SkipStream returns a stream of length bytes of rc from offset, or the rest of it if length is negative. The bytes
+// before offset are discarded, or skipped if rc can seek. Closing the stream closes rc.
+func SkipStream(rc io.ReadCloser, offset, length int64) (io.ReadCloser, error) {
+	if seeker, ok := rc.(io.Seeker); ok {
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			_ = rc.Close()
+			return nil, err
+		}
+	} else if _, err := io.CopyN(io.Discard, rc, offset); err != nil {
+		_ = rc.Close()
+		return nil, err
+	}
+	if length < 0 {
+		return rc, nil
+	}
+	return &limitedReadCloser{io.LimitReader(rc, length), rc}, nil
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+type streamDriver struct {
+	fakeArtifactDriver
+}
+
+func (d *streamDriver) OpenStream(_ context.Context, _ *wfv1.Artifact) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(d.data)), nil
+}
+
+type rangedDriver struct {
+	streamDriver
+}
+
+func (d *rangedDriver) OpenStreamRange(_ context.Context, _ *wfv1.Artifact, _, _ int64) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader([]byte("ranged"))), nil
+}
+
+type seekReadCloser struct {
+	*bytes.Reader
+}
+
+func (seekReadCloser) Close() error { return nil }
+
+func TestOpenStreamRange(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-key"}}}
+	data := []byte("hello world")
+
+	t.Run("RangedReader", func(t *testing.T) {
+		rc, err := OpenStreamRange(ctx, &rangedDriver{streamDriver{fakeArtifactDriver{data: data}}}, art, 6, 5)
+		require.NoError(t, err)
+		all, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "ranged", string(all))
+	})
+	t.Run("Discard", func(t *testing.T) {
+		rc, err := OpenStreamRange(ctx, &streamDriver{fakeArtifactDriver{data: data}}, art, 6, 3)
+		require.NoError(t, err)
+		all, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "wor", string(all))
+	})
+	t.Run("ToEnd", func(t *testing.T) {
+		rc, err := OpenStreamRange(ctx, &streamDriver{fakeArtifactDriver{data: data}}, art, 6, -1)
+		require.NoError(t, err)
+		all, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "world", string(all))
+	})
+	t.Run("BeyondEnd", func(t *testing.T) {
+		_, err := OpenStreamRange(ctx, &streamDriver{fakeArtifactDriver{data: data}}, art, 20, -1)
+		require.ErrorIs(t, err, io.EOF)
+	})
+}
+
+func TestSkipStreamSeek(t *testing.T) {
+	rc, err := SkipStream(seekReadCloser{bytes.NewReader([]byte("hello world"))}, 6, 3)
+	require.NoError(t, err)
+	all, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "wor", string(all))
+}
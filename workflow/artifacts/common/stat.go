@@ -0,0 +1,37 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// ArtifactInfo is the metadata of a file artifact in its repository
+type ArtifactInfo struct {
+	// Size is the size of the artifact in bytes
+	Size int64
+	// ETag identifies the version of the artifact, if the repository has one
+	ETag string
+	// LastModified is when the artifact was last saved
+	LastModified time.Time
+}
+
+// Statter is implemented by drivers which can get the metadata of an artifact without loading it
+type Statter interface {
+	// Stat returns the metadata of the object at the key of the artifact
+	Stat(ctx context.Context, artifact *v1alpha1.Artifact) (*ArtifactInfo, error)
+}
+
+// ErrStatNotSupported Sentinel error definition for drivers which cannot get the metadata of an artifact
+var ErrStatNotSupported = errors.New("getting the metadata of an artifact is not supported for this artifact storage")
+
+// Stat returns the metadata of the artifact, or ErrStatNotSupported if the driver cannot get it
+func Stat(ctx context.Context, driver ArtifactDriver, artifact *v1alpha1.Artifact) (*ArtifactInfo, error) {
+	statter, ok := driver.(Statter)
+	if !ok {
+		return nil, ErrStatNotSupported
+	}
+	return statter.Stat(ctx, artifact)
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+type statDriver struct {
+	fakeArtifactDriver
+}
+
+func (d *statDriver) Stat(_ context.Context, _ *wfv1.Artifact) (*ArtifactInfo, error) {
+	return &ArtifactInfo{Size: 11, ETag: "v1"}, nil
+}
+
+func TestStat(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-key"}}}
+
+	info, err := Stat(ctx, &statDriver{}, art)
+	require.NoError(t, err)
+	assert.Equal(t, &ArtifactInfo{Size: 11, ETag: "v1"}, info)
+
+	_, err = Stat(ctx, &fakeArtifactDriver{}, art)
+	require.ErrorIs(t, err, ErrStatNotSupported)
+}
@@ -0,0 +1,138 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+type uploadStateKey string
+
+const uploadStateDir uploadStateKey = `upload_state_dir`
+
+// UploadState is the progress of a resumable upload of a file, recorded so that a retried upload resumes rather than
+// starting over
+type UploadState struct {
+	// Size, ModTime and Digest are those of the file, so that an upload of a changed file starts over. The digest,
+	// when known, identifies the file rather than its modification time.
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	Digest  string    `json:"digest,omitempty"`
+	// UploadID identifies the upload to the storage, such as an S3 multipart upload ID or a GCS session URI
+	UploadID string `json:"uploadID"`
+	// PartSize is the size of each part of the file but the last
+	PartSize int64 `json:"partSize,omitempty"`
+	// Parts are the entity tags of the uploaded parts, by part number
+	Parts map[int]string `json:"parts,omitempty"`
+}
+
+// NewUploadState returns the state of a new upload of the file with the digest, which may be empty
+func NewUploadState(info os.FileInfo, digest, uploadID string, partSize int64) *UploadState {
+	return &UploadState{
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Digest:   digest,
+		UploadID: uploadID,
+		PartSize: partSize,
+		Parts:    map[int]string{},
+	}
+}
+
+// WithUploadStateDir returns a context in which drivers record the state of resumable uploads in the directory
+func WithUploadStateDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, uploadStateDir, dir)
+}
+
+// UploadStateDir returns the directory in which drivers record the state of resumable uploads, or "" if uploads are
+// not resumable
+func UploadStateDir(ctx context.Context) string {
+	if dir, ok := ctx.Value(uploadStateDir).(string); ok {
+		return dir
+	}
+	return ""
+}
+
+func uploadStatePath(ctx context.Context, key string) string {
+	dir := UploadStateDir(ctx)
+	if dir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// LoadUploadState returns the recorded state of the upload of the file with the digest to the key, or nil if there is
+// none or the file has changed since
+func LoadUploadState(ctx context.Context, key string, info os.FileInfo, digest string) *UploadState {
+	path := uploadStatePath(ctx, key)
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logging.RequireLoggerFromContext(ctx).WithField("key", key).WithError(err).Warn(ctx, "Failed to read upload state, starting the upload over")
+		}
+		return nil
+	}
+	state := &UploadState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		logging.RequireLoggerFromContext(ctx).WithField("key", key).WithError(err).Warn(ctx, "Failed to parse upload state, starting the upload over")
+		return nil
+	}
+	if state.UploadID == "" || state.Size != info.Size() || state.Digest != digest {
+		return nil
+	}
+	if digest == "" && !state.ModTime.Equal(info.ModTime()) {
+		return nil
+	}
+	if state.Parts == nil {
+		state.Parts = map[int]string{}
+	}
+	return state
+}
+
+// SaveUploadState records the state of the upload to the key. Failing to record it only means a retried upload
+// starts over, so errors are logged rather than returned.
+func SaveUploadState(ctx context.Context, key string, state *UploadState) {
+	path := uploadStatePath(ctx, key)
+	if path == "" {
+		return
+	}
+	log := logging.RequireLoggerFromContext(ctx).WithField("key", key)
+	data, err := json.Marshal(state)
+	if err != nil {
+		log.WithError(err).Warn(ctx, "Failed to marshal upload state")
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		log.WithError(err).Warn(ctx, "Failed to create upload state directory")
+		return
+	}
+	// write then rename, so that a killed container never leaves a partial state file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		log.WithError(err).Warn(ctx, "Failed to write upload state")
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		log.WithError(err).Warn(ctx, "Failed to write upload state")
+	}
+}
+
+// DeleteUploadState removes the recorded state of the upload to the key, once it has completed or cannot be resumed
+func DeleteUploadState(ctx context.Context, key string) {
+	path := uploadStatePath(ctx, key)
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		logging.RequireLoggerFromContext(ctx).WithField("key", key).WithError(err).Warn(ctx, "Failed to delete upload state")
+	}
+}
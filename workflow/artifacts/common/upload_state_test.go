@@ -0,0 +1,57 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+func TestUploadState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "my-file")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	t.Run("NoStateDir", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		SaveUploadState(ctx, "my-key", NewUploadState(info, "", "my-upload", 1))
+		assert.Nil(t, LoadUploadState(ctx, "my-key", info, ""))
+	})
+	t.Run("SaveLoadDelete", func(t *testing.T) {
+		ctx := WithUploadStateDir(logging.TestContext(t.Context()), filepath.Join(t.TempDir(), "uploads"))
+		state := NewUploadState(info, "", "my-upload", 1)
+		state.Parts[1] = "my-etag"
+		SaveUploadState(ctx, "my-key", state)
+		loaded := LoadUploadState(ctx, "my-key", info, "")
+		require.NotNil(t, loaded)
+		assert.Equal(t, "my-upload", loaded.UploadID)
+		assert.Equal(t, map[int]string{1: "my-etag"}, loaded.Parts)
+		assert.Nil(t, LoadUploadState(ctx, "other-key", info, ""))
+		DeleteUploadState(ctx, "my-key")
+		assert.Nil(t, LoadUploadState(ctx, "my-key", info, ""))
+	})
+	t.Run("ChangedFile", func(t *testing.T) {
+		ctx := WithUploadStateDir(logging.TestContext(t.Context()), t.TempDir())
+		SaveUploadState(ctx, "my-key", NewUploadState(info, "", "my-upload", 1))
+		require.NoError(t, os.Chtimes(path, time.Now(), info.ModTime().Add(time.Minute)))
+		changed, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Nil(t, LoadUploadState(ctx, "my-key", changed, ""))
+	})
+	t.Run("Digest", func(t *testing.T) {
+		ctx := WithUploadStateDir(logging.TestContext(t.Context()), t.TempDir())
+		SaveUploadState(ctx, "my-key", NewUploadState(info, "sha256:abc", "my-upload", 1))
+		// a file staged again by a restarted container has a new modification time, but the same digest
+		require.NoError(t, os.Chtimes(path, time.Now(), info.ModTime().Add(time.Hour)))
+		staged, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.NotNil(t, LoadUploadState(ctx, "my-key", staged, "sha256:abc"))
+		assert.Nil(t, LoadUploadState(ctx, "my-key", staged, "sha256:def"))
+	})
+}
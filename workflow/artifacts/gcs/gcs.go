@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -33,8 +34,9 @@ type ArtifactDriver struct {
 }
 
 var (
-	_            common.ArtifactDriver = &ArtifactDriver{}
-	defaultRetry                       = wait.Backoff{Duration: time.Second * 2, Factor: 2.0, Steps: 5, Jitter: 0.1, Cap: time.Minute * 10}
+	_            common.ArtifactDriver   = &ArtifactDriver{}
+	_            common.ExistenceChecker = &ArtifactDriver{}
+	defaultRetry                         = wait.Backoff{Duration: time.Second * 2, Factor: 2.0, Steps: 5, Jitter: 0.1, Cap: time.Minute * 10}
 )
 
 // from https://github.com/googleapis/google-cloud-go/blob/master/storage/go110.go
@@ -227,12 +229,74 @@ func (h *ArtifactDriver) OpenStream(ctx context.Context, a *wfv1.Artifact) (io.R
 	return common.LoadToStream(ctx, a, h)
 }
 
+// OpenStreamRange opens part of a file artifact in GCS for reading, without loading it to disk first
+func (h *ArtifactDriver) OpenStreamRange(ctx context.Context, a *wfv1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	key := normalizeGCSKey(filepath.Clean(a.GCS.Key))
+	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"key": key, "offset": offset, "length": length}).Info(ctx, "GCS OpenStreamRange")
+	client, err := h.newGCSClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := client.Bucket(a.GCS.Bucket).Object(key).NewRangeReader(ctx, offset, length)
+	if err != nil {
+		_ = client.Close()
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, argoerrors.New(argoerrors.CodeNotFound, err.Error())
+		}
+		return nil, err
+	}
+	return &rangeReader{r, client}, nil
+}
+
+// rangeReader closes the client once the range has been read
+type rangeReader struct {
+	*storage.Reader
+	client *storage.Client
+}
+
+func (r *rangeReader) Close() error {
+	err := r.Reader.Close()
+	_ = r.client.Close()
+	return err
+}
+
+// PresignedURL returns a signed URL of the object at the key of the artifact. The URL is signed with the private key of
+// the service account key, or by the IAM Credentials API for other credentials.
+func (h *ArtifactDriver) PresignedURL(ctx context.Context, a *wfv1.Artifact, expiry time.Duration) (string, error) {
+	client, err := h.newGCSClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	key := normalizeGCSKey(filepath.Clean(a.GCS.Key))
+	return client.Bucket(a.GCS.Bucket).SignedURL(key, &storage.SignedURLOptions{
+		Scheme:  storage.SigningSchemeV4,
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(expiry),
+	})
+}
+
 // Save an artifact to GCS compliant storage, e.g., uploading a local file to GCS bucket
 func (h *ArtifactDriver) Save(ctx context.Context, path string, outputArtifact *wfv1.Artifact) error {
 	err := waitutil.Backoff(defaultRetry,
 		func() (bool, error) {
 			key := filepath.Clean(outputArtifact.GCS.Key)
 			logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"path": path, "key": key}).Info(ctx, "GCS Save")
+			resumable, err := isResumable(ctx, path)
+			if err != nil {
+				return true, err
+			}
+			if resumable {
+				httpClient, err := h.newHTTPClient(ctx)
+				if err != nil {
+					return !isTransientGCSErr(ctx, err), err
+				}
+				err = uploadObjectResumable(ctx, httpClient, uploadEndpoint, outputArtifact.GCS.Bucket, normalizeGCSKey(key), path, outputArtifact.Digest, resumableChunkSize)
+				if err != nil {
+					return !isTransientGCSErr(ctx, err), err
+				}
+				return true, nil
+			}
 			client, err := h.newGCSClient(ctx)
 			if err != nil {
 				return !isTransientGCSErr(ctx, err), err
@@ -381,3 +445,52 @@ func (h *ArtifactDriver) ListObjects(ctx context.Context, artifact *wfv1.Artifac
 func (h *ArtifactDriver) IsDirectory(ctx context.Context, artifact *wfv1.Artifact) (bool, error) {
 	return false, argoerrors.New(argoerrors.CodeNotImplemented, "IsDirectory currently unimplemented for GCS")
 }
+
+// Exists returns whether an object exists at the key of the artifact
+func (h *ArtifactDriver) Exists(ctx context.Context, artifact *wfv1.Artifact) (bool, error) {
+	var exists bool
+	err := waitutil.Backoff(defaultRetry,
+		func() (bool, error) {
+			client, err := h.newGCSClient(ctx)
+			if err != nil {
+				return !isTransientGCSErr(ctx, err), err
+			}
+			defer client.Close()
+			key := normalizeGCSKey(filepath.Clean(artifact.GCS.Key))
+			_, err = client.Bucket(artifact.GCS.Bucket).Object(key).Attrs(ctx)
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				exists = false
+				return true, nil
+			}
+			if err != nil {
+				return !isTransientGCSErr(ctx, err), err
+			}
+			exists = true
+			return true, nil
+		})
+	return exists, err
+}
+
+// Stat returns the size, ETag and modification time of the object at the key of the artifact
+func (h *ArtifactDriver) Stat(ctx context.Context, artifact *wfv1.Artifact) (*common.ArtifactInfo, error) {
+	var info *common.ArtifactInfo
+	err := waitutil.Backoff(defaultRetry,
+		func() (bool, error) {
+			client, err := h.newGCSClient(ctx)
+			if err != nil {
+				return !isTransientGCSErr(ctx, err), err
+			}
+			defer client.Close()
+			key := normalizeGCSKey(filepath.Clean(artifact.GCS.Key))
+			attrs, err := client.Bucket(artifact.GCS.Bucket).Object(key).Attrs(ctx)
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				return true, argoerrors.New(argoerrors.CodeNotFound, err.Error())
+			}
+			if err != nil {
+				return !isTransientGCSErr(ctx, err), err
+			}
+			info = &common.ArtifactInfo{Size: attrs.Size, ETag: attrs.Etag, LastModified: attrs.Updated}
+			return true, nil
+		})
+	return info, err
+}
@@ -0,0 +1,185 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+)
+
+const (
+	uploadEndpoint = "https://storage.googleapis.com/upload/storage/v1"
+	// the size of each chunk of a resumable upload, which GCS requires to be a multiple of 256KiB
+	resumableChunkSize = 16 * 1024 * 1024
+)
+
+// newHTTPClient returns an authenticated client of the GCS JSON API, for resumable uploads
+func (h *ArtifactDriver) newHTTPClient(ctx context.Context) (*http.Client, error) {
+	opts := []option.ClientOption{option.WithScopes(storage.ScopeReadWrite)}
+	if h.ServiceAccountKey != "" {
+		creds, err := google.CredentialsFromJSONWithType(ctx, []byte(h.ServiceAccountKey), google.ServiceAccount, storage.ScopeReadWrite)
+		if err != nil {
+			return nil, fmt.Errorf("GCS client CredentialsFromJSONWithType: %w", err)
+		}
+		opts = append(opts, option.WithCredentials(creds))
+	}
+	client, _, err := htransport.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("GCS http client: %w", err)
+	}
+	return client, nil
+}
+
+// isResumable returns whether the path is saved with a resumable upload, which is the case for files of more than a
+// chunk when the executor records upload state
+func isResumable(ctx context.Context, path string) (bool, error) {
+	if common.UploadStateDir(ctx) == "" {
+		return false, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return info.Mode().IsRegular() && info.Size() > resumableChunkSize, nil
+}
+
+// uploadObjectResumable uploads a file to the key in a GCS resumable upload session, recording the session URI so
+// that a retried upload asks GCS how much it received and sends only the rest. GCS cannot list the sessions of a key,
+// so a retried pod, which has no recorded session, starts a new one.
+func uploadObjectResumable(ctx context.Context, client *http.Client, endpoint, bucket, key, localPath, digest string, chunkSize int64) error {
+	f, err := os.Open(filepath.Clean(localPath))
+	if err != nil {
+		return fmt.Errorf("os open: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("os stat: %w", err)
+	}
+	size := info.Size()
+	log := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"bucket": bucket, "key": key})
+	stateKey := "gs://" + bucket + "/" + key
+	var offset int64
+	state := common.LoadUploadState(ctx, stateKey, info, digest)
+	if state != nil {
+		var done bool
+		offset, done, err = putChunk(ctx, client, state.UploadID, nil, 0, 0, size)
+		switch {
+		case isSessionGone(err):
+			log.WithError(err).Warn(ctx, "Resumable upload session no longer exists, starting over")
+			common.DeleteUploadState(ctx, stateKey)
+			state = nil
+		case err != nil:
+			return fmt.Errorf("query upload session: %w", err)
+		case done:
+			common.DeleteUploadState(ctx, stateKey)
+			return nil
+		default:
+			log.WithFields(logging.Fields{"offset": offset, "size": size}).Info(ctx, "Resuming upload")
+		}
+	}
+	if state == nil {
+		sessionURI, err := startSession(ctx, client, endpoint, bucket, key, size)
+		if err != nil {
+			return fmt.Errorf("start upload session: %w", err)
+		}
+		state = common.NewUploadState(info, digest, sessionURI, chunkSize)
+		common.SaveUploadState(ctx, stateKey, state)
+		offset = 0
+	}
+	for {
+		length := min(chunkSize, size-offset)
+		next, done, err := putChunk(ctx, client, state.UploadID, io.NewSectionReader(f, offset, length), offset, length, size)
+		if isSessionGone(err) {
+			common.DeleteUploadState(ctx, stateKey)
+		}
+		if err != nil {
+			return fmt.Errorf("upload chunk at %d: %w", offset, err)
+		}
+		if done {
+			common.DeleteUploadState(ctx, stateKey)
+			return nil
+		}
+		offset = next
+	}
+}
+
+// startSession starts a resumable upload session for an object of the size, returning its URI
+func startSession(ctx context.Context, client *http.Client, endpoint, bucket, key string, size int64) (string, error) {
+	u := fmt.Sprintf("%s/b/%s/o?uploadType=resumable&name=%s", endpoint, url.PathEscape(bucket), url.QueryEscape(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Upload-Content-Length", strconv.FormatInt(size, 10))
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return "", err
+	}
+	sessionURI := resp.Header.Get("Location")
+	if sessionURI == "" {
+		return "", fmt.Errorf("no session URI in response")
+	}
+	return sessionURI, nil
+}
+
+// putChunk puts the chunk of length bytes from offset of an object of the size to the session, returning the offset
+// GCS has received up to and whether the upload is complete. A nil chunk queries the session without sending bytes.
+func putChunk(ctx context.Context, client *http.Client, sessionURI string, chunk io.Reader, offset, length, size int64) (int64, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, sessionURI, chunk)
+	if err != nil {
+		return 0, false, err
+	}
+	req.ContentLength = length
+	if chunk == nil {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+	} else {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, size))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusPermanentRedirect {
+		// incomplete, the Range header is the bytes received so far, if any
+		received, ok := strings.CutPrefix(resp.Header.Get("Range"), "bytes=0-")
+		if !ok {
+			return 0, false, nil
+		}
+		last, err := strconv.ParseInt(received, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid Range %q: %w", resp.Header.Get("Range"), err)
+		}
+		return last + 1, false, nil
+	}
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return 0, false, err
+	}
+	return size, true, nil
+}
+
+// isSessionGone returns whether the error is that the upload session has expired or was cancelled
+func isSessionGone(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && (apiErr.Code == http.StatusNotFound || apiErr.Code == http.StatusGone)
+}
@@ -0,0 +1,81 @@
+package gcs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+)
+
+// fakeUploadServer implements enough of GCS resumable uploads to test them, failing the chunk at failAt once
+type fakeUploadServer struct {
+	received []byte
+	sessions int
+	failAt   int
+}
+
+func (s *fakeUploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		s.sessions++
+		w.Header().Set("Location", fmt.Sprintf("http://%s/session/%d", r.Host, s.sessions))
+		return
+	}
+	var start, end, size int
+	contentRange := r.Header.Get("Content-Range")
+	if _, err := fmt.Sscanf(contentRange, "bytes */%d", &size); err != nil {
+		if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &size); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if start == s.failAt {
+			s.failAt = -1
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		s.received = append(s.received[:start], data...)
+	}
+	if len(s.received) == size {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if len(s.received) > 0 {
+		w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(s.received)-1))
+	}
+	w.WriteHeader(http.StatusPermanentRedirect)
+}
+
+func TestUploadObjectResumable(t *testing.T) {
+	ctx := common.WithUploadStateDir(logging.TestContext(t.Context()), t.TempDir())
+	path := filepath.Join(t.TempDir(), "my-file")
+	require.NoError(t, os.WriteFile(path, []byte("hello resumable world"), 0o600))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	fake := &fakeUploadServer{failAt: 8}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	err = uploadObjectResumable(ctx, server.Client(), server.URL, "my-bucket", "my-key", path, "", 4)
+	require.Error(t, err)
+	assert.True(t, isTransientGCSErr(ctx, err))
+	state := common.LoadUploadState(ctx, "gs://my-bucket/my-key", info, "")
+	require.NotNil(t, state)
+	assert.True(t, strings.HasSuffix(state.UploadID, "/session/1"))
+	assert.Equal(t, "hello re", string(fake.received))
+
+	require.NoError(t, uploadObjectResumable(ctx, server.Client(), server.URL, "my-bucket", "my-key", path, "", 4))
+	assert.Equal(t, "hello resumable world", string(fake.received))
+	assert.Equal(t, 1, fake.sessions)
+	assert.Nil(t, common.LoadUploadState(ctx, "gs://my-bucket/my-key", info, ""))
+}
@@ -0,0 +1,78 @@
+package integrity
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/file"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+)
+
+// driver verifies the digests of artifacts as they are loaded, so corrupt or truncated artifacts fail with a clear error.
+// Artifacts without a digest, and directories, are not verified.
+type driver struct {
+	common.ArtifactDriver
+}
+
+func New(d common.ArtifactDriver) common.ArtifactDriver {
+	return &driver{d}
+}
+
+func (d *driver) Load(ctx context.Context, inputArtifact *wfv1.Artifact, path string) error {
+	if err := d.ArtifactDriver.Load(ctx, inputArtifact, path); err != nil {
+		return err
+	}
+	if inputArtifact.Digest == "" {
+		return nil
+	}
+	isDir, err := file.IsDirectory(path)
+	if err != nil || isDir {
+		return err
+	}
+	digest, err := common.FileDigest(path)
+	if err != nil {
+		return err
+	}
+	return common.VerifyDigest(inputArtifact, digest)
+}
+
+// OpenStream verifies the artifact as it is read, so reading a corrupt artifact fails at the end of the stream
+func (d *driver) OpenStream(ctx context.Context, inputArtifact *wfv1.Artifact) (io.ReadCloser, error) {
+	rc, err := d.ArtifactDriver.OpenStream(ctx, inputArtifact)
+	if err != nil || inputArtifact.Digest == "" {
+		return rc, err
+	}
+	return common.NewVerifiedStream(rc, inputArtifact), nil
+}
+
+// OpenStreamRange returns the part of the artifact from the wrapped driver without verifying it, as the digest is of the
+// whole artifact and verifying it would mean reading all of it. Clients can verify a download once it is complete.
+func (d *driver) OpenStreamRange(ctx context.Context, inputArtifact *wfv1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	return common.OpenStreamRange(ctx, d.ArtifactDriver, inputArtifact, offset, length)
+}
+
+// SaveStream records the digest of the saved contents on the artifact
+func (d *driver) SaveStream(ctx context.Context, reader io.Reader, outputArtifact *wfv1.Artifact) error {
+	h := sha256.New()
+	if err := d.ArtifactDriver.SaveStream(ctx, io.TeeReader(reader, h), outputArtifact); err != nil {
+		return err
+	}
+	outputArtifact.Digest = common.FormatDigest(h)
+	return nil
+}
+
+func (d *driver) Exists(ctx context.Context, artifact *wfv1.Artifact) (bool, error) {
+	return common.Exists(ctx, d.ArtifactDriver, artifact)
+}
+
+func (d *driver) Stat(ctx context.Context, artifact *wfv1.Artifact) (*common.ArtifactInfo, error) {
+	return common.Stat(ctx, d.ArtifactDriver, artifact)
+}
+
+// PresignedURL returns a pre-signed URL of the artifact, which is downloaded without being verified
+func (d *driver) PresignedURL(ctx context.Context, artifact *wfv1.Artifact, expiry time.Duration) (string, error) {
+	return common.PresignedURL(ctx, d.ArtifactDriver, artifact, expiry)
+}
@@ -0,0 +1,141 @@
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+)
+
+const helloWorldDigest = "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+type fakeArtifactDriver struct {
+	common.ArtifactDriver
+	data  []byte
+	saved []byte
+}
+
+func (d *fakeArtifactDriver) Load(_ context.Context, _ *wfv1.Artifact, path string) error {
+	return os.WriteFile(path, d.data, 0o600)
+}
+
+func (d *fakeArtifactDriver) OpenStream(_ context.Context, _ *wfv1.Artifact) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(d.data)), nil
+}
+
+func (d *fakeArtifactDriver) SaveStream(_ context.Context, reader io.Reader, _ *wfv1.Artifact) error {
+	var err error
+	d.saved, err = io.ReadAll(reader)
+	return err
+}
+
+func TestLoad(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	drv := New(&fakeArtifactDriver{data: []byte("hello world")})
+	path := filepath.Join(t.TempDir(), "hello.txt")
+
+	t.Run("NoDigest", func(t *testing.T) {
+		require.NoError(t, drv.Load(ctx, &wfv1.Artifact{}, path))
+	})
+	t.Run("Match", func(t *testing.T) {
+		require.NoError(t, drv.Load(ctx, &wfv1.Artifact{Digest: helloWorldDigest}, path))
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		err := drv.Load(ctx, &wfv1.Artifact{Name: "hello", Digest: "sha256:0000"}, path)
+		require.ErrorIs(t, err, common.ErrDigestMismatch)
+		assert.Contains(t, err.Error(), `artifact "hello"`)
+	})
+}
+
+func TestOpenStream(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	drv := New(&fakeArtifactDriver{data: []byte("hello world")})
+
+	t.Run("Match", func(t *testing.T) {
+		rc, err := drv.OpenStream(ctx, &wfv1.Artifact{Digest: helloWorldDigest})
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(data))
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		rc, err := drv.OpenStream(ctx, &wfv1.Artifact{Digest: "sha256:0000"})
+		require.NoError(t, err)
+		_, err = io.ReadAll(rc)
+		require.ErrorIs(t, err, common.ErrDigestMismatch)
+	})
+}
+
+// rangedArtifactDriver counts the bytes read from it, and fails the test if the whole artifact is opened
+type rangedArtifactDriver struct {
+	fakeArtifactDriver
+	t    *testing.T
+	read int64
+}
+
+func (d *rangedArtifactDriver) OpenStream(_ context.Context, _ *wfv1.Artifact) (io.ReadCloser, error) {
+	d.t.Error("the whole artifact was opened")
+	return io.NopCloser(bytes.NewReader(d.data)), nil
+}
+
+func (d *rangedArtifactDriver) OpenStreamRange(_ context.Context, _ *wfv1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	data := d.data[offset:]
+	if length >= 0 {
+		data = data[:length]
+	}
+	return io.NopCloser(&countingReader{bytes.NewReader(data), &d.read}), nil
+}
+
+type countingReader struct {
+	io.Reader
+	n *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	*r.n += int64(n)
+	return n, err
+}
+
+func TestOpenStreamRange(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+
+	t.Run("NoDigest", func(t *testing.T) {
+		drv := New(&fakeArtifactDriver{data: []byte("hello world")}).(common.RangedReader)
+		rc, err := drv.OpenStreamRange(ctx, &wfv1.Artifact{}, 6, -1)
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "world", string(data))
+	})
+	t.Run("Digest", func(t *testing.T) {
+		spy := &rangedArtifactDriver{fakeArtifactDriver: fakeArtifactDriver{data: []byte("hello world")}, t: t}
+		drv := New(spy).(common.RangedReader)
+		// ranges are not verified, as that would mean reading the whole artifact
+		rc, err := drv.OpenStreamRange(ctx, &wfv1.Artifact{Digest: "sha256:0000"}, 6, 3)
+		require.NoError(t, err)
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "wor", string(data))
+		assert.Equal(t, int64(3), spy.read)
+	})
+}
+
+func TestSaveStream(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	fake := &fakeArtifactDriver{}
+	art := &wfv1.Artifact{}
+	require.NoError(t, New(fake).SaveStream(ctx, bytes.NewReader([]byte("hello world")), art))
+	assert.Equal(t, "hello world", string(fake.saved))
+	assert.Equal(t, helloWorldDigest, art.Digest)
+}
@@ -47,6 +47,22 @@ func (d *driver) OpenStream(ctx context.Context, inputArtifact *wfv1.Artifact) (
 	return rc, err
 }
 
+func (d *driver) OpenStreamRange(ctx context.Context, inputArtifact *wfv1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	log := logging.RequireLoggerFromContext(ctx)
+	log.Info(ctx, "Opening stream range")
+	t := time.Now()
+	key, _ := inputArtifact.GetKey()
+	rc, err := common.OpenStreamRange(ctx, d.ArtifactDriver, inputArtifact, offset, length)
+	log.WithField("artifactName", inputArtifact.Name).
+		WithField("key", key).
+		WithField("offset", offset).
+		WithField("length", length).
+		WithField("duration", time.Since(t)).
+		WithError(err).
+		Info(ctx, "Stream artifact range")
+	return rc, err
+}
+
 func (d *driver) Save(ctx context.Context, path string, outputArtifact *wfv1.Artifact) error {
 	log := logging.RequireLoggerFromContext(ctx)
 	log.Info(ctx, "Saving artifact")
@@ -116,3 +132,45 @@ func (d *driver) IsDirectory(ctx context.Context, artifact *wfv1.Artifact) (bool
 		Info(ctx, "Check if directory")
 	return isDir, err
 }
+
+func (d *driver) Exists(ctx context.Context, artifact *wfv1.Artifact) (bool, error) {
+	log := logging.RequireLoggerFromContext(ctx)
+	log.Info(ctx, "Checking if exists")
+	t := time.Now()
+	key, _ := artifact.GetKey()
+	exists, err := common.Exists(ctx, d.ArtifactDriver, artifact)
+	log.WithField("artifactName", artifact.Name).
+		WithField("key", key).
+		WithField("duration", time.Since(t)).
+		WithError(err).
+		Info(ctx, "Check if exists")
+	return exists, err
+}
+
+func (d *driver) Stat(ctx context.Context, artifact *wfv1.Artifact) (*common.ArtifactInfo, error) {
+	log := logging.RequireLoggerFromContext(ctx)
+	log.Info(ctx, "Getting metadata")
+	t := time.Now()
+	key, _ := artifact.GetKey()
+	info, err := common.Stat(ctx, d.ArtifactDriver, artifact)
+	log.WithField("artifactName", artifact.Name).
+		WithField("key", key).
+		WithField("duration", time.Since(t)).
+		WithError(err).
+		Info(ctx, "Get metadata")
+	return info, err
+}
+
+func (d *driver) PresignedURL(ctx context.Context, artifact *wfv1.Artifact, expiry time.Duration) (string, error) {
+	log := logging.RequireLoggerFromContext(ctx)
+	t := time.Now()
+	key, _ := artifact.GetKey()
+	url, err := common.PresignedURL(ctx, d.ArtifactDriver, artifact, expiry)
+	log.WithField("artifactName", artifact.Name).
+		WithField("key", key).
+		WithField("expiry", expiry).
+		WithField("duration", time.Since(t)).
+		WithError(err).
+		Info(ctx, "Pre-sign artifact URL")
+	return url, err
+}
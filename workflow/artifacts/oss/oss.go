@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net/http"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -39,8 +41,9 @@ type ArtifactDriver struct {
 }
 
 var (
-	_            common.ArtifactDriver = &ArtifactDriver{}
-	defaultRetry                       = wait.Backoff{Duration: time.Second * 2, Factor: 2.0, Steps: 5, Jitter: 0.1}
+	_            common.ArtifactDriver   = &ArtifactDriver{}
+	_            common.ExistenceChecker = &ArtifactDriver{}
+	defaultRetry                         = wait.Backoff{Duration: time.Second * 2, Factor: 2.0, Steps: 5, Jitter: 0.1}
 
 	// OSS error code reference: https://error-center.alibabacloud.com/status/product/Oss
 	ossTransientErrorCodes = []string{"RequestTimeout", "QuotaExceeded.Refresh", "Default", "ServiceUnavailable", "Throttling", "RequestTimeTooSkewed", "SocketException", "SocketTimeout", "ServiceBusy", "DomainNetWorkVisitedException", "ConnectionTimeout", "CachedTimeTooLarge", "InternalError"}
@@ -214,6 +217,41 @@ func (ossDriver *ArtifactDriver) OpenStream(ctx context.Context, inputArtifact *
 	return stream, err
 }
 
+// OpenStreamRange opens a stream reader for part of a file artifact from OSS compliant storage
+func (ossDriver *ArtifactDriver) OpenStreamRange(ctx context.Context, inputArtifact *wfv1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	if length == 0 {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	// the standard behavior returns an error for an invalid range, rather than the whole object
+	options := []oss.Option{oss.RangeBehavior("standard"), oss.NormalizedRange(fmt.Sprintf("%d-", offset))}
+	if length > 0 {
+		options[1] = oss.Range(offset, offset+length-1)
+	}
+	var stream io.ReadCloser
+	err := waitutil.Backoff(defaultRetry,
+		func() (bool, error) {
+			logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"key": inputArtifact.OSS.Key, "offset": offset, "length": length}).Info(ctx, "OSS OpenStreamRange")
+			osscli, err := ossDriver.newOSSClient(ctx)
+			if err != nil {
+				return !isTransientOSSErr(ctx, err), err
+			}
+			bucket, err := osscli.Bucket(inputArtifact.OSS.Bucket)
+			if err != nil {
+				return !isTransientOSSErr(ctx, err), err
+			}
+			stream, err = bucket.GetObject(inputArtifact.OSS.Key, options...)
+			if err != nil {
+				var serr oss.ServiceError
+				if errors.As(err, &serr) && serr.StatusCode == http.StatusNotFound {
+					return true, argoerrors.New(argoerrors.CodeNotFound, fmt.Sprintf("no key found of name %s", inputArtifact.OSS.Key))
+				}
+				return !isTransientOSSErr(ctx, err), fmt.Errorf("failed to get file range: %w", err)
+			}
+			return true, nil
+		})
+	return stream, err
+}
+
 // Save stores an artifact to OSS compliant storage, e.g., uploading a local file to OSS bucket
 func (ossDriver *ArtifactDriver) Save(ctx context.Context, path string, outputArtifact *wfv1.Artifact) error {
 	err := waitutil.Backoff(defaultRetry,
@@ -628,3 +666,67 @@ func (ossDriver *ArtifactDriver) IsDirectory(ctx context.Context, artifact *wfv1
 	}
 	return isDir, nil
 }
+
+// Exists returns whether an object exists at the key of the artifact
+func (ossDriver *ArtifactDriver) Exists(ctx context.Context, artifact *wfv1.Artifact) (bool, error) {
+	var exists bool
+	err := waitutil.Backoff(defaultRetry,
+		func() (bool, error) {
+			osscli, err := ossDriver.newOSSClient(ctx)
+			if err != nil {
+				return !isTransientOSSErr(ctx, err), err
+			}
+			bucket, err := osscli.Bucket(artifact.OSS.Bucket)
+			if err != nil {
+				return !isTransientOSSErr(ctx, err), err
+			}
+			exists, err = bucket.IsObjectExist(artifact.OSS.Key)
+			if err != nil {
+				return !isTransientOSSErr(ctx, err), err
+			}
+			return true, nil
+		})
+	return exists, err
+}
+
+// PresignedURL returns a signed URL of the object at the key of the artifact
+func (ossDriver *ArtifactDriver) PresignedURL(ctx context.Context, artifact *wfv1.Artifact, expiry time.Duration) (string, error) {
+	osscli, err := ossDriver.newOSSClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	bucket, err := osscli.Bucket(artifact.OSS.Bucket)
+	if err != nil {
+		return "", err
+	}
+	return bucket.SignURL(artifact.OSS.Key, oss.HTTPGet, int64(expiry.Seconds()))
+}
+
+// Stat returns the size, ETag and modification time of the object at the key of the artifact
+func (ossDriver *ArtifactDriver) Stat(ctx context.Context, artifact *wfv1.Artifact) (*common.ArtifactInfo, error) {
+	var info *common.ArtifactInfo
+	err := waitutil.Backoff(defaultRetry,
+		func() (bool, error) {
+			osscli, err := ossDriver.newOSSClient(ctx)
+			if err != nil {
+				return !isTransientOSSErr(ctx, err), err
+			}
+			bucket, err := osscli.Bucket(artifact.OSS.Bucket)
+			if err != nil {
+				return !isTransientOSSErr(ctx, err), err
+			}
+			meta, err := bucket.GetObjectDetailedMeta(artifact.OSS.Key)
+			if err != nil {
+				var serr oss.ServiceError
+				if errors.As(err, &serr) && serr.StatusCode == http.StatusNotFound {
+					return true, argoerrors.New(argoerrors.CodeNotFound, fmt.Sprintf("no key found of name %s", artifact.OSS.Key))
+				}
+				return !isTransientOSSErr(ctx, err), err
+			}
+			info = &common.ArtifactInfo{ETag: strings.Trim(meta.Get(oss.HTTPHeaderEtag), `"`)}
+			info.Size, _ = strconv.ParseInt(meta.Get(oss.HTTPHeaderContentLength), 10, 64)
+			info.LastModified, _ = http.ParseTime(meta.Get(oss.HTTPHeaderLastModified))
+			return true, nil
+		})
+	return info, err
+}
@@ -2,18 +2,22 @@ package s3
 
 import (
 	"context"
+	"crypto/md5"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
@@ -47,15 +51,37 @@ type Client interface {
 	// a separate key in the bucket.
 	PutDirectory(bucket, key, path string) error
 
+	// NewMultipartUpload starts a multipart upload to the key, returning its upload ID
+	NewMultipartUpload(bucket, key string) (string, error)
+
+	// PutObjectPart uploads a part of a multipart upload, returning its entity tag
+	PutObjectPart(bucket, key, uploadID string, partNumber int, data io.Reader, size int64) (string, error)
+
+	// CompleteMultipartUpload assembles the uploaded parts of a multipart upload into the object at the key
+	CompleteMultipartUpload(bucket, key, uploadID string, parts []minio.CompletePart) error
+
+	// ListMultipartUploadParts returns the ID and uploaded parts of the most recently started multipart upload to the
+	// key which is still in progress, or "" if there is none
+	ListMultipartUploadParts(bucket, key string) (string, []minio.ObjectPart, error)
+
 	// GetFile downloads a file to a local file path
 	GetFile(bucket, key, path string) error
 
 	// OpenFile opens a file for much lower disk and memory usage that GetFile
 	OpenFile(bucket, key string) (io.ReadCloser, error)
 
+	// OpenFileRange opens length bytes of a file from offset, or the rest of it if length is negative
+	OpenFileRange(bucket, key string, offset, length int64) (io.ReadCloser, error)
+
 	// KeyExists checks if object exists (and if we have permission to access)
 	KeyExists(bucket, key string) (bool, error)
 
+	// StatObject gets the metadata of the object at the key
+	StatObject(bucket, key string) (minio.ObjectInfo, error)
+
+	// PresignedURL returns a URL which gets the object at the key until it expires
+	PresignedURL(bucket, key string, expiry time.Duration) (*url.URL, error)
+
 	// Delete deletes the key from the bucket
 	Delete(bucket, key string) error
 
@@ -137,6 +163,8 @@ type ArtifactDriver struct {
 
 var _ artifactscommon.ArtifactDriver = &ArtifactDriver{}
 
+var _ artifactscommon.ExistenceChecker = &ArtifactDriver{}
+
 func parseAddressingStyle(s string) AddressingStyle {
 	switch s {
 	case "path":
@@ -264,6 +292,30 @@ func streamS3Artifact(_ context.Context, s3cli Client, inputArtifact *wfv1.Artif
 	return nil, argoerrs.New(argoerrs.CodeNotImplemented, "Directory Stream capability currently unimplemented for S3")
 }
 
+// OpenStreamRange opens a stream reader for part of a file artifact from S3 compliant storage
+func (s3Driver *ArtifactDriver) OpenStreamRange(ctx context.Context, inputArtifact *wfv1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	log := logging.RequireLoggerFromContext(ctx)
+	log.WithFields(logging.Fields{"key": inputArtifact.S3.Key, "offset": offset, "length": length}).Info(ctx, "S3 OpenStreamRange")
+	//nolint:contextcheck
+	s3cli, err := s3Driver.newClient(log.NewBackgroundContext())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new S3 client: %w", err)
+	}
+
+	return streamS3ArtifactRange(ctx, s3cli, inputArtifact, offset, length)
+}
+
+func streamS3ArtifactRange(_ context.Context, s3cli Client, inputArtifact *wfv1.Artifact, offset, length int64) (io.ReadCloser, error) {
+	stream, err := s3cli.OpenFileRange(inputArtifact.S3.Bucket, inputArtifact.S3.Key, offset, length)
+	if err != nil {
+		if IsS3ErrCode(err, "NoSuchKey") {
+			return nil, argoerrs.New(argoerrs.CodeNotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to get file range: %w", err)
+	}
+	return stream, nil
+}
+
 // Save saves an artifact to S3 compliant storage
 func (s3Driver *ArtifactDriver) Save(ctx context.Context, path string, outputArtifact *wfv1.Artifact) error {
 	ctx, cancel := context.WithCancel(ctx)
@@ -353,6 +405,10 @@ func saveS3Artifact(ctx context.Context, s3cli Client, path string, outputArtifa
 		if err = s3cli.PutDirectory(outputArtifact.S3.Bucket, outputArtifact.S3.Key, path); err != nil {
 			return !isTransientS3Err(ctx, err), fmt.Errorf("failed to put directory: %w", err)
 		}
+	} else if artifactscommon.UploadStateDir(ctx) != "" {
+		if err = putFileResumable(ctx, s3cli, outputArtifact.S3.Bucket, outputArtifact.S3.Key, path, outputArtifact.Digest); err != nil {
+			return !isTransientS3Err(ctx, err), fmt.Errorf("failed to put file: %w", err)
+		}
 	} else {
 		if err = s3cli.PutFile(outputArtifact.S3.Bucket, outputArtifact.S3.Key, path); err != nil {
 			return !isTransientS3Err(ctx, err), fmt.Errorf("failed to put file: %w", err)
@@ -361,6 +417,130 @@ func saveS3Artifact(ctx context.Context, s3cli Client, path string, outputArtifa
 	return true, nil
 }
 
+const (
+	// the part size of resumable uploads, unless configured otherwise, as for minio
+	defaultResumablePartSize = 16 * 1024 * 1024
+	// the part size limits and maximum number of parts of a multipart upload
+	minResumablePartSize = 5 * 1024 * 1024
+	maxResumablePartSize = 5 * 1024 * 1024 * 1024
+	maxResumableParts    = 10000
+)
+
+// resumablePartSize returns the part size of a resumable upload of a file of the size
+func resumablePartSize(size int64) int64 {
+	partSize := int64(defaultResumablePartSize)
+	if partSizeMiB, err := strconv.ParseInt(os.Getenv(common.EnvVarS3UploadPartSizeMiB), 10, 64); err == nil {
+		if configured := partSizeMiB * 1024 * 1024; configured >= minResumablePartSize && configured <= maxResumablePartSize {
+			partSize = configured
+		}
+	}
+	// large files need larger parts to stay within the maximum number of parts
+	return max(partSize, (size+maxResumableParts-1)/maxResumableParts)
+}
+
+// putFileResumable puts a file to the key as a multipart upload, recording the upload ID and uploaded parts so that a
+// retried upload only uploads the remaining parts. Without a recorded upload, such as in a retried pod, it resumes the
+// upload to the key left in progress by an earlier attempt, if any. Files of a single part are put as usual.
+func putFileResumable(ctx context.Context, s3cli Client, bucket, key, path, digest string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	partSize := resumablePartSize(info.Size())
+	if info.Size() <= partSize {
+		return s3cli.PutFile(bucket, key, path)
+	}
+	log := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"bucket": bucket, "key": key})
+	stateKey := "s3://" + bucket + "/" + key
+	state := artifactscommon.LoadUploadState(ctx, stateKey, info, digest)
+	if state == nil {
+		state = findMultipartUpload(ctx, s3cli, bucket, key, path, info, digest, partSize)
+	}
+	if state != nil {
+		log.WithFields(logging.Fields{"uploadID": state.UploadID, "uploadedParts": len(state.Parts)}).Info(ctx, "Resuming multipart upload")
+		err = putFileParts(ctx, s3cli, bucket, key, path, stateKey, state)
+		if !IsS3ErrCode(err, "NoSuchUpload") {
+			return err
+		}
+		// the upload was completed, aborted or expired since it was recorded
+		log.WithError(err).Warn(ctx, "Multipart upload no longer exists, starting over")
+		artifactscommon.DeleteUploadState(ctx, stateKey)
+	}
+	uploadID, err := s3cli.NewMultipartUpload(bucket, key)
+	if err != nil {
+		return err
+	}
+	state = artifactscommon.NewUploadState(info, digest, uploadID, partSize)
+	artifactscommon.SaveUploadState(ctx, stateKey, state)
+	return putFileParts(ctx, s3cli, bucket, key, path, stateKey, state)
+}
+
+// findMultipartUpload returns the state of the multipart upload to the key left in progress by an earlier attempt, or
+// nil if there is none or it cannot be listed. The file may have changed since, for example when it is archived again,
+// so only the parts whose size and MD5 entity tag match the file are kept, and the others are uploaded again.
+func findMultipartUpload(ctx context.Context, s3cli Client, bucket, key, path string, info os.FileInfo, digest string, partSize int64) *artifactscommon.UploadState {
+	log := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"bucket": bucket, "key": key})
+	uploadID, parts, err := s3cli.ListMultipartUploadParts(bucket, key)
+	if err != nil {
+		log.WithError(err).Warn(ctx, "Failed to list multipart uploads, starting a new upload")
+		return nil
+	}
+	if uploadID == "" {
+		return nil
+	}
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		log.WithError(err).Warn(ctx, "Failed to open file, starting a new upload")
+		return nil
+	}
+	defer f.Close()
+	state := artifactscommon.NewUploadState(info, digest, uploadID, partSize)
+	for _, part := range parts {
+		offset := int64(part.PartNumber-1) * partSize
+		if part.PartNumber < 1 || offset >= info.Size() || part.Size != min(partSize, info.Size()-offset) {
+			continue
+		}
+		h := md5.New()
+		if _, err := io.Copy(h, io.NewSectionReader(f, offset, part.Size)); err != nil {
+			log.WithError(err).Warn(ctx, "Failed to read file, starting a new upload")
+			return nil
+		}
+		if strings.Trim(part.ETag, `"`) == hex.EncodeToString(h.Sum(nil)) {
+			state.Parts[part.PartNumber] = part.ETag
+		}
+	}
+	log.WithFields(logging.Fields{"uploadID": uploadID, "parts": len(parts), "matchingParts": len(state.Parts)}).Info(ctx, "Found multipart upload of an earlier attempt")
+	return state
+}
+
+// putFileParts uploads the parts of the file not yet uploaded, recording each, then completes the upload
+func putFileParts(ctx context.Context, s3cli Client, bucket, key, path, stateKey string, state *artifactscommon.UploadState) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var parts []minio.CompletePart
+	for number, offset := 1, int64(0); offset < state.Size; number, offset = number+1, offset+state.PartSize {
+		etag, ok := state.Parts[number]
+		if !ok {
+			size := min(state.PartSize, state.Size-offset)
+			etag, err = s3cli.PutObjectPart(bucket, key, state.UploadID, number, io.NewSectionReader(f, offset, size), size)
+			if err != nil {
+				return err
+			}
+			state.Parts[number] = etag
+			artifactscommon.SaveUploadState(ctx, stateKey, state)
+		}
+		parts = append(parts, minio.CompletePart{PartNumber: number, ETag: etag})
+	}
+	if err := s3cli.CompleteMultipartUpload(bucket, key, state.UploadID, parts); err != nil {
+		return err
+	}
+	artifactscommon.DeleteUploadState(ctx, stateKey)
+	return nil
+}
+
 func bucketAlreadyExistsErr(err error) bool {
 	resp := &minio.ErrorResponse{}
 	// https://docs.aws.amazon.com/AmazonS3/latest/API/ErrorResponses.html
@@ -420,6 +600,77 @@ func (s3Driver *ArtifactDriver) IsDirectory(ctx context.Context, artifact *wfv1.
 	return s3cli.IsDirectory(artifact.S3.Bucket, artifact.S3.Key)
 }
 
+// Exists returns whether an object exists at the key of the artifact
+func (s3Driver *ArtifactDriver) Exists(ctx context.Context, artifact *wfv1.Artifact) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var exists bool
+	err := waitutil.Backoff(executorretry.ExecutorRetry(ctx),
+		func() (bool, error) {
+			s3cli, err := s3Driver.newClient(ctx)
+			if err != nil {
+				return !isTransientS3Err(ctx, err), fmt.Errorf("failed to create new S3 client: %w", err)
+			}
+			exists, err = s3cli.KeyExists(artifact.S3.Bucket, artifact.S3.Key)
+			if err != nil {
+				return !isTransientS3Err(ctx, err), fmt.Errorf("failed to check if key %s exists from bucket %s: %w", artifact.S3.Key, artifact.S3.Bucket, err)
+			}
+			return true, nil
+		})
+	return exists, err
+}
+
+// Stat returns the size, ETag and modification time of the object at the key of the artifact
+func (s3Driver *ArtifactDriver) Stat(ctx context.Context, artifact *wfv1.Artifact) (*artifactscommon.ArtifactInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var info *artifactscommon.ArtifactInfo
+	err := waitutil.Backoff(executorretry.ExecutorRetry(ctx),
+		func() (bool, error) {
+			s3cli, err := s3Driver.newClient(ctx)
+			if err != nil {
+				return !isTransientS3Err(ctx, err), fmt.Errorf("failed to create new S3 client: %w", err)
+			}
+			var done bool
+			done, info, err = statS3Artifact(ctx, s3cli, artifact)
+			return done, err
+		})
+	return info, err
+}
+
+// PresignedURL returns a pre-signed URL of the object at the key of the artifact. Objects encrypted with a customer key
+// cannot be downloaded without it, so they have none.
+func (s3Driver *ArtifactDriver) PresignedURL(ctx context.Context, artifact *wfv1.Artifact, expiry time.Duration) (string, error) {
+	if s3Driver.ServerSideCustomerKey != "" {
+		return "", artifactscommon.ErrPresignNotSupported
+	}
+	s3cli, err := s3Driver.newClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create new S3 client: %w", err)
+	}
+	u, err := s3cli.PresignedURL(artifact.S3.Bucket, artifact.S3.Key, expiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to pre-sign URL of key %s in bucket %s: %w", artifact.S3.Key, artifact.S3.Bucket, err)
+	}
+	return u.String(), nil
+}
+
+// statS3Artifact gets the metadata of the object at the key of the artifact
+// returns true if success or can't be retried (non-transient error)
+// returns false if it can be retried (transient error)
+func statS3Artifact(ctx context.Context, s3cli Client, artifact *wfv1.Artifact) (bool, *artifactscommon.ArtifactInfo, error) {
+	objInfo, err := s3cli.StatObject(artifact.S3.Bucket, artifact.S3.Key)
+	if err != nil {
+		if IsS3ErrCode(err, "NoSuchKey") {
+			return true, nil, argoerrs.New(argoerrs.CodeNotFound, fmt.Sprintf("no key found of name %s", artifact.S3.Key))
+		}
+		return !isTransientS3Err(ctx, err), nil, fmt.Errorf("failed to get metadata of key %s from bucket %s: %w", artifact.S3.Key, artifact.S3.Bucket, err)
+	}
+	return true, &artifactscommon.ArtifactInfo{Size: objInfo.Size, ETag: objInfo.ETag, LastModified: objInfo.LastModified}, nil
+}
+
 // Get AWS credentials based on default order from aws SDK
 func getAWSCredentials(ctx context.Context, opts ClientOpts) (*credentials.Credentials, error) {
 	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(opts.Region))
@@ -665,6 +916,78 @@ func (s *s3client) PutFile(bucket, key, path string) error {
 	return nil
 }
 
+func (s *s3client) NewMultipartUpload(bucket, key string) (string, error) {
+	logging.RequireLoggerFromContext(s.ctx).WithFields(logging.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key}).Info(s.ctx, "Starting multipart upload to s3")
+	encOpts, err := s.EncryptOpts.buildServerSideEnc(bucket, key)
+	if err != nil {
+		return "", err
+	}
+	return minio.Core{Client: s.minioClient}.NewMultipartUpload(s.ctx, bucket, key, minio.PutObjectOptions{ServerSideEncryption: encOpts})
+}
+
+func (s *s3client) PutObjectPart(bucket, key, uploadID string, partNumber int, data io.Reader, size int64) (string, error) {
+	logging.RequireLoggerFromContext(s.ctx).WithFields(logging.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key, "partNumber": partNumber, "size": size}).Info(s.ctx, "Uploading part to s3")
+	encOpts, err := s.EncryptOpts.buildServerSideEnc(bucket, key)
+	if err != nil {
+		return "", err
+	}
+	part, err := minio.Core{Client: s.minioClient}.PutObjectPart(s.ctx, bucket, key, uploadID, partNumber, data, size, minio.PutObjectPartOptions{SSE: encOpts})
+	if err != nil {
+		return "", err
+	}
+	return part.ETag, nil
+}
+
+func (s *s3client) CompleteMultipartUpload(bucket, key, uploadID string, parts []minio.CompletePart) error {
+	logging.RequireLoggerFromContext(s.ctx).WithFields(logging.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key, "parts": len(parts)}).Info(s.ctx, "Completing multipart upload to s3")
+	encOpts, err := s.EncryptOpts.buildServerSideEnc(bucket, key)
+	if err != nil {
+		return err
+	}
+	_, err = minio.Core{Client: s.minioClient}.CompleteMultipartUpload(s.ctx, bucket, key, uploadID, parts, minio.PutObjectOptions{ServerSideEncryption: encOpts})
+	return err
+}
+
+func (s *s3client) ListMultipartUploadParts(bucket, key string) (string, []minio.ObjectPart, error) {
+	logging.RequireLoggerFromContext(s.ctx).WithFields(logging.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key}).Info(s.ctx, "Listing multipart uploads in s3")
+	core := minio.Core{Client: s.minioClient}
+	var latest *minio.ObjectMultipartInfo
+	keyMarker, uploadIDMarker := "", ""
+	for {
+		result, err := core.ListMultipartUploads(s.ctx, bucket, key, keyMarker, uploadIDMarker, "", 1000)
+		if err != nil {
+			return "", nil, err
+		}
+		for i, upload := range result.Uploads {
+			// the prefix also matches longer keys
+			if upload.Key == key && (latest == nil || upload.Initiated.After(latest.Initiated)) {
+				latest = &result.Uploads[i]
+			}
+		}
+		if !result.IsTruncated {
+			break
+		}
+		keyMarker, uploadIDMarker = result.NextKeyMarker, result.NextUploadIDMarker
+	}
+	if latest == nil {
+		return "", nil, nil
+	}
+	var parts []minio.ObjectPart
+	partNumberMarker := 0
+	for {
+		result, err := core.ListObjectParts(s.ctx, bucket, key, latest.UploadID, partNumberMarker, 1000)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, result.ObjectParts...)
+		if !result.IsTruncated {
+			break
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+	return latest.UploadID, parts, nil
+}
+
 func (s *s3client) BucketExists(bucketName string) (bool, error) {
 	logging.RequireLoggerFromContext(s.ctx).WithField("bucket", bucketName).Info(s.ctx, "Checking if bucket exists")
 	result, err := s.minioClient.BucketExists(s.ctx, bucketName)
@@ -764,6 +1087,38 @@ func (s *s3client) OpenFile(bucket, key string) (io.ReadCloser, error) {
 	return f, nil
 }
 
+// OpenFileRange opens part of a file for reading
+func (s *s3client) OpenFileRange(bucket, key string, offset, length int64) (io.ReadCloser, error) {
+	logging.RequireLoggerFromContext(s.ctx).WithFields(logging.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key, "offset": offset, "length": length}).Info(s.ctx, "Opening file range from s3")
+
+	encOpts, err := s.EncryptOpts.buildServerSideEnc(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	opts := minio.GetObjectOptions{ServerSideEncryption: encOpts}
+	switch {
+	case length == 0:
+		return io.NopCloser(strings.NewReader("")), nil
+	case length > 0:
+		err = opts.SetRange(offset, offset+length-1)
+	case offset > 0:
+		err = opts.SetRange(offset, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.minioClient.GetObject(s.ctx, bucket, key, opts)
+	if err != nil {
+		return nil, err
+	}
+	// as for OpenFile, Stat() returns the error if the key doesn't exist
+	_, err = f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
 // checks if object exists (and if we have permission to access)
 func (s *s3client) KeyExists(bucket, key string) (bool, error) {
 	logging.RequireLoggerFromContext(s.ctx).WithFields(logging.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key}).Info(s.ctx, "Checking key exists from s3")
@@ -784,6 +1139,23 @@ func (s *s3client) KeyExists(bucket, key string) (bool, error) {
 	return false, err
 }
 
+// StatObject gets the metadata of the object at the key
+func (s *s3client) StatObject(bucket, key string) (minio.ObjectInfo, error) {
+	logging.RequireLoggerFromContext(s.ctx).WithFields(logging.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key}).Info(s.ctx, "Getting object metadata from s3")
+
+	encOpts, err := s.EncryptOpts.buildServerSideEnc(bucket, key)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+	return s.minioClient.StatObject(s.ctx, bucket, key, minio.StatObjectOptions{ServerSideEncryption: encOpts})
+}
+
+// PresignedURL returns a URL which gets the object at the key until it expires
+func (s *s3client) PresignedURL(bucket, key string, expiry time.Duration) (*url.URL, error) {
+	logging.RequireLoggerFromContext(s.ctx).WithFields(logging.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key, "expiry": expiry}).Info(s.ctx, "Pre-signing object URL from s3")
+	return s.minioClient.PresignedGetObject(s.ctx, bucket, key, expiry, nil)
+}
+
 func (s *s3client) Delete(bucket, key string) error {
 	logging.RequireLoggerFromContext(s.ctx).WithFields(logging.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key}).Info(s.ctx, "Deleting object from s3")
 	return s.minioClient.RemoveObject(s.ctx, bucket, key, minio.RemoveObjectOptions{})
@@ -2,19 +2,26 @@ package s3
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/hex"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	argoerrs "github.com/argoproj/argo-workflows/v4/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v4/util/logging"
+	artifactscommon "github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
 )
 
 const transientEnvVarKey = "TRANSIENT_ERROR_PATTERN"
@@ -24,6 +31,11 @@ type mockClient struct {
 	files map[string][]string
 	// mockedErrs is a map where key is the function name and value is the mocked error of that function
 	mockedErrs map[string]error
+	// uploadedParts are the part numbers uploaded by PutObjectPart, in order
+	uploadedParts []int
+	// inProgressUpload is the upload ID and parts returned by ListMultipartUploadParts
+	inProgressUpload string
+	inProgressParts  []minio.ObjectPart
 }
 
 func newMockClient(files map[string][]string, mockedErrs map[string]error) Client {
@@ -52,6 +64,39 @@ func (s *mockClient) PutDirectory(bucket, key, path string) error {
 	return s.getMockedErr("PutDirectory")
 }
 
+func (s *mockClient) NewMultipartUpload(bucket, key string) (string, error) {
+	if err := s.getMockedErr("NewMultipartUpload"); err != nil {
+		return "", err
+	}
+	return "upload-" + strconv.Itoa(len(s.uploadedParts)), nil
+}
+
+func (s *mockClient) PutObjectPart(bucket, key, uploadID string, partNumber int, data io.Reader, size int64) (string, error) {
+	if err := s.getMockedErr("PutObjectPart"); err != nil {
+		return "", err
+	}
+	n, err := io.Copy(io.Discard, data)
+	if err != nil {
+		return "", err
+	}
+	if n != size {
+		return "", io.ErrUnexpectedEOF
+	}
+	s.uploadedParts = append(s.uploadedParts, partNumber)
+	return "etag-" + strconv.Itoa(partNumber), nil
+}
+
+func (s *mockClient) CompleteMultipartUpload(bucket, key, uploadID string, parts []minio.CompletePart) error {
+	return s.getMockedErr("CompleteMultipartUpload")
+}
+
+func (s *mockClient) ListMultipartUploadParts(bucket, key string) (string, []minio.ObjectPart, error) {
+	if err := s.getMockedErr("ListMultipartUploadParts"); err != nil {
+		return "", nil, err
+	}
+	return s.inProgressUpload, s.inProgressParts, nil
+}
+
 // GetFile downloads a file to a local file path
 func (s *mockClient) GetFile(bucket, key, path string) error {
 	return s.getMockedErr("GetFile")
@@ -65,6 +110,17 @@ func (s *mockClient) OpenFile(bucket, key string) (io.ReadCloser, error) {
 	return nil, err
 }
 
+func (s *mockClient) OpenFileRange(bucket, key string, offset, length int64) (io.ReadCloser, error) {
+	if err := s.getMockedErr("OpenFileRange"); err != nil {
+		return nil, err
+	}
+	data := []byte(key)
+	if length < 0 {
+		length = int64(len(data)) - offset
+	}
+	return io.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+}
+
 func (s *mockClient) KeyExists(bucket, key string) (bool, error) {
 	err := s.getMockedErr("KeyExists")
 	if files, ok := s.files[bucket]; ok {
@@ -77,6 +133,25 @@ func (s *mockClient) KeyExists(bucket, key string) (bool, error) {
 	return false, err
 }
 
+func (s *mockClient) StatObject(bucket, key string) (minio.ObjectInfo, error) {
+	if err := s.getMockedErr("StatObject"); err != nil {
+		return minio.ObjectInfo{}, err
+	}
+	for _, file := range s.files[bucket] {
+		if file == key {
+			return minio.ObjectInfo{Key: key, Size: int64(len(key)), ETag: "etag-" + key}, nil
+		}
+	}
+	return minio.ObjectInfo{}, minio.ErrorResponse{Code: "NoSuchKey"}
+}
+
+func (s *mockClient) PresignedURL(bucket, key string, expiry time.Duration) (*url.URL, error) {
+	if err := s.getMockedErr("PresignedURL"); err != nil {
+		return nil, err
+	}
+	return &url.URL{Scheme: "https", Host: bucket, Path: "/" + key, RawQuery: "X-Amz-Expires=" + strconv.Itoa(int(expiry.Seconds()))}, nil
+}
+
 // GetDirectory downloads a directory to a local file path
 func (s *mockClient) GetDirectory(bucket, key, path string) error {
 	return s.getMockedErr("GetDirectory")
@@ -648,6 +723,108 @@ func TestListObjects(t *testing.T) {
 	}
 }
 
+func TestStatS3Artifact(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	s3cli := newMockClient(
+		map[string][]string{
+			"my-bucket": {
+				"/folder/hello-art.tar.gz",
+			},
+		},
+		map[string]error{})
+	art := func(key string) *wfv1.Artifact {
+		return &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, Key: key}}}
+	}
+
+	done, info, err := statS3Artifact(ctx, s3cli, art("/folder/hello-art.tar.gz"))
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, int64(len("/folder/hello-art.tar.gz")), info.Size)
+	assert.Equal(t, "etag-/folder/hello-art.tar.gz", info.ETag)
+
+	done, _, err = statS3Artifact(ctx, s3cli, art("/folder/missing.tar.gz"))
+	assert.True(t, done)
+	require.EqualError(t, err, "no key found of name /folder/missing.tar.gz")
+}
+
+func TestStreamS3ArtifactRange(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, Key: "hello-world"}}}
+
+	stream, err := streamS3ArtifactRange(ctx, newMockClient(map[string][]string{}, map[string]error{}), art, 6, -1)
+	require.NoError(t, err)
+	data, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(data))
+
+	_, err = streamS3ArtifactRange(ctx, newMockClient(map[string][]string{}, map[string]error{
+		"OpenFileRange": minio.ErrorResponse{Code: "NoSuchKey", Message: "The specified key does not exist."},
+	}), art, 6, -1)
+	require.True(t, argoerrs.IsCode(argoerrs.CodeNotFound, err))
+}
+
+func TestPresignedURLWithCustomerKey(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, Key: "hello-world"}}}
+	_, err := (&ArtifactDriver{ServerSideCustomerKey: "my-key"}).PresignedURL(ctx, art, time.Minute)
+	require.ErrorIs(t, err, artifactscommon.ErrPresignNotSupported)
+}
+
+func TestPutFileResumable(t *testing.T) {
+	t.Setenv("ARTIFACT_S3_UPLOAD_PART_SIZE_MIB", "5")
+	ctx := artifactscommon.WithUploadStateDir(logging.TestContext(t.Context()), t.TempDir())
+	path := filepath.Join(t.TempDir(), "my-file")
+	require.NoError(t, os.WriteFile(path, make([]byte, 12*1024*1024), 0o600))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	stateKey := "s3://my-bucket/my-key"
+
+	t.Run("Interrupted", func(t *testing.T) {
+		s3cli := &mockClient{mockedErrs: map[string]error{"PutObjectPart": minio.ErrorResponse{Code: "RequestTimeout"}}}
+		err := putFileResumable(ctx, s3cli, "my-bucket", "my-key", path, "")
+		require.Error(t, err)
+		state := artifactscommon.LoadUploadState(ctx, stateKey, info, "")
+		require.NotNil(t, state)
+		assert.Equal(t, "upload-0", state.UploadID)
+		assert.Equal(t, int64(5*1024*1024), state.PartSize)
+		assert.Empty(t, state.Parts)
+	})
+	t.Run("Resumed", func(t *testing.T) {
+		state := artifactscommon.NewUploadState(info, "", "my-upload", 5*1024*1024)
+		state.Parts[1] = "etag-1"
+		artifactscommon.SaveUploadState(ctx, stateKey, state)
+		s3cli := &mockClient{}
+		require.NoError(t, putFileResumable(ctx, s3cli, "my-bucket", "my-key", path, ""))
+		assert.Equal(t, []int{2, 3}, s3cli.uploadedParts)
+		assert.Nil(t, artifactscommon.LoadUploadState(ctx, stateKey, info, ""))
+	})
+	t.Run("ResumedByRetriedPod", func(t *testing.T) {
+		// a retried pod has no recorded state, so it finds the upload of the earlier attempt
+		ctx := artifactscommon.WithUploadStateDir(logging.TestContext(t.Context()), t.TempDir())
+		partMD5 := md5.Sum(make([]byte, 5*1024*1024))
+		s3cli := &mockClient{inProgressUpload: "my-upload", inProgressParts: []minio.ObjectPart{
+			{PartNumber: 1, Size: 5 * 1024 * 1024, ETag: `"` + hex.EncodeToString(partMD5[:]) + `"`},
+			// the contents of this part differ from the file, so it is uploaded again
+			{PartNumber: 2, Size: 5 * 1024 * 1024, ETag: `"0000"`},
+		}}
+		require.NoError(t, putFileResumable(ctx, s3cli, "my-bucket", "my-key", path, ""))
+		assert.Equal(t, []int{2, 3}, s3cli.uploadedParts)
+	})
+	t.Run("ListFailed", func(t *testing.T) {
+		ctx := artifactscommon.WithUploadStateDir(logging.TestContext(t.Context()), t.TempDir())
+		s3cli := &mockClient{mockedErrs: map[string]error{"ListMultipartUploadParts": minio.ErrorResponse{Code: "AccessDenied"}}}
+		require.NoError(t, putFileResumable(ctx, s3cli, "my-bucket", "my-key", path, ""))
+		assert.Equal(t, []int{1, 2, 3}, s3cli.uploadedParts)
+	})
+	t.Run("SinglePart", func(t *testing.T) {
+		small := filepath.Join(t.TempDir(), "my-small-file")
+		require.NoError(t, os.WriteFile(small, []byte("hello"), 0o600))
+		s3cli := &mockClient{}
+		require.NoError(t, putFileResumable(ctx, s3cli, "my-bucket", "my-key", small, ""))
+		assert.Empty(t, s3cli.uploadedParts)
+	})
+}
+
 // TestNewClient tests the s3 constructor
 func TestNewClient(t *testing.T) {
 	opts := ClientOpts{
@@ -62,6 +62,22 @@ const (
 	// the strategy whose artifacts are being deleted
 	AnnotationKeyArtifactGCStrategy = workflow.WorkflowFullName + "/artifact-gc-strategy"
 
+	// AnnotationKeyArtifactRetentionExpired is set on a completed Workflow, to the time the retention of its artifact
+	// repository expired, so that its artifacts are deleted
+	AnnotationKeyArtifactRetentionExpired = workflow.WorkflowFullName + "/artifact-retention-expired"
+
+	// AnnotationKeyArtifactsReplicated is set on a completed Workflow, to the time its artifacts were copied to the
+	// replicas of its artifact repository
+	AnnotationKeyArtifactsReplicated = workflow.WorkflowFullName + "/artifacts-replicated"
+
+	// AnnotationKeyArtifactsReplicationProgress is set on a completed Workflow, to the number of copies of its
+	// artifacts to the replicas of its artifact repository made so far, so that replication resumes from there
+	AnnotationKeyArtifactsReplicationProgress = workflow.WorkflowFullName + "/artifacts-replication-progress"
+
+	// AnnotationKeyNotificationSinks is a JSON list of the sinks a Workflow adds to those the phase transitions of it,
+	// and of its nodes, are published to
+	AnnotationKeyNotificationSinks = workflow.WorkflowFullName + "/notification-sinks"
+
 	// LabelParallelismLimit is a label applied on namespace objects to control the per namespace parallelism.
 	LabelParallelismLimit = workflow.WorkflowFullName + "/parallelism-limit"
 
@@ -126,6 +142,12 @@ const (
 
 	// LabelKeyCronWorkflowBackfill is a label applied to the cron workflow when the workflow is created by backfill
 	LabelKeyCronWorkflowBackfill = workflow.WorkflowFullName + "/backfill"
+	// LabelKeyCronWorkflowCatchUp is a label applied to Workflows which a CronWorkflow runs for a missed schedule. Its
+	// value is the time the Workflow was scheduled for, formatted with CronWorkflowCatchUpTimeFormat
+	LabelKeyCronWorkflowCatchUp = workflow.WorkflowFullName + "/catch-up"
+	// CronWorkflowCatchUpTimeFormat is the format of the LabelKeyCronWorkflowCatchUp label, a UTC time which is a valid
+	// label value
+	CronWorkflowCatchUpTimeFormat = "20060102T150405Z"
 
 	// ExecutorArtifactBaseDir is the base directory in the init container in which artifacts will be copied to.
 	// Each artifact will be named according to its input name (e.g: /argo/inputs/artifacts/CODE)
@@ -166,6 +188,12 @@ const (
 	// paths in the executor (e.g. the input-artifacts overlap fallback in
 	// stageArchiveFile).
 	EnvVarInitlessPod = "ARGO_INITLESS_POD"
+	// EnvVarArtifactKeyPrefix is the static key prefix of the artifact repository, under which deduplicated
+	// artifacts are saved
+	EnvVarArtifactKeyPrefix = "ARGO_ARTIFACT_KEY_PREFIX"
+	// EnvVarArtifactFallbacks is the JSON list of the fallback locations of the artifact repository, which output
+	// artifacts are saved to when saving them to the repository fails
+	EnvVarArtifactFallbacks = "ARGO_ARTIFACT_FALLBACKS"
 	// EnvVarPodName contains the name of the pod (currently unused)
 	EnvVarPodName = "ARGO_POD_NAME"
 	// EnvVarPodUID is the workflow's UID
@@ -214,6 +242,8 @@ const (
 	EnvAgentTaskWorkers = "ARGO_AGENT_TASK_WORKERS"
 	// EnvAgentPatchRate is the rate that the Argo Agent will patch the Workflow TaskSet
 	EnvAgentPatchRate = "ARGO_AGENT_PATCH_RATE"
+	// EnvAgentConfig is the JSON of the controller's agent configuration
+	EnvAgentConfig = "ARGO_AGENT_CONFIG"
 
 	// Finalizer to block deletion of the workflow if deletion of artifacts fail for some reason.
 	FinalizerArtifactGC = workflow.WorkflowFullName + "/artifact-gc"
@@ -266,6 +296,10 @@ const (
 	// ArgoProgressPath defines the path to a file used for self reporting progress
 	ArgoProgressPath = VarRunArgoPath + "/progress"
 
+	// ArtifactUploadStatePath is the directory in which the executor records the progress of resumable artifact
+	// uploads, so that a retried upload resumes them
+	ArtifactUploadStatePath = VarRunArgoPath + "/uploads"
+
 	// StatusMarkerPath is the marker file the supervisor writes atomically once
 	// pre-main setup concludes (init-less pod mode). The emissary in main waits
 	// for it before exec'ing the user command. Its contents encode the outcome:
@@ -369,3 +369,13 @@ func CheckAllHooksFullfilled(node *wfv1.NodeStatus, nodes wfv1.Nodes) bool {
 	}
 	return true
 }
+
+// OutputsDir returns the directory, relative to VarRunArgoPath, to which the emissary copies the outputs of the
+// container, which the wait container reads them from. The main container saves the outputs of the template, so the
+// other containers of a container set save theirs to a directory of their own.
+func OutputsDir(containerName string) string {
+	if containerName == MainContainerName {
+		return "outputs"
+	}
+	return filepath.Join("ctr", containerName, "outputs")
+}
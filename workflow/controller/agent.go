@@ -152,6 +152,13 @@ func (woc *wfOperationCtx) createAgentPod(ctx context.Context) (*apiv1.Pod, erro
 		})
 	}
 
+	if agentConfig := woc.controller.Config.Agent; agentConfig != nil {
+		envVars = append(envVars, apiv1.EnvVar{
+			Name:  common.EnvAgentConfig,
+			Value: wfv1.MustMarshallJSON(agentConfig),
+		})
+	}
+
 	serviceAccountName := woc.execWf.Spec.ServiceAccountName
 	tokenVolume, tokenVolumeMount, err := woc.getServiceAccountTokenVolume(ctx, serviceAccountName)
 	if err != nil {
@@ -310,6 +310,21 @@ func Test_createAgentPod_alreadyExists(t *testing.T) {
 	assert.Equal(t, podName, pod.Name)
 }
 
+func Test_createAgentPod_agentConfig(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(agentTaskSetWf)
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx, wf, defaultServiceAccount)
+	defer cancel()
+	controller.Config.Agent = &config.AgentConfig{
+		HTTPRateLimit: &config.HTTPRateLimit{Limit: 5, Burst: 10},
+	}
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+
+	pod, err := woc.createAgentPod(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, pod.Spec.Containers[0].Env, apiv1.EnvVar{Name: common.EnvAgentConfig, Value: `{"httpRateLimit":{"limit":5,"burst":10}}`})
+}
+
 func TestDisableAgentPodCreation(t *testing.T) {
 	ctx := logging.TestContext(t.Context())
 	wf := wfv1.MustUnmarshalWorkflow(`apiVersion: argoproj.io/v1alpha1
@@ -117,10 +117,22 @@ func (woc *wfOperationCtx) artifactGCStrategiesReady() map[wfv1.ArtifactGCStrate
 			strategies[wfv1.ArtifactGCOnWorkflowDeletion] = struct{}{}
 		}
 	}
+	if woc.artifactRetentionExpired() {
+		if !woc.wf.Status.ArtifactGCStatus.IsArtifactGCStrategyProcessed(wfv1.ArtifactGCOnRetentionExpiry) {
+			strategies[wfv1.ArtifactGCOnRetentionExpiry] = struct{}{}
+		}
+	}
 
 	return strategies
 }
 
+// artifactRetentionExpired returns whether the retention of the workflow's artifact repository has expired, see
+// artifactRetentionController
+func (woc *wfOperationCtx) artifactRetentionExpired() bool {
+	_, expired := woc.wf.Annotations[common.AnnotationKeyArtifactRetentionExpired]
+	return expired && woc.wf.Labels[common.LabelKeyCompleted] == "true"
+}
+
 type templatesToArtifacts map[string]wfv1.ArtifactSearchResults
 
 // Artifact GC Strategy is ready: start up Pods to handle it
@@ -263,6 +275,8 @@ func (woc *wfOperationCtx) artGCPodName(strategy wfv1.ArtifactGCStrategy, info p
 		abbreviatedName = "wfcomp"
 	case wfv1.ArtifactGCOnWorkflowDeletion:
 		abbreviatedName = "wfdel"
+	case wfv1.ArtifactGCOnRetentionExpiry:
+		abbreviatedName = "retain"
 	default:
 		return "", fmt.Errorf("ArtifactGCStrategy %q not valid", strategy)
 	}
@@ -602,12 +616,17 @@ func (woc *wfOperationCtx) processArtifactGCCompletion(ctx context.Context) erro
 }
 
 func (woc *wfOperationCtx) allArtifactsDeleted() bool {
+	retentionExpired := woc.artifactRetentionExpired()
 	for _, n := range woc.wf.Status.Nodes {
 		if n.Type != wfv1.NodeTypePod {
 			continue
 		}
 		for _, a := range n.GetOutputs().GetArtifacts() {
-			if !a.Deleted && woc.execWf.GetArtifactGCStrategy(&a) != wfv1.ArtifactGCNever && woc.execWf.GetArtifactGCStrategy(&a) != wfv1.ArtifactGCStrategyUndefined {
+			if a.Deleted {
+				continue
+			}
+			strategy := woc.execWf.GetArtifactGCStrategy(&a)
+			if (strategy != wfv1.ArtifactGCNever && strategy != wfv1.ArtifactGCStrategyUndefined) || (retentionExpired && woc.deletableByRetention(&a)) {
 				return false
 			}
 		}
@@ -626,6 +645,9 @@ func (woc *wfOperationCtx) findArtifactsToGC(strategy wfv1.ArtifactGCStrategy) w
 			// artifact strategy is either based on overall Workflow ArtifactGC Strategy, or
 			// if it's specified on the individual artifact level that takes priority
 			artifactStrategy := woc.execWf.GetArtifactGCStrategy(&a)
+			if strategy == wfv1.ArtifactGCOnRetentionExpiry && woc.deletableByRetention(&a) {
+				artifactStrategy = strategy
+			}
 			if artifactStrategy == strategy && !a.Deleted {
 				results = append(results, wfv1.ArtifactSearchResult{Artifact: a, NodeID: n.ID})
 			}
@@ -634,6 +656,19 @@ func (woc *wfOperationCtx) findArtifactsToGC(strategy wfv1.ArtifactGCStrategy) w
 	return results
 }
 
+// deletableByRetention returns whether the retention of the repository applies to the artifact. It applies to all
+// artifacts, whatever their strategy, except deduplicated artifacts and those whose strategy is explicitly Never.
+func (woc *wfOperationCtx) deletableByRetention(a *wfv1.Artifact) bool {
+	if a.Deduplicate {
+		return false
+	}
+	strategy := a.GetArtifactGC().GetStrategy()
+	if strategy == wfv1.ArtifactGCStrategyUndefined {
+		strategy = woc.execWf.Spec.GetArtifactGC().GetStrategy()
+	}
+	return strategy != wfv1.ArtifactGCNever
+}
+
 func (woc *wfOperationCtx) processCompletedArtifactGCPod(ctx context.Context, pod *corev1.Pod) error {
 	woc.log.WithField("podName", pod.Name).Info(ctx, "processing completed Artifact GC Pod")
 
@@ -670,6 +705,13 @@ func (woc *wfOperationCtx) processCompletedArtifactGCPod(ctx context.Context, po
 			}
 		}
 	}
+
+	// the archived workflow is not updated with the workflow, so mark its artifacts as deleted too
+	if woc.wf.Labels[common.LabelKeyWorkflowArchivingStatus] == "Archived" {
+		if err := woc.controller.wfArchive.ArchiveWorkflow(ctx, woc.wf.DeepCopy()); err != nil {
+			woc.log.WithError(err).Error(ctx, "failed to update archived workflow with deleted artifacts")
+		}
+	}
 	return nil
 }
 
@@ -559,6 +559,50 @@ status:
           success: true
 `
 
+func TestProcessArtifactGCStrategyOnRetentionExpiry(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(artgcWorkflow)
+	wf.Annotations[common.AnnotationKeyArtifactRetentionExpired] = "2022-09-01T00:00:00Z"
+	// an artifact which is never deleted is kept by the retention
+	node := wf.Status.Nodes["two-artgc-8tcvt-802059674"]
+	node.Outputs.Artifacts[1].ArtifactGC.Strategy = wfv1.ArtifactGCNever
+	wf.Status.Nodes["two-artgc-8tcvt-802059674"] = node
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx, wf)
+	defer cancel()
+
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.wf.Status.ArtifactGCStatus = &wfv1.ArtGCStatus{}
+	assert.Contains(t, woc.artifactGCStrategiesReady(), wfv1.ArtifactGCOnRetentionExpiry)
+
+	err := woc.processArtifactGCStrategy(ctx, wfv1.ArtifactGCOnRetentionExpiry)
+	require.NoError(t, err)
+
+	pods, err := woc.controller.kubeclientset.CoreV1().Pods(woc.wf.GetNamespace()).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, pods.Items)
+	for _, pod := range pods.Items {
+		assert.Regexp(t, "^two-artgc-8tcvt-artgc-retain-", pod.Name)
+	}
+
+	wfats, err := controller.wfclientset.ArgoprojV1alpha1().WorkflowArtifactGCTasks(woc.wf.GetNamespace()).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	artifacts := make(map[string]bool)
+	for _, wfat := range wfats.Items {
+		for _, artifactsByNode := range wfat.Spec.ArtifactsByNode {
+			for name := range artifactsByNode.Artifacts {
+				artifacts[name] = true
+			}
+		}
+	}
+	assert.Equal(t, map[string]bool{
+		"first-on-completion-1": true,
+		"main-logs":             true,
+		"on-deletion":           true,
+		"second-on-completion":  true,
+	}, artifacts)
+	assert.False(t, woc.allArtifactsDeleted())
+}
+
 func TestProcessCompletedWorkflowArtifactGCTask(t *testing.T) {
 	wf := wfv1.MustUnmarshalWorkflow(artgcWorkflow)
 	wfat := wfv1.MustUnmarshalWorkflowArtifactGCTask(artgcTask)
@@ -0,0 +1,294 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/env"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	artifactscommon "github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/resource"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/util"
+)
+
+// artifactReplicationController periodically copies the output artifacts of completed workflows to the replicas of
+// their artifact repositories. At most maxCopies artifacts are copied each period. The progress of each workflow is
+// recorded in an annotation, so copying resumes where it stopped in the next period, and workflows are annotated once
+// all their artifacts have been copied.
+func (wfc *WorkflowController) artifactReplicationController(ctx context.Context) {
+	defer runtimeutil.HandleCrashWithContext(ctx, runtimeutil.PanicHandlers...)
+
+	periodicity := env.LookupEnvDurationOr(ctx, "ARTIFACT_REPLICATION_PERIOD", 5*time.Minute)
+	maxCopies := env.LookupEnvIntOr(ctx, "ARTIFACT_REPLICATION_MAX_COPIES", 100)
+	logger := logging.RequireLoggerFromContext(ctx)
+	ctx, logger = logger.WithField("component", "artifact_replication").InContext(ctx)
+	logger.WithFields(logging.Fields{"periodicity": periodicity, "maxCopies": maxCopies}).Info(ctx, "Performing periodic artifact replication")
+	ticker := time.NewTicker(periodicity)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			wfc.replicateArtifacts(ctx, time.Now(), maxCopies)
+		}
+	}
+}
+
+// replicateArtifacts copies the artifacts of the completed workflows, which have not been copied yet, to the replicas
+// of the artifact repository each of them used, until maxCopies artifacts have been copied
+func (wfc *WorkflowController) replicateArtifacts(ctx context.Context, now time.Time, maxCopies int) {
+	logger := logging.RequireLoggerFromContext(ctx)
+	for _, obj := range wfc.wfInformer.GetStore().List() {
+		if maxCopies <= 0 {
+			return
+		}
+		un, ok := obj.(*unstructured.Unstructured)
+		if !ok || un.GetLabels()[common.LabelKeyCompleted] != "true" || un.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if _, ok := un.GetAnnotations()[common.AnnotationKeyArtifactsReplicated]; ok {
+			continue
+		}
+		wf, err := util.FromUnstructured(un)
+		if err != nil {
+			logger.WithError(err).WithField("workflow", un.GetName()).Warn(ctx, "Failed to convert workflow for artifact replication")
+			continue
+		}
+		ref := wf.Status.ArtifactRepositoryRef
+		if ref == nil || ref.ArtifactRepository == nil || len(ref.ArtifactRepository.Replicas) == 0 {
+			continue
+		}
+		log := logger.WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name})
+		if err := wfc.hydrator.Hydrate(ctx, wf); err != nil {
+			log.WithError(err).Warn(ctx, "Failed to hydrate workflow for artifact replication")
+			continue
+		}
+		start := artifactsReplicationProgress(wf)
+		done, complete, err := wfc.replicateWorkflowArtifacts(ctx, wf, ref.ArtifactRepository, start, maxCopies)
+		maxCopies -= done - start
+		if err != nil {
+			log.WithError(err).Error(ctx, "Failed to replicate artifacts")
+		}
+		if complete {
+			if err := wfc.markArtifactsReplicated(ctx, wf, now); err != nil {
+				log.WithError(err).Error(ctx, "Failed to mark artifacts as replicated")
+			}
+		} else if done > start {
+			if err := wfc.markArtifactsReplicationProgress(ctx, wf, done); err != nil {
+				log.WithError(err).Error(ctx, "Failed to record artifact replication progress")
+			}
+		}
+	}
+}
+
+// artifactsReplicationProgress returns the number of copies of the artifacts of the workflow already made, as
+// recorded by markArtifactsReplicationProgress
+func artifactsReplicationProgress(wf *wfv1.Workflow) int {
+	done, err := strconv.Atoi(wf.GetAnnotations()[common.AnnotationKeyArtifactsReplicationProgress])
+	if err != nil || done < 0 {
+		return 0
+	}
+	return done
+}
+
+// replicateWorkflowArtifacts copies the artifacts of the workflow saved to the repository, or to its fallbacks, to
+// each of its replicas. Artifacts keep their keys in the replicas. The copies are made in order, replica by replica,
+// skipping the first start of them, which were made before, and making at most maxCopies. It returns the number of
+// copies made in all, and whether all of them have been made.
+func (wfc *WorkflowController) replicateWorkflowArtifacts(ctx context.Context, wf *wfv1.Workflow, repo *wfv1.ArtifactRepository, start, maxCopies int) (int, bool, error) {
+	arts, err := repositoryArtifacts(wf, repo)
+	if err != nil {
+		return start, false, err
+	}
+	total := len(repo.Replicas) * len(arts)
+	ri := artifactResources{wfc.kubeclientset, wf.Namespace}
+	done := start
+	for ; done < total && done-start < maxCopies; done++ {
+		i, art := done/len(arts), arts[done%len(arts)]
+		key, err := art.GetKey()
+		if err != nil {
+			return done, false, err
+		}
+		dst := art.DeepCopy()
+		dst.ArtifactLocation = *repo.Replicas[i].ToArtifactLocation()
+		if err := dst.SetKey(key); err != nil {
+			return done, false, err
+		}
+		if err := wfc.copyArtifact(ctx, ri, &art, dst); err != nil {
+			return done, false, fmt.Errorf("failed to copy artifact %q to replica %d: %w", art.Name, i, err)
+		}
+	}
+	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name, "copied": done - start, "remaining": total - done}).Info(ctx, "Replicated artifacts")
+	return done, done >= total, nil
+}
+
+// copyArtifact copies the file or directory artifact src to dst
+func (wfc *WorkflowController) copyArtifact(ctx context.Context, ri resource.Interface, src, dst *wfv1.Artifact) error {
+	srcDriver, err := wfc.newArtifactDriver(ctx, src, ri)
+	if err != nil {
+		return err
+	}
+	dstDriver, err := wfc.newArtifactDriver(ctx, dst, ri)
+	if err != nil {
+		return err
+	}
+	isDir, err := srcDriver.IsDirectory(ctx, src)
+	if err != nil {
+		return err
+	}
+	if !isDir {
+		return copyArtifactFile(ctx, srcDriver, dstDriver, src, dst)
+	}
+	keys, err := srcDriver.ListObjects(ctx, src)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		srcFile, dstFile := src.DeepCopy(), dst.DeepCopy()
+		// a digest is of the whole artifact, rather than of each of its files
+		srcFile.Digest, dstFile.Digest = "", ""
+		if err := srcFile.SetKey(key); err != nil {
+			return err
+		}
+		if err := dstFile.SetKey(key); err != nil {
+			return err
+		}
+		if err := copyArtifactFile(ctx, srcDriver, dstDriver, srcFile, dstFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyArtifactFile(ctx context.Context, srcDriver, dstDriver artifactscommon.ArtifactDriver, src, dst *wfv1.Artifact) error {
+	stream, err := srcDriver.OpenStream(ctx, src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+	return dstDriver.SaveStream(ctx, stream, dst)
+}
+
+// markArtifactsReplicated annotates the workflow with the time its artifacts were replicated, and removes the
+// annotation with its progress
+func (wfc *WorkflowController) markArtifactsReplicated(ctx context.Context, wf *wfv1.Workflow, now time.Time) error {
+	return wfc.patchArtifactsReplicationAnnotations(ctx, wf, map[string]any{
+		common.AnnotationKeyArtifactsReplicated:          now.UTC().Format(time.RFC3339),
+		common.AnnotationKeyArtifactsReplicationProgress: nil,
+	})
+}
+
+// markArtifactsReplicationProgress annotates the workflow with the number of copies of its artifacts made so far
+func (wfc *WorkflowController) markArtifactsReplicationProgress(ctx context.Context, wf *wfv1.Workflow, done int) error {
+	return wfc.patchArtifactsReplicationAnnotations(ctx, wf, map[string]any{
+		common.AnnotationKeyArtifactsReplicationProgress: strconv.Itoa(done),
+	})
+}
+
+func (wfc *WorkflowController) patchArtifactsReplicationAnnotations(ctx context.Context, wf *wfv1.Workflow, annotations map[string]any) error {
+	data, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	_, err = wfc.wfclientset.ArgoprojV1alpha1().Workflows(wf.Namespace).Patch(ctx, wf.Name, types.MergePatchType, data, metav1.PatchOptions{})
+	if apierr.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// repositoryArtifacts returns the output artifacts of the workflow which were saved to the repository or to one of
+// its fallbacks, with their locations. Deleted artifacts, and those of templates with their own archive location, are
+// left out.
+func repositoryArtifacts(wf *wfv1.Workflow, repo *wfv1.ArtifactRepository) ([]wfv1.Artifact, error) {
+	location := repo.ToArtifactLocation()
+	fallbacks := repo.FallbackLocations()
+	nodeIDs := make([]string, 0, len(wf.Status.Nodes))
+	for id := range wf.Status.Nodes {
+		nodeIDs = append(nodeIDs, id)
+	}
+	sort.Strings(nodeIDs)
+	var results []wfv1.Artifact
+	for _, id := range nodeIDs {
+		node := wf.Status.Nodes[id]
+		for _, art := range node.GetOutputs().GetArtifacts() {
+			if art.Deleted {
+				continue
+			}
+			if art.HasLocation() {
+				if !inLocations(art.ArtifactLocation, fallbacks) {
+					continue
+				}
+			} else {
+				if tmpl := wf.GetTemplateByName(node.TemplateName); tmpl != nil && tmpl.ArchiveLocation.HasLocation() {
+					continue
+				}
+				if err := art.Relocate(location); err != nil {
+					return nil, fmt.Errorf("failed to locate artifact %q of node %s: %w", art.Name, id, err)
+				}
+			}
+			results = append(results, art)
+		}
+	}
+	return results, nil
+}
+
+// inLocations returns whether the artifact location is one of the locations, other than its key
+func inLocations(l wfv1.ArtifactLocation, locations []wfv1.ArtifactLocation) bool {
+	for _, location := range locations {
+		key, err := location.GetKey()
+		if err != nil {
+			continue
+		}
+		c := l.DeepCopy()
+		if err := c.SetKey(key); err != nil {
+			continue
+		}
+		c.ArchiveLogs = location.ArchiveLogs
+		if equality.Semantic.DeepEqual(*c, location) {
+			return true
+		}
+	}
+	return false
+}
+
+// artifactResources resolves the secrets and config maps referenced by artifacts in the namespace of their workflow
+type artifactResources struct {
+	kubeclientset kubernetes.Interface
+	namespace     string
+}
+
+func (r artifactResources) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := r.kubeclientset.CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[key]), nil
+}
+
+func (r artifactResources) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := r.kubeclientset.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[key], nil
+}
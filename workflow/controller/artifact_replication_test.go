@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	artifactscommon "github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/resource"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+)
+
+func TestReplicateWorkflowArtifacts(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx)
+	defer cancel()
+	drivers := map[string]*memoryArtifactDriver{
+		"primary":  {objects: map[string][]byte{"my-wf/my-pod/out.tgz": []byte("primary")}},
+		"fallback": {objects: map[string][]byte{"fallback/my-wf/my-pod/fallback.tgz": []byte("fallback")}},
+		"replica":  {objects: map[string][]byte{}},
+	}
+	controller.newArtifactDriver = func(_ context.Context, a *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return drivers[a.S3.Bucket], nil
+	}
+	repo := &wfv1.ArtifactRepository{
+		S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Endpoint: "primary:9000", Bucket: "primary"}},
+		Fallbacks: []wfv1.ArtifactRepositoryLocation{
+			{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Endpoint: "fallback:9000", Bucket: "fallback"}, KeyFormat: "fallback/{{workflow.name}}/{{pod.name}}"}},
+		},
+		Replicas: []wfv1.ArtifactRepositoryLocation{
+			{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Endpoint: "replica:9000", Bucket: "replica"}}},
+		},
+	}
+	s3Artifact := func(name, endpoint, bucket, key string) wfv1.Artifact {
+		return wfv1.Artifact{Name: name, ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Endpoint: endpoint, Bucket: bucket}, Key: key}}}
+	}
+	deleted := s3Artifact("deleted", "", "", "my-wf/my-pod/deleted.tgz")
+	deleted.Deleted = true
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+		"my-pod": {ID: "my-pod", TemplateName: "main", Outputs: &wfv1.Outputs{Artifacts: []wfv1.Artifact{
+			s3Artifact("out", "", "", "my-wf/my-pod/out.tgz"),
+			s3Artifact("fallback", "fallback:9000", "fallback", "fallback/my-wf/my-pod/fallback.tgz"),
+			s3Artifact("elsewhere", "elsewhere:9000", "elsewhere", "my-wf/my-pod/elsewhere.tgz"),
+			deleted,
+		}}},
+	}}}
+
+	done, complete, err := controller.replicateWorkflowArtifacts(ctx, wf, repo, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 2, done)
+	assert.True(t, complete)
+	assert.Equal(t, map[string][]byte{
+		"my-wf/my-pod/out.tgz":               []byte("primary"),
+		"fallback/my-wf/my-pod/fallback.tgz": []byte("fallback"),
+	}, drivers["replica"].objects)
+}
+
+func TestReplicateWorkflowArtifactsProgress(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx)
+	defer cancel()
+	drivers := map[string]*memoryArtifactDriver{
+		"primary":   {objects: map[string][]byte{"a.tgz": []byte("a"), "b.tgz": []byte("b")}},
+		"replica-0": {objects: map[string][]byte{}},
+		"replica-1": {objects: map[string][]byte{}},
+	}
+	controller.newArtifactDriver = func(_ context.Context, a *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return drivers[a.S3.Bucket], nil
+	}
+	repo := &wfv1.ArtifactRepository{
+		S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "primary"}},
+		Replicas: []wfv1.ArtifactRepositoryLocation{
+			{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "replica-0"}}},
+			{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "replica-1"}}},
+		},
+	}
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+		"my-pod": {ID: "my-pod", TemplateName: "main", Outputs: &wfv1.Outputs{Artifacts: []wfv1.Artifact{
+			{Name: "a", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "a.tgz"}}},
+			{Name: "b", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "b.tgz"}}},
+		}}},
+	}}}
+
+	t.Run("Bounded", func(t *testing.T) {
+		done, complete, err := controller.replicateWorkflowArtifacts(ctx, wf, repo, 0, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 3, done)
+		assert.False(t, complete)
+		assert.Len(t, drivers["replica-0"].objects, 2)
+		assert.Equal(t, map[string][]byte{"a.tgz": []byte("a")}, drivers["replica-1"].objects)
+	})
+	t.Run("Resumed", func(t *testing.T) {
+		delete(drivers["replica-0"].objects, "a.tgz")
+		done, complete, err := controller.replicateWorkflowArtifacts(ctx, wf, repo, 3, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 4, done)
+		assert.True(t, complete)
+		assert.NotContains(t, drivers["replica-0"].objects, "a.tgz", "copies already made are not made again")
+		assert.Len(t, drivers["replica-1"].objects, 2)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		delete(drivers["primary"].objects, "b.tgz")
+		done, complete, err := controller.replicateWorkflowArtifacts(ctx, wf, repo, 0, 100)
+		require.Error(t, err)
+		assert.Equal(t, 1, done, "the copies made before the failure are kept")
+		assert.False(t, complete)
+	})
+}
+
+func TestArtifactsReplicationProgress(t *testing.T) {
+	for value, want := range map[string]int{"": 0, "3": 3, "-1": 0, "x": 0} {
+		wf := &wfv1.Workflow{}
+		wf.SetAnnotations(map[string]string{common.AnnotationKeyArtifactsReplicationProgress: value})
+		assert.Equal(t, want, artifactsReplicationProgress(wf), value)
+	}
+}
@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/env"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/util"
+)
+
+// artifactRetentionController periodically enforces the retention of the artifact repositories on the completed
+// workflows in the cluster. The workflows whose retention has expired are annotated and given the artifact GC
+// finalizer, so that their artifacts are deleted by the ArtifactGCOnRetentionExpiry strategy when they are reconciled.
+func (wfc *WorkflowController) artifactRetentionController(ctx context.Context) {
+	defer runtimeutil.HandleCrashWithContext(ctx, runtimeutil.PanicHandlers...)
+
+	if !artifactGCEnabled {
+		return
+	}
+	periodicity := env.LookupEnvDurationOr(ctx, "ARTIFACT_RETENTION_PERIOD", 10*time.Minute)
+	logger := logging.RequireLoggerFromContext(ctx)
+	ctx, logger = logger.WithField("component", "artifact_retention").InContext(ctx)
+	logger.WithField("periodicity", periodicity).Info(ctx, "Performing periodic artifact retention")
+	ticker := time.NewTicker(periodicity)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			wfc.enforceArtifactRetention(ctx, time.Now())
+		}
+	}
+}
+
+// enforceArtifactRetention expires the artifacts of the completed workflows according to the retention of the
+// artifact repository each of them used
+func (wfc *WorkflowController) enforceArtifactRetention(ctx context.Context, now time.Time) {
+	logger := logging.RequireLoggerFromContext(ctx)
+	byRepository := make(map[string][]*wfv1.Workflow)
+	refs := make(map[string]*wfv1.ArtifactRepositoryRefStatus)
+	for _, obj := range wfc.wfInformer.GetStore().List() {
+		un, ok := obj.(*unstructured.Unstructured)
+		if !ok || un.GetLabels()[common.LabelKeyCompleted] != "true" || un.GetDeletionTimestamp() != nil {
+			continue
+		}
+		wf, err := util.FromUnstructured(un)
+		if err != nil {
+			logger.WithError(err).WithField("workflow", un.GetName()).Warn(ctx, "Failed to convert workflow for artifact retention")
+			continue
+		}
+		if wf.Status.ArtifactRepositoryRef == nil {
+			continue
+		}
+		key := wf.Status.ArtifactRepositoryRef.String()
+		byRepository[key] = append(byRepository[key], wf)
+		refs[key] = wf.Status.ArtifactRepositoryRef
+	}
+	for key, wfs := range byRepository {
+		// the repository is read again, rather than taken from the workflows' status, so changes to its retention apply
+		ref := refs[key].DeepCopy()
+		ref.ArtifactRepository = nil
+		repo, err := wfc.artifactRepositories.Get(ctx, ref)
+		if err != nil {
+			logger.WithError(err).WithField("artifactRepositoryRef", key).Warn(ctx, "Failed to get artifact repository for artifact retention")
+			continue
+		}
+		if repo == nil || repo.Retention == nil {
+			continue
+		}
+		expired, err := expiredByRetention(repo.Retention, wfs, now)
+		if err != nil {
+			logger.WithError(err).WithField("artifactRepositoryRef", key).Error(ctx, "Invalid artifact retention")
+			continue
+		}
+		for _, wf := range expired {
+			if err := wfc.expireArtifacts(ctx, wf, now); err != nil {
+				logger.WithError(err).WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name}).Error(ctx, "Failed to expire artifacts")
+			}
+		}
+	}
+}
+
+// expireArtifacts marks the workflow's artifacts as expired, and adds the artifact GC finalizer so the workflow is
+// reconciled until they are deleted
+func (wfc *WorkflowController) expireArtifacts(ctx context.Context, wf *wfv1.Workflow, now time.Time) error {
+	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name}).Info(ctx, "Artifact retention expired, deleting artifacts")
+	finalizers := wf.GetFinalizers()
+	if !slices.Contains(finalizers, common.FinalizerArtifactGC) {
+		finalizers = append(finalizers, common.FinalizerArtifactGC)
+	}
+	data, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"resourceVersion": wf.ResourceVersion,
+			"annotations":     map[string]string{common.AnnotationKeyArtifactRetentionExpired: now.UTC().Format(time.RFC3339)},
+			"finalizers":      finalizers,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	_, err = wfc.wfclientset.ArgoprojV1alpha1().Workflows(wf.Namespace).Patch(ctx, wf.Name, types.MergePatchType, data, metav1.PatchOptions{})
+	if apierr.IsNotFound(err) || apierr.IsConflict(err) {
+		// it will be considered again in the next period, if it still exists
+		return nil
+	}
+	return err
+}
+
+// expiredByRetention returns the workflows, of the completed workflows which used a repository, whose artifacts have
+// expired under its retention and have not been deleted yet
+func expiredByRetention(retention *wfv1.ArtifactRetention, wfs []*wfv1.Workflow, now time.Time) ([]*wfv1.Workflow, error) {
+	var maxAge time.Duration
+	if retention.MaxAge != "" {
+		var err error
+		maxAge, err = wfv1.ParseStringToDuration(retention.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("maxAge: %w", err)
+		}
+	}
+	if retention.KeepLast != nil && *retention.KeepLast < 0 {
+		return nil, fmt.Errorf("keepLast must be non-negative")
+	}
+	keep := labels.Nothing()
+	if retention.KeepSelector != nil {
+		var err error
+		keep, err = metav1.LabelSelectorAsSelector(retention.KeepSelector)
+		if err != nil {
+			return nil, fmt.Errorf("keepSelector: %w", err)
+		}
+	}
+
+	expired := make(map[*wfv1.Workflow]bool)
+	if maxAge > 0 {
+		for _, wf := range wfs {
+			if !wf.Status.FinishedAt.IsZero() && wf.Status.FinishedAt.Add(maxAge).Before(now) {
+				expired[wf] = true
+			}
+		}
+	}
+	if retention.KeepLast != nil {
+		byTemplate := make(map[string][]*wfv1.Workflow)
+		for _, wf := range wfs {
+			if name, ok := wf.Labels[common.LabelKeyWorkflowTemplate]; ok {
+				byTemplate[wf.Namespace+"/"+name] = append(byTemplate[wf.Namespace+"/"+name], wf)
+			} else if name, ok := wf.Labels[common.LabelKeyClusterWorkflowTemplate]; ok {
+				byTemplate[name] = append(byTemplate[name], wf)
+			}
+		}
+		for _, runs := range byTemplate {
+			// newest first
+			sort.SliceStable(runs, func(i, j int) bool {
+				return runs[i].Status.FinishedAt.After(runs[j].Status.FinishedAt.Time)
+			})
+			succeeded := int32(0)
+			for _, wf := range runs {
+				if succeeded >= *retention.KeepLast {
+					expired[wf] = true
+				} else if wf.Status.Phase == wfv1.WorkflowSucceeded {
+					succeeded++
+				}
+			}
+		}
+	}
+
+	var results []*wfv1.Workflow
+	for _, wf := range wfs {
+		if !expired[wf] || keep.Matches(labels.Set(wf.Labels)) || !hasUndeletedArtifacts(wf) {
+			continue
+		}
+		if _, ok := wf.Annotations[common.AnnotationKeyArtifactRetentionExpired]; ok {
+			continue
+		}
+		results = append(results, wf)
+	}
+	return results, nil
+}
+
+// hasUndeletedArtifacts returns whether the workflow may have output artifacts which have not been deleted. If its
+// nodes are offloaded or compressed it is assumed to.
+func hasUndeletedArtifacts(wf *wfv1.Workflow) bool {
+	if len(wf.Status.Nodes) == 0 {
+		return wf.Status.IsOffloadNodeStatus() || wf.Status.CompressedNodes != ""
+	}
+	for _, node := range wf.Status.Nodes {
+		for _, art := range node.GetOutputs().GetArtifacts() {
+			if !art.Deleted {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+)
+
+func newRetentionTestWorkflow(name, template string, phase wfv1.WorkflowPhase, finishedAt time.Time) *wfv1.Workflow {
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-ns", Labels: map[string]string{}},
+		Status: wfv1.WorkflowStatus{
+			Phase:      phase,
+			FinishedAt: metav1.NewTime(finishedAt),
+			Nodes: wfv1.Nodes{
+				name: {ID: name, Outputs: &wfv1.Outputs{Artifacts: wfv1.Artifacts{
+					{Name: "result", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: name + "/result.tgz"}}},
+				}}},
+			},
+		},
+	}
+	if template != "" {
+		wf.Labels[common.LabelKeyWorkflowTemplate] = template
+	}
+	return wf
+}
+
+func workflowNames(wfs []*wfv1.Workflow) []string {
+	var results []string
+	for _, wf := range wfs {
+		results = append(results, wf.Name)
+	}
+	return results
+}
+
+func TestExpiredByRetention(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	t.Run("MaxAge", func(t *testing.T) {
+		wfs := []*wfv1.Workflow{
+			newRetentionTestWorkflow("old", "", wfv1.WorkflowSucceeded, now.Add(-8*day)),
+			newRetentionTestWorkflow("new", "", wfv1.WorkflowSucceeded, now.Add(-6*day)),
+		}
+		expired, err := expiredByRetention(&wfv1.ArtifactRetention{MaxAge: "168h"}, wfs, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"old"}, workflowNames(expired))
+	})
+	t.Run("KeepLast", func(t *testing.T) {
+		wfs := []*wfv1.Workflow{
+			newRetentionTestWorkflow("a-1", "a", wfv1.WorkflowSucceeded, now.Add(-4*day)),
+			newRetentionTestWorkflow("a-2", "a", wfv1.WorkflowSucceeded, now.Add(-3*day)),
+			newRetentionTestWorkflow("a-3", "a", wfv1.WorkflowFailed, now.Add(-2*day)),
+			newRetentionTestWorkflow("a-4", "a", wfv1.WorkflowSucceeded, now.Add(-1*day)),
+			newRetentionTestWorkflow("b-1", "b", wfv1.WorkflowSucceeded, now.Add(-4*day)),
+			newRetentionTestWorkflow("adhoc", "", wfv1.WorkflowSucceeded, now.Add(-4*day)),
+		}
+		expired, err := expiredByRetention(&wfv1.ArtifactRetention{KeepLast: ptr.To(int32(2))}, wfs, now)
+		require.NoError(t, err)
+		// a-3 failed, so it is kept as it is newer than the second last successful run
+		assert.Equal(t, []string{"a-1"}, workflowNames(expired))
+	})
+	t.Run("KeepSelector", func(t *testing.T) {
+		wfs := []*wfv1.Workflow{
+			newRetentionTestWorkflow("pinned", "", wfv1.WorkflowSucceeded, now.Add(-8*day)),
+			newRetentionTestWorkflow("old", "", wfv1.WorkflowSucceeded, now.Add(-8*day)),
+		}
+		wfs[0].Labels["pinned"] = "true"
+		expired, err := expiredByRetention(&wfv1.ArtifactRetention{
+			MaxAge:       "168h",
+			KeepSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pinned": "true"}},
+		}, wfs, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"old"}, workflowNames(expired))
+	})
+	t.Run("AlreadyExpired", func(t *testing.T) {
+		expiredWf := newRetentionTestWorkflow("expired", "", wfv1.WorkflowSucceeded, now.Add(-8*day))
+		expiredWf.Annotations = map[string]string{common.AnnotationKeyArtifactRetentionExpired: now.Format(time.RFC3339)}
+		deletedWf := newRetentionTestWorkflow("deleted", "", wfv1.WorkflowSucceeded, now.Add(-8*day))
+		deletedWf.Status.Nodes["deleted"].Outputs.Artifacts[0].Deleted = true
+		expired, err := expiredByRetention(&wfv1.ArtifactRetention{MaxAge: "168h"}, []*wfv1.Workflow{expiredWf, deletedWf}, now)
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := expiredByRetention(&wfv1.ArtifactRetention{MaxAge: "a week"}, nil, now)
+		require.Error(t, err)
+		_, err = expiredByRetention(&wfv1.ArtifactRetention{KeepLast: ptr.To(int32(-1))}, nil, now)
+		require.EqualError(t, err, "keepLast must be non-negative")
+	})
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	argoerrors "github.com/argoproj/argo-workflows/v4/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/resource"
+)
+
+// artifactCacheKeyPrefix is the prefix of the keys of all artifact cache entries within the artifact repository
+const artifactCacheKeyPrefix = "memoization"
+
+// artifactCache stores one JSON encoded Entry per key in the artifact repository, under the namespace of the workflows
+// using it, so that workflows in different namespaces do not share entries.
+// Entries are not rewritten on a hit, so LastHitTimestamp is not maintained and
+// expired entries should be removed with a lifecycle policy on the bucket.
+type artifactCache struct {
+	repository *wfv1.ArtifactRepository
+	newDriver  artifacts.NewDriverFunc
+	resources  resource.Interface
+	namespace  string
+	name       string
+}
+
+func NewArtifactCache(repository *wfv1.ArtifactRepository, newDriver artifacts.NewDriverFunc, ri resource.Interface, ns, n string) MemoizationCache {
+	return &artifactCache{
+		repository: repository,
+		newDriver:  newDriver,
+		resources:  ri,
+		namespace:  ns,
+		name:       n,
+	}
+}
+
+func (c *artifactCache) logInfo(ctx context.Context, fields logging.Fields, message string) {
+	logger := logging.RequireLoggerFromContext(ctx)
+	logger.WithFields(logging.Fields{"namespace": c.namespace, "name": c.name}).WithFields(fields).Info(ctx, message)
+}
+
+// artifact returns the location of the entry for key within the artifact repository
+func (c *artifactCache) artifact(key string) (*wfv1.Artifact, error) {
+	l := c.repository.ToArtifactLocation()
+	if err := l.SetKey(path.Join(artifactCacheKeyPrefix, c.namespace, c.name, key+".json")); err != nil {
+		return nil, err
+	}
+	return &wfv1.Artifact{Name: key, ArtifactLocation: *l}, nil
+}
+
+func (c *artifactCache) Load(ctx context.Context, key string) (*Entry, error) {
+	if !cacheKeyRegex.MatchString(key) {
+		return nil, fmt.Errorf("invalid cache key: %s", key)
+	}
+	art, err := c.artifact(key)
+	if err != nil {
+		return nil, err
+	}
+	drv, err := c.newDriver(ctx, art, c.resources)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := drv.OpenStream(ctx, art)
+	if err != nil {
+		if argoerrors.IsCode(argoerrors.CodeNotFound, err) {
+			c.logInfo(ctx, logging.Fields{"key": key}, "artifact cache miss: entry does not exist")
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = stream.Close() }()
+	rawEntry, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(rawEntry, &entry); err != nil {
+		return nil, fmt.Errorf("malformed cache entry: could not unmarshal JSON; unable to parse: %w", err)
+	}
+	c.logInfo(ctx, logging.Fields{"key": key}, "artifact cache loaded")
+	entry.LastHitTimestamp = metav1.Time{Time: time.Now()}
+	return &entry, nil
+}
+
+func (c *artifactCache) Save(ctx context.Context, key string, nodeID string, value *wfv1.Outputs) error {
+	if !cacheKeyRegex.MatchString(key) {
+		return fmt.Errorf("invalid cache key: %s", key)
+	}
+	art, err := c.artifact(key)
+	if err != nil {
+		return err
+	}
+	creationTime := time.Now()
+	entryJSON, err := json.Marshal(Entry{
+		NodeID:            nodeID,
+		Outputs:           value,
+		CreationTimestamp: metav1.Time{Time: creationTime},
+		LastHitTimestamp:  metav1.Time{Time: creationTime},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal cache entry: %w", err)
+	}
+	drv, err := c.newDriver(ctx, art, c.resources)
+	if err != nil {
+		return err
+	}
+	c.logInfo(ctx, logging.Fields{"key": key, "nodeID": nodeID}, "Saving artifact cache entry")
+	if err := drv.SaveStream(ctx, bytes.NewReader(entryJSON), art); err != nil {
+		return fmt.Errorf("error creating cache entry: %w", err)
+	}
+	return nil
+}
+
+// kubeResources resolves the secrets referenced by the artifact repository in the controller's namespace
+type kubeResources struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+func (r kubeResources) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := r.kubeClient.CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[key]), nil
+}
+
+func (r kubeResources) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := r.kubeClient.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[key], nil
+}
@@ -10,6 +10,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/sqldb"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts"
 )
 
 var cacheKeyRegex = regexp.MustCompile("^[a-zA-Z0-9][-a-zA-Z0-9]*$")
@@ -48,36 +50,120 @@ func (e *Entry) GetOutputsWithMaxAge(maxAge time.Duration) (*wfv1.Outputs, bool)
 	return e.Outputs, true
 }
 
+// Config holds the controller configuration that the non-ConfigMap caches are backed by
+type Config struct {
+	// SessionProxy is the persistence database session, DatabaseCache is unavailable when nil
+	SessionProxy *sqldb.SessionProxy
+	// ClusterName is used to separate the entries of different clusters sharing a database
+	ClusterName string
+	// ArtifactRepository is the default artifact repository, ArtifactCache is unavailable when nil
+	ArtifactRepository *wfv1.ArtifactRepository
+	// Namespace is the controller's namespace, in which the secrets of ArtifactRepository are resolved
+	Namespace string
+	// NewDriver creates the artifact driver for ArtifactCache, defaults to artifacts.NewDriver
+	NewDriver artifacts.NewDriverFunc
+}
+
 type cacheFactory struct {
 	caches     map[string]MemoizationCache
 	kubeclient kubernetes.Interface
 	namespace  string
+	config     Config
 	lock       sync.RWMutex
 }
 
 type Factory interface {
-	GetCache(ct Type, name string) MemoizationCache
+	// GetCache returns the cache of the type with the name, for the workflows in the namespace
+	GetCache(ct Type, namespace, name string) MemoizationCache
+	// Configure replaces the backends of the database and artifact caches
+	Configure(config Config)
+	// DeleteExpired removes database cache entries that have not been hit for the given duration
+	DeleteExpired(ctx context.Context, notHitDuration time.Duration) error
 }
 
 func NewCacheFactory(ki kubernetes.Interface, ns string) Factory {
 	return &cacheFactory{
-		make(map[string]MemoizationCache),
-		ki,
-		ns,
-		sync.RWMutex{},
+		caches:     make(map[string]MemoizationCache),
+		kubeclient: ki,
+		namespace:  ns,
+		lock:       sync.RWMutex{},
 	}
 }
 
 type Type string
 
 const (
-	// Only config maps are currently supported for caching
 	ConfigMapCache Type = "ConfigMapCache"
+	DatabaseCache  Type = "DatabaseCache"
+	ArtifactCache  Type = "ArtifactCache"
 )
 
-// Returns a cache if it exists and creates it otherwise
-func (cf *cacheFactory) GetCache(ct Type, name string) MemoizationCache {
+// TypeOf returns the type of cache configured by c, and its name
+func TypeOf(c *wfv1.Cache) (Type, string) {
+	switch {
+	case c == nil:
+		return "", ""
+	case c.Database != nil:
+		return DatabaseCache, c.Database.Name
+	case c.Artifact != nil:
+		return ArtifactCache, c.Artifact.Name
+	default:
+		return ConfigMapCache, c.GetName()
+	}
+}
+
+// StatusType returns the type of cache recorded in a node's memoization status.
+// Statuses written before CacheType existed always refer to a ConfigMapCache.
+func StatusType(s *wfv1.MemoizationStatus) Type {
+	if s == nil || s.CacheType == "" {
+		return ConfigMapCache
+	}
+	return Type(s.CacheType)
+}
+
+func (cf *cacheFactory) Configure(config Config) {
+	cf.lock.Lock()
+	defer cf.lock.Unlock()
+	if config.NewDriver == nil {
+		config.NewDriver = artifacts.NewDriver
+	}
+	cf.config = config
+}
+
+func (cf *cacheFactory) DeleteExpired(ctx context.Context, notHitDuration time.Duration) error {
 	cf.lock.RLock()
+	sessionProxy, clusterName := cf.config.SessionProxy, cf.config.ClusterName
+	cf.lock.RUnlock()
+	if sessionProxy == nil {
+		return nil
+	}
+	return deleteExpiredDatabaseCacheEntries(ctx, sessionProxy, clusterName, cf.namespace, time.Now().Add(-notHitDuration))
+}
+
+// Returns a cache if it exists and creates it otherwise.
+// Returns nil if the type is unknown or its backend is not configured.
+// Database and artifact caches are created from the current configuration on each call, as they hold no state.
+func (cf *cacheFactory) GetCache(ct Type, namespace, name string) MemoizationCache {
+	cf.lock.RLock()
+	switch ct {
+	case DatabaseCache:
+		defer cf.lock.RUnlock()
+		if cf.config.SessionProxy == nil {
+			return nil
+		}
+		return NewDatabaseCache(cf.config.SessionProxy, cf.config.ClusterName, cf.namespace, name)
+	case ArtifactCache:
+		defer cf.lock.RUnlock()
+		if cf.config.ArtifactRepository.Get() == nil {
+			return nil
+		}
+		return NewArtifactCache(cf.config.ArtifactRepository, cf.config.NewDriver, kubeResources{cf.kubeclient, cf.config.Namespace}, namespace, name)
+	case ConfigMapCache:
+		// created once, below
+	default:
+		cf.lock.RUnlock()
+		return nil
+	}
 
 	idx := string(ct) + "." + name
 	if c := cf.caches[idx]; c != nil {
@@ -92,13 +178,7 @@ func (cf *cacheFactory) GetCache(ct Type, name string) MemoizationCache {
 	if c := cf.caches[idx]; c != nil {
 		return c
 	}
-
-	switch ct {
-	case ConfigMapCache:
-		c := NewConfigMapCache(cf.namespace, cf.kubeclient, name)
-		cf.caches[idx] = c
-		return c
-	default:
-		return nil
-	}
+	c := NewConfigMapCache(cf.namespace, cf.kubeclient, name)
+	cf.caches[idx] = c
+	return c
 }
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/upper/db/v4"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/util/sqldb"
+)
+
+// DatabaseCacheTableName is the table holding the entries of all database caches, it is created by the persistence migration
+const DatabaseCacheTableName = "argo_memoization_cache"
+
+type databaseCacheRecord struct {
+	ClusterName string    `db:"clustername"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Key         string    `db:"cachekey"`
+	NodeID      string    `db:"nodeid"`
+	Outputs     string    `db:"outputs"`
+	CreatedAt   time.Time `db:"createdat"`
+	LastHitAt   time.Time `db:"lasthitat"`
+}
+
+type databaseCache struct {
+	sessionProxy *sqldb.SessionProxy
+	clusterName  string
+	namespace    string
+	name         string
+}
+
+func NewDatabaseCache(sessionProxy *sqldb.SessionProxy, clusterName, ns, n string) MemoizationCache {
+	return &databaseCache{
+		sessionProxy: sessionProxy,
+		clusterName:  clusterName,
+		namespace:    ns,
+		name:         n,
+	}
+}
+
+func (c *databaseCache) logInfo(ctx context.Context, fields logging.Fields, message string) {
+	logger := logging.RequireLoggerFromContext(ctx)
+	logger.WithFields(logging.Fields{"namespace": c.namespace, "name": c.name}).WithFields(fields).Info(ctx, message)
+}
+
+func (c *databaseCache) where(key string) db.Cond {
+	return db.Cond{"clustername": c.clusterName, "namespace": c.namespace, "name": c.name, "cachekey": key}
+}
+
+func (c *databaseCache) Load(ctx context.Context, key string) (*Entry, error) {
+	if !cacheKeyRegex.MatchString(key) {
+		return nil, fmt.Errorf("invalid cache key: %s", key)
+	}
+	var record databaseCacheRecord
+	hitTime := time.Now().UTC()
+	err := c.sessionProxy.With(ctx, func(s db.Session) error {
+		err := s.SQL().SelectFrom(DatabaseCacheTableName).Where(c.where(key)).One(&record)
+		if err != nil {
+			return err
+		}
+		_, err = s.SQL().Update(DatabaseCacheTableName).Set("lasthitat", hitTime).Where(c.where(key)).Exec()
+		return err
+	})
+	if errors.Is(err, db.ErrNoMoreRows) {
+		c.logInfo(ctx, logging.Fields{"key": key}, "database cache miss: entry does not exist")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.logInfo(ctx, logging.Fields{"key": key}, "database cache loaded")
+	var outputs *wfv1.Outputs
+	if err := json.Unmarshal([]byte(record.Outputs), &outputs); err != nil {
+		return nil, fmt.Errorf("malformed cache entry: could not unmarshal JSON; unable to parse: %w", err)
+	}
+	return &Entry{
+		NodeID:            record.NodeID,
+		Outputs:           outputs,
+		CreationTimestamp: metav1.Time{Time: record.CreatedAt},
+		LastHitTimestamp:  metav1.Time{Time: hitTime},
+	}, nil
+}
+
+func (c *databaseCache) Save(ctx context.Context, key string, nodeID string, value *wfv1.Outputs) error {
+	if !cacheKeyRegex.MatchString(key) {
+		return fmt.Errorf("invalid cache key: %s", key)
+	}
+	outputs, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("unable to marshal cache entry: %w", err)
+	}
+	c.logInfo(ctx, logging.Fields{"key": key, "nodeID": nodeID}, "Saving database cache entry")
+	creationTime := time.Now().UTC()
+	record := &databaseCacheRecord{
+		ClusterName: c.clusterName,
+		Namespace:   c.namespace,
+		Name:        c.name,
+		Key:         key,
+		NodeID:      nodeID,
+		Outputs:     string(outputs),
+		CreatedAt:   creationTime,
+		LastHitAt:   creationTime,
+	}
+	// delete and insert in one transaction, an upsert statement is not portable between MySQL and Postgres
+	return c.sessionProxy.TxWith(ctx, func(s *sqldb.SessionProxy) error {
+		sess := s.Session()
+		_, err := sess.SQL().DeleteFrom(DatabaseCacheTableName).Where(c.where(key)).Exec()
+		if err != nil {
+			return err
+		}
+		_, err = sess.Collection(DatabaseCacheTableName).Insert(record)
+		if err != nil {
+			return fmt.Errorf("error creating cache entry: %w", err)
+		}
+		return nil
+	}, nil)
+}
+
+func deleteExpiredDatabaseCacheEntries(ctx context.Context, sessionProxy *sqldb.SessionProxy, clusterName, ns string, notHitSince time.Time) error {
+	return sessionProxy.With(ctx, func(s db.Session) error {
+		rs, err := s.SQL().
+			DeleteFrom(DatabaseCacheTableName).
+			Where(db.Cond{"clustername": clusterName, "namespace": ns}).
+			And(db.Cond{"lasthitat <": notHitSince.UTC()}).
+			Exec()
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := rs.RowsAffected()
+		if err != nil {
+			return err
+		}
+		logging.RequireLoggerFromContext(ctx).WithField("rowsAffected", rowsAffected).Debug(ctx, "Deleted expired database cache entries")
+		return nil
+	})
+}
@@ -42,6 +42,10 @@ func (wfc *WorkflowController) syncAllCacheForGC(ctx context.Context) {
 			logger.WithField("configMap", cm.Name).WithError(err).Error(ctx, "Unable to sync ConfigMap")
 		}
 	}
+
+	if err := wfc.cacheFactory.DeleteExpired(ctx, gcAfterNotHitDuration); err != nil {
+		logger.WithError(err).Error(ctx, "Unable to delete expired database cache entries")
+	}
 }
 
 func (wfc *WorkflowController) cleanupUnusedCache(ctx context.Context, cm *apiv1.ConfigMap) error {
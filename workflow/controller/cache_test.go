@@ -1,15 +1,24 @@
 package controller
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	argoerrors "github.com/argoproj/argo-workflows/v4/errors"
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v4/util/logging"
+	artifactscommon "github.com/argoproj/argo-workflows/v4/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/resource"
 	"github.com/argoproj/argo-workflows/v4/workflow/common"
 	"github.com/argoproj/argo-workflows/v4/workflow/controller/cache"
 )
@@ -104,3 +113,145 @@ func TestConfigMapCacheSave(t *testing.T) {
 	wfv1.MustUnmarshal([]byte(cm.Data["hi-there-world"]), &entry)
 	assert.Equal(t, entry.LastHitTimestamp.Time, entry.CreationTimestamp.Time)
 }
+
+// memoryArtifactDriver is an in-memory artifact driver keyed by S3 key
+type memoryArtifactDriver struct {
+	objects map[string][]byte
+}
+
+func (d *memoryArtifactDriver) Load(context.Context, *wfv1.Artifact, string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (d *memoryArtifactDriver) OpenStream(_ context.Context, a *wfv1.Artifact) (io.ReadCloser, error) {
+	data, ok := d.objects[a.S3.Key]
+	if !ok {
+		return nil, argoerrors.New(argoerrors.CodeNotFound, a.S3.Key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (d *memoryArtifactDriver) Save(context.Context, string, *wfv1.Artifact) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (d *memoryArtifactDriver) SaveStream(_ context.Context, reader io.Reader, a *wfv1.Artifact) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	d.objects[a.S3.Key] = data
+	return nil
+}
+
+func (d *memoryArtifactDriver) Delete(_ context.Context, a *wfv1.Artifact) error {
+	delete(d.objects, a.S3.Key)
+	return nil
+}
+
+func (d *memoryArtifactDriver) ListObjects(context.Context, *wfv1.Artifact) ([]string, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (d *memoryArtifactDriver) IsDirectory(context.Context, *wfv1.Artifact) (bool, error) {
+	return false, nil
+}
+
+func newArtifactCacheConfig(drv *memoryArtifactDriver) cache.Config {
+	return cache.Config{
+		ArtifactRepository: &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}}},
+		NewDriver: func(context.Context, *wfv1.Artifact, resource.Interface) (artifactscommon.ArtifactDriver, error) {
+			return drv, nil
+		},
+	}
+}
+
+func TestArtifactCacheSaveLoad(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx)
+	defer cancel()
+	drv := &memoryArtifactDriver{objects: map[string][]byte{}}
+	controller.cacheFactory.Configure(newArtifactCacheConfig(drv))
+	c := controller.cacheFactory.GetCache(cache.ArtifactCache, "default", "whalesay-cache")
+	require.NotNil(t, c)
+
+	entry, err := c.Load(ctx, "hi-there-world")
+	require.NoError(t, err)
+	assert.False(t, entry.Hit())
+
+	outputs := wfv1.Outputs{Parameters: []wfv1.Parameter{{Name: "hello", Value: wfv1.AnyStringPtr("Hello world")}}}
+	require.NoError(t, c.Save(ctx, "hi-there-world", "my-node", &outputs))
+	assert.Contains(t, drv.objects, "memoization/default/whalesay-cache/hi-there-world.json")
+
+	entry, err = c.Load(ctx, "hi-there-world")
+	require.NoError(t, err)
+	assert.True(t, entry.Hit())
+	assert.Equal(t, "my-node", entry.NodeID)
+	require.Len(t, entry.Outputs.Parameters, 1)
+	assert.Equal(t, "Hello world", entry.Outputs.Parameters[0].Value.String())
+
+	// workflows in other namespaces do not share the entries
+	entry, err = controller.cacheFactory.GetCache(cache.ArtifactCache, "other", "whalesay-cache").Load(ctx, "hi-there-world")
+	require.NoError(t, err)
+	assert.False(t, entry.Hit())
+}
+
+func TestCacheFactoryUnconfiguredBackends(t *testing.T) {
+	f := cache.NewCacheFactory(fake.NewSimpleClientset(), "default")
+	assert.NotNil(t, f.GetCache(cache.ConfigMapCache, "default", "whalesay-cache"))
+	assert.Nil(t, f.GetCache(cache.DatabaseCache, "default", "whalesay-cache"))
+	assert.Nil(t, f.GetCache(cache.ArtifactCache, "default", "whalesay-cache"))
+
+	f.Configure(newArtifactCacheConfig(&memoryArtifactDriver{objects: map[string][]byte{}}))
+	assert.Nil(t, f.GetCache(cache.DatabaseCache, "default", "whalesay-cache"))
+	assert.NotNil(t, f.GetCache(cache.ArtifactCache, "default", "whalesay-cache"))
+
+	f.Configure(cache.Config{})
+	assert.Nil(t, f.GetCache(cache.ArtifactCache, "default", "whalesay-cache"))
+}
+
+func TestArtifactCacheSecretNamespace(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	kube := fake.NewSimpleClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-minio-cred", Namespace: "argo"},
+		Data:       map[string][]byte{"accesskey": []byte("my-access-key")},
+	})
+	// The factory's namespace differs from the controller's, so the secret is only found in the configured namespace
+	f := cache.NewCacheFactory(kube, "default")
+	var accessKey string
+	f.Configure(cache.Config{
+		ArtifactRepository: &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}}},
+		Namespace:          "argo",
+		NewDriver: func(ctx context.Context, _ *wfv1.Artifact, ri resource.Interface) (artifactscommon.ArtifactDriver, error) {
+			var err error
+			accessKey, err = ri.GetSecret(ctx, "my-minio-cred", "accesskey")
+			if err != nil {
+				return nil, err
+			}
+			return &memoryArtifactDriver{objects: map[string][]byte{}}, nil
+		},
+	})
+
+	entry, err := f.GetCache(cache.ArtifactCache, "default", "whalesay-cache").Load(ctx, "hi-there-world")
+	require.NoError(t, err)
+	assert.False(t, entry.Hit())
+	assert.Equal(t, "my-access-key", accessKey)
+}
+
+func TestCacheTypeOf(t *testing.T) {
+	for _, tt := range []struct {
+		cache    *wfv1.Cache
+		wantType cache.Type
+		wantName string
+	}{
+		{&wfv1.Cache{ConfigMap: &apiv1.LocalObjectReference{Name: "cm"}}, cache.ConfigMapCache, "cm"},
+		{&wfv1.Cache{Database: &wfv1.DatabaseCache{Name: "db"}}, cache.DatabaseCache, "db"},
+		{&wfv1.Cache{Artifact: &wfv1.ArtifactCache{Name: "art"}}, cache.ArtifactCache, "art"},
+	} {
+		cacheType, name := cache.TypeOf(tt.cache)
+		assert.Equal(t, tt.wantType, cacheType)
+		assert.Equal(t, tt.wantName, name)
+	}
+	assert.Equal(t, cache.ConfigMapCache, cache.StatusType(&wfv1.MemoizationStatus{CacheName: "cm"}))
+	assert.Equal(t, cache.DatabaseCache, cache.StatusType(&wfv1.MemoizationStatus{CacheName: "db", CacheType: string(cache.DatabaseCache)}))
+}
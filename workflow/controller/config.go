@@ -15,6 +15,7 @@ import (
 	"github.com/argoproj/argo-workflows/v4/util/logging"
 	"github.com/argoproj/argo-workflows/v4/util/sqldb"
 	"github.com/argoproj/argo-workflows/v4/workflow/artifactrepositories"
+	controllercache "github.com/argoproj/argo-workflows/v4/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v4/workflow/hydrator"
 )
 
@@ -79,6 +80,7 @@ func (wfc *WorkflowController) updateConfig(ctx context.Context) error {
 		logger.Info(ctx, "Persistence configuration disabled")
 	}
 
+	wfc.configureCacheFactory()
 	wfc.hydrator = hydrator.New(wfc.offloadNodeStatusRepo)
 	wfc.updateEstimatorFactory(ctx)
 	wfc.rateLimiter = wfc.newRateLimiter()
@@ -92,6 +94,23 @@ func (wfc *WorkflowController) updateConfig(ctx context.Context) error {
 	return nil
 }
 
+// configureCacheFactory configures the backends of the database and artifact memoization caches
+func (wfc *WorkflowController) configureCacheFactory() {
+	if wfc.cacheFactory == nil {
+		return
+	}
+	cacheConfig := controllercache.Config{}
+	if persistence := wfc.Config.Persistence; persistence != nil {
+		cacheConfig.SessionProxy = wfc.sessionProxy
+		cacheConfig.ClusterName = persistence.GetClusterName()
+	}
+	if wfc.Config.ArtifactRepository.Get() != nil {
+		cacheConfig.ArtifactRepository = wfc.Config.ArtifactRepository.DeepCopy()
+		cacheConfig.Namespace = wfc.namespace
+	}
+	wfc.cacheFactory.Configure(cacheConfig)
+}
+
 // initDB inits argo DB tables
 func (wfc *WorkflowController) initDB(ctx context.Context) error {
 	persistence := wfc.Config.Persistence
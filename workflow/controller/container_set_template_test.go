@@ -9,6 +9,8 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/util/variables"
+	varkeys "github.com/argoproj/argo-workflows/v4/util/variables/keys"
 	"github.com/argoproj/argo-workflows/v4/workflow/common"
 )
 
@@ -217,3 +219,50 @@ spec:
 		}
 	}
 }
+
+func TestContainerSetContainerOutputs(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: pod
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      containerSet:
+        containers:
+          - name: build
+            image: argoproj/argosay:v2
+            command: [sh, -c]
+            args: ["echo 1.2.3 > /tmp/version"]
+            outputs:
+              parameters:
+                - name: version
+                  valueFrom:
+                    path: /tmp/version
+          - name: main
+            image: argoproj/argosay:v2
+            command: [sh, -c]
+            args: ["echo done"]
+            dependencies: [build]
+`)
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx, wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.operate(ctx)
+
+	woc.setContainerOutputs(ctx, "pod", "build", wfv1.Outputs{
+		Parameters: []wfv1.Parameter{{Name: "version", Value: wfv1.AnyStringPtr("1.2.3")}},
+	})
+	ctrNode, err := woc.wf.GetNodeByName("pod.build")
+	require.NoError(t, err)
+	require.NotNil(t, ctrNode.Outputs)
+	assert.Equal(t, "1.2.3", ctrNode.Outputs.Parameters[0].Value.String())
+
+	node, err := woc.wf.GetNodeByName("pod")
+	require.NoError(t, err)
+	scope := &wfScope{scope: variables.NewScope()}
+	woc.buildLocalScope(scope, varkeys.StepsNodeRef, "build", node)
+	assert.Equal(t, "1.2.3", scope.scope.AsAnyMap()["steps.build.containers.build.outputs.parameters.version"])
+	assert.NotContains(t, scope.scope.AsAnyMap(), "steps.build.containers.main.outputs.parameters.version")
+}
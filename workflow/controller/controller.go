@@ -54,6 +54,7 @@ import (
 	"github.com/argoproj/argo-workflows/v4/util/telemetry"
 	waitutil "github.com/argoproj/argo-workflows/v4/util/wait"
 	"github.com/argoproj/argo-workflows/v4/workflow/artifactrepositories"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts"
 	"github.com/argoproj/argo-workflows/v4/workflow/common"
 	controllercache "github.com/argoproj/argo-workflows/v4/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v4/workflow/controller/entrypoint"
@@ -66,6 +67,7 @@ import (
 	"github.com/argoproj/argo-workflows/v4/workflow/gccontroller"
 	"github.com/argoproj/argo-workflows/v4/workflow/hydrator"
 	"github.com/argoproj/argo-workflows/v4/workflow/metrics"
+	"github.com/argoproj/argo-workflows/v4/workflow/notifications"
 	"github.com/argoproj/argo-workflows/v4/workflow/sync"
 	"github.com/argoproj/argo-workflows/v4/workflow/tracing"
 	"github.com/argoproj/argo-workflows/v4/workflow/util"
@@ -155,6 +157,8 @@ type WorkflowController struct {
 	eventRecorderManager       events.EventRecorderManager
 	archiveLabelSelector       labels.Selector
 	cacheFactory               controllercache.Factory
+	newArtifactDriver          artifacts.NewDriverFunc
+	notifier                   *notifications.Notifier
 	wfTaskSetInformer          wfextvv1alpha1.WorkflowTaskSetInformer
 	artGCTaskInformer          wfextvv1alpha1.WorkflowArtifactGCTaskInformer
 	taskResultInformer         cache.SharedIndexInformer
@@ -179,6 +183,7 @@ const (
 	workflowTemplateResyncPeriod        = 20 * time.Minute
 	clusterWorkflowTemplateResyncPeriod = 20 * time.Minute
 	workflowExistenceCheckPeriod        = 1 * time.Minute
+	syncLeaseCheckPeriod                = 10 * time.Second
 	workflowTaskSetResyncPeriod         = 20 * time.Minute
 	configMapResyncPeriod               = 20 * time.Minute
 )
@@ -232,7 +237,9 @@ func NewWorkflowController(ctx context.Context, restConfig *rest.Config, kubecli
 		configController:           config.NewController(namespace, configMap, kubeclientset),
 		workflowKeyLock:            syncpkg.NewKeyLock(),
 		cacheFactory:               controllercache.NewCacheFactory(kubeclientset, namespace),
+		newArtifactDriver:          artifacts.NewDriver,
 		eventRecorderManager:       events.NewEventRecorderManager(kubeclientset),
+		notifier:                   notifications.NewNotifier(kubeclientset, namespace, env.LookupEnvIntOr(ctx, "NOTIFICATION_QUEUE_SIZE", 1000)),
 		progressPatchTickDuration:  env.LookupEnvDurationOr(ctx, common.EnvVarProgressPatchTickDuration, 1*time.Minute),
 		progressFileTickDuration:   env.LookupEnvDurationOr(ctx, common.EnvVarProgressFileTickDuration, 3*time.Second),
 		lastWrittenVersions: lastWrittenVersions{
@@ -302,7 +309,7 @@ func (wfc *WorkflowController) runPodController(ctx context.Context, podGCWorker
 func (wfc *WorkflowController) runCronController(ctx context.Context, cronWorkflowWorkers int) {
 	defer runtimeutil.HandleCrashWithContext(ctx, runtimeutil.PanicHandlers...)
 
-	cronController := cron.NewCronController(ctx, wfc.wfclientset, wfc.dynamicInterface, wfc.namespace, wfc.GetManagedNamespace(), wfc.Config.InstanceID, wfc.metrics, wfc.eventRecorderManager, cronWorkflowWorkers, wfc.wftmplInformer, wfc.cwftmplInformer, wfc.Config.WorkflowDefaults)
+	cronController := cron.NewCronController(ctx, wfc.kubeclientset, wfc.wfclientset, wfc.dynamicInterface, wfc.namespace, wfc.GetManagedNamespace(), wfc.Config.InstanceID, wfc.metrics, wfc.eventRecorderManager, cronWorkflowWorkers, wfc.wftmplInformer, wfc.cwftmplInformer, wfc.Config.WorkflowDefaults)
 	cronController.Run(ctx)
 }
 
@@ -438,11 +445,15 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 
 	go wfc.workflowGarbageCollector(ctx)
 	go wfc.archivedWorkflowGarbageCollector(ctx)
+	go wfc.artifactRetentionController(ctx)
+	go wfc.artifactReplicationController(ctx)
+	go wfc.notifier.Run(ctx, env.LookupEnvIntOr(ctx, "NOTIFICATION_WORKERS", 4))
 
 	go wfc.runGCcontroller(ctx, workflowTTLWorkers)
 	go wfc.runCronController(ctx, cronWorkflowWorkers)
 
 	go wait.UntilWithContext(ctx, wfc.syncManager.CheckWorkflowExistence, workflowExistenceCheckPeriod)
+	go wait.UntilWithContext(ctx, wfc.syncManager.RevokeExpiredLeases, syncLeaseCheckPeriod)
 
 	workerCtx, _ := logger.WithField("component", "workflow_worker").InContext(ctx)
 	for range wfWorkers {
@@ -506,7 +517,7 @@ func (wfc *WorkflowController) createSynchronizationManager(ctx context.Context)
 		logging.RequireLoggerFromContext(ctx).WithError(err).Error(ctx, "Failed to create sync lock manager")
 		return
 	}
-	wfc.syncManager = syncManager.WithMetrics(ctx, wfc.metrics)
+	wfc.syncManager = syncManager.WithMetrics(ctx, wfc.metrics).WithFairShare(wfc.Config.SemaphoreFairShare)
 }
 
 // list all running workflows to initialize throttler and syncManager
@@ -1417,7 +1428,7 @@ func (wfc *WorkflowController) newTypedConfigMapInformer(ctx context.Context) ca
 
 // call this func whenever the configuration changes, or when the workflow informer changes
 func (wfc *WorkflowController) updateEstimatorFactory(ctx context.Context) {
-	wfc.estimatorFactory = estimation.NewEstimatorFactory(ctx, wfc.wfInformer, wfc.hydrator, wfc.wfArchive)
+	wfc.estimatorFactory = estimation.NewEstimatorFactory(ctx, wfc.wfInformer, wfc.hydrator, wfc.wfArchive, wfc.Config.DurationEstimation)
 }
 
 // setWorkflowDefaults sets values in the workflow.Spec with defaults from the
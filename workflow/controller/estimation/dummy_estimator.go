@@ -16,3 +16,11 @@ func (e *dummyEstimator) EstimateWorkflowDuration() wfv1.EstimatedDuration {
 func (e *dummyEstimator) EstimateNodeDuration(_ context.Context, nodeName string) wfv1.EstimatedDuration {
 	return wfv1.NewEstimatedDuration(time.Second)
 }
+
+func (e *dummyEstimator) EstimateWorkflowDurationUpperBound() wfv1.EstimatedDuration {
+	return wfv1.NewEstimatedDuration(2 * time.Second)
+}
+
+func (e *dummyEstimator) EstimateNodeDurationUpperBound(_ context.Context, nodeName string) wfv1.EstimatedDuration {
+	return wfv1.NewEstimatedDuration(2 * time.Second)
+}
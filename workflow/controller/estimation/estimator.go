@@ -12,6 +12,10 @@ import (
 type Estimator interface {
 	EstimateWorkflowDuration() wfv1.EstimatedDuration
 	EstimateNodeDuration(ctx context.Context, nodeName string) wfv1.EstimatedDuration
+	// EstimateWorkflowDurationUpperBound returns zero if the estimator has no upper bound
+	EstimateWorkflowDurationUpperBound() wfv1.EstimatedDuration
+	// EstimateNodeDurationUpperBound returns zero if the estimator has no upper bound
+	EstimateNodeDurationUpperBound(ctx context.Context, nodeName string) wfv1.EstimatedDuration
 }
 
 type estimator struct {
@@ -40,3 +44,12 @@ func (e *estimator) EstimateNodeDuration(ctx context.Context, nodeName string) w
 	}
 	return wfv1.NewEstimatedDuration(node.GetDuration())
 }
+
+// A single baseline gives no indication of the spread of durations, so there is no upper bound
+func (e *estimator) EstimateWorkflowDurationUpperBound() wfv1.EstimatedDuration {
+	return 0
+}
+
+func (e *estimator) EstimateNodeDurationUpperBound(context.Context, string) wfv1.EstimatedDuration {
+	return 0
+}
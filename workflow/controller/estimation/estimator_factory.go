@@ -3,11 +3,14 @@ package estimation
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/argoproj/argo-workflows/v4/config"
 	"github.com/argoproj/argo-workflows/v4/persist/sqldb"
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v4/util/env"
@@ -26,16 +29,37 @@ type estimatorFactory struct {
 	wfInformer cache.SharedIndexInformer
 	hydrator   hydrator.Interface
 	wfArchive  sqldb.WorkflowArchive
+	config     *config.DurationEstimationConfig
+	// statistics caches the duration statistics computed from the archive, keyed by namespace and label
+	statistics     map[string]cachedStatistics
+	statisticsLock sync.Mutex
 }
 
+type cachedStatistics struct {
+	stats     *durationStatistics
+	expiresAt time.Time
+}
+
+// statisticsTTL is how long statistics computed from the archive are reused, to avoid querying it for every workflow
+const statisticsTTL = time.Minute
+
+// nowFn is the clock the statistics cache expires against, replaced in tests
+var nowFn = time.Now
+
 var _ EstimatorFactory = &estimatorFactory{}
 
 var (
 	skipWorkflowDurationEstimation = env.LookupEnvStringOr("SKIP_WORKFLOW_DURATION_ESTIMATION", "false")
 )
 
-func NewEstimatorFactory(ctx context.Context, wfInformer cache.SharedIndexInformer, hydrator hydrator.Interface, wfArchive sqldb.WorkflowArchive) EstimatorFactory {
-	return &estimatorFactory{wfInformer, hydrator, wfArchive}
+func NewEstimatorFactory(ctx context.Context, wfInformer cache.SharedIndexInformer, hydrator hydrator.Interface, wfArchive sqldb.WorkflowArchive, estimationConfig *config.DurationEstimationConfig) EstimatorFactory {
+	return &estimatorFactory{
+		wfInformer: wfInformer,
+		hydrator:   hydrator,
+		wfArchive:  wfArchive,
+		config:     estimationConfig,
+		statistics: make(map[string]cachedStatistics),
+	}
 }
 
 func (f *estimatorFactory) NewEstimator(ctx context.Context, wf *wfv1.Workflow) (Estimator, error) {
@@ -50,6 +74,16 @@ func (f *estimatorFactory) NewEstimator(ctx context.Context, wf *wfv1.Workflow)
 	} {
 		labelValue, exists := wf.Labels[labelName]
 		if exists {
+			if f.config.GetStrategy() == config.DurationEstimationStrategyPercentile && f.wfArchive.IsEnabled() {
+				stats, err := f.getDurationStatistics(ctx, wf.Namespace, labelName, labelValue)
+				if err != nil {
+					return defaultEstimator, err
+				}
+				if stats != nil {
+					return &percentileEstimator{wf, stats}, nil
+				}
+				// nothing has been archived yet, so fall back to the most recent workflow
+			}
 			objs, err := f.wfInformer.GetIndexer().ByIndex(indexName, indexes.MetaNamespaceLabelIndex(wf.Namespace, labelValue))
 			if err != nil {
 				return defaultEstimator, fmt.Errorf("failed to list workflows by index: %w", err)
@@ -93,3 +127,38 @@ func (f *estimatorFactory) NewEstimator(ctx context.Context, wf *wfv1.Workflow)
 	}
 	return defaultEstimator, nil
 }
+
+// getDurationStatistics returns the duration statistics of the most recent successful archived workflows with the label,
+// or nil if there are none
+func (f *estimatorFactory) getDurationStatistics(ctx context.Context, namespace, labelName, labelValue string) (*durationStatistics, error) {
+	key := namespace + "/" + labelName + "=" + labelValue
+	f.statisticsLock.Lock()
+	cached, ok := f.statistics[key]
+	f.statisticsLock.Unlock()
+	if ok && nowFn().Before(cached.expiresAt) {
+		return cached.stats, nil
+	}
+	requirements, err := labels.ParseToRequirements(labelName + "=" + labelValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse selector to requirements: %w", err)
+	}
+	wfs, err := f.wfArchive.ListWorkflowsForEstimator(ctx, namespace, requirements, f.config.GetHistorySize())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived workflows for estimator: %w", err)
+	}
+	var stats *durationStatistics
+	if len(wfs) > 0 {
+		stats = newDurationStatistics(wfs, f.config.GetPercentile(), f.config.GetUpperBoundPercentile())
+	}
+	now := nowFn()
+	f.statisticsLock.Lock()
+	// Prune expired statistics, so that those of labels which are no longer used are not kept for the life of the controller
+	for k, c := range f.statistics {
+		if !now.Before(c.expiresAt) {
+			delete(f.statistics, k)
+		}
+	}
+	f.statistics[key] = cachedStatistics{stats: stats, expiresAt: now.Add(statisticsTTL)}
+	f.statisticsLock.Unlock()
+	return stats, nil
+}
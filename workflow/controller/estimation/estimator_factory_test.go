@@ -2,7 +2,9 @@ package estimation
 
 import (
 	"testing"
+	"time"
 
+	"github.com/argoproj/argo-workflows/v4/config"
 	"github.com/argoproj/argo-workflows/v4/util/logging"
 
 	"github.com/stretchr/testify/assert"
@@ -60,7 +62,7 @@ metadata:
 	wfArchive.On("GetWorkflowForEstimator", mock.Anything, "my-ns", r).Return(testutil.MustUnmarshalWorkflow(`
 metadata:
   name: my-archived-wftmpl-baseline`), nil)
-	f := NewEstimatorFactory(ctx, informer, hydratorfake.Always, wfArchive)
+	f := NewEstimatorFactory(ctx, informer, hydratorfake.Always, wfArchive, nil)
 	t.Run("None", func(t *testing.T) {
 		p, err := f.NewEstimator(ctx, &wfv1.Workflow{})
 		require.NoError(t, err)
@@ -113,3 +115,90 @@ metadata:
 		assert.Equal(t, "my-archived-wftmpl-baseline", e.baselineWF.Name)
 	})
 }
+
+func Test_estimatorFactoryPercentile(t *testing.T) {
+	informer := testutil.NewSharedIndexInformer()
+	ctx := logging.TestContext(t.Context())
+	informer.Indexer.SetByIndex(indexes.WorkflowTemplateIndex, "my-ns/my-wftmpl", testutil.MustUnmarshalUnstructured(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: my-wftmpl-baseline
+  labels:
+    workflows.argoproj.io/phase: Succeeded
+`))
+	wfArchive := &sqldbmocks.WorkflowArchive{}
+	wfArchive.On("IsEnabled").Return(true)
+	r, err := labels.ParseToRequirements("workflows.argoproj.io/workflow-template=my-archived-wftmpl")
+	require.NoError(t, err)
+	wfArchive.On("ListWorkflowsForEstimator", mock.Anything, "my-ns", r, 5).Return(wfv1.Workflows{
+		newHistoricalWorkflow("my-archived-a", wfv1.WorkflowSucceeded, 10),
+		newHistoricalWorkflow("my-archived-b", wfv1.WorkflowSucceeded, 20),
+		newHistoricalWorkflow("my-archived-c", wfv1.WorkflowSucceeded, 30),
+	}, nil).Once()
+	r, err = labels.ParseToRequirements("workflows.argoproj.io/workflow-template=my-wftmpl")
+	require.NoError(t, err)
+	wfArchive.On("ListWorkflowsForEstimator", mock.Anything, "my-ns", r, 5).Return(wfv1.Workflows{}, nil)
+	f := NewEstimatorFactory(ctx, informer, hydratorfake.Always, wfArchive, &config.DurationEstimationConfig{
+		Strategy:    config.DurationEstimationStrategyPercentile,
+		HistorySize: 5,
+	})
+	t.Run("Archived", func(t *testing.T) {
+		for range 2 {
+			p, err := f.NewEstimator(ctx, &wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Labels: map[string]string{common.LabelKeyWorkflowTemplate: "my-archived-wftmpl"}},
+			})
+			require.NoError(t, err)
+			e, ok := p.(*percentileEstimator)
+			require.True(t, ok)
+			assert.Equal(t, wfv1.EstimatedDuration(20), e.EstimateWorkflowDuration())
+			assert.Equal(t, wfv1.EstimatedDuration(30), e.EstimateWorkflowDurationUpperBound())
+		}
+		// the statistics are cached, so the archive is only queried once
+		wfArchive.AssertNumberOfCalls(t, "ListWorkflowsForEstimator", 1)
+	})
+	t.Run("NotArchived", func(t *testing.T) {
+		p, err := f.NewEstimator(ctx, &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Labels: map[string]string{common.LabelKeyWorkflowTemplate: "my-wftmpl"}},
+		})
+		require.NoError(t, err)
+		e, ok := p.(*estimator)
+		require.True(t, ok)
+		require.NotNil(t, e.baselineWF)
+		assert.Equal(t, "my-wftmpl-baseline", e.baselineWF.Name)
+	})
+}
+
+func Test_estimatorFactoryPrunesStatistics(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	originalNowFn := nowFn
+	defer func() { nowFn = originalNowFn }()
+	now := time.Now()
+	nowFn = func() time.Time { return now }
+
+	wfArchive := &sqldbmocks.WorkflowArchive{}
+	wfArchive.On("IsEnabled").Return(true)
+	wfArchive.On("ListWorkflowsForEstimator", mock.Anything, "my-ns", mock.Anything, 5).Return(wfv1.Workflows{
+		newHistoricalWorkflow("my-archived", wfv1.WorkflowSucceeded, 10),
+	}, nil)
+	f := NewEstimatorFactory(ctx, testutil.NewSharedIndexInformer(), hydratorfake.Always, wfArchive, &config.DurationEstimationConfig{
+		Strategy:    config.DurationEstimationStrategyPercentile,
+		HistorySize: 5,
+	}).(*estimatorFactory)
+	newEstimator := func(wftmpl string) {
+		_, err := f.NewEstimator(ctx, &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Labels: map[string]string{common.LabelKeyWorkflowTemplate: wftmpl}},
+		})
+		require.NoError(t, err)
+	}
+
+	newEstimator("my-wftmpl-a")
+	newEstimator("my-wftmpl-b")
+	assert.Len(t, f.statistics, 2)
+
+	// the statistics of the other templates expired, so they are pruned when new statistics are cached
+	nowFn = func() time.Time { return now.Add(2 * statisticsTTL) }
+	newEstimator("my-wftmpl-c")
+	assert.Len(t, f.statistics, 1)
+	assert.Contains(t, f.statistics, "my-ns/"+common.LabelKeyWorkflowTemplate+"=my-wftmpl-c")
+}
@@ -0,0 +1,90 @@
+package estimation
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// durationPercentiles are the estimate and upper bound of one workflow or node
+type durationPercentiles struct {
+	estimate   wfv1.EstimatedDuration
+	upperBound wfv1.EstimatedDuration
+}
+
+// durationStatistics are the duration percentiles across a number of historical workflows
+type durationStatistics struct {
+	workflow durationPercentiles
+	// nodes are keyed by the node name with the workflow name prefix removed, so they match between workflows
+	nodes map[string]durationPercentiles
+}
+
+// newDurationStatistics computes the percentiles of the durations of the succeeded workflows and their succeeded nodes
+func newDurationStatistics(wfs wfv1.Workflows, percentile, upperBoundPercentile int) *durationStatistics {
+	var wfDurations []time.Duration
+	nodeDurations := make(map[string][]time.Duration)
+	for _, wf := range wfs {
+		if !wf.Status.Successful() {
+			continue
+		}
+		wfDurations = append(wfDurations, wf.Status.GetDuration())
+		for _, node := range wf.Status.Nodes {
+			if node.Phase != wfv1.NodeSucceeded || node.StartedAt.IsZero() || node.FinishedAt.IsZero() {
+				continue
+			}
+			key := strings.TrimPrefix(node.Name, wf.Name)
+			nodeDurations[key] = append(nodeDurations[key], node.GetDuration())
+		}
+	}
+	stats := &durationStatistics{
+		workflow: newDurationPercentiles(wfDurations, percentile, upperBoundPercentile),
+		nodes:    make(map[string]durationPercentiles, len(nodeDurations)),
+	}
+	for key, durations := range nodeDurations {
+		stats.nodes[key] = newDurationPercentiles(durations, percentile, upperBoundPercentile)
+	}
+	return stats
+}
+
+func newDurationPercentiles(durations []time.Duration, percentile, upperBoundPercentile int) durationPercentiles {
+	slices.Sort(durations)
+	return durationPercentiles{
+		estimate:   wfv1.NewEstimatedDuration(nearestRank(durations, percentile)),
+		upperBound: wfv1.NewEstimatedDuration(nearestRank(durations, upperBoundPercentile)),
+	}
+}
+
+// nearestRank returns the percentile p of the sorted durations using the nearest-rank method
+func nearestRank(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+// percentileEstimator estimates durations from the percentiles of historical durations
+type percentileEstimator struct {
+	wf    *wfv1.Workflow
+	stats *durationStatistics
+}
+
+func (e *percentileEstimator) EstimateWorkflowDuration() wfv1.EstimatedDuration {
+	return e.stats.workflow.estimate
+}
+
+func (e *percentileEstimator) EstimateWorkflowDurationUpperBound() wfv1.EstimatedDuration {
+	return e.stats.workflow.upperBound
+}
+
+func (e *percentileEstimator) EstimateNodeDuration(_ context.Context, nodeName string) wfv1.EstimatedDuration {
+	return e.stats.nodes[strings.TrimPrefix(nodeName, e.wf.Name)].estimate
+}
+
+func (e *percentileEstimator) EstimateNodeDurationUpperBound(_ context.Context, nodeName string) wfv1.EstimatedDuration {
+	return e.stats.nodes[strings.TrimPrefix(nodeName, e.wf.Name)].upperBound
+}
@@ -0,0 +1,70 @@
+package estimation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+func Test_nearestRank(t *testing.T) {
+	var durations []time.Duration
+	for i := 1; i <= 10; i++ {
+		durations = append(durations, time.Duration(i)*time.Second)
+	}
+	assert.Equal(t, time.Duration(0), nearestRank(nil, 50))
+	assert.Equal(t, time.Second, nearestRank(durations, 0))
+	assert.Equal(t, 5*time.Second, nearestRank(durations, 50))
+	assert.Equal(t, 9*time.Second, nearestRank(durations, 90))
+	assert.Equal(t, 10*time.Second, nearestRank(durations, 100))
+}
+
+func newHistoricalWorkflow(name string, phase wfv1.WorkflowPhase, seconds int) wfv1.Workflow {
+	started := metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	finished := metav1.Time{Time: started.Add(time.Duration(seconds) * time.Second)}
+	nodePhase := wfv1.NodeSucceeded
+	if phase != wfv1.WorkflowSucceeded {
+		nodePhase = wfv1.NodeFailed
+	}
+	return wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: wfv1.WorkflowStatus{
+			Phase:      phase,
+			StartedAt:  started,
+			FinishedAt: finished,
+			Nodes: wfv1.Nodes{
+				name: {Name: name, Phase: nodePhase, StartedAt: started, FinishedAt: finished},
+				name + "-1": {
+					Name:       name + ".pod",
+					Phase:      nodePhase,
+					StartedAt:  started,
+					FinishedAt: metav1.Time{Time: started.Add(time.Duration(seconds) * time.Second / 2)},
+				},
+			},
+		},
+	}
+}
+
+func Test_percentileEstimator(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	var wfs wfv1.Workflows
+	for i := 1; i <= 10; i++ {
+		wfs = append(wfs, newHistoricalWorkflow("my-baseline-"+string(rune('a'+i)), wfv1.WorkflowSucceeded, i*10))
+	}
+	wfs = append(wfs, newHistoricalWorkflow("my-failed", wfv1.WorkflowFailed, 1000))
+	e := &percentileEstimator{
+		wf:    &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}},
+		stats: newDurationStatistics(wfs, 50, 90),
+	}
+	assert.Equal(t, wfv1.EstimatedDuration(50), e.EstimateWorkflowDuration())
+	assert.Equal(t, wfv1.EstimatedDuration(90), e.EstimateWorkflowDurationUpperBound())
+	assert.Equal(t, wfv1.EstimatedDuration(50), e.EstimateNodeDuration(ctx, "my-wf"))
+	assert.Equal(t, wfv1.EstimatedDuration(90), e.EstimateNodeDurationUpperBound(ctx, "my-wf"))
+	assert.Equal(t, wfv1.EstimatedDuration(25), e.EstimateNodeDuration(ctx, "my-wf.pod"))
+	assert.Equal(t, wfv1.EstimatedDuration(45), e.EstimateNodeDurationUpperBound(ctx, "my-wf.pod"))
+	assert.Equal(t, wfv1.EstimatedDuration(0), e.EstimateNodeDuration(ctx, "my-wf.unknown"))
+}
@@ -58,6 +58,7 @@ func (woc *wfOperationCtx) executeWfLifeCycleHook(ctx context.Context, tmplCtx *
 
 func (woc *wfOperationCtx) executeTmplLifeCycleHook(ctx context.Context, scope *wfScope, lifeCycleHooks wfv1.LifecycleHooks, parentNode *wfv1.NodeStatus, boundaryID string, tmplCtx *templateresolution.TemplateContext, ref varkeys.NodeRefKeys, name string) (bool, error) {
 	var hookNodes []*wfv1.NodeStatus
+	softTimeoutHook := woc.softTimeoutHook(parentNode)
 	for hookName, hook := range lifeCycleHooks {
 		// exit hook will be executed in runOnExitNode
 		if hookName == wfv1.ExitLifecycleEvent {
@@ -66,13 +67,15 @@ func (woc *wfOperationCtx) executeTmplLifeCycleHook(ctx context.Context, scope *
 		hookNodeName := generateLifeHookNodeName(parentNode.Name, string(hookName))
 		// To check a node was triggered
 		hookedNode, _ := woc.wf.GetNodeByName(hookNodeName)
-		if hook.Expression == "" {
-			return false, errors.Errorf(errors.CodeBadRequest, "Expression required for hook %s", hookNodeName)
-		}
-		// nil-preserving view so expressions can apply `??` fallbacks to skipped/omitted outputs
-		execute, err := argoexpr.EvalBool(hook.Expression, env.GetFuncMap(scope.getParametersAny(woc.globalParams())))
-		if err != nil {
-			return false, err
+		// the hook of a timeout policy is run by the soft timeout, so does not need an expression
+		execute := softTimeoutHook != "" && string(hookName) == softTimeoutHook
+		if hook.Expression != "" {
+			// nil-preserving view so expressions can apply `??` fallbacks to skipped/omitted outputs
+			matches, err := argoexpr.EvalBool(hook.Expression, env.GetFuncMap(scope.getParametersAny(woc.globalParams())))
+			if err != nil {
+				return false, err
+			}
+			execute = execute || matches
 		}
 		// executeTemplated should be invoked when hookedNode != nil, because we should reexecute the function to check mutex condition, etc.
 		if execute || hookedNode != nil {
@@ -2,8 +2,12 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/argoproj/argo-workflows/v4/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/template"
+	varkeys "github.com/argoproj/argo-workflows/v4/util/variables/keys"
 )
 
 func (woc *wfOperationCtx) executeHTTPTemplate(ctx context.Context, nodeName string, templateScope string, tmpl *wfv1.Template, orgTmpl wfv1.TemplateReferenceHolder, opts *executeTemplateOpts) *wfv1.NodeStatus {
@@ -12,7 +16,35 @@ func (woc *wfOperationCtx) executeHTTPTemplate(ctx context.Context, nodeName str
 		_, node = woc.initializeExecutableNode(ctx, nodeName, wfv1.NodeTypeHTTP, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodePending, opts.nodeFlag, true)
 	}
 	if !node.Fulfilled() {
-		woc.taskSet[node.ID] = *tmpl
+		taskTmpl := tmpl.DeepCopy()
+		if err := woc.addHTTPArchiveLocation(ctx, nodeName, taskTmpl); err != nil {
+			return woc.markNodeError(ctx, nodeName, err)
+		}
+		woc.taskSet[node.ID] = *taskTmpl
 	}
 	return node
 }
+
+// addHTTPArchiveLocation adds the default artifact repository to an HTTP template whose response body is saved as an
+// output artifact, so the agent can save it. Like a pod's, the key is resolved for the node's pod name.
+func (woc *wfOperationCtx) addHTTPArchiveLocation(ctx context.Context, nodeName string, tmpl *wfv1.Template) error {
+	if len(tmpl.Outputs.Artifacts) == 0 || tmpl.Outputs.Artifacts[0].HasLocation() || tmpl.ArchiveLocation.HasLocation() {
+		return nil
+	}
+	archiveLocation := woc.artifactRepository.ToArtifactLocation()
+	if !archiveLocation.HasLocation() {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputs.artifacts.%s needs an artifact repository to save the response body", tmpl.Name, tmpl.Outputs.Artifacts[0].Name)
+	}
+	params := woc.globalParams().DeepCopy()
+	params[varkeys.PodName.Template()] = woc.getPodName(nodeName, tmpl.Name)
+	locationBytes, err := json.Marshal(archiveLocation)
+	if err != nil {
+		return errors.InternalWrapError(err)
+	}
+	resolved, err := template.Replace(ctx, string(locationBytes), template.ToAnyMap(params), true)
+	if err != nil {
+		return err
+	}
+	tmpl.ArchiveLocation = &wfv1.ArtifactLocation{}
+	return json.Unmarshal([]byte(resolved), tmpl.ArchiveLocation)
+}
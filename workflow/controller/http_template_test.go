@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
@@ -41,3 +42,39 @@ func TestNodeRequiresHttpReconciliation(t *testing.T) {
 	assert.True(t, woc.nodeRequiresTaskSetReconciliation(ctx, "child-http"))
 	assert.True(t, woc.nodeRequiresTaskSetReconciliation(ctx, "parent"))
 }
+
+func TestHTTPTemplateArchiveLocation(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := v1alpha1.MustUnmarshalWorkflow(`apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: http-body-artifact
+  namespace: default
+spec:
+  entrypoint: http
+  templates:
+    - name: http
+      http:
+        url: http://my-api/reports/latest
+      outputs:
+        artifacts:
+          - name: report
+`)
+	cancel, controller := newController(ctx, wf, defaultServiceAccount)
+	defer cancel()
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.operate(ctx)
+
+	ts, err := woc.controller.wfclientset.ArgoprojV1alpha1().WorkflowTaskSets("default").Get(ctx, wf.Name, v1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, ts.Spec.Tasks, 1)
+	for nodeID, task := range ts.Spec.Tasks {
+		require.NotNil(t, task.ArchiveLocation)
+		require.NotNil(t, task.ArchiveLocation.S3)
+		assert.Equal(t, "my-bucket", task.ArchiveLocation.S3.Bucket)
+		node := woc.wf.Status.Nodes[nodeID]
+		assert.Equal(t, "http-body-artifact/"+woc.getPodName(node.Name, "http"), task.ArchiveLocation.S3.Key)
+	}
+	// The workflow's own template is not changed
+	assert.Nil(t, woc.wf.Spec.Templates[0].ArchiveLocation)
+}
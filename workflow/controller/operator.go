@@ -64,6 +64,7 @@ import (
 	"github.com/argoproj/argo-workflows/v4/workflow/controller/indexes"
 	"github.com/argoproj/argo-workflows/v4/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v4/workflow/progress"
+	wfsync "github.com/argoproj/argo-workflows/v4/workflow/sync"
 	"github.com/argoproj/argo-workflows/v4/workflow/templateresolution"
 	wfutil "github.com/argoproj/argo-workflows/v4/workflow/util"
 	"github.com/argoproj/argo-workflows/v4/workflow/validate"
@@ -287,6 +288,12 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		acquired, wfUpdate, msg, failedLockName, syncErr := woc.controller.syncManager.TryAcquire(lockCtx, woc.wf, "", woc.execWf.Spec.Synchronization)
 		lockSpan.SetAttributes(attribute.Bool("LockAcquired", acquired))
 		lockSpan.End()
+		if errors.Is(syncErr, wfsync.ErrLeaseExpired) {
+			woc.log.WithField("lockName", failedLockName).Warn(ctx, "Synchronization lock lease expired")
+			woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowLockLeaseExpired", syncErr.Error())
+			woc.markWorkflowFailed(ctx, syncErr.Error())
+			return
+		}
 		if syncErr != nil {
 			woc.log.WithField("lockName", failedLockName).Warn(ctx, "Failed to acquire the lock")
 			woc.markWorkflowFailed(ctx, fmt.Sprintf("Failed to acquire the synchronization lock. %s", syncErr.Error()))
@@ -335,7 +342,7 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 			woc.requeueAfter(time.Until(*woc.workflowDeadline))
 		}
 
-		woc.wf.Status.EstimatedDuration = woc.estimateWorkflowDuration(ctx)
+		woc.wf.Status.EstimatedDuration, woc.wf.Status.EstimatedDurationUpperBound = woc.estimateWorkflowDuration(ctx)
 	} else {
 		woc.workflowDeadline = woc.getWorkflowDeadline()
 		podReconciliationCompleted, podReconcErr := woc.podReconciliation(reconcileCtx)
@@ -843,6 +850,8 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 
 	// Create WorkflowNode* events for nodes that have changed phase
 	woc.recordNodePhaseChangeEvents(ctx, woc.orig.Status.Nodes, woc.wf.Status.Nodes)
+	// Publish the phase transitions persisted by this update to the notification sinks
+	woc.controller.notifier.Notify(ctx, woc.controller.Config.Notifications, woc.orig, woc.wf)
 
 	if !woc.controller.hydrator.IsHydrated(woc.wf) {
 		panic("workflow should be hydrated")
@@ -1044,6 +1053,27 @@ func (woc *wfOperationCtx) processNodeRetries(ctx context.Context, node *wfv1.No
 		return woc.markNodePhase(ctx, node.Name, lastChildNode.Phase, message), true, nil
 	}
 
+	// retries is the number of retries counted against the limit, and the exponent of the backoff
+	retries := len(childNodeIds)
+	if len(retryStrategy.Rules) > 0 {
+		rule, counts, err := matchRetryRules(node, retryStrategy.Rules, woc.wf.Status.Nodes)
+		if err != nil {
+			return nil, false, err
+		}
+		if !maps.Equal(node.RetryRuleCounts, counts) {
+			node.RetryRuleCounts = counts
+			woc.wf.Status.Nodes.Set(ctx, node.ID, *node)
+		}
+		if rule == nil {
+			woc.log.Info(ctx, "No retry rule matched. Failing...")
+			return woc.markNodePhase(ctx, node.Name, lastChildNode.Phase, lastChildNode.Message), true, nil
+		}
+		woc.log.WithFields(logging.Fields{"rule": rule.Name, "count": counts[rule.Name]}).Info(ctx, "Retry rule matched")
+		retryStrategy.Limit = rule.Limit
+		retryStrategy.Backoff = rule.Backoff
+		retries = int(counts[rule.Name])
+	}
+
 	if retryStrategy.Backoff != nil {
 		maxDurationDeadline := time.Time{}
 		// Process max duration limit
@@ -1081,7 +1111,7 @@ func (woc *wfOperationCtx) processNodeRetries(ctx context.Context, node *wfv1.No
 		if retryStrategyBackoffFactor != nil && *retryStrategyBackoffFactor > 0 {
 			// Formula: timeToWait = duration * factor^retry_number
 			// Note that timeToWait should equal to duration for the first retry attempt.
-			factor := math.Pow(float64(*retryStrategyBackoffFactor), float64(len(childNodeIds)-1))
+			factor := math.Pow(float64(*retryStrategyBackoffFactor), float64(retries-1))
 			// Prevent overflow: cap at max duration if multiplication would exceed MaxInt64
 			if factor > float64(math.MaxInt64)/float64(baseDuration) {
 				timeToWait = time.Duration(math.MaxInt64)
@@ -1107,7 +1137,7 @@ func (woc *wfOperationCtx) processNodeRetries(ctx context.Context, node *wfv1.No
 		}
 
 		// See if we have waited past the deadline
-		if time.Now().Before(waitingDeadline) && retryStrategy.Limit != nil && int32(len(childNodeIds)) <= int32(retryStrategy.Limit.IntValue()) {
+		if time.Now().Before(waitingDeadline) && retryStrategy.Limit != nil && int32(retries) <= int32(retryStrategy.Limit.IntValue()) {
 			woc.requeueAfter(timeToWait)
 			retryMessage := fmt.Sprintf("Backoff for %s", humanize.Duration(timeToWait))
 			return woc.markNodePhase(ctx, node.Name, node.Phase, retryMessage), false, nil
@@ -1155,7 +1185,7 @@ func (woc *wfOperationCtx) processNodeRetries(ctx context.Context, node *wfv1.No
 	if err != nil {
 		return nil, false, err
 	}
-	if retryStrategy.Limit != nil && limit != nil && int32(len(childNodeIds)) > *limit {
+	if retryStrategy.Limit != nil && limit != nil && int32(retries) > *limit {
 		woc.log.Info(ctx, "No more retries left. Failing...")
 		return woc.markNodePhase(ctx, node.Name, lastChildNode.Phase, "No more retries left"), true, nil
 	}
@@ -1213,6 +1243,7 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) (bool, error)
 		node, err := woc.wf.Status.Nodes.Get(nodeID)
 		if err == nil {
 			if newState := woc.assessNodeStatus(ctx, pod, node); newState != nil {
+				applySoftTimeoutResult(newState)
 				// update if a pod deletion timestamp exists on a completed workflow, ensures this pod is always looked at
 				// in the pod cleanup process
 				if pod.DeletionTimestamp != nil && newState.Fulfilled() {
@@ -1227,8 +1258,7 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) (bool, error)
 				woc.addOutputsToGlobalScope(ctx, newState.Outputs)
 				if newState.MemoizationStatus != nil {
 					if newState.Succeeded() {
-						c := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, newState.MemoizationStatus.CacheName)
-						err := c.Save(ctx, newState.MemoizationStatus.Key, newState.ID, newState.Outputs)
+						err := woc.saveToMemoizationCache(ctx, newState)
 						if err != nil {
 							woc.log.WithFields(logging.Fields{"nodeID": newState.ID}).WithError(err).Error(ctx, "Failed to save node outputs to cache")
 							newState.Phase = wfv1.NodeError
@@ -1461,6 +1491,7 @@ func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod,
 	case apiv1.PodFailed:
 		// ignore pod failure for daemoned steps
 		updated.Phase, updated.Message = woc.inferFailedReason(ctx, pod, tmpl)
+		updated.ResourceFailure = podResourceFailure(pod, tmpl)
 		woc.log.WithFields(logging.Fields{"message": updated.Message, "displayName": old.DisplayName, "templateName": wfutil.GetTemplateFromNode(*old), "pod": pod.Name}).Info(ctx, "Pod failed")
 		updated.Daemoned = nil
 
@@ -2108,28 +2139,68 @@ func getRetryNodeChildrenIds(node *wfv1.NodeStatus, nodes wfv1.Nodes) []string {
 }
 
 func buildRetryStrategyLocalScope(node *wfv1.NodeStatus, nodes wfv1.Nodes) map[string]any {
-	localScope := make(map[string]any)
-
 	// `retries` variable
 	childNodeIds, lastChildNode := getChildNodeIdsAndLastRetriedNode(node, nodes)
 
 	if lastChildNode == nil || len(childNodeIds) == 0 {
-		return localScope
+		return make(map[string]any)
 	}
-	localScope[varkeys.Retries.Template()] = strconv.Itoa(len(childNodeIds) - 1)
+	return buildRetryAttemptLocalScope(len(childNodeIds)-1, lastChildNode)
+}
+
+// buildRetryAttemptLocalScope returns the scope of the retry strategy after the attempt, the retries'th of the node
+func buildRetryAttemptLocalScope(retries int, attempt *wfv1.NodeStatus) map[string]any {
+	localScope := make(map[string]any)
+	localScope[varkeys.Retries.Template()] = strconv.Itoa(retries)
 
 	exitCode := "-1"
-	if lastChildNode.Outputs != nil && lastChildNode.Outputs.ExitCode != nil {
-		exitCode = *lastChildNode.Outputs.ExitCode
+	if attempt.Outputs != nil && attempt.Outputs.ExitCode != nil {
+		exitCode = *attempt.Outputs.ExitCode
 	}
 	localScope[varkeys.RetriesLastExitCode.Template()] = exitCode
-	localScope[varkeys.RetriesLastStatus.Template()] = string(lastChildNode.Phase)
-	localScope[varkeys.RetriesLastDuration.Template()] = fmt.Sprint(lastChildNode.GetDuration().Seconds())
-	localScope[varkeys.RetriesLastMessage.Template()] = lastChildNode.Message
+	localScope[varkeys.RetriesLastStatus.Template()] = string(attempt.Phase)
+	localScope[varkeys.RetriesLastDuration.Template()] = fmt.Sprint(attempt.GetDuration().Seconds())
+	localScope[varkeys.RetriesLastMessage.Template()] = attempt.Message
 
 	return localScope
 }
 
+// matchRetryRules returns the first of the rules which matches the failure of the last attempt of the retry node, if
+// any, and the number of retries each rule allowed, by matching the failure of each attempt. The counts are
+// recomputed from the attempts, rather than incremented, so that they are the same however often the node is operated.
+func matchRetryRules(node *wfv1.NodeStatus, rules []wfv1.RetryRule, nodes wfv1.Nodes) (*wfv1.RetryRule, map[string]int32, error) {
+	childNodeIds := getChildNodeIdsRetried(node, nodes)
+	counts := make(map[string]int32)
+	var matched *wfv1.RetryRule
+	for i, childNodeID := range childNodeIds {
+		attempt, err := nodes.Get(childNodeID)
+		if err != nil {
+			return nil, nil, err
+		}
+		matched = nil
+		// daemoned attempts are retried when they succeed, as they should not exit
+		if !attempt.FailedOrError() && !(attempt.IsDaemoned() && attempt.Phase == wfv1.NodeSucceeded) {
+			continue
+		}
+		scope := env.GetFuncMap(buildRetryAttemptLocalScope(i, attempt))
+		for j := range rules {
+			if rules[j].Expression != "" {
+				matches, err := argoexpr.EvalBool(rules[j].Expression, scope)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to evaluate the expression of retry rule %q: %w", rules[j].Name, err)
+				}
+				if !matches {
+					continue
+				}
+			}
+			matched = &rules[j]
+			counts[matched.Name]++
+			break
+		}
+	}
+	return matched, counts, nil
+}
+
 type executeTemplateOpts struct {
 	// boundaryID is an ID for node grouping
 	boundaryID string
@@ -2256,15 +2327,7 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 	deadline, pendingDeadline, err := woc.checkTemplateTimeouts(processedTmpl, node, time.Now().UTC())
 	if err != nil {
 		woc.log.WithField("template", processedTmpl.Name).Warn(ctx, "Template exceeded its deadline")
-		if node.Type == wfv1.NodeTypePod {
-			// delete the timed-out pod so the resources it was waiting for are freed.
-			// Deletion is by UID so a pod recreated by a retry cannot be affected.
-			if pod, exists, podErr := woc.podExists(node.ID); podErr != nil {
-				woc.log.WithError(podErr).Warn(ctx, "failed to check pod existence while cleaning up timed-out node")
-			} else if exists {
-				woc.controller.PodController.DeletePodByUID(ctx, pod.Namespace, pod.Name, string(pod.UID))
-			}
-		}
+		woc.deleteNodePod(ctx, node)
 		return woc.markNodePhase(ctx, nodeName, wfv1.NodeFailed, err.Error()), err
 	}
 	// Ensure that we will check again soon after the earliest deadline
@@ -2287,6 +2350,12 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 		lockAcquired, wfUpdated, msg, failedLockName, syncErr := woc.controller.syncManager.TryAcquire(lockCtx, woc.wf, woc.wf.NodeID(nodeName), processedTmpl.Synchronization)
 		lockSpan.SetAttributes(attribute.Bool("LockAcquired", lockAcquired))
 		lockSpan.End()
+		if node != nil && errors.Is(syncErr, wfsync.ErrLeaseExpired) {
+			woc.log.WithFields(logging.Fields{"nodeName": nodeName, "lockName": failedLockName}).Warn(ctx, "Synchronization lock lease expired")
+			woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowLockLeaseExpired", fmt.Sprintf("Node %s: %s", nodeName, syncErr))
+			woc.deleteNodePod(ctx, node)
+			return woc.markNodePhase(ctx, nodeName, wfv1.NodeFailed, syncErr.Error()), nil
+		}
 		if syncErr != nil {
 			errNode := woc.initializeNodeOrMarkError(ctx, node, nodeName, templateScope, orgTmpl, opts.boundaryID, opts.nodeFlag, syncErr)
 			return errNode, syncErr
@@ -2315,10 +2384,11 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 	// Check memoization cache if the node is about to be created, or was created in the past but is only now allowed to run due to acquiring a lock
 	if processedTmpl.Memoize != nil {
 		if node == nil || unlockedNode {
-			memoizationCache := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, processedTmpl.Memoize.Cache.ConfigMap.Name)
+			cacheType, cacheName := controllercache.TypeOf(processedTmpl.Memoize.Cache)
+			memoizationCache := woc.controller.cacheFactory.GetCache(cacheType, woc.wf.Namespace, cacheName)
 			if memoizationCache == nil {
 				cacheErr := fmt.Errorf("cache could not be found or created")
-				woc.log.WithFields(logging.Fields{"cacheName": processedTmpl.Memoize.Cache.ConfigMap.Name}).WithError(cacheErr)
+				woc.log.WithFields(logging.Fields{"cacheName": cacheName, "cacheType": cacheType}).WithError(cacheErr)
 				errNode := woc.initializeNodeOrMarkError(ctx, node, nodeName, templateScope, orgTmpl, opts.boundaryID, opts.nodeFlag, cacheErr)
 				return errNode, cacheErr
 			}
@@ -2349,7 +2419,8 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 			memoizationStatus := &wfv1.MemoizationStatus{
 				Hit:       hit,
 				Key:       processedTmpl.Memoize.Key,
-				CacheName: processedTmpl.Memoize.Cache.ConfigMap.Name,
+				CacheName: cacheName,
+				CacheType: string(cacheType),
 			}
 			if hit {
 				if node == nil {
@@ -2382,7 +2453,7 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 		// Memoized nodes don't have StartedAt.
 		if node.StartedAt.IsZero() {
 			node.StartedAt = metav1.Time{Time: time.Now().UTC()}
-			node.EstimatedDuration = woc.estimateNodeDuration(ctx, node.Name)
+			node.EstimatedDuration, node.EstimatedDurationUpperBound = woc.estimateNodeDuration(ctx, node.Name)
 			woc.wf.Status.Nodes.Set(ctx, node.ID, *node)
 			woc.updated = true
 		}
@@ -2498,6 +2569,12 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 		}
 	}
 
+	if node != nil && processedTmpl.TimeoutPolicy != nil {
+		if node, err = woc.applyTimeoutPolicy(ctx, processedTmpl, node); err != nil {
+			return woc.markNodeError(ctx, nodeName, err), err
+		}
+	}
+
 	switch processedTmpl.GetType() {
 	case wfv1.TemplateTypeContainer:
 		node, err = woc.executeContainer(ctx, nodeName, templateScope, processedTmpl, orgTmpl, opts)
@@ -2750,7 +2827,7 @@ func (woc *wfOperationCtx) markWorkflowPhase(ctx context.Context, phase wfv1.Wor
 	if woc.wf.Status.StartedAt.IsZero() && phase != wfv1.WorkflowPending {
 		woc.updated = true
 		woc.wf.Status.StartedAt = metav1.Time{Time: time.Now().UTC()}
-		woc.wf.Status.EstimatedDuration = woc.estimateWorkflowDuration(ctx)
+		woc.wf.Status.EstimatedDuration, woc.wf.Status.EstimatedDurationUpperBound = woc.estimateWorkflowDuration(ctx)
 	}
 	if woc.wf.Status.Message != message {
 		woc.log.WithFields(logging.Fields{"fromMessage": woc.wf.Status.Message, "toMessage": message}).Info(ctx, "updated message")
@@ -2812,12 +2889,16 @@ func (woc *wfOperationCtx) getEstimator(ctx context.Context) estimation.Estimato
 	return woc.estimator
 }
 
-func (woc *wfOperationCtx) estimateWorkflowDuration(ctx context.Context) wfv1.EstimatedDuration {
-	return woc.getEstimator(ctx).EstimateWorkflowDuration()
+// estimateWorkflowDuration returns the estimated duration of the workflow and its upper bound
+func (woc *wfOperationCtx) estimateWorkflowDuration(ctx context.Context) (wfv1.EstimatedDuration, wfv1.EstimatedDuration) {
+	estimator := woc.getEstimator(ctx)
+	return estimator.EstimateWorkflowDuration(), estimator.EstimateWorkflowDurationUpperBound()
 }
 
-func (woc *wfOperationCtx) estimateNodeDuration(ctx context.Context, nodeName string) wfv1.EstimatedDuration {
-	return woc.getEstimator(ctx).EstimateNodeDuration(ctx, nodeName)
+// estimateNodeDuration returns the estimated duration of the node and its upper bound
+func (woc *wfOperationCtx) estimateNodeDuration(ctx context.Context, nodeName string) (wfv1.EstimatedDuration, wfv1.EstimatedDuration) {
+	estimator := woc.getEstimator(ctx)
+	return estimator.EstimateNodeDuration(ctx, nodeName), estimator.EstimateNodeDurationUpperBound(ctx, nodeName)
 }
 
 func (woc *wfOperationCtx) hasDaemonNodes() bool {
@@ -2918,10 +2999,12 @@ func (woc *wfOperationCtx) initializeExecutableNode(ctx context.Context, nodeNam
 
 	// Set the MemoizationStatus
 	if node.MemoizationStatus == nil && executeTmpl.Memoize != nil {
+		cacheType, cacheName := controllercache.TypeOf(executeTmpl.Memoize.Cache)
 		memoizationStatus := &wfv1.MemoizationStatus{
 			Hit:       false,
 			Key:       executeTmpl.Memoize.Key,
-			CacheName: executeTmpl.Memoize.Cache.ConfigMap.Name,
+			CacheName: cacheName,
+			CacheType: string(cacheType),
 		}
 		node.MemoizationStatus = memoizationStatus
 	}
@@ -3001,18 +3084,20 @@ func (woc *wfOperationCtx) initializeNode(ctx context.Context, nodeName string,
 		panic(fmt.Sprintf("node %s already initialized", nodeName))
 	}
 
+	estimatedDuration, estimatedDurationUpperBound := woc.estimateNodeDuration(ctx, nodeName)
 	node := wfv1.NodeStatus{
-		ID:                nodeID,
-		Name:              nodeName,
-		TemplateName:      orgTmpl.GetTemplateName(),
-		TemplateRef:       orgTmpl.GetTemplateRef(),
-		TemplateScope:     templateScope,
-		Type:              nodeType,
-		BoundaryID:        boundaryID,
-		Phase:             phase,
-		NodeFlag:          nodeFlag,
-		StartedAt:         metav1.Time{Time: time.Now().UTC()},
-		EstimatedDuration: woc.estimateNodeDuration(ctx, nodeName),
+		ID:                          nodeID,
+		Name:                        nodeName,
+		TemplateName:                orgTmpl.GetTemplateName(),
+		TemplateRef:                 orgTmpl.GetTemplateRef(),
+		TemplateScope:               templateScope,
+		Type:                        nodeType,
+		BoundaryID:                  boundaryID,
+		Phase:                       phase,
+		NodeFlag:                    nodeFlag,
+		StartedAt:                   metav1.Time{Time: time.Now().UTC()},
+		EstimatedDuration:           estimatedDuration,
+		EstimatedDurationUpperBound: estimatedDurationUpperBound,
 	}
 
 	if executable(nodeType) && !omitTaskResultSynced {
@@ -3063,6 +3148,17 @@ func (woc *wfOperationCtx) updateAsCacheHitNode(ctx context.Context, node *wfv1.
 	woc.log.WithFields(logging.Fields{"node": node.ID, "phase": node.Phase, "message": message}).Info(ctx, "node updated")
 }
 
+// saveToMemoizationCache saves the outputs of a memoized node to the cache recorded in its memoization status
+func (woc *wfOperationCtx) saveToMemoizationCache(ctx context.Context, node *wfv1.NodeStatus) error {
+	memStat := node.MemoizationStatus
+	cacheType := controllercache.StatusType(memStat)
+	c := woc.controller.cacheFactory.GetCache(cacheType, woc.wf.Namespace, memStat.CacheName)
+	if c == nil {
+		return fmt.Errorf("%s %q could not be found or created", cacheType, memStat.CacheName)
+	}
+	return c.Save(ctx, memStat.Key, node.ID, node.Outputs)
+}
+
 // markNodePhase marks a node with the given phase, creating the node if necessary and handles timestamps
 func (woc *wfOperationCtx) markNodePhase(ctx context.Context, nodeName string, phase wfv1.NodePhase, message ...string) *wfv1.NodeStatus {
 	namespacedName := woc.wf.Namespace + "/" + woc.wf.Name
@@ -3610,6 +3706,42 @@ func (woc *wfOperationCtx) buildLocalScope(scope *wfScope, ref varkeys.NodeRefKe
 		ref.HostNodeName.Set(scope.scope, node.HostNodeName, name)
 	}
 	woc.addNodeOutputsToLocalScope(ref, name, node.Outputs, scope)
+	woc.addContainerOutputsToLocalScope(ref, name, node, scope)
+}
+
+// addContainerOutputsToLocalScope adds the outputs of the containers of a container set node to the scope. The nodes
+// of the containers are the children of the node, or of the containers they depend on.
+func (woc *wfOperationCtx) addContainerOutputsToLocalScope(ref varkeys.NodeRefKeys, name string, node *wfv1.NodeStatus, scope *wfScope) {
+	if scope == nil || node.Type != wfv1.NodeTypePod {
+		return
+	}
+	visited := make(map[string]bool)
+	queue := slices.Clone(node.Children)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		ctrNode, err := woc.wf.Status.Nodes.Get(id)
+		if err != nil || ctrNode.Type != wfv1.NodeTypeContainer || ctrNode.BoundaryID != node.ID {
+			continue
+		}
+		queue = append(queue, ctrNode.Children...)
+		if ctrNode.Outputs == nil {
+			continue
+		}
+		containerName := strings.TrimPrefix(ctrNode.Name, node.Name+".")
+		for _, param := range ctrNode.Outputs.Parameters {
+			if param.Value != nil {
+				ref.ContainerOutputsParameterByName.Set(scope.scope, param.Value.String(), name, containerName, param.Name)
+			}
+		}
+		for _, art := range ctrNode.Outputs.Artifacts {
+			ref.ContainerOutputsArtifactByName.Set(scope.scope, art, name, containerName, art.Name)
+		}
+	}
 }
 
 func (woc *wfOperationCtx) addNodeOutputsToLocalScope(ref varkeys.NodeRefKeys, name string, outputs *wfv1.Outputs, scope *wfScope) {
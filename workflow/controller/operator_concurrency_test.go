@@ -1136,3 +1136,82 @@ spec:
 		}
 	}
 }
+
+const wfWithLeasedMutex = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: leased-mutex
+  namespace: default
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    synchronization:
+      mutexes:
+      - name: my-mutex
+        leaseDuration: 1ns
+    container:
+      image: alpine:3.23
+`
+
+func TestMutexLeaseExpired(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx)
+	defer cancel()
+	controller.syncManager, _ = sync.NewLockManager(ctx, controller.kubeclientset, controller.namespace, nil, getSyncLimitFunc(ctx, controller.kubeclientset), func(key string) {
+	}, workflowExistenceFunc, false)
+
+	wf := wfv1.MustUnmarshalWorkflow(wfWithLeasedMutex)
+	wf, err := controller.wfclientset.ArgoprojV1alpha1().Workflows(wf.Namespace).Create(ctx, wf, metav1.CreateOptions{})
+	require.NoError(t, err)
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.operate(ctx)
+	require.NotNil(t, woc.wf.Status.Synchronization)
+	require.Len(t, woc.wf.Status.Synchronization.Mutex.Holding, 1)
+
+	controller.syncManager.RevokeExpiredLeases(ctx)
+
+	woc = newWorkflowOperationCtx(ctx, woc.wf, controller)
+	woc.operate(ctx)
+	node := woc.wf.Status.Nodes.FindByDisplayName("leased-mutex")
+	require.NotNil(t, node)
+	assert.Equal(t, wfv1.NodeFailed, node.Phase)
+	assert.Contains(t, node.Message, "synchronization lease expired")
+
+	var reasons []string
+	events := controller.eventRecorderManager.(*testEventRecorderManager).eventRecorder.Events
+	for len(events) > 0 {
+		reasons = append(reasons, strings.Fields(<-events)[1])
+	}
+	assert.Contains(t, reasons, "WorkflowLockLeaseExpired")
+
+	woc = newWorkflowOperationCtx(ctx, woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+	assert.Nil(t, woc.wf.Status.Synchronization, "the failed workflow releases its locks")
+}
+
+func TestWorkflowMutexLeaseExpired(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx)
+	defer cancel()
+	controller.syncManager, _ = sync.NewLockManager(ctx, controller.kubeclientset, controller.namespace, nil, getSyncLimitFunc(ctx, controller.kubeclientset), func(key string) {
+	}, workflowExistenceFunc, false)
+
+	wf := wfv1.MustUnmarshalWorkflow(wfWithLeasedMutex)
+	wf.Spec.Synchronization = wf.Spec.Templates[0].Synchronization
+	wf.Spec.Templates[0].Synchronization = nil
+	wf, err := controller.wfclientset.ArgoprojV1alpha1().Workflows(wf.Namespace).Create(ctx, wf, metav1.CreateOptions{})
+	require.NoError(t, err)
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.operate(ctx)
+	require.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+
+	controller.syncManager.RevokeExpiredLeases(ctx)
+
+	woc = newWorkflowOperationCtx(ctx, woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+	assert.Contains(t, woc.wf.Status.Message, "synchronization lease expired")
+}
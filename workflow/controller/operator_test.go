@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -228,6 +229,8 @@ status:
 	assert.Equal(t, wfv1.EstimatedDuration(1), woc.wf.Status.EstimatedDuration)
 	assert.Equal(t, wfv1.EstimatedDuration(1), woc.wf.Status.Nodes[woc.wf.Name].EstimatedDuration)
 	assert.Equal(t, wfv1.EstimatedDuration(1), woc.wf.Status.Nodes.FindByDisplayName("pod").EstimatedDuration)
+	assert.Equal(t, wfv1.EstimatedDuration(2), woc.wf.Status.EstimatedDurationUpperBound)
+	assert.Equal(t, wfv1.EstimatedDuration(2), woc.wf.Status.Nodes.FindByDisplayName("pod").EstimatedDurationUpperBound)
 }
 
 func TestDefaultProgress(t *testing.T) {
@@ -1019,6 +1022,72 @@ func TestProcessNodeRetriesWithExpression(t *testing.T) {
 	assert.Equal(t, "No more retries left", n.Message)
 }
 
+// TestProcessNodeRetriesWithRules tests retrying with rules, which each have their own limit and backoff
+func TestProcessNodeRetriesWithRules(t *testing.T) {
+	cancel, controller := newController(logging.TestContext(t.Context()))
+	defer cancel()
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	ctx := logging.TestContext(t.Context())
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+
+	nodeName := "test-node"
+	nodeID := woc.wf.NodeID(nodeName)
+	_, node := woc.initializeNode(ctx, nodeName, wfv1.NodeTypeRetry, "", &wfv1.WorkflowStep{}, "", wfv1.NodeRunning, &wfv1.NodeFlag{}, true)
+	woc.wf.Status.Nodes[nodeID] = *node
+	retries := wfv1.RetryStrategy{Rules: []wfv1.RetryRule{
+		{Name: "transient", Expression: `lastRetry.exitCode == "75"`, Limit: intstrutil.ParsePtr("2")},
+		{Name: "evicted", Expression: `lastRetry.message matches "Evicted"`, Limit: intstrutil.ParsePtr("1"), Backoff: &wfv1.Backoff{Duration: "5m"}},
+	}}
+
+	addFailedChild := func(exitCode, message string) *wfv1.NodeStatus {
+		childName := fmt.Sprintf("%s(%d)", nodeName, len(getChildNodeIdsRetried(node, woc.wf.Status.Nodes)))
+		_, child := woc.initializeNode(ctx, childName, wfv1.NodeTypePod, "", &wfv1.WorkflowStep{}, "", wfv1.NodeFailed, &wfv1.NodeFlag{Retried: true}, true)
+		child.Outputs = &wfv1.Outputs{ExitCode: &exitCode}
+		child.Message = message
+		child.FinishedAt = metav1.Now()
+		woc.wf.Status.Nodes.Set(ctx, child.ID, *child)
+		woc.addChildNode(ctx, nodeName, childName)
+		n, err := woc.wf.GetNodeByName(nodeName)
+		require.NoError(t, err)
+		node = n
+		return n
+	}
+
+	n, _, err := woc.processNodeRetries(ctx, addFailedChild("75", "Error (exit code 75)"), retries, &executeTemplateOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeRunning, n.Phase)
+	assert.Empty(t, n.Message)
+	assert.Equal(t, map[string]int32{"transient": 1}, n.RetryRuleCounts)
+
+	n, _, err = woc.processNodeRetries(ctx, addFailedChild("-1", "Pod was Evicted"), retries, &executeTemplateOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeRunning, n.Phase)
+	backoff, err := parseRetryMessage(n.Message)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, backoff, 300)
+	assert.Less(t, 295, backoff)
+	assert.Equal(t, map[string]int32{"transient": 1, "evicted": 1}, n.RetryRuleCounts)
+
+	n, _, err = woc.processNodeRetries(ctx, addFailedChild("75", "Error (exit code 75)"), retries, &executeTemplateOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeRunning, n.Phase)
+	assert.Equal(t, map[string]int32{"transient": 2, "evicted": 1}, n.RetryRuleCounts)
+
+	n, _, err = woc.processNodeRetries(ctx, addFailedChild("75", "Error (exit code 75)"), retries, &executeTemplateOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeFailed, n.Phase)
+	assert.Equal(t, "No more retries left", n.Message)
+	assert.Equal(t, map[string]int32{"transient": 3, "evicted": 1}, n.RetryRuleCounts)
+
+	t.Run("NoRuleMatched", func(t *testing.T) {
+		woc.markNodePhase(ctx, nodeName, wfv1.NodeRunning)
+		n, _, err := woc.processNodeRetries(ctx, addFailedChild("1", "Error (exit code 1)"), retries, &executeTemplateOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, wfv1.NodeFailed, n.Phase)
+		assert.Equal(t, "Error (exit code 1)", n.Message)
+	})
+}
+
 func TestProcessNodeRetriesMessageOrder(t *testing.T) {
 	cancel, controller := newController(logging.TestContext(t.Context()))
 	defer cancel()
@@ -8539,6 +8608,87 @@ func TestRetryOnNodeAntiAffinity(t *testing.T) {
 	assert.Equal(t, sourceNodeSelectorRequirement, targetNodeSelectorRequirement)
 }
 
+var resourceEscalationWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: retry-oom
+spec:
+  entrypoint: retry-oom
+  templates:
+  - name: retry-oom
+    retryStrategy:
+      limit: 2
+      resourceEscalation:
+        memory:
+          factor: "1.5"
+          max: 2Gi
+    container:
+      image: my-image
+      command: [my-command]
+      resources:
+        requests:
+          memory: 1Gi
+        limits:
+          memory: 1Gi
+`
+
+func withOOMKilled() with {
+	return func(pod *apiv1.Pod, _ *wfOperationCtx) {
+		pod.Status.Message = ""
+		pod.Status.ContainerStatuses = []apiv1.ContainerStatus{{
+			Name:  common.MainContainerName,
+			State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+		}}
+	}
+}
+
+func TestRetryResourceEscalation(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(resourceEscalationWorkflow)
+	cancel, controller := newController(logging.TestContext(t.Context()), wf)
+	defer cancel()
+
+	ctx := logging.TestContext(t.Context())
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.operate(ctx)
+
+	memory := func(name string) (string, string) {
+		pods, err := listPods(ctx, woc)
+		require.NoError(t, err)
+		for _, p := range pods.Items {
+			if p.Annotations[common.AnnotationKeyNodeName] == name {
+				main := p.Spec.Containers[slices.IndexFunc(p.Spec.Containers, func(c apiv1.Container) bool { return c.Name == common.MainContainerName })]
+				return main.Resources.Requests.Memory().String(), main.Resources.Limits.Memory().String()
+			}
+		}
+		require.Failf(t, "pod not found", "no pod for node %s", name)
+		return "", ""
+	}
+	requests, limits := memory("retry-oom(0)")
+	assert.Equal(t, "1Gi", requests)
+	assert.Equal(t, "1Gi", limits)
+
+	makePodsPhase(ctx, woc, apiv1.PodFailed, withOOMKilled())
+	woc.operate(ctx)
+	node := woc.wf.Status.Nodes.FindByDisplayName("retry-oom(1)")
+	require.NotNil(t, node)
+	requests, limits = memory("retry-oom(1)")
+	assert.Equal(t, "1536Mi", requests)
+	assert.Equal(t, "1536Mi", limits)
+	assert.Equal(t, "1536Mi", node.EscalatedResources.Memory().String())
+
+	makePodsPhase(ctx, woc, apiv1.PodFailed, withOOMKilled())
+	woc.operate(ctx)
+	node = woc.wf.Status.Nodes.FindByDisplayName("retry-oom(2)")
+	require.NotNil(t, node)
+	requests, limits = memory("retry-oom(2)")
+	assert.Equal(t, "2Gi", requests)
+	assert.Equal(t, "2Gi", limits)
+	assert.Equal(t, "2Gi", node.EscalatedResources.Memory().String())
+	assert.Nil(t, woc.wf.Status.Nodes.FindByDisplayName("retry-oom(0)").EscalatedResources)
+	assert.Equal(t, apiv1.ResourceMemory, woc.wf.Status.Nodes.FindByDisplayName("retry-oom(0)").ResourceFailure)
+}
+
 var nodeAntiAffinityStepsWorkflow = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -32,6 +32,16 @@ func (c *Controller) TerminateContainers(ctx context.Context, namespace, name st
 	c.queuePodForCleanup(ctx, namespace, name, terminateContainers)
 }
 
+// TerminateMainContainer sends SIGTERM to the main container of the pod
+func (c *Controller) TerminateMainContainer(ctx context.Context, namespace, name string) {
+	c.queuePodForCleanup(ctx, namespace, name, terminateMainContainer)
+}
+
+// KillMainContainer sends SIGKILL to the main container of the pod
+func (c *Controller) KillMainContainer(ctx context.Context, namespace, name string) {
+	c.queuePodForCleanup(ctx, namespace, name, killMainContainer)
+}
+
 func (c *Controller) DeletePod(ctx context.Context, namespace, name string) {
 	c.queuePodForCleanup(ctx, namespace, name, deletePod)
 }
@@ -22,6 +22,10 @@ const (
 	terminateContainers podCleanupAction = "terminateContainers"
 	killContainers      podCleanupAction = "killContainers"
 	removeFinalizer     podCleanupAction = "removeFinalizer"
+	// terminateMainContainer and killMainContainer signal only the main container, so the wait container still
+	// saves its outputs
+	terminateMainContainer podCleanupAction = "terminateMainContainer"
+	killMainContainer      podCleanupAction = "killMainContainer"
 )
 
 func newPodCleanupKey(namespace string, podName string, action podCleanupAction) podCleanupKey {
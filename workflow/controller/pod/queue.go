@@ -71,6 +71,21 @@ func (c *Controller) signalContainers(ctx context.Context, namespace string, pod
 	return time.Duration(*pod.Spec.TerminationGracePeriodSeconds) * time.Second, nil
 }
 
+// signalContainer signals a container of a pod, if it is running
+func (c *Controller) signalContainer(ctx context.Context, namespace string, podName string, containerName string, sig syscall.Signal) error {
+	pod, err := c.GetPod(namespace, podName)
+	if pod == nil || err != nil {
+		return err
+	}
+	for _, container := range pod.Status.ContainerStatuses {
+		if container.Name == containerName && container.State.Running != nil {
+			// problems are already logged at info level, so we just ignore errors here
+			_ = signal.Container(ctx, c.restConfig, pod, container.Name, sig)
+		}
+	}
+	return nil
+}
+
 func (c *Controller) patchPodForCleanup(ctx context.Context, pods typedv1.PodInterface, namespace, podName string, labelPodCompleted bool) error {
 	pod, err := c.GetPod(namespace, podName)
 	// err is always nil in all kind of caches for now
@@ -128,6 +143,14 @@ func (c *Controller) processNextPodCleanupItem(ctx context.Context) bool {
 			if _, err := c.signalContainers(ctx, namespace, podName, syscall.SIGKILL); err != nil {
 				return err
 			}
+		case terminateMainContainer:
+			if err := c.signalContainer(ctx, namespace, podName, common.MainContainerName, syscall.SIGTERM); err != nil {
+				return err
+			}
+		case killMainContainer:
+			if err := c.signalContainer(ctx, namespace, podName, common.MainContainerName, syscall.SIGKILL); err != nil {
+				return err
+			}
 		case labelPodCompleted:
 			pods := c.kubeclientset.CoreV1().Pods(namespace)
 			if err := c.patchPodForCleanup(ctx, pods, namespace, podName, true); err != nil {
@@ -1,7 +1,12 @@
 package controller
 
 import (
+	"math"
+	"slices"
+	"strings"
+
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/env"
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
@@ -38,3 +43,102 @@ func RetryOnDifferentHost(retryNodeName string) RetryTweak {
 		}
 	}
 }
+
+// RetryEscalateResources raises the resources of the main containers of the pod for each previous attempt which failed
+// for lack of them, as recorded on its node when its pod failed, since the pod itself may have been deleted by then.
+func RetryEscalateResources(retryNodeName string, mainContainerNames []string) RetryTweak {
+	return func(retryStrategy wfv1.RetryStrategy, nodes wfv1.Nodes, pod *apiv1.Pod) {
+		escalation := retryStrategy.ResourceEscalation
+		if escalation == nil {
+			return
+		}
+		escalateResource(pod, mainContainerNames, apiv1.ResourceMemory, escalation.Memory,
+			wfretry.CountFailedPods(nodes, retryNodeName, failedForLackOf(apiv1.ResourceMemory)))
+		escalateResource(pod, mainContainerNames, apiv1.ResourceEphemeralStorage, escalation.EphemeralStorage,
+			wfretry.CountFailedPods(nodes, retryNodeName, failedForLackOf(apiv1.ResourceEphemeralStorage)))
+	}
+}
+
+func failedForLackOf(name apiv1.ResourceName) func(node wfv1.NodeStatus) bool {
+	return func(node wfv1.NodeStatus) bool {
+		return node.ResourceFailure == name
+	}
+}
+
+// podResourceFailure returns the resource the failed pod lacked: memory if a main container was terminated as
+// OOMKilled, or ephemeral-storage if the kubelet evicted the pod for it. It returns "" otherwise.
+func podResourceFailure(pod *apiv1.Pod, tmpl *wfv1.Template) apiv1.ResourceName {
+	for _, ctr := range pod.Status.ContainerStatuses {
+		if tmpl.IsMainContainerName(ctr.Name) && ctr.State.Terminated != nil && ctr.State.Terminated.Reason == "OOMKilled" {
+			return apiv1.ResourceMemory
+		}
+	}
+	// the kubelet names the resource in the message of an eviction, either exceeding a limit of the pod, such as
+	// "Pod ephemeral local storage usage exceeds the total limit of containers 1Gi.", or the node running low on it
+	if pod.Status.Reason == "Evicted" &&
+		(strings.Contains(pod.Status.Message, "ephemeral local storage") || strings.Contains(pod.Status.Message, string(apiv1.ResourceEphemeralStorage))) {
+		return apiv1.ResourceEphemeralStorage
+	}
+	return ""
+}
+
+func escalateResource(pod *apiv1.Pod, mainContainerNames []string, name apiv1.ResourceName, policy *wfv1.ResourceEscalationPolicy, failures int) {
+	if policy == nil || failures == 0 {
+		return
+	}
+	factor, err := policy.GetFactor()
+	if err != nil {
+		return
+	}
+	multiplier := math.Pow(factor, float64(failures))
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if !slices.Contains(mainContainerNames, c.Name) {
+			continue
+		}
+		for _, resources := range []apiv1.ResourceList{c.Resources.Requests, c.Resources.Limits} {
+			if q, ok := resources[name]; ok {
+				resources[name] = escalateQuantity(q, multiplier, policy.Max)
+			}
+		}
+	}
+}
+
+// escalateQuantity multiplies the quantity, up to the max unless it already exceeds it
+func escalateQuantity(q resource.Quantity, multiplier float64, maxQuantity *resource.Quantity) resource.Quantity {
+	escalated := resource.NewQuantity(int64(math.Ceil(q.AsApproximateFloat64()*multiplier)), q.Format)
+	if maxQuantity != nil && escalated.Cmp(*maxQuantity) > 0 {
+		if q.Cmp(*maxQuantity) >= 0 {
+			return q
+		}
+		return maxQuantity.DeepCopy()
+	}
+	return *escalated
+}
+
+// escalatedRequests sums the requests of the escalated resources over the main containers of the pod, defaulting
+// them to the limits as Kubernetes does
+func escalatedRequests(pod *apiv1.Pod, mainContainerNames []string) apiv1.ResourceList {
+	requests := apiv1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		if !slices.Contains(mainContainerNames, c.Name) {
+			continue
+		}
+		for _, name := range []apiv1.ResourceName{apiv1.ResourceMemory, apiv1.ResourceEphemeralStorage} {
+			q, ok := c.Resources.Requests[name]
+			if !ok {
+				q, ok = c.Resources.Limits[name]
+			}
+			if !ok {
+				continue
+			}
+			if total, ok := requests[name]; ok {
+				total.Add(q)
+				requests[name] = total
+			} else {
+				requests[name] = q.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
@@ -4,8 +4,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
 )
 
 func TestFindRetryNode(t *testing.T) {
@@ -93,3 +96,71 @@ func TestFindRetryNode(t *testing.T) {
 		assert.Equal(t, FindRetryNode(allNodes, "C2"), &node)
 	})
 }
+
+func TestRetryEscalateResources(t *testing.T) {
+	nodes := wfv1.Nodes{
+		"retry": {ID: "retry", Type: wfv1.NodeTypeRetry, Phase: wfv1.NodeRunning, Children: []string{"n0", "n1", "n2"}},
+		"n0":    {ID: "n0", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, ResourceFailure: apiv1.ResourceEphemeralStorage},
+		"n1":    {ID: "n1", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, ResourceFailure: apiv1.ResourceEphemeralStorage},
+		// the message alone does not make it a failure for lack of memory
+		"n2": {ID: "n2", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, Message: "Error (exit code 1): OOMKilled"},
+	}
+	newPod := func() *apiv1.Pod {
+		return &apiv1.Pod{Spec: apiv1.PodSpec{Containers: []apiv1.Container{
+			{Name: common.WaitContainerName, Resources: apiv1.ResourceRequirements{Requests: apiv1.ResourceList{apiv1.ResourceEphemeralStorage: resource.MustParse("1Gi")}}},
+			{Name: common.MainContainerName, Resources: apiv1.ResourceRequirements{
+				Requests: apiv1.ResourceList{apiv1.ResourceEphemeralStorage: resource.MustParse("1Gi"), apiv1.ResourceMemory: resource.MustParse("1Gi")},
+			}},
+		}}}
+	}
+	strategy := wfv1.RetryStrategy{ResourceEscalation: &wfv1.ResourceEscalation{
+		Memory:           &wfv1.ResourceEscalationPolicy{Factor: "2"},
+		EphemeralStorage: &wfv1.ResourceEscalationPolicy{Factor: "2", Max: new(resource.MustParse("3Gi"))},
+	}}
+
+	t.Run("Escalated", func(t *testing.T) {
+		pod := newPod()
+		RetryEscalateResources("retry", []string{common.MainContainerName})(strategy, nodes, pod)
+		assert.Equal(t, "1Gi", pod.Spec.Containers[0].Resources.Requests.StorageEphemeral().String())
+		assert.Equal(t, "3Gi", pod.Spec.Containers[1].Resources.Requests.StorageEphemeral().String())
+		assert.Equal(t, "1Gi", pod.Spec.Containers[1].Resources.Requests.Memory().String())
+		requests := escalatedRequests(pod, []string{common.MainContainerName})
+		assert.Equal(t, "3Gi", requests.StorageEphemeral().String())
+	})
+	t.Run("NoEscalation", func(t *testing.T) {
+		pod := newPod()
+		RetryEscalateResources("retry", []string{common.MainContainerName})(wfv1.RetryStrategy{}, nodes, pod)
+		assert.Equal(t, "1Gi", pod.Spec.Containers[1].Resources.Requests.StorageEphemeral().String())
+	})
+	t.Run("AboveMax", func(t *testing.T) {
+		pod := newPod()
+		pod.Spec.Containers[1].Resources.Requests[apiv1.ResourceEphemeralStorage] = resource.MustParse("4Gi")
+		RetryEscalateResources("retry", []string{common.MainContainerName})(strategy, nodes, pod)
+		assert.Equal(t, "4Gi", pod.Spec.Containers[1].Resources.Requests.StorageEphemeral().String())
+	})
+}
+
+func TestPodResourceFailure(t *testing.T) {
+	tmpl := &wfv1.Template{Container: &apiv1.Container{}}
+	terminated := func(name, reason string) apiv1.ContainerStatus {
+		return apiv1.ContainerStatus{Name: name, State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: reason, ExitCode: 137}}}
+	}
+	tests := []struct {
+		name     string
+		status   apiv1.PodStatus
+		expected apiv1.ResourceName
+	}{
+		{"OOMKilled", apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{terminated(common.MainContainerName, "OOMKilled")}}, apiv1.ResourceMemory},
+		{"SidecarOOMKilled", apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{terminated("sidecar", "OOMKilled")}}, ""},
+		{"Error", apiv1.PodStatus{Message: "OOMKilled", ContainerStatuses: []apiv1.ContainerStatus{terminated(common.MainContainerName, "Error")}}, ""},
+		{"EvictedForLimit", apiv1.PodStatus{Reason: "Evicted", Message: "Pod ephemeral local storage usage exceeds the total limit of containers 1Gi."}, apiv1.ResourceEphemeralStorage},
+		{"EvictedForNode", apiv1.PodStatus{Reason: "Evicted", Message: "The node was low on resource: ephemeral-storage."}, apiv1.ResourceEphemeralStorage},
+		{"EvictedForMemory", apiv1.PodStatus{Reason: "Evicted", Message: "The node was low on resource: memory."}, ""},
+		{"NotEvicted", apiv1.PodStatus{Message: "The node was low on resource: ephemeral-storage."}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, podResourceFailure(&apiv1.Pod{Status: tt.status}, tmpl))
+		})
+	}
+}
@@ -145,6 +145,23 @@ func (woc *wfOperationCtx) addSkippedNodeOutputsToScope(ctx context.Context, tmp
 			ref.OutputsArtifactByName.Set(scope.scope, wfv1.Artifact{}, name, artifact.Name)
 		}
 	}
+	for _, ctr := range tmpl.ContainerSet.GetGraph() {
+		if ctr.Outputs == nil {
+			continue
+		}
+		for _, param := range ctr.Outputs.Parameters {
+			if param.ValueFrom != nil && param.ValueFrom.Default != nil {
+				ref.ContainerOutputsParameterByName.Set(scope.scope, param.ValueFrom.Default.String(), name, ctr.Name, param.Name)
+			} else {
+				ref.ContainerOutputsParameterByName.SetSkipped(scope.scope, nil, name, ctr.Name, param.Name)
+			}
+		}
+		if includeArtifacts {
+			for _, artifact := range ctr.Outputs.Artifacts {
+				ref.ContainerOutputsArtifactByName.Set(scope.scope, wfv1.Artifact{}, name, ctr.Name, artifact.Name)
+			}
+		}
+	}
 	if tmpl.Outputs.Result != nil {
 		ref.OutputsResult.SetSkipped(scope.scope, nil, name)
 	}
@@ -247,6 +264,8 @@ func (s *wfScope) resolveArtifact(ctx context.Context, art *wfv1.Artifact) (*wfv
 			return copyArt, errors.New(errors.CodeBadRequest, "failed to unmarshal artifact subpath for templating")
 		}
 
+		// the digest is of the whole artifact, not of the object at its subpath
+		copyArt.Digest = ""
 		err = copyArt.AppendToKey(resolvedSubPath)
 		if err != nil && copyArt.Optional { // Ignore error when artifact optional
 			return copyArt, nil
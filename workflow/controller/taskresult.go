@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"time"
 
@@ -134,6 +135,9 @@ func (woc *wfOperationCtx) taskResultReconciliation(ctx context.Context) {
 		if result.Progress.IsValid() {
 			newNode.Progress = result.Progress
 		}
+		for name, outputs := range result.ContainerOutputs {
+			woc.setContainerOutputs(ctx, old.Name, name, outputs)
+		}
 		if !reflect.DeepEqual(old, newNode) {
 			woc.log.
 				WithField("nodeID", nodeID).
@@ -144,3 +148,17 @@ func (woc *wfOperationCtx) taskResultReconciliation(ctx context.Context) {
 		span.End()
 	}
 }
+
+// setContainerOutputs records the outputs of the container of a container set on the node of the container
+func (woc *wfOperationCtx) setContainerOutputs(ctx context.Context, nodeName, containerName string, outputs wfv1.Outputs) {
+	ctrNode, err := woc.wf.GetNodeByName(fmt.Sprintf("%s.%s", nodeName, containerName))
+	if err != nil {
+		return
+	}
+	if ctrNode.Outputs != nil && reflect.DeepEqual(*ctrNode.Outputs, outputs) {
+		return
+	}
+	ctrNode.Outputs = outputs.DeepCopy()
+	woc.wf.Status.Nodes.Set(ctx, ctrNode.ID, *ctrNode)
+	woc.updated = true
+}
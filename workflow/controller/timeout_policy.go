@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+// softTimeoutMessage is the message of nodes which completed with the result of their timeout policy
+const softTimeoutMessage = "Soft timeout exceeded"
+
+// applyTimeoutPolicy acts on the soft timeout of the timeout policy of the pod node passing. It records the soft
+// timeout on the node, which runs its hook, and if the policy terminates the node, sends SIGTERM to the main
+// container, and SIGKILL once the grace period has passed. A node which is still pending is not started at all.
+func (woc *wfOperationCtx) applyTimeoutPolicy(ctx context.Context, tmpl *wfv1.Template, node *wfv1.NodeStatus) (*wfv1.NodeStatus, error) {
+	policy := tmpl.TimeoutPolicy
+	if policy == nil || node.Type != wfv1.NodeTypePod || node.Fulfilled() || node.StartedAt.IsZero() {
+		return node, nil
+	}
+	softTimeout, err := policy.GetSoftTimeout()
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeoutPolicy.softTimeout: %w", err)
+	}
+	gracePeriod, err := policy.GetGracePeriod()
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeoutPolicy.gracePeriod: %w", err)
+	}
+	now := time.Now().UTC()
+	log := woc.log.WithFields(logging.Fields{"nodeName": node.Name, "softTimeout": policy.SoftTimeout})
+	if node.SoftTimeout == nil {
+		softDeadline := node.StartedAt.Add(softTimeout)
+		if now.Before(softDeadline) {
+			woc.requeueAfter(softDeadline.Sub(now))
+			return node, nil
+		}
+		log.Info(ctx, "Node exceeded its soft timeout")
+		node.SoftTimeout = &wfv1.NodeSoftTimeout{At: metav1.NewTime(now), Hook: policy.Hook, Result: policy.GetResult()}
+		woc.wf.Status.Nodes.Set(ctx, node.ID, *node)
+		woc.updated = true
+		woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowNodeSoftTimeout", fmt.Sprintf("Node %s exceeded its soft timeout of %s", node.Name, policy.SoftTimeout))
+		if policy.Terminate {
+			if node.Phase == wfv1.NodePending {
+				log.Info(ctx, "Deleting pending pod which exceeded its soft timeout")
+				woc.deleteNodePod(ctx, node)
+				phase := wfv1.NodeFailed
+				if node.SoftTimeout.Result == wfv1.TimeoutResultSucceeded {
+					phase = wfv1.NodeSucceeded
+				}
+				return woc.markNodePhase(ctx, node.Name, phase, softTimeoutMessage), nil
+			}
+			woc.signalMainContainer(ctx, node, false)
+		}
+	}
+	if !policy.Terminate {
+		return node, nil
+	}
+	killAt := node.SoftTimeout.At.Add(gracePeriod)
+	if now.Before(killAt) {
+		woc.requeueAfter(killAt.Sub(now))
+		return node, nil
+	}
+	log.Info(ctx, "Killing main container which did not exit within its grace period")
+	woc.signalMainContainer(ctx, node, true)
+	return node, nil
+}
+
+// signalMainContainer sends SIGTERM, or SIGKILL, to the main container of the pod of the node
+func (woc *wfOperationCtx) signalMainContainer(ctx context.Context, node *wfv1.NodeStatus, kill bool) {
+	pod, exists, err := woc.podExists(node.ID)
+	if err != nil {
+		woc.log.WithError(err).WithField("nodeName", node.Name).Warn(ctx, "failed to get the pod of the node")
+		return
+	}
+	if !exists {
+		return
+	}
+	if kill {
+		woc.controller.PodController.KillMainContainer(ctx, pod.Namespace, pod.Name)
+	} else {
+		woc.controller.PodController.TerminateMainContainer(ctx, pod.Namespace, pod.Name)
+	}
+}
+
+// applySoftTimeoutResult sets the phase of the node, which completed after its soft timeout, to the result of its
+// timeout policy
+func applySoftTimeoutResult(node *wfv1.NodeStatus) {
+	if node.SoftTimeout == nil || !node.Phase.Completed() {
+		return
+	}
+	var phase wfv1.NodePhase
+	switch node.SoftTimeout.Result {
+	case wfv1.TimeoutResultSucceeded:
+		phase = wfv1.NodeSucceeded
+	case wfv1.TimeoutResultFailed:
+		phase = wfv1.NodeFailed
+	default:
+		return
+	}
+	if node.Phase != phase {
+		node.Phase = phase
+		node.Message = softTimeoutMessage
+	}
+}
+
+// softTimeoutHook returns the name of the lifecycle hook which the soft timeout of the node, or of its last retry,
+// runs, if it passed
+func (woc *wfOperationCtx) softTimeoutHook(node *wfv1.NodeStatus) string {
+	if lastChildNode := woc.possiblyGetRetryChildNode(node); lastChildNode != nil {
+		node = lastChildNode
+	}
+	if node.SoftTimeout == nil {
+		return ""
+	}
+	return node.SoftTimeout.Hook
+}
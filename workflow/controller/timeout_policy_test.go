@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+func TestApplySoftTimeoutResult(t *testing.T) {
+	tests := []struct {
+		name        string
+		softTimeout *wfv1.NodeSoftTimeout
+		phase       wfv1.NodePhase
+		wantPhase   wfv1.NodePhase
+		wantMessage string
+	}{
+		{"NoSoftTimeout", nil, wfv1.NodeFailed, wfv1.NodeFailed, "Error (exit code 143)"},
+		{"Running", &wfv1.NodeSoftTimeout{Result: wfv1.TimeoutResultSucceeded}, wfv1.NodeRunning, wfv1.NodeRunning, "Error (exit code 143)"},
+		{"Continue", &wfv1.NodeSoftTimeout{Result: wfv1.TimeoutResultContinue}, wfv1.NodeFailed, wfv1.NodeFailed, "Error (exit code 143)"},
+		{"Succeeded", &wfv1.NodeSoftTimeout{Result: wfv1.TimeoutResultSucceeded}, wfv1.NodeFailed, wfv1.NodeSucceeded, softTimeoutMessage},
+		{"Failed", &wfv1.NodeSoftTimeout{Result: wfv1.TimeoutResultFailed}, wfv1.NodeSucceeded, wfv1.NodeFailed, softTimeoutMessage},
+		{"AlreadyFailed", &wfv1.NodeSoftTimeout{Result: wfv1.TimeoutResultFailed}, wfv1.NodeFailed, wfv1.NodeFailed, "Error (exit code 143)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &wfv1.NodeStatus{Phase: tt.phase, Message: "Error (exit code 143)", SoftTimeout: tt.softTimeout}
+			applySoftTimeoutResult(node)
+			assert.Equal(t, tt.wantPhase, node.Phase)
+			assert.Equal(t, tt.wantMessage, node.Message)
+		})
+	}
+}
+
+var timeoutPolicyWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: timeout-policy
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      steps:
+        - - name: train
+            template: train
+            hooks:
+              checkpoint:
+                template: checkpoint
+    - name: train
+      timeoutPolicy:
+        softTimeout: 1m
+        hook: checkpoint
+        terminate: true
+        result: Succeeded
+      container:
+        image: alpine:latest
+        command: [sh, -c]
+        args: ["sleep 1h"]
+    - name: checkpoint
+      container:
+        image: alpine:latest
+        command: [sh, -c]
+        args: ["sleep 1h"]
+`
+
+func TestTimeoutPolicy(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(timeoutPolicyWorkflow)
+	cancel, controller := newController(logging.TestContext(t.Context()), wf)
+	defer cancel()
+
+	ctx := logging.TestContext(t.Context())
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodRunning)
+	woc.operate(ctx)
+
+	node := woc.wf.Status.Nodes.FindByDisplayName("train")
+	require.NotNil(t, node)
+	assert.Equal(t, wfv1.NodeRunning, node.Phase)
+	assert.Nil(t, node.SoftTimeout)
+	assert.Nil(t, woc.wf.Status.Nodes.FindByDisplayName("train.hooks.checkpoint"))
+
+	// the node has run for longer than its soft timeout
+	node.StartedAt = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	woc.wf.Status.Nodes.Set(ctx, node.ID, *node)
+	woc = newWorkflowOperationCtx(ctx, woc.wf, controller)
+	woc.operate(ctx)
+
+	node = woc.wf.Status.Nodes.FindByDisplayName("train")
+	require.NotNil(t, node.SoftTimeout)
+	assert.Equal(t, "checkpoint", node.SoftTimeout.Hook)
+	assert.Equal(t, wfv1.TimeoutResultSucceeded, node.SoftTimeout.Result)
+	assert.NotNil(t, woc.wf.Status.Nodes.FindByDisplayName("train.hooks.checkpoint"))
+
+	// the main container exits on SIGTERM, which the policy counts as success
+	makePodsPhase(ctx, woc, apiv1.PodFailed, withExitCode(143))
+	woc = newWorkflowOperationCtx(ctx, woc.wf, controller)
+	woc.operate(ctx)
+
+	node = woc.wf.Status.Nodes.FindByDisplayName("train")
+	assert.Equal(t, wfv1.NodeSucceeded, node.Phase)
+	assert.Equal(t, softTimeoutMessage, node.Message)
+}
+
+func TestTimeoutPolicyPending(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(timeoutPolicyWorkflow)
+	cancel, controller := newController(logging.TestContext(t.Context()), wf)
+	defer cancel()
+
+	ctx := logging.TestContext(t.Context())
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.operate(ctx)
+
+	node := woc.wf.Status.Nodes.FindByDisplayName("train")
+	require.NotNil(t, node)
+	assert.Equal(t, wfv1.NodePending, node.Phase)
+	node.StartedAt = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	woc.wf.Status.Nodes.Set(ctx, node.ID, *node)
+	woc = newWorkflowOperationCtx(ctx, woc.wf, controller)
+	woc.operate(ctx)
+
+	node = woc.wf.Status.Nodes.FindByDisplayName("train")
+	assert.Equal(t, wfv1.NodeSucceeded, node.Phase)
+	assert.Equal(t, softTimeoutMessage, node.Message)
+	require.NotNil(t, node.SoftTimeout)
+}
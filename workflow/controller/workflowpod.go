@@ -15,6 +15,7 @@ import (
 
 	"go.opentelemetry.io/otel/propagation"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
@@ -73,22 +74,38 @@ const legacyArgoexecBinaryPath = common.LegacyArgoExecBinPath
 // (inputArtifactExecutorMounts).
 const inputArtifactsVolumeName = "input-artifacts"
 
-// scheduleOnDifferentHost adds affinity to prevent retry on the same host when
-// retryStrategy.affinity.nodeAntiAffinity{} is specified
-func (pb *podBuilder) scheduleOnDifferentHost(ctx context.Context, node *wfv1.NodeStatus, tmpl *wfv1.Template, pod *apiv1.Pod) error {
-	if node != nil && pod != nil {
-		if retryNode := pb.deps.findRetryNode(node.ID); retryNode != nil {
-			// recover template for the retry node
-			retryTmpl, err := pb.deps.retryNodeTemplate(ctx, retryNode, tmpl)
-			if err != nil {
-				return err
-			}
-			if retryStrategy := pb.deps.retryStrategyForTemplate(retryTmpl); retryStrategy != nil {
-				pb.deps.applyRetryOnDifferentHost(retryNode.ID, *retryStrategy, pod)
-			}
-		}
+// applyRetryTweaks adds affinity to prevent retry on the same host when
+// retryStrategy.affinity.nodeAntiAffinity{} is specified, and raises the
+// resources of the retry when retryStrategy.resourceEscalation is. It returns
+// the requests of the escalated resources, if they were raised.
+func (pb *podBuilder) applyRetryTweaks(ctx context.Context, node *wfv1.NodeStatus, tmpl *wfv1.Template, pod *apiv1.Pod) (apiv1.ResourceList, error) {
+	if node == nil || pod == nil {
+		return nil, nil
 	}
-	return nil
+	retryNode := pb.deps.findRetryNode(node.ID)
+	if retryNode == nil {
+		return nil, nil
+	}
+	// recover template for the retry node
+	retryTmpl, err := pb.deps.retryNodeTemplate(ctx, retryNode, tmpl)
+	if err != nil {
+		return nil, err
+	}
+	retryStrategy := pb.deps.retryStrategyForTemplate(retryTmpl)
+	if retryStrategy == nil {
+		return nil, nil
+	}
+	pb.deps.applyRetryOnDifferentHost(retryNode.ID, *retryStrategy, pod)
+	if retryStrategy.ResourceEscalation == nil {
+		return nil, nil
+	}
+	mainContainerNames := tmpl.GetMainContainerNames()
+	requests := escalatedRequests(pod, mainContainerNames)
+	pb.deps.applyResourceEscalation(retryNode.ID, mainContainerNames, *retryStrategy, pod)
+	if escalated := escalatedRequests(pod, mainContainerNames); !equality.Semantic.DeepEqual(requests, escalated) {
+		return escalated, nil
+	}
+	return nil, nil
 }
 
 type createWorkflowPodOpts struct {
@@ -218,6 +235,7 @@ type podBuilderDeps interface {
 	retryStrategyForTemplate(tmpl *wfv1.Template) *wfv1.RetryStrategy
 	retryNodeTemplate(ctx context.Context, retryNode *wfv1.NodeStatus, fallback *wfv1.Template) (*wfv1.Template, error)
 	applyRetryOnDifferentHost(retryNodeID string, retryStrategy wfv1.RetryStrategy, pod *apiv1.Pod)
+	applyResourceEscalation(retryNodeID string, mainContainerNames []string, retryStrategy wfv1.RetryStrategy, pod *apiv1.Pod)
 	checkTemplateTimeouts(tmpl *wfv1.Template, node *wfv1.NodeStatus, now time.Time) (deadline, pendingDeadline *time.Time, err error)
 	getServiceAccountTokenName(ctx context.Context, name string) (string, error)
 	getPodGCDelay(ctx context.Context, podGC *wfv1.PodGC) time.Duration
@@ -338,6 +356,10 @@ type podBuildResult struct {
 	// metadata that submitPod writes onto the node status after a successful
 	// pod create.
 	ProgressToApply *wfv1.Progress
+	// EscalatedResourcesToApply, when non-nil, are the requests of the resources
+	// raised by the resource escalation of the retry strategy, which submitPod
+	// writes onto the node status after a successful pod create.
+	EscalatedResourcesToApply apiv1.ResourceList
 }
 
 func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName string, mainCtrs []apiv1.Container, tmpl *wfv1.Template, opts *createWorkflowPodOpts) (*apiv1.Pod, error) {
@@ -519,7 +541,7 @@ func (pb *podBuilder) build(ctx context.Context) (*podBuildResult, error) {
 		pod.Labels[common.LabelKeyControllerInstanceID] = pb.in.instanceID
 	}
 
-	pb.addArchiveLocation(ctx, tmpl)
+	archiveToRepository := pb.addArchiveLocation(ctx, tmpl)
 
 	// Populate plugin artifact connection timeouts for all input and output artifacts
 	pb.populateAllPluginArtifactTimeouts(tmpl)
@@ -637,6 +659,14 @@ func (pb *podBuilder) build(ctx context.Context) (*podBuildResult, error) {
 		{Name: common.EnvVarDeadline, Value: pb.getDeadline(opts).Format(time.RFC3339)},
 		{Name: common.EnvVarWorkflowName, Value: pb.in.wfName},
 	}
+	if slices.ContainsFunc(tmpl.GetOutputArtifacts(), func(art wfv1.Artifact) bool { return art.Deduplicate }) {
+		if prefix := pb.in.artifactRepository.KeyPrefix(); prefix != "" {
+			envVars = append(envVars, apiv1.EnvVar{Name: common.EnvVarArtifactKeyPrefix, Value: prefix})
+		}
+	}
+	if fallbacks := pb.in.artifactRepository.FallbackLocations(); archiveToRepository && len(fallbacks) > 0 {
+		envVars = append(envVars, apiv1.EnvVar{Name: common.EnvVarArtifactFallbacks, Value: wfv1.MustMarshallJSON(fallbacks)})
+	}
 
 	carrier := telemetry.Carrier{SetEnvFunc: func(key, value string) {
 		envVars = append(envVars, apiv1.EnvVar{Name: key, Value: value})
@@ -772,7 +802,7 @@ func (pb *podBuilder) build(ctx context.Context) (*podBuildResult, error) {
 			})
 		}
 		// Shared tmp subpath for shared output directory
-		outputArtifactPlugins := len(tmpl.Outputs.Artifacts.GetPluginNames(ctx, pb.in.artifactRepository, wfv1.IncludeLogs, tmpl.ArchiveLocation)) > 0
+		outputArtifactPlugins := len(tmpl.GetOutputArtifacts().GetPluginNames(ctx, pb.in.artifactRepository, wfv1.IncludeLogs, tmpl.ArchiveLocation)) > 0
 		if outputArtifactPlugins && common.IsArgoSidecar(c.Name) {
 			c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
 				Name:      volumeTmpDir.Name,
@@ -935,9 +965,11 @@ func (pb *podBuilder) build(ctx context.Context) (*podBuildResult, error) {
 		return nil, err
 	}
 
-	if scheduleErr := pb.scheduleOnDifferentHost(ctx, node, tmpl, pod); scheduleErr != nil {
-		return nil, scheduleErr
+	escalatedResources, retryErr := pb.applyRetryTweaks(ctx, node, tmpl, pod)
+	if retryErr != nil {
+		return nil, retryErr
 	}
+	result.EscalatedResourcesToApply = escalatedResources
 
 	if templateDeadline != nil && (pod.Spec.ActiveDeadlineSeconds == nil || pb.in.now.Sub(*templateDeadline).Seconds() < float64(*pod.Spec.ActiveDeadlineSeconds)) {
 		newActiveDeadlineSeconds := int64(templateDeadline.Sub(pb.in.now).Seconds())
@@ -980,6 +1012,20 @@ func (woc *wfOperationCtx) podExists(nodeID string) (existing *apiv1.Pod, exists
 	return nil, false, nil
 }
 
+// deleteNodePod deletes the pod of a pod node which is being failed before it completes, so that
+// the resources it holds or is waiting for are freed. Deletion is by UID, so a pod recreated by a
+// retry cannot be affected.
+func (woc *wfOperationCtx) deleteNodePod(ctx context.Context, node *wfv1.NodeStatus) {
+	if node == nil || node.Type != wfv1.NodeTypePod {
+		return
+	}
+	if pod, exists, err := woc.podExists(node.ID); err != nil {
+		woc.log.WithError(err).Warn(ctx, "failed to check pod existence while cleaning up failed node")
+	} else if exists {
+		woc.controller.PodController.DeletePodByUID(ctx, pod.Namespace, pod.Name, string(pod.UID))
+	}
+}
+
 func (pb *podBuilder) getDeadline(opts *createWorkflowPodOpts) *time.Time {
 	deadline := time.Time{}
 	if pb.in.workflowDeadline != nil && !opts.onExitPod {
@@ -1161,7 +1207,7 @@ func argoBinVolumeMount() apiv1.VolumeMount {
 // both inputs and outputs produces exactly one container.
 func (woc *wfOperationCtx) buildPluginSidecars(ctx context.Context, tmpl *wfv1.Template) ([]apiv1.Container, []wfv1.ArtifactPluginName, error) {
 	inputPlugins := tmpl.Inputs.Artifacts.GetPluginNames(ctx, woc.artifactRepository, wfv1.ExcludeLogs, tmpl.ArchiveLocation)
-	outputPlugins := tmpl.Outputs.Artifacts.GetPluginNames(ctx, woc.artifactRepository, wfv1.IncludeLogs, tmpl.ArchiveLocation)
+	outputPlugins := tmpl.GetOutputArtifacts().GetPluginNames(ctx, woc.artifactRepository, wfv1.IncludeLogs, tmpl.ArchiveLocation)
 
 	seen := make(map[wfv1.ArtifactPluginName]bool, len(inputPlugins)+len(outputPlugins))
 	unionOrdered := make([]wfv1.ArtifactPluginName, 0, len(inputPlugins)+len(outputPlugins))
@@ -1664,7 +1710,7 @@ func (woc *wfOperationCtx) addInputArtifactsVolumes(ctx context.Context, pod *ap
 
 func (woc *wfOperationCtx) createArtifactVolumeMounts(ctx context.Context, tmpl *wfv1.Template) []apiv1.Volume {
 	artifactVolumeMounts := []apiv1.Volume{}
-	plugins := tmpl.Outputs.Artifacts.GetPluginNames(ctx, woc.artifactRepository, wfv1.IncludeLogs, tmpl.ArchiveLocation)
+	plugins := tmpl.GetOutputArtifacts().GetPluginNames(ctx, woc.artifactRepository, wfv1.IncludeLogs, tmpl.ArchiveLocation)
 
 	for _, plugin := range plugins {
 		artifactVolumeMounts = append(artifactVolumeMounts, plugin.Volume())
@@ -1728,15 +1774,15 @@ func addOutputArtifactsVolumes(pod *apiv1.Pod, tmpl *wfv1.Template) {
 // addArchiveLocation conditionally updates the template with the default artifact repository
 // information configured in the controller, for the purposes of archiving outputs. This is skipped
 // for templates which do not need to archive anything, or have explicitly set an archive location
-// in the template.
-func (pb *podBuilder) addArchiveLocation(ctx context.Context, tmpl *wfv1.Template) {
+// in the template. It returns whether the archive location is the artifact repository.
+func (pb *podBuilder) addArchiveLocation(ctx context.Context, tmpl *wfv1.Template) bool {
 	if tmpl.ArchiveLocation.HasLocation() {
 		// User explicitly set the location. nothing else to do.
-		return
+		return false
 	}
 	archiveLogs := pb.deps.IsArchiveLogs(tmpl)
 	needLocation := archiveLogs
-	artifacts := slices.Concat(tmpl.Inputs.Artifacts, tmpl.Outputs.Artifacts)
+	artifacts := slices.Concat(tmpl.Inputs.Artifacts, tmpl.GetOutputArtifacts())
 	if tmpl.Data != nil {
 		if art, exist := tmpl.Data.Source.GetArtifactIfNeeded(); exist {
 			artifacts = append(artifacts, *art)
@@ -1749,10 +1795,11 @@ func (pb *podBuilder) addArchiveLocation(ctx context.Context, tmpl *wfv1.Templat
 	}
 	logging.RequireLoggerFromContext(ctx).WithField("needLocation", needLocation).Debug(ctx, "addArchiveLocation")
 	if !needLocation {
-		return
+		return false
 	}
 	tmpl.ArchiveLocation = pb.in.artifactRepository.ToArtifactLocation()
 	tmpl.ArchiveLocation.ArchiveLogs = &archiveLogs
+	return true
 }
 
 // populateAllPluginArtifactTimeouts populates connection timeouts for all plugin artifacts in the template
@@ -1780,6 +1827,16 @@ func (pb *podBuilder) populateAllPluginArtifactTimeouts(tmpl *wfv1.Template) {
 	for i := range tmpl.Outputs.Artifacts {
 		populateTimeout(tmpl.Outputs.Artifacts[i].Plugin)
 	}
+
+	// Populate timeouts for the output artifacts of the containers of a container set
+	for _, ctr := range tmpl.ContainerSet.GetGraph() {
+		if ctr.Outputs == nil {
+			continue
+		}
+		for i := range ctr.Outputs.Artifacts {
+			populateTimeout(ctr.Outputs.Artifacts[i].Plugin)
+		}
+	}
 }
 
 // IsArchiveLogs determines if container should archive logs
@@ -1928,7 +1985,7 @@ func (pb *podBuilder) addSidecars(ctx context.Context, pod *apiv1.Pod, tmpl *wfv
 // legacy layout. Layout dispatch happens in podLayout.wireArtifactPlugins, so
 // this must not re-check the init-less flag itself.
 func (woc *wfOperationCtx) addArtifactPluginsLegacy(ctx context.Context, pod *apiv1.Pod, tmpl *wfv1.Template, config *config.Config) error {
-	plugins := tmpl.Outputs.Artifacts.GetPluginNames(ctx, woc.artifactRepository, wfv1.IncludeLogs, tmpl.ArchiveLocation)
+	plugins := tmpl.GetOutputArtifacts().GetPluginNames(ctx, woc.artifactRepository, wfv1.IncludeLogs, tmpl.ArchiveLocation)
 	drivers, err := config.GetArtifactDrivers(plugins)
 	if err != nil {
 		return err
@@ -2112,7 +2169,7 @@ func createSecretVolumesAndMounts(tmpl *wfv1.Template) ([]apiv1.Volume, []apiv1.
 
 	createArchiveLocationSecret(tmpl, allVolumesMap, uniqueKeyMap)
 
-	for _, art := range tmpl.Outputs.Artifacts {
+	for _, art := range tmpl.GetOutputArtifacts() {
 		createSecretVolume(allVolumesMap, art, uniqueKeyMap)
 	}
 	for _, art := range tmpl.Inputs.Artifacts {
@@ -132,6 +132,8 @@ func (s *stubPodBuilderDeps) retryNodeTemplate(_ context.Context, _ *wfv1.NodeSt
 }
 func (s *stubPodBuilderDeps) applyRetryOnDifferentHost(_ string, _ wfv1.RetryStrategy, _ *apiv1.Pod) {
 }
+func (s *stubPodBuilderDeps) applyResourceEscalation(_ string, _ []string, _ wfv1.RetryStrategy, _ *apiv1.Pod) {
+}
 func (s *stubPodBuilderDeps) checkTemplateTimeouts(_ *wfv1.Template, _ *wfv1.NodeStatus, now time.Time) (deadline, pendingDeadline *time.Time, err error) {
 	s.gotTimeoutNow = now
 	return nil, nil, nil
@@ -18,7 +18,7 @@ import (
 // controller behaviour so the pure builder never holds a concrete
 // *wfOperationCtx / *WorkflowController reference. The side-effecting wrappers
 // here (createConfigMap, createPod, reserveRateLimiter, incrementActivePods,
-// setNodeProgress, markNodeFailedOnShutdown, getPod, startCreateWorkflowPodSpan)
+// setNodeProgress, setNodeEscalatedResources, markNodeFailedOnShutdown, getPod, startCreateWorkflowPodSpan)
 // are NOT part of podBuilderDeps; they are called by the submit core
 // (woc.createPodFromBuild / woc.submitPod, workflowpod_submit.go), by the
 // dispatch layer woc.createWorkflowPod, and by the agent path
@@ -73,6 +73,12 @@ func (woc *wfOperationCtx) applyRetryOnDifferentHost(retryNodeID string, retrySt
 	RetryOnDifferentHost(retryNodeID)(retryStrategy, woc.wf.Status.Nodes, pod)
 }
 
+// applyResourceEscalation applies the resource escalation tweak using live
+// workflow status.
+func (woc *wfOperationCtx) applyResourceEscalation(retryNodeID string, mainContainerNames []string, retryStrategy wfv1.RetryStrategy, pod *apiv1.Pod) {
+	RetryEscalateResources(retryNodeID, mainContainerNames)(retryStrategy, woc.wf.Status.Nodes, pod)
+}
+
 // persistentVolumeClaims returns the workflow's live PVC volume references.
 func (woc *wfOperationCtx) persistentVolumeClaims() []apiv1.Volume {
 	return woc.wf.Status.PersistentVolumeClaims
@@ -101,6 +107,20 @@ func (woc *wfOperationCtx) setNodeProgress(ctx context.Context, nodeID string, p
 	woc.wf.Status.Nodes.Set(ctx, nodeID, *node)
 }
 
+// setNodeEscalatedResources records the requests of the resources raised by
+// the resource escalation of the retry strategy onto the node status. Side
+// effect — called by woc.submitPod.
+func (woc *wfOperationCtx) setNodeEscalatedResources(ctx context.Context, nodeID string, resources apiv1.ResourceList) {
+	node, getNodeErr := woc.wf.Status.Nodes.Get(nodeID)
+	if getNodeErr != nil {
+		// As for setNodeProgress, the pod has already been created, so log and skip.
+		logging.RequireLoggerFromContext(ctx).WithError(getNodeErr).Error(ctx, "was unable to obtain node")
+		return
+	}
+	node.EscalatedResources = resources
+	woc.wf.Status.Nodes.Set(ctx, nodeID, *node)
+}
+
 // startCreateWorkflowPodSpan opens the create_workflow_pod tracing span.
 func (woc *wfOperationCtx) startCreateWorkflowPodSpan(ctx context.Context, nodeID string) (context.Context, trace.Span) {
 	return woc.controller.tracing.StartCreateWorkflowPod(ctx, nodeID)
@@ -24,9 +24,10 @@ import (
 //	c-e. shared submit core — see createPodFromBuild, which owns these steps
 //	f.   activePods++          — parallelism accounting
 //	g.   apply ProgressToApply — initial node-status progress write
+//	h.   apply EscalatedResourcesToApply — escalated node-status requests write
 //
-// Step (g) runs only after a successful create so the workflow status never
-// records progress for a pod that failed to be created.
+// Steps (g) and (h) run only after a successful create so the workflow status
+// never records progress or resources for a pod that failed to be created.
 func (woc *wfOperationCtx) submitPod(ctx context.Context, result *podBuildResult, nodeName, nodeID string, baseLog logging.Logger) (*apiv1.Pod, error) {
 	log := baseLog.WithFields(logging.Fields{"nodeName": nodeName, "nodeID": nodeID})
 	ctx = logging.WithLogger(ctx, log)
@@ -79,6 +80,12 @@ func (woc *wfOperationCtx) submitPod(ctx context.Context, result *podBuildResult
 		woc.setNodeProgress(ctx, nodeID, *result.ProgressToApply)
 	}
 
+	// (h) record the resources raised by the retry strategy's resource
+	// escalation on the node status, if build escalated any.
+	if result.EscalatedResourcesToApply != nil {
+		woc.setNodeEscalatedResources(ctx, nodeID, result.EscalatedResourcesToApply)
+	}
+
 	return created, nil
 }
 
@@ -555,6 +555,66 @@ func TestConditionalArchiveLocation(t *testing.T) {
 	assert.Nil(t, tmpl.ArchiveLocation)
 }
 
+// TestArtifactKeyPrefix verifies the key prefix of the repository is passed to the executor for deduplicated outputs
+func TestArtifactKeyPrefix(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	for _, deduplicate := range []bool{true, false} {
+		wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+		wf.Spec.Templates[0].Outputs = wfv1.Outputs{
+			Artifacts: []wfv1.Artifact{{Name: "foo", Path: "/tmp/file", Deduplicate: deduplicate}},
+		}
+		woc := newWoc(ctx, *wf)
+		setArtifactRepository(woc.controller, &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{
+			S3Bucket:  wfv1.S3Bucket{Bucket: "foo"},
+			KeyFormat: "team-a/{{workflow.name}}/{{pod.name}}",
+		}})
+		woc.operate(ctx)
+		pods, err := listPods(ctx, woc)
+		require.NoError(t, err)
+		require.Len(t, pods.Items, 1)
+		idx, err := wfutil.FindWaitCtrIndex(&pods.Items[0])
+		require.NoError(t, err)
+		env := apiv1.EnvVar{Name: common.EnvVarArtifactKeyPrefix, Value: "team-a"}
+		if deduplicate {
+			assert.Contains(t, pods.Items[0].Spec.Containers[idx].Env, env)
+		} else {
+			assert.NotContains(t, pods.Items[0].Spec.Containers[idx].Env, env)
+		}
+	}
+}
+
+// TestArtifactFallbacks verifies the fallback locations of the repository are passed to the executor, with their keys
+// resolved
+func TestArtifactFallbacks(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	wf.Spec.Templates[0].Outputs = wfv1.Outputs{
+		Artifacts: []wfv1.Artifact{{Name: "foo", Path: "/tmp/file"}},
+	}
+	woc := newWoc(ctx, *wf)
+	setArtifactRepository(woc.controller, &wfv1.ArtifactRepository{
+		S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "foo"}},
+		Fallbacks: []wfv1.ArtifactRepositoryLocation{
+			{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Endpoint: "fallback:9000", Bucket: "bar"}, KeyFormat: "fallback/{{workflow.name}}/{{pod.name}}"}},
+		},
+	})
+	woc.operate(ctx)
+	pods, err := listPods(ctx, woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	idx, err := wfutil.FindWaitCtrIndex(&pods.Items[0])
+	require.NoError(t, err)
+	var fallbacks []wfv1.ArtifactLocation
+	for _, env := range pods.Items[0].Spec.Containers[idx].Env {
+		if env.Name == common.EnvVarArtifactFallbacks {
+			require.NoError(t, json.Unmarshal([]byte(env.Value), &fallbacks))
+		}
+	}
+	require.Len(t, fallbacks, 1)
+	assert.Equal(t, "bar", fallbacks[0].S3.Bucket)
+	assert.Equal(t, "fallback/hello-world/"+pods.Items[0].Name, fallbacks[0].S3.Key)
+}
+
 // TestConditionalAddArchiveLocationTemplateArchiveLogs verifies we do  add archive location if it is needed for logs
 func TestConditionalAddArchiveLocationTemplateArchiveLogs(t *testing.T) {
 	tests := []struct {
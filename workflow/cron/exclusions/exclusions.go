@@ -0,0 +1,173 @@
+// Package exclusions decides whether the scheduled times of a CronWorkflow fall within its exclusions.
+package exclusions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// DateFormat is the format of excluded dates
+const DateFormat = "2006-01-02"
+
+// maxSkipped bounds the number of excluded schedule times NextRuntimes will skip, in case everything is excluded
+const maxSkipped = 100_000
+
+type window struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// Exclusions are the parsed exclusions of a CronWorkflow. A nil *Exclusions excludes nothing.
+type Exclusions struct {
+	location   *time.Location
+	dateRanges []v1alpha1.ExclusionDateRange
+	windows    []window
+	holidays   map[string]bool
+}
+
+// Location returns the timezone of the CronWorkflow, which is that of the controller if it has none
+func Location(spec *v1alpha1.CronWorkflowSpec) (*time.Location, error) {
+	if spec.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(spec.Timezone)
+}
+
+// New parses the exclusions of a CronWorkflow. The holidays are the dates read from its HolidaysConfigMap.
+func New(spec *v1alpha1.CronWorkflowSpec, holidays []string) (*Exclusions, error) {
+	if spec.Exclusions == nil {
+		return nil, nil
+	}
+	location, err := Location(spec)
+	if err != nil {
+		return nil, err
+	}
+	e := &Exclusions{location: location, holidays: make(map[string]bool, len(holidays))}
+	for _, dateRange := range spec.Exclusions.DateRanges {
+		if err := ValidateDateRange(dateRange); err != nil {
+			return nil, err
+		}
+		e.dateRanges = append(e.dateRanges, dateRange)
+	}
+	for _, w := range spec.Exclusions.Windows {
+		schedule, duration, err := ParseWindow(w)
+		if err != nil {
+			return nil, err
+		}
+		e.windows = append(e.windows, window{schedule: schedule, duration: duration})
+	}
+	for _, holiday := range holidays {
+		e.holidays[holiday] = true
+	}
+	return e, nil
+}
+
+// ValidateDateRange returns an error if the dates of the range are malformed or out of order
+func ValidateDateRange(dateRange v1alpha1.ExclusionDateRange) error {
+	for _, date := range []string{dateRange.Start, dateRange.GetEnd()} {
+		if _, err := time.Parse(DateFormat, date); err != nil {
+			return fmt.Errorf("date '%s' is not formatted YYYY-MM-DD", date)
+		}
+	}
+	if dateRange.GetEnd() < dateRange.Start {
+		return fmt.Errorf("date range end '%s' is before its start '%s'", dateRange.End, dateRange.Start)
+	}
+	return nil
+}
+
+// ParseWindow parses the schedule and duration of a window
+func ParseWindow(w v1alpha1.ExclusionWindow) (cron.Schedule, time.Duration, error) {
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return nil, 0, fmt.Errorf("window schedule '%s' is invalid: %w", w.Schedule, err)
+	}
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return nil, 0, fmt.Errorf("window duration '%s' is invalid: %w", w.Duration, err)
+	}
+	if duration <= 0 {
+		return nil, 0, fmt.Errorf("window duration '%s' must be positive", w.Duration)
+	}
+	return schedule, duration, nil
+}
+
+// ParseHolidays returns the dates in the data of a holidays ConfigMap
+func ParseHolidays(data map[string]string) ([]string, error) {
+	var holidays []string
+	for key, value := range data {
+		for date := range strings.FieldsSeq(value) {
+			if _, err := time.Parse(DateFormat, date); err != nil {
+				return nil, fmt.Errorf("holiday '%s' in key '%s' is not formatted YYYY-MM-DD", date, key)
+			}
+			holidays = append(holidays, date)
+		}
+	}
+	sort.Strings(holidays)
+	return holidays, nil
+}
+
+// Excludes returns true if t falls on an excluded date or within an excluded window
+func (e *Exclusions) Excludes(t time.Time) bool {
+	if e == nil {
+		return false
+	}
+	t = t.In(e.location)
+	date := t.Format(DateFormat)
+	if e.holidays[date] {
+		return true
+	}
+	for _, dateRange := range e.dateRanges {
+		if dateRange.Start <= date && date <= dateRange.GetEnd() {
+			return true
+		}
+	}
+	for _, w := range e.windows {
+		// t is within the window if the window opened after t-duration, and no later than t
+		if !w.schedule.Next(t.Add(-w.duration)).After(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextRuntimes returns up to count of the next times after from on which the CronWorkflow is scheduled and not
+// excluded, soonest first
+func NextRuntimes(spec *v1alpha1.CronWorkflowSpec, e *Exclusions, from time.Time, count int) ([]time.Time, error) {
+	var schedules []cron.Schedule
+	for _, schedule := range spec.GetSchedulesWithTimezone() {
+		cronSchedule, err := cron.ParseStandard(schedule)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, cronSchedule)
+	}
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+	var next []time.Time
+	for skipped := 0; len(next) < count && skipped < maxSkipped; {
+		var soonest time.Time
+		for _, schedule := range schedules {
+			t := schedule.Next(from)
+			if soonest.IsZero() || t.Before(soonest) {
+				soonest = t
+			}
+		}
+		if soonest.IsZero() {
+			break
+		}
+		from = soonest
+		if e.Excludes(soonest) {
+			skipped++
+			continue
+		}
+		next = append(next, soonest)
+	}
+	return next, nil
+}
@@ -0,0 +1,114 @@
+package exclusions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+func TestExcludes(t *testing.T) {
+	spec := &v1alpha1.CronWorkflowSpec{
+		Timezone: "America/New_York",
+		Exclusions: &v1alpha1.CronWorkflowExclusions{
+			DateRanges: []v1alpha1.ExclusionDateRange{
+				{Start: "2026-12-20", End: "2027-01-05"},
+				{Start: "2026-11-26"},
+			},
+			// Weekends, from 10pm on Friday to 6am on Monday
+			Windows: []v1alpha1.ExclusionWindow{{Schedule: "0 22 * * 5", Duration: "56h"}},
+		},
+	}
+	e, err := New(spec, []string{"2026-07-03"})
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, newYork)
+	}
+
+	assert.True(t, e.Excludes(at(time.December, 20, 0)))
+	assert.True(t, e.Excludes(at(time.December, 31, 23)))
+	assert.False(t, e.Excludes(at(time.December, 17, 23)), "a Thursday before the range")
+	assert.True(t, e.Excludes(at(time.November, 26, 12)))
+	assert.False(t, e.Excludes(at(time.November, 27, 12)), "a Friday before 10pm")
+	assert.True(t, e.Excludes(at(time.July, 3, 12)), "a holiday")
+	// The date is in the CronWorkflow's timezone, not UTC
+	assert.False(t, e.Excludes(time.Date(2026, time.July, 3, 2, 0, 0, 0, time.UTC)), "July 3rd in UTC, but July 2nd in New York")
+	assert.True(t, e.Excludes(time.Date(2026, time.July, 3, 4, 0, 0, 0, time.UTC)), "July 3rd in New York")
+
+	assert.True(t, e.Excludes(at(time.October, 16, 22)), "Friday 10pm")
+	assert.True(t, e.Excludes(at(time.October, 18, 12)), "Sunday")
+	assert.True(t, e.Excludes(at(time.October, 19, 5)), "Monday 5am")
+	assert.False(t, e.Excludes(at(time.October, 19, 6)), "Monday 6am")
+	assert.False(t, e.Excludes(at(time.October, 16, 21)), "Friday 9pm")
+
+	var none *Exclusions
+	assert.False(t, none.Excludes(at(time.December, 25, 0)))
+}
+
+func TestNew(t *testing.T) {
+	e, err := New(&v1alpha1.CronWorkflowSpec{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+
+	for name, exclusions := range map[string]v1alpha1.CronWorkflowExclusions{
+		"MalformedDate":    {DateRanges: []v1alpha1.ExclusionDateRange{{Start: "20/12/2026"}}},
+		"OutOfOrder":       {DateRanges: []v1alpha1.ExclusionDateRange{{Start: "2026-12-20", End: "2026-12-19"}}},
+		"InvalidSchedule":  {Windows: []v1alpha1.ExclusionWindow{{Schedule: "* *", Duration: "1h"}}},
+		"InvalidDuration":  {Windows: []v1alpha1.ExclusionWindow{{Schedule: "* * * * *", Duration: "1 hour"}}},
+		"NegativeDuration": {Windows: []v1alpha1.ExclusionWindow{{Schedule: "* * * * *", Duration: "-1h"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(&v1alpha1.CronWorkflowSpec{Exclusions: &exclusions}, nil)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseHolidays(t *testing.T) {
+	holidays, err := ParseHolidays(map[string]string{
+		"christmas":    "2026-12-25\n2027-12-25\n",
+		"independence": "2026-07-03",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026-07-03", "2026-12-25", "2027-12-25"}, holidays)
+
+	_, err = ParseHolidays(map[string]string{"christmas": "25 December"})
+	require.EqualError(t, err, "holiday '25' in key 'christmas' is not formatted YYYY-MM-DD")
+}
+
+func TestNextRuntimes(t *testing.T) {
+	spec := &v1alpha1.CronWorkflowSpec{
+		Schedules: []string{"0 9 * * *"},
+		Timezone:  "UTC",
+		Exclusions: &v1alpha1.CronWorkflowExclusions{
+			DateRanges:        []v1alpha1.ExclusionDateRange{{Start: "2026-10-20", End: "2026-10-21"}},
+			HolidaysConfigMap: &corev1.LocalObjectReference{Name: "holidays"},
+		},
+	}
+	e, err := New(spec, []string{"2026-10-23"})
+	require.NoError(t, err)
+	day := func(day int) time.Time { return time.Date(2026, time.October, day, 9, 0, 0, 0, time.UTC) }
+
+	next, err := NextRuntimes(spec, e, day(18), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{day(19), day(22), day(24)}, next)
+
+	t.Run("EverythingExcluded", func(t *testing.T) {
+		spec := &v1alpha1.CronWorkflowSpec{
+			Schedules:  []string{"0 9 * * *"},
+			Timezone:   "UTC",
+			Exclusions: &v1alpha1.CronWorkflowExclusions{Windows: []v1alpha1.ExclusionWindow{{Schedule: "0 0 * * *", Duration: "24h"}}},
+		}
+		e, err := New(spec, nil)
+		require.NoError(t, err)
+		next, err := NextRuntimes(spec, e, day(18), 3)
+		require.NoError(t, err)
+		assert.Empty(t, next)
+	})
+}
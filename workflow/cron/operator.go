@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ import (
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 
 	argoerrs "github.com/argoproj/argo-workflows/v4/errors"
 
@@ -34,6 +36,7 @@ import (
 	"github.com/argoproj/argo-workflows/v4/workflow/metrics"
 
 	"github.com/argoproj/argo-workflows/v4/workflow/controller/informer"
+	"github.com/argoproj/argo-workflows/v4/workflow/cron/exclusions"
 	"github.com/argoproj/argo-workflows/v4/workflow/util"
 	"github.com/argoproj/argo-workflows/v4/workflow/validate"
 )
@@ -45,6 +48,7 @@ const (
 type cronWfOperationCtx struct {
 	// CronWorkflow is the CronWorkflow to be run
 	cronWf          *v1alpha1.CronWorkflow
+	kubeClient      kubernetes.Interface
 	wfClientset     versioned.Interface
 	wfClient        typed.WorkflowInterface
 	wfDefaults      *v1alpha1.Workflow
@@ -59,13 +63,14 @@ type cronWfOperationCtx struct {
 	ctx context.Context
 }
 
-func newCronWfOperationCtx(ctx context.Context, cronWorkflow *v1alpha1.CronWorkflow, wfClientset versioned.Interface,
+func newCronWfOperationCtx(ctx context.Context, cronWorkflow *v1alpha1.CronWorkflow, kubeClient kubernetes.Interface, wfClientset versioned.Interface,
 	metrics *metrics.Metrics, wftmplInformer wfextvv1alpha1.WorkflowTemplateInformer,
 	cwftmplInformer wfextvv1alpha1.ClusterWorkflowTemplateInformer, wfDefaults *v1alpha1.Workflow,
 ) *cronWfOperationCtx {
 	log := logging.RequireLoggerFromContext(ctx)
 	return &cronWfOperationCtx{
 		cronWf:          cronWorkflow,
+		kubeClient:      kubeClient,
 		wfClientset:     wfClientset,
 		wfClient:        wfClientset.ArgoprojV1alpha1().Workflows(cronWorkflow.Namespace),
 		wfDefaults:      wfDefaults,
@@ -90,10 +95,12 @@ func newCronWfOperationCtx(ctx context.Context, cronWorkflow *v1alpha1.CronWorkf
 // Run handles the running of a cron workflow
 // It fits the github.com/robfig/cron.Job interface
 func (woc *cronWfOperationCtx) Run() {
-	woc.run(woc.ctx, woc.scheduledTimeFunc(woc.ctx))
+	woc.run(woc.ctx, woc.scheduledTimeFunc(woc.ctx), false)
 }
 
-func (woc *cronWfOperationCtx) run(ctx context.Context, scheduledRuntime time.Time) {
+// run submits a Workflow for the scheduled time, returning whether it did. Catch-up runs, for missed schedules, are
+// labelled with their time.
+func (woc *cronWfOperationCtx) run(ctx context.Context, scheduledRuntime time.Time, catchUp bool) bool {
 	defer woc.persistUpdate(ctx)
 
 	woc.log.Info(ctx, "Running")
@@ -105,43 +112,56 @@ func (woc *cronWfOperationCtx) run(ctx context.Context, scheduledRuntime time.Ti
 
 	err := woc.validateCronWorkflow(ctx)
 	if err != nil {
-		return
+		return false
 	}
 
 	completed, err := woc.checkStopingCondition()
 	if err != nil {
 		woc.reportCronWorkflowError(ctx, v1alpha1.ConditionTypeSpecError, fmt.Sprintf("failed to check CronWorkflow '%s' stopping condition: %s", woc.cronWf.Name, err))
-		return
+		return false
 	} else if completed {
 		woc.setAsCompleted()
 	}
 
+	excluded, err := woc.isExcluded(ctx, scheduledRuntime)
+	if err != nil {
+		woc.reportCronWorkflowError(ctx, v1alpha1.ConditionTypeSpecError, fmt.Sprintf("failed to check CronWorkflow '%s' exclusions: %s", woc.cronWf.Name, err))
+		return false
+	} else if excluded {
+		woc.log.WithField("scheduledTime", scheduledRuntime).Info(ctx, "Scheduled time is excluded, skipping execution")
+		return false
+	}
+
 	proceed, err := woc.enforceRuntimePolicy(ctx)
 	if err != nil {
 		woc.reportCronWorkflowError(ctx, v1alpha1.ConditionTypeSubmissionError, fmt.Sprintf("run policy error: %s", err))
-		return
+		return false
 	} else if !proceed {
-		return
+		return false
 	}
 
 	woc.metrics.CronWfTrigger(ctx, woc.cronWf.Name, woc.cronWf.Namespace)
 
 	wf := common.ConvertCronWorkflowToWorkflowWithProperties(ctx, woc.cronWf, getChildWorkflowName(woc.cronWf.Name, scheduledRuntime), scheduledRuntime)
+	if catchUp {
+		wf.Labels[common.LabelKeyCronWorkflowCatchUp] = scheduledRuntime.UTC().Format(common.CronWorkflowCatchUpTimeFormat)
+	}
 
 	runWf, err := util.SubmitWorkflow(ctx, woc.wfClient, woc.wfClientset, woc.cronWf.Namespace, wf, woc.wfDefaults, &v1alpha1.SubmitOpts{})
 	if err != nil {
 		// If the workflow already exists (i.e. this is a duplicate submission), do not report an error
 		if apierrors.IsAlreadyExists(err) {
-			return
+			return false
 		}
 		woc.reportCronWorkflowError(ctx, v1alpha1.ConditionTypeSubmissionError, fmt.Sprintf("Failed to submit Workflow: %s", err))
-		return
+		return false
 	}
 
 	woc.cronWf.Status.Active = append(woc.cronWf.Status.Active, getWorkflowObjectReference(wf, runWf))
 	woc.cronWf.Status.Phase = v1alpha1.ActivePhase
 	woc.cronWf.Status.LastScheduledTime = &v1.Time{Time: scheduledRuntime}
 	woc.cronWf.Status.Conditions.RemoveCondition(v1alpha1.ConditionTypeSubmissionError)
+	return true
 }
 
 func (woc *cronWfOperationCtx) validateCronWorkflow(ctx context.Context) error {
@@ -311,17 +331,108 @@ func (woc *cronWfOperationCtx) terminateOutstandingWorkflows(ctx context.Context
 }
 
 func (woc *cronWfOperationCtx) runOutstandingWorkflows(ctx context.Context) (bool, error) {
+	if woc.cronWf.Spec.CatchUpPolicy != nil {
+		return woc.catchUp(ctx, time.Now())
+	}
 	missedExecutionTime, err := woc.shouldOutstandingWorkflowsBeRun(ctx)
 	if err != nil {
 		return false, err
 	}
 	if !missedExecutionTime.IsZero() {
-		woc.run(ctx, missedExecutionTime)
-		return true, nil
+		return woc.run(ctx, missedExecutionTime, true), nil
 	}
 	return false, nil
 }
 
+// catchUp runs the missed schedules selected by the catch-up policy, oldest first, returning whether it submitted any
+// Workflows
+func (woc *cronWfOperationCtx) catchUp(ctx context.Context, now time.Time) (bool, error) {
+	cronExclusions, err := woc.getExclusions(ctx)
+	if err != nil {
+		return false, err
+	}
+	missedExecutionTimes, err := woc.missedExecutionTimes(now, cronExclusions)
+	if err != nil {
+		return false, err
+	}
+	submitted := false
+	for _, missedExecutionTime := range missedExecutionTimes {
+		woc.log.WithFields(logging.Fields{
+			"strategy":            woc.cronWf.Spec.CatchUpPolicy.Strategy,
+			"missedExecutionTime": missedExecutionTime.Format("Mon Jan _2 15:04:05 2006"),
+		}).Info(ctx, "catching up on a missed execution")
+		if woc.run(ctx, missedExecutionTime, true) {
+			submitted = true
+		}
+	}
+	return submitted, nil
+}
+
+// missedExecutionTimes returns the execution times missed since the last scheduled time which the catch-up policy
+// selects, oldest first. If StartingDeadlineSeconds is set, execution times older than it are not returned, nor are
+// excluded execution times.
+func (woc *cronWfOperationCtx) missedExecutionTimes(now time.Time, cronExclusions *exclusions.Exclusions) ([]time.Time, error) {
+	maxCount := woc.cronWf.Spec.CatchUpPolicy.GetMaxCount()
+	// If the CronWorkflow schedule was just updated, or it has never been run, then nothing was missed.
+	if maxCount == 0 || woc.cronWf.IsUsingNewSchedule() || woc.cronWf.Status.LastScheduledTime == nil {
+		return nil, nil
+	}
+	var missed []time.Time
+	for _, schedule := range woc.cronWf.Spec.GetSchedulesWithTimezone() {
+		cronSchedule, err := cron.ParseStandard(schedule)
+		if err != nil {
+			return nil, err
+		}
+		var scheduleMissed []time.Time
+		for next := cronSchedule.Next(woc.cronWf.Status.LastScheduledTime.Time); next.Before(now); next = cronSchedule.Next(next) {
+			if woc.cronWf.Spec.StartingDeadlineSeconds != nil && !now.Before(next.Add(time.Duration(*woc.cronWf.Spec.StartingDeadlineSeconds)*time.Second)) {
+				continue
+			}
+			if cronExclusions.Excludes(next) {
+				continue
+			}
+			// Only the most recent execution times can be selected, so long outages do not need unbounded memory
+			if len(scheduleMissed) == maxCount {
+				scheduleMissed = scheduleMissed[1:]
+			}
+			scheduleMissed = append(scheduleMissed, next)
+		}
+		missed = append(missed, scheduleMissed...)
+	}
+	sort.Slice(missed, func(i, j int) bool { return missed[i].Before(missed[j]) })
+	// Schedules may coincide
+	missed = slices.CompactFunc(missed, func(a, b time.Time) bool { return a.Equal(b) })
+	return missed[max(0, len(missed)-maxCount):], nil
+}
+
+// getExclusions returns the CronWorkflow's exclusions, with the holidays from its ConfigMap
+func (woc *cronWfOperationCtx) getExclusions(ctx context.Context) (*exclusions.Exclusions, error) {
+	if woc.cronWf.Spec.Exclusions == nil {
+		return nil, nil
+	}
+	var holidays []string
+	if ref := woc.cronWf.Spec.Exclusions.HolidaysConfigMap; ref != nil {
+		cm, err := woc.kubeClient.CoreV1().ConfigMaps(woc.cronWf.Namespace).Get(ctx, ref.Name, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get holidays ConfigMap '%s': %w", ref.Name, err)
+		}
+		holidays, err = exclusions.ParseHolidays(cm.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return exclusions.New(&woc.cronWf.Spec, holidays)
+}
+
+// isExcluded returns true if the scheduled time falls within the CronWorkflow's exclusions
+func (woc *cronWfOperationCtx) isExcluded(ctx context.Context, scheduledRuntime time.Time) (bool, error) {
+	cronExclusions, err := woc.getExclusions(ctx)
+	if err != nil {
+		return false, err
+	}
+	return cronExclusions.Excludes(scheduledRuntime), nil
+}
+
 func (woc *cronWfOperationCtx) shouldOutstandingWorkflowsBeRun(ctx context.Context) (time.Time, error) {
 	// If the CronWorkflow schedule was just updated, then do not run any outstanding workflows.
 	if woc.cronWf.IsUsingNewSchedule() {
@@ -7,7 +7,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned/fake"
@@ -815,3 +817,215 @@ func TestEvaluateWhenUnresolvedOutside(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, result)
 }
+
+const catchUpCronWf = `apiVersion: argoproj.io/v1alpha1
+kind: CronWorkflow
+metadata:
+  name: catch-up
+  namespace: argo
+spec:
+  schedules:
+    - "0 * * * *"
+  timezone: UTC
+  workflowSpec:
+    entrypoint: main
+    templates:
+      - name: main
+        container:
+          image: argoproj/argosay:v2
+`
+
+func TestMissedExecutionTimes(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC)
+	hours := func(hours ...int) []time.Time {
+		times := make([]time.Time, len(hours))
+		for i, hour := range hours {
+			times[i] = time.Date(2026, 10, 18, hour, 0, 0, 0, time.UTC)
+		}
+		return times
+	}
+	tests := []struct {
+		name                    string
+		policy                  *v1alpha1.CatchUpPolicy
+		startingDeadlineSeconds *int64
+		expected                []time.Time
+	}{
+		{"None", &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpNone}, nil, nil},
+		{"Latest", &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpLatest}, nil, hours(12)},
+		{"All", &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpAll}, nil, hours(8, 9, 10, 11, 12)},
+		{"AllMaxCount", &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpAll, MaxCount: new(int32(2))}, nil, hours(11, 12)},
+		{"AllStartingDeadline", &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpAll}, new(int64(7200)), hours(11, 12)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cronWf v1alpha1.CronWorkflow
+			v1alpha1.MustUnmarshal([]byte(catchUpCronWf), &cronWf)
+			cronWf.Spec.CatchUpPolicy = tt.policy
+			cronWf.Spec.StartingDeadlineSeconds = tt.startingDeadlineSeconds
+			cronWf.Status.LastScheduledTime = &v1.Time{Time: time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC)}
+			cronWf.SetSchedule(cronWf.Spec.GetScheduleWithTimezoneString())
+			woc := &cronWfOperationCtx{cronWf: &cronWf}
+
+			missed, err := woc.missedExecutionTimes(now, nil)
+			require.NoError(t, err)
+			require.Len(t, missed, len(tt.expected))
+			for i := range tt.expected {
+				assert.True(t, tt.expected[i].Equal(missed[i]), "expected %s, got %s", tt.expected[i], missed[i])
+			}
+		})
+	}
+
+	t.Run("NeverRun", func(t *testing.T) {
+		var cronWf v1alpha1.CronWorkflow
+		v1alpha1.MustUnmarshal([]byte(catchUpCronWf), &cronWf)
+		cronWf.Spec.CatchUpPolicy = &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpAll}
+		cronWf.SetSchedule(cronWf.Spec.GetScheduleWithTimezoneString())
+		missed, err := (&cronWfOperationCtx{cronWf: &cronWf}).missedExecutionTimes(now, nil)
+		require.NoError(t, err)
+		assert.Empty(t, missed)
+	})
+	t.Run("MultipleSchedules", func(t *testing.T) {
+		var cronWf v1alpha1.CronWorkflow
+		v1alpha1.MustUnmarshal([]byte(catchUpCronWf), &cronWf)
+		cronWf.Spec.Schedules = []string{"0 */2 * * *", "0 */3 * * *"}
+		cronWf.Spec.CatchUpPolicy = &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpAll}
+		cronWf.Status.LastScheduledTime = &v1.Time{Time: time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC)}
+		cronWf.SetSchedule(cronWf.Spec.GetScheduleWithTimezoneString())
+		missed, err := (&cronWfOperationCtx{cronWf: &cronWf}).missedExecutionTimes(now, nil)
+		require.NoError(t, err)
+		// 12:00 is in both schedules, but is only run once
+		assert.Equal(t, hours(8, 9, 10, 12), missed)
+	})
+}
+
+func TestCatchUp(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	var cronWf v1alpha1.CronWorkflow
+	v1alpha1.MustUnmarshal([]byte(catchUpCronWf), &cronWf)
+	cronWf.Spec.CatchUpPolicy = &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpAll}
+	now := time.Now().UTC()
+	lastScheduledTime := now.Truncate(time.Hour).Add(-3 * time.Hour)
+	cronWf.Status.LastScheduledTime = &v1.Time{Time: lastScheduledTime}
+	cronWf.SetSchedule(cronWf.Spec.GetScheduleWithTimezoneString())
+
+	cs := fake.NewClientset(&cronWf)
+	testMetrics, err := metrics.New(ctx, telemetry.TestScopeName, telemetry.TestScopeName, &telemetry.MetricsConfig{}, metrics.Callbacks{})
+	require.NoError(t, err)
+	woc := &cronWfOperationCtx{
+		wfClientset: cs,
+		wfClient:    cs.ArgoprojV1alpha1().Workflows("argo"),
+		cronWfIf:    cs.ArgoprojV1alpha1().CronWorkflows("argo"),
+		cronWf:      &cronWf,
+		log:         logging.RequireLoggerFromContext(ctx),
+		metrics:     testMetrics,
+	}
+
+	ran, err := woc.catchUp(ctx, now)
+	require.NoError(t, err)
+	assert.True(t, ran)
+	wfs, err := cs.ArgoprojV1alpha1().Workflows("argo").List(ctx, v1.ListOptions{})
+	require.NoError(t, err)
+	var catchUpTimes []string
+	for _, wf := range wfs.Items {
+		catchUpTimes = append(catchUpTimes, wf.Labels[common.LabelKeyCronWorkflowCatchUp])
+	}
+	assert.ElementsMatch(t, []string{
+		lastScheduledTime.Add(time.Hour).Format(common.CronWorkflowCatchUpTimeFormat),
+		lastScheduledTime.Add(2 * time.Hour).Format(common.CronWorkflowCatchUpTimeFormat),
+		lastScheduledTime.Add(3 * time.Hour).Format(common.CronWorkflowCatchUpTimeFormat),
+	}, catchUpTimes)
+	assert.True(t, lastScheduledTime.Add(3*time.Hour).Equal(woc.cronWf.Status.LastScheduledTime.Time))
+
+	// Nothing is missed any more
+	ran, err = woc.catchUp(ctx, now)
+	require.NoError(t, err)
+	assert.False(t, ran)
+}
+
+func TestCatchUpForbidWithActiveWorkflow(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	var cronWf v1alpha1.CronWorkflow
+	v1alpha1.MustUnmarshal([]byte(catchUpCronWf), &cronWf)
+	cronWf.Spec.CatchUpPolicy = &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpAll}
+	cronWf.Spec.ConcurrencyPolicy = v1alpha1.ForbidConcurrent
+	now := time.Now().UTC()
+	cronWf.Status.LastScheduledTime = &v1.Time{Time: now.Truncate(time.Hour).Add(-2 * time.Hour)}
+	cronWf.Status.Active = []corev1.ObjectReference{{Name: "catch-up-active", Namespace: "argo"}}
+	cronWf.SetSchedule(cronWf.Spec.GetScheduleWithTimezoneString())
+
+	cs := fake.NewClientset(&cronWf)
+	testMetrics, err := metrics.New(ctx, telemetry.TestScopeName, telemetry.TestScopeName, &telemetry.MetricsConfig{}, metrics.Callbacks{})
+	require.NoError(t, err)
+	woc := &cronWfOperationCtx{
+		wfClientset: cs,
+		wfClient:    cs.ArgoprojV1alpha1().Workflows("argo"),
+		cronWfIf:    cs.ArgoprojV1alpha1().CronWorkflows("argo"),
+		cronWf:      &cronWf,
+		log:         logging.RequireLoggerFromContext(ctx),
+		metrics:     testMetrics,
+	}
+
+	// nothing is submitted, so the CronWorkflow must still be scheduled
+	ran, err := woc.catchUp(ctx, now)
+	require.NoError(t, err)
+	assert.False(t, ran)
+	wfs, err := cs.ArgoprojV1alpha1().Workflows("argo").List(ctx, v1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, wfs.Items)
+}
+
+func TestExclusions(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	var cronWf v1alpha1.CronWorkflow
+	v1alpha1.MustUnmarshal([]byte(catchUpCronWf), &cronWf)
+	cronWf.Spec.Exclusions = &v1alpha1.CronWorkflowExclusions{
+		DateRanges:        []v1alpha1.ExclusionDateRange{{Start: "2026-10-17"}},
+		HolidaysConfigMap: &corev1.LocalObjectReference{Name: "holidays"},
+	}
+	cronWf.SetSchedule(cronWf.Spec.GetScheduleWithTimezoneString())
+
+	cs := fake.NewClientset(&cronWf)
+	testMetrics, err := metrics.New(ctx, telemetry.TestScopeName, telemetry.TestScopeName, &telemetry.MetricsConfig{}, metrics.Callbacks{})
+	require.NoError(t, err)
+	woc := &cronWfOperationCtx{
+		kubeClient: kubefake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{Name: "holidays", Namespace: "argo"},
+			Data:       map[string]string{"founders-day": "2026-10-18"},
+		}),
+		wfClientset: cs,
+		wfClient:    cs.ArgoprojV1alpha1().Workflows("argo"),
+		cronWfIf:    cs.ArgoprojV1alpha1().CronWorkflows("argo"),
+		cronWf:      &cronWf,
+		log:         logging.RequireLoggerFromContext(ctx),
+		metrics:     testMetrics,
+	}
+
+	woc.run(ctx, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), false)
+	woc.run(ctx, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), false)
+	wfs, err := cs.ArgoprojV1alpha1().Workflows("argo").List(ctx, v1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, wfs.Items)
+
+	woc.run(ctx, time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), false)
+	wfs, err = cs.ArgoprojV1alpha1().Workflows("argo").List(ctx, v1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, wfs.Items, 1)
+
+	t.Run("MissedExecutionTimes", func(t *testing.T) {
+		cronExclusions, err := woc.getExclusions(ctx)
+		require.NoError(t, err)
+		woc.cronWf.Spec.CatchUpPolicy = &v1alpha1.CatchUpPolicy{Strategy: v1alpha1.CatchUpAll}
+		woc.cronWf.Status.LastScheduledTime = &v1.Time{Time: time.Date(2026, 10, 17, 21, 0, 0, 0, time.UTC)}
+		missed, err := woc.missedExecutionTimes(time.Date(2026, 10, 19, 1, 30, 0, 0, time.UTC), cronExclusions)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 10, 19, 1, 0, 0, 0, time.UTC),
+		}, missed)
+	})
+	t.Run("MissingConfigMap", func(t *testing.T) {
+		woc.cronWf.Spec.Exclusions.HolidaysConfigMap.Name = "missing"
+		_, err := woc.isExcluded(ctx, time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+		require.ErrorContains(t, err, "failed to get holidays ConfigMap 'missing'")
+	})
+}
@@ -1,10 +1,15 @@
 package data
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"fmt"
+	"io"
+	"unicode/utf8"
 
 	"github.com/expr-lang/expr"
+	"sigs.k8s.io/yaml"
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 )
@@ -30,6 +35,16 @@ func processSource(ctx context.Context, source wfv1.DataSource, processor wfv1.D
 		if err != nil {
 			return nil, fmt.Errorf("unable to source artifact paths: %w", err)
 		}
+	case source.ArtifactContents != nil:
+		data, err = processor.ProcessArtifactContents(ctx, source.ArtifactContents)
+		if err != nil {
+			return nil, fmt.Errorf("unable to source artifact contents: %w", err)
+		}
+	case source.ConfigMap != nil:
+		data, err = processor.ProcessConfigMap(ctx, source.ConfigMap)
+		if err != nil {
+			return nil, fmt.Errorf("unable to source config map: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("no valid source is used for data template")
 	}
@@ -63,3 +78,63 @@ func processExpression(expression string, data any) (any, error) {
 	}
 	return expr.Run(program, env)
 }
+
+// ParseArtifactContents parses the contents of an artifact contents source according to its format
+func ParseArtifactContents(contents []byte, source *wfv1.ArtifactContents) (any, error) {
+	if source.IsCSV() {
+		return parseCSVRows(bytes.NewReader(contents), source.CSV)
+	}
+	return parseContents(contents)
+}
+
+// parseContents parses JSON or YAML contents into objects, lists and values
+func parseContents(contents []byte) (any, error) {
+	var data any
+	if err := yaml.Unmarshal(contents, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// parseCSVRows parses CSV contents into a list of rows. Each row is a list of values, or
+// an object keyed by column name if the first row is a header.
+func parseCSVRows(r io.Reader, options *wfv1.CSVOptions) (any, error) {
+	if options == nil {
+		options = &wfv1.CSVOptions{}
+	}
+	reader := csv.NewReader(r)
+	if options.Delimiter != "" {
+		delimiter, size := utf8.DecodeRuneInString(options.Delimiter)
+		if size != len(options.Delimiter) {
+			return nil, fmt.Errorf("delimiter must be a single character: %q", options.Delimiter)
+		}
+		reader.Comma = delimiter
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]any, 0, len(records))
+	if !options.Header {
+		for _, record := range records {
+			values := make([]any, len(record))
+			for i, value := range record {
+				values[i] = value
+			}
+			rows = append(rows, values)
+		}
+		return rows, nil
+	}
+	if len(records) == 0 {
+		return rows, nil
+	}
+	header := records[0]
+	for _, record := range records[1:] {
+		row := make(map[string]any, len(header))
+		for i, column := range header {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -3,10 +3,12 @@ package data
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v4/util/logging"
@@ -20,6 +22,17 @@ func (t testDataSourceProcessor) ProcessArtifactPaths(context.Context, *v1alpha1
 	return []any{"foo.py", "bar.pdf", "goo/foo.py", "moo/bar.pdf"}, nil
 }
 
+func (t testDataSourceProcessor) ProcessArtifactContents(_ context.Context, source *v1alpha1.ArtifactContents) (any, error) {
+	if source.IsCSV() {
+		return ParseArtifactContents([]byte("name,size\nfoo,1\nbar,2\n"), source)
+	}
+	return ParseArtifactContents([]byte(`{"shards": [1, 2, 3]}`), source)
+}
+
+func (t testDataSourceProcessor) ProcessConfigMap(context.Context, *apiv1.LocalObjectReference) (any, error) {
+	return map[string]any{"regions": "eu,us"}, nil
+}
+
 type nullTestDataSourceProcessor struct{}
 
 var _ v1alpha1.DataSourceProcessor = &nullTestDataSourceProcessor{}
@@ -28,6 +41,14 @@ func (t nullTestDataSourceProcessor) ProcessArtifactPaths(context.Context, *v1al
 	return nil, fmt.Errorf("cannot get artifacts")
 }
 
+func (t nullTestDataSourceProcessor) ProcessArtifactContents(context.Context, *v1alpha1.ArtifactContents) (any, error) {
+	return nil, fmt.Errorf("cannot get artifact")
+}
+
+func (t nullTestDataSourceProcessor) ProcessConfigMap(context.Context, *apiv1.LocalObjectReference) (any, error) {
+	return nil, fmt.Errorf("cannot get config map")
+}
+
 func TestProcessSource(t *testing.T) {
 	ctx := logging.TestContext(t.Context())
 	artifactPathsSource := v1alpha1.DataSource{ArtifactPaths: &v1alpha1.ArtifactPaths{}}
@@ -42,6 +63,80 @@ func TestProcessSource(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestProcessSourceContents(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	t.Run("ArtifactContents", func(t *testing.T) {
+		source := v1alpha1.DataSource{ArtifactContents: &v1alpha1.ArtifactContents{}}
+		data, err := processSource(ctx, source, &testDataSourceProcessor{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"shards": []any{float64(1), float64(2), float64(3)}}, data)
+		_, err = processSource(ctx, source, &nullTestDataSourceProcessor{})
+		require.ErrorContains(t, err, "unable to source artifact contents")
+	})
+	t.Run("ArtifactContentsCSV", func(t *testing.T) {
+		source := v1alpha1.DataSource{ArtifactContents: &v1alpha1.ArtifactContents{Format: v1alpha1.ArtifactContentsFormatCSV, CSV: &v1alpha1.CSVOptions{Header: true}}}
+		data, err := processSource(ctx, source, &testDataSourceProcessor{})
+		require.NoError(t, err)
+		assert.Equal(t, []any{map[string]any{"name": "foo", "size": "1"}, map[string]any{"name": "bar", "size": "2"}}, data)
+	})
+	t.Run("ConfigMap", func(t *testing.T) {
+		source := v1alpha1.DataSource{ConfigMap: &apiv1.LocalObjectReference{Name: "my-cm"}}
+		data, err := processSource(ctx, source, &testDataSourceProcessor{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"regions": "eu,us"}, data)
+		_, err = processSource(ctx, source, &nullTestDataSourceProcessor{})
+		require.ErrorContains(t, err, "unable to source config map")
+	})
+}
+
+func TestParseArtifactContents(t *testing.T) {
+	data, err := ParseArtifactContents([]byte(`{"items": ["a", "b"], "count": 2}`), &v1alpha1.ArtifactContents{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"items": []any{"a", "b"}, "count": float64(2)}, data)
+
+	data, err = ParseArtifactContents([]byte("items:\n- a\n- b\n"), &v1alpha1.ArtifactContents{Format: v1alpha1.ArtifactContentsFormatYAML})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"items": []any{"a", "b"}}, data)
+
+	_, err = ParseArtifactContents([]byte("items: [a"), &v1alpha1.ArtifactContents{})
+	require.Error(t, err)
+
+	data, err = ParseArtifactContents([]byte("a,b\n"), &v1alpha1.ArtifactContents{Format: v1alpha1.ArtifactContentsFormatCSV})
+	require.NoError(t, err)
+	assert.Equal(t, []any{[]any{"a", "b"}}, data)
+}
+
+func TestParseCSVRows(t *testing.T) {
+	contents := "a;b\n1;2\n"
+	rows, err := parseCSVRows(strings.NewReader(contents), &v1alpha1.CSVOptions{Delimiter: ";"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{[]any{"a", "b"}, []any{"1", "2"}}, rows)
+
+	rows, err = parseCSVRows(strings.NewReader(contents), &v1alpha1.CSVOptions{Delimiter: ";", Header: true})
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"a": "1", "b": "2"}}, rows)
+
+	rows, err = parseCSVRows(strings.NewReader(""), &v1alpha1.CSVOptions{Header: true})
+	require.NoError(t, err)
+	assert.Equal(t, []any{}, rows)
+
+	_, err = parseCSVRows(strings.NewReader(contents), &v1alpha1.CSVOptions{Delimiter: ";;"})
+	require.ErrorContains(t, err, "delimiter must be a single character")
+
+	_, err = parseCSVRows(strings.NewReader("a,b\n1\n"), nil)
+	require.Error(t, err)
+}
+
+func TestProcessDataFanOut(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	data, err := ProcessData(ctx, &v1alpha1.Data{
+		Source:         v1alpha1.DataSource{ArtifactContents: &v1alpha1.ArtifactContents{Format: v1alpha1.ArtifactContentsFormatCSV, CSV: &v1alpha1.CSVOptions{Header: true}}},
+		Transformation: v1alpha1.Transformation{{Expression: `map(filter(data, {int(#.size) > 1}), {#.name})`}},
+	}, &testDataSourceProcessor{})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"bar"}, data)
+}
+
 func TestProcessTransformation(t *testing.T) {
 	files := []any{"foo.py", "bar.pdf", "goo/foo.py", "moo/bar.pdf"}
 
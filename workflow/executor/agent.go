@@ -3,15 +3,26 @@ package executor
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/evilmonkeyinc/jsonpath"
+	"github.com/expr-lang/expr"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+	apiv1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -22,6 +33,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 
+	argoConfig "github.com/argoproj/argo-workflows/v4/config"
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	workflow "github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
@@ -30,7 +42,11 @@ import (
 	"github.com/argoproj/argo-workflows/v4/util/env"
 	"github.com/argoproj/argo-workflows/v4/util/errors"
 	"github.com/argoproj/argo-workflows/v4/util/expr/argoexpr"
+	exprenv "github.com/argoproj/argo-workflows/v4/util/expr/env"
+	"github.com/argoproj/argo-workflows/v4/util/intstr"
 	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/workflow/artifacts"
+	httpartifact "github.com/argoproj/argo-workflows/v4/workflow/artifacts/http"
 	"github.com/argoproj/argo-workflows/v4/workflow/common"
 )
 
@@ -42,12 +58,22 @@ type AgentExecutor struct {
 	RESTClient        rest.Interface
 	Namespace         string
 	consideredTasks   *sync.Map
-	plugins           []executorplugins.TemplateExecutor
+	// httpPolls are the HTTP templates polling for the completion of their operations, by node ID. Their state is also
+	// recorded in the results of their nodes, so that a restarted agent resumes them.
+	httpPolls sync.Map
+	// httpRetry retries the requests of the HTTP templates which do not have their own retry
+	httpRetry *wfv1.HTTPRetry
+	// httpRateLimit limits the rate of requests to each host, with the token buckets in hostLimiters
+	httpRateLimit *argoConfig.HTTPRateLimit
+	hostLimiters  sync.Map
+	// httpAuthClients are the clients of HTTP templates with TLS or authentication, by their configuration
+	httpAuthClients sync.Map
+	plugins         []executorplugins.TemplateExecutor
 }
 
-type templateExecutor = func(ctx context.Context, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error)
+type templateExecutor = func(ctx context.Context, nodeID string, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error)
 
-func NewAgentExecutor(clientSet kubernetes.Interface, restClient rest.Interface, config *rest.Config, namespace, workflowName, workflowUID string, plugins []executorplugins.TemplateExecutor) *AgentExecutor {
+func NewAgentExecutor(clientSet kubernetes.Interface, restClient rest.Interface, config *rest.Config, namespace, workflowName, workflowUID string, plugins []executorplugins.TemplateExecutor, agentConfig argoConfig.AgentConfig) *AgentExecutor {
 	return &AgentExecutor{
 		ClientSet:         clientSet,
 		RESTClient:        restClient,
@@ -57,6 +83,8 @@ func NewAgentExecutor(clientSet kubernetes.Interface, restClient rest.Interface,
 		WorkflowInterface: workflow.NewForConfigOrDie(config),
 		consideredTasks:   &sync.Map{},
 		plugins:           plugins,
+		httpRetry:         agentConfig.HTTPRetry,
+		httpRateLimit:     agentConfig.HTTPRateLimit,
 	}
 }
 
@@ -113,6 +141,7 @@ func (ae *AgentExecutor) Agent(ctx context.Context) error {
 			}
 
 			for nodeID, tmpl := range taskSet.Spec.Tasks {
+				ae.resumeHTTPPoll(ctx, nodeID, tmpl, taskSet.Status.Nodes[nodeID])
 				taskQueue <- task{NodeID: nodeID, Template: tmpl}
 			}
 		}
@@ -137,7 +166,7 @@ func (ae *AgentExecutor) taskWorker(ctx context.Context, taskQueue chan task, re
 		}
 
 		logger.Info(ctx, "Processing task")
-		result, requeue, err := ae.processTask(ctx, tmpl)
+		result, requeue, err := ae.processTask(ctx, nodeID, tmpl)
 		if err != nil {
 			logger.WithError(err).Error(ctx, "Error in agent task")
 			result = &wfv1.NodeResult{
@@ -226,7 +255,7 @@ func (ae *AgentExecutor) patchWorker(ctx context.Context, taskSetInterface v1alp
 	}
 }
 
-func (ae *AgentExecutor) processTask(ctx context.Context, tmpl wfv1.Template) (*wfv1.NodeResult, time.Duration, error) {
+func (ae *AgentExecutor) processTask(ctx context.Context, nodeID string, tmpl wfv1.Template) (*wfv1.NodeResult, time.Duration, error) {
 	var executeTemplate templateExecutor
 	switch {
 	case tmpl.HTTP != nil:
@@ -237,7 +266,7 @@ func (ae *AgentExecutor) processTask(ctx context.Context, tmpl wfv1.Template) (*
 		return nil, 0, fmt.Errorf("agent cannot execute: unknown task type: %v", tmpl.GetType())
 	}
 	result := &wfv1.NodeResult{}
-	requeue, err := executeTemplate(ctx, tmpl, result)
+	requeue, err := executeTemplate(ctx, nodeID, tmpl, result)
 	if err != nil {
 		result.Phase = wfv1.NodeFailed
 		result.Message = err.Error()
@@ -245,10 +274,13 @@ func (ae *AgentExecutor) processTask(ctx context.Context, tmpl wfv1.Template) (*
 	return result, requeue, nil
 }
 
-func (ae *AgentExecutor) executeHTTPTemplate(ctx context.Context, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error) {
+func (ae *AgentExecutor) executeHTTPTemplate(ctx context.Context, nodeID string, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error) {
 	if tmpl.HTTP == nil {
 		return 0, nil
 	}
+	if value, ok := ae.httpPolls.Load(nodeID); ok {
+		return ae.pollHTTPTemplate(ctx, nodeID, tmpl, value.(*httpPoll), result)
+	}
 	// Read response.Body after cancel(), sometimes it return a context canceled error
 	// For more detail  https://groups.google.com/g/golang-nuts/c/2FKwG6oEvos
 	var cancel context.CancelFunc
@@ -262,12 +294,33 @@ func (ae *AgentExecutor) executeHTTPTemplate(ctx context.Context, tmpl wfv1.Temp
 	}
 	defer response.Body.Close()
 
-	bodyBytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		return 0, err
+	if tmpl.HTTP.Poll != nil {
+		return ae.startHTTPPoll(nodeID, tmpl, response, result)
 	}
+	return 0, ae.processHTTPResponse(ctx, tmpl, response, result)
+}
 
-	outputs := wfv1.Outputs{Result: new(string(bodyBytes))}
+// processHTTPResponse sets the phase and outputs of the HTTP template from its response
+func (ae *AgentExecutor) processHTTPResponse(ctx context.Context, tmpl wfv1.Template, response *http.Response, result *wfv1.NodeResult) error {
+	outputs := wfv1.Outputs{}
+	var bodyBytes []byte
+	var err error
+	if len(tmpl.Outputs.Artifacts) > 0 {
+		// The body is saved as an artifact, rather than kept in the node status
+		art, err := ae.saveHTTPBodyArtifact(ctx, tmpl, response.Body)
+		if err != nil {
+			return err
+		}
+		outputs.Artifacts = wfv1.Artifacts{*art}
+	} else {
+		bodyBytes, err = io.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		outputs.Result = new(string(bodyBytes))
+	}
+
+	evalScope := httpEvalScope(tmpl.HTTP, response, bodyBytes)
 	phase := wfv1.NodeSucceeded
 	message := ""
 	if tmpl.HTTP.SuccessCondition == "" {
@@ -278,23 +331,9 @@ func (ae *AgentExecutor) executeHTTPTemplate(ctx context.Context, tmpl wfv1.Temp
 			message = fmt.Sprintf("received non-2xx response code: %d", response.StatusCode)
 		}
 	} else {
-		evalScope := map[string]any{
-			"request": map[string]any{
-				"method":    tmpl.HTTP.Method,
-				"url":       tmpl.HTTP.URL,
-				"body":      tmpl.HTTP.Body,
-				"bodyBytes": tmpl.HTTP.GetBodyBytes(),
-				"headers":   tmpl.HTTP.Headers.ToHeader(),
-			},
-			"response": map[string]any{
-				"statusCode": response.StatusCode,
-				"body":       string(bodyBytes),
-				"headers":    response.Header,
-			},
-		}
 		success, err := argoexpr.EvalBool(tmpl.HTTP.SuccessCondition, evalScope)
 		if err != nil {
-			return 0, err
+			return err
 		}
 		if !success {
 			phase = wfv1.NodeFailed
@@ -302,10 +341,387 @@ func (ae *AgentExecutor) executeHTTPTemplate(ctx context.Context, tmpl wfv1.Temp
 		}
 	}
 
+	outputs.Parameters, err = httpOutputParameters(ctx, tmpl.Outputs.Parameters, evalScope, bodyBytes)
+	// The response of a failed request often does not have the expected shape, so only a successful one must
+	if err != nil && phase == wfv1.NodeSucceeded {
+		return err
+	}
+
 	result.Phase = phase
 	result.Message = message
 	result.Outputs = &outputs
-	return 0, nil
+	return nil
+}
+
+// httpEvalScope is the scope of the expressions of an HTTP template
+func httpEvalScope(httpTemplate *wfv1.HTTP, response *http.Response, bodyBytes []byte) map[string]any {
+	return map[string]any{
+		"request": map[string]any{
+			"method":    httpTemplate.Method,
+			"url":       httpTemplate.URL,
+			"body":      httpTemplate.Body,
+			"bodyBytes": httpTemplate.GetBodyBytes(),
+			"headers":   httpTemplate.Headers.ToHeader(),
+		},
+		"response": map[string]any{
+			"statusCode": response.StatusCode,
+			"body":       string(bodyBytes),
+			"headers":    response.Header,
+		},
+	}
+}
+
+// httpBackoff is the backoff between the polls or the retries of an HTTP template
+type httpBackoff struct {
+	delay  time.Duration
+	factor int
+	cap    time.Duration
+	// deadline is when to stop, unless it is zero
+	deadline time.Time
+}
+
+// newHTTPBackoff returns the backoff, with the defaults for the fields it does not have. A zero maxDuration has no
+// deadline.
+func newHTTPBackoff(backoff *wfv1.Backoff, delay time.Duration, factor int, maxDuration time.Duration) (*httpBackoff, error) {
+	b := &httpBackoff{delay: delay, factor: factor}
+	if backoff != nil {
+		var err error
+		if backoff.Duration != "" {
+			if b.delay, err = wfv1.ParseStringToDuration(backoff.Duration); err != nil {
+				return nil, err
+			}
+		}
+		if backoff.Factor != nil {
+			factor, err := intstr.Int(backoff.Factor)
+			if err != nil {
+				return nil, err
+			}
+			b.factor = max(*factor, 1)
+		}
+		if backoff.Cap != "" {
+			if b.cap, err = wfv1.ParseStringToDuration(backoff.Cap); err != nil {
+				return nil, err
+			}
+		}
+		if backoff.MaxDuration != "" {
+			if maxDuration, err = wfv1.ParseStringToDuration(backoff.MaxDuration); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if maxDuration > 0 {
+		b.deadline = time.Now().Add(maxDuration)
+	}
+	return b, nil
+}
+
+// next returns the delay until the next poll or retry, and backs off the one after it
+func (b *httpBackoff) next() time.Duration {
+	delay := b.delay
+	b.delay *= time.Duration(b.factor)
+	if b.cap > 0 && b.delay > b.cap {
+		b.delay = b.cap
+	}
+	return delay
+}
+
+// capped returns the delay, limited to the cap of the backoff
+func (b *httpBackoff) capped(delay time.Duration) time.Duration {
+	if b.cap > 0 {
+		return min(delay, b.cap)
+	}
+	return delay
+}
+
+// httpPoll is the state of an HTTP template which is polling for the completion of the operation it started
+type httpPoll struct {
+	httpBackoff
+	url string
+}
+
+func newHTTPPoll(url string, backoff *wfv1.Backoff) (*httpPoll, error) {
+	b, err := newHTTPBackoff(backoff, 10*time.Second, 1, wfv1.DefaultHTTPPollMaxDuration)
+	if err != nil {
+		return nil, err
+	}
+	return &httpPoll{httpBackoff: *b, url: url}, nil
+}
+
+// status returns the state of the poll, which is recorded in the result of its node
+func (p *httpPoll) status() *wfv1.HTTPPollStatus {
+	return &wfv1.HTTPPollStatus{URL: p.url, Delay: p.delay.String(), Deadline: metav1.NewTime(p.deadline)}
+}
+
+// resumeHTTPPoll resumes the poll recorded in the result of the node, which a previous agent was polling for, so that
+// the request which started the operation is not sent again
+func (ae *AgentExecutor) resumeHTTPPoll(ctx context.Context, nodeID string, tmpl wfv1.Template, result wfv1.NodeResult) {
+	if tmpl.HTTP == nil || tmpl.HTTP.Poll == nil || result.Phase != wfv1.NodeRunning || result.HTTPPoll == nil {
+		return
+	}
+	// this agent is polling, or has already completed the node
+	if _, ok := ae.consideredTasks.Load(nodeID); ok {
+		return
+	}
+	if _, ok := ae.httpPolls.Load(nodeID); ok {
+		return
+	}
+	poll, err := newHTTPPoll(result.HTTPPoll.URL, tmpl.HTTP.Poll.Backoff)
+	if err == nil && result.HTTPPoll.Delay != "" {
+		poll.delay, err = time.ParseDuration(result.HTTPPoll.Delay)
+	}
+	if err != nil {
+		logging.RequireLoggerFromContext(ctx).WithField("nodeID", nodeID).WithError(err).Warn(ctx, "Failed to resume HTTP poll")
+		return
+	}
+	if !result.HTTPPoll.Deadline.IsZero() {
+		poll.deadline = result.HTTPPoll.Deadline.Time
+	}
+	ae.httpPolls.LoadOrStore(nodeID, poll)
+	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"nodeID": nodeID, "url": poll.url}).Info(ctx, "Resuming HTTP poll")
+}
+
+// startHTTPPoll starts polling for the completion of the operation started by the response. The node is requeued
+// for each poll, rather than tying up a worker.
+func (ae *AgentExecutor) startHTTPPoll(nodeID string, tmpl wfv1.Template, response *http.Response, result *wfv1.NodeResult) (time.Duration, error) {
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		result.Phase = wfv1.NodeFailed
+		result.Message = fmt.Sprintf("received non-2xx response code: %d", response.StatusCode)
+		return 0, nil
+	}
+	pollURL := tmpl.HTTP.URL
+	if tmpl.HTTP.Poll.URLExpression != "" {
+		value, err := evalHTTPExpression(tmpl.HTTP.Poll.URLExpression, httpEvalScope(tmpl.HTTP, response, bodyBytes))
+		if err != nil {
+			return 0, err
+		}
+		s, ok := value.(string)
+		if !ok {
+			return 0, fmt.Errorf("poll urlExpression '%s' evaluated to %v, not a URL", tmpl.HTTP.Poll.URLExpression, value)
+		}
+		// e.g. a Location header may be relative to the request
+		u, err := response.Request.URL.Parse(s)
+		if err != nil {
+			return 0, err
+		}
+		pollURL = u.String()
+	}
+	poll, err := newHTTPPoll(pollURL, tmpl.HTTP.Poll.Backoff)
+	if err != nil {
+		return 0, err
+	}
+	ae.httpPolls.Store(nodeID, poll)
+	delay := poll.next()
+	result.Phase = wfv1.NodeRunning
+	result.Message = fmt.Sprintf("polling %s", pollURL)
+	result.HTTPPoll = poll.status()
+	return delay, nil
+}
+
+// pollHTTPTemplate makes a polling request for an HTTP template, and completes it if the operation has completed
+func (ae *AgentExecutor) pollHTTPTemplate(ctx context.Context, nodeID string, tmpl wfv1.Template, poll *httpPoll, result *wfv1.NodeResult) (time.Duration, error) {
+	pollTmpl := tmpl
+	pollTmpl.HTTP = tmpl.HTTP.DeepCopy()
+	pollTmpl.HTTP.URL = poll.url
+	pollTmpl.HTTP.Method = tmpl.HTTP.Poll.GetMethod()
+	pollTmpl.HTTP.Body = ""
+	pollTmpl.HTTP.BodyFrom = nil
+	var cancel context.CancelFunc
+	if tmpl.HTTP.TimeoutSeconds != nil {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*tmpl.HTTP.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+	// Any outcome other than polling again ends the poll
+	done := func() { ae.httpPolls.Delete(nodeID) }
+	response, err := ae.executeHTTPTemplateRequest(ctx, pollTmpl.HTTP)
+	if err != nil {
+		done()
+		return 0, err
+	}
+	defer response.Body.Close()
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		done()
+		return 0, err
+	}
+	evalScope := exprenv.GetFuncMap(httpEvalScope(pollTmpl.HTTP, response, bodyBytes))
+	if condition := tmpl.HTTP.Poll.FailureCondition; condition != "" {
+		failed, err := argoexpr.EvalBool(condition, evalScope)
+		if err != nil {
+			done()
+			return 0, err
+		}
+		if failed {
+			done()
+			result.Phase = wfv1.NodeFailed
+			result.Message = fmt.Sprintf("poll failureCondition '%s' evaluated true", condition)
+			return 0, nil
+		}
+	}
+	completed, err := argoexpr.EvalBool(tmpl.HTTP.Poll.CompletionCondition, evalScope)
+	if err != nil {
+		done()
+		return 0, err
+	}
+	if completed {
+		done()
+		response.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		return 0, ae.processHTTPResponse(ctx, pollTmpl, response, result)
+	}
+	if time.Now().After(poll.deadline) {
+		done()
+		result.Phase = wfv1.NodeFailed
+		result.Message = fmt.Sprintf("operation did not complete before the poll's maxDuration, last polled %s", poll.url)
+		return 0, nil
+	}
+	delay := poll.next()
+	result.Phase = wfv1.NodeRunning
+	result.Message = fmt.Sprintf("polling %s", poll.url)
+	result.HTTPPoll = poll.status()
+	return delay, nil
+}
+
+// evalHTTPExpression evaluates an expression of an HTTP template
+func evalHTTPExpression(expression string, evalScope map[string]any) (any, error) {
+	funcMap := exprenv.GetFuncMap(evalScope)
+	program, err := expr.Compile(expression, expr.Env(funcMap))
+	if err != nil {
+		return nil, err
+	}
+	value, err := expr.Run(program, funcMap)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("expression '%s' evaluated to nil", expression)
+	}
+	return value, nil
+}
+
+// httpOutputParameters extracts the output parameters of an HTTP template from its response. A parameter which cannot
+// be extracted takes its default, if it has one.
+func httpOutputParameters(ctx context.Context, params []wfv1.Parameter, evalScope map[string]any, bodyBytes []byte) ([]wfv1.Parameter, error) {
+	var outputs []wfv1.Parameter
+	for _, param := range params {
+		param := *param.DeepCopy()
+		if param.ValueFrom != nil {
+			value, err := httpOutputParameter(ctx, param.ValueFrom, evalScope, bodyBytes)
+			if err != nil {
+				if param.ValueFrom.Default == nil {
+					return nil, fmt.Errorf("failed to extract output parameter '%s' from the response: %w", param.Name, err)
+				}
+				value = param.ValueFrom.Default.String()
+			}
+			param.Value = wfv1.AnyStringPtr(value)
+		}
+		outputs = append(outputs, param)
+	}
+	return outputs, nil
+}
+
+func httpOutputParameter(ctx context.Context, valueFrom *wfv1.ValueFrom, evalScope map[string]any, bodyBytes []byte) (string, error) {
+	switch {
+	case valueFrom.Expression != "":
+		value, err := evalHTTPExpression(valueFrom.Expression, evalScope)
+		if err != nil {
+			return "", err
+		}
+		return httpOutputParameterString(value)
+	case valueFrom.JSONPath != "":
+		var body any
+		if err := json.Unmarshal(bodyBytes, &body); err != nil {
+			return "", err
+		}
+		value, err := jsonpath.Query(valueFrom.JSONPath, body)
+		if err != nil {
+			return "", err
+		}
+		return httpOutputParameterString(value)
+	case valueFrom.JQFilter != "":
+		return jqFilter(ctx, bodyBytes, valueFrom.JQFilter)
+	}
+	return "", fmt.Errorf("one of expression, jsonPath or jqFilter must be specified")
+}
+
+// httpOutputParameterString formats a value extracted from a response as strings are, and other values as JSON
+func httpOutputParameterString(value any) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(value)
+	return string(b), err
+}
+
+// saveHTTPBodyArtifact saves the response body as the output artifact of the HTTP template, unarchived
+func (ae *AgentExecutor) saveHTTPBodyArtifact(ctx context.Context, tmpl wfv1.Template, body io.Reader) (*wfv1.Artifact, error) {
+	art := tmpl.Outputs.Artifacts[0].DeepCopy()
+	f, err := os.CreateTemp("", "http-body-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	_, err = io.Copy(f, body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response body: %w", err)
+	}
+	if !art.HasLocation() {
+		key, err := tmpl.ArchiveLocation.GetKey()
+		if err != nil {
+			return nil, err
+		}
+		artLocation, err := tmpl.ArchiveLocation.Get()
+		if err != nil {
+			return nil, err
+		}
+		if err := art.SetType(artLocation); err != nil {
+			return nil, err
+		}
+		if err := art.SetKey(path.Join(key, art.Name)); err != nil {
+			return nil, err
+		}
+	}
+	art.Archive = &wfv1.ArchiveStrategy{None: &wfv1.NoneStrategy{}}
+	if err := setDigest(art, f.Name()); err != nil {
+		return nil, err
+	}
+	driverArt := art.DeepCopy()
+	if err := driverArt.Relocate(tmpl.ArchiveLocation); err != nil {
+		return nil, err
+	}
+	driver, err := artifacts.NewDriver(ctx, driverArt, ae)
+	if err != nil {
+		return nil, err
+	}
+	if err := driver.Save(ctx, f.Name(), driverArt); err != nil {
+		return nil, fmt.Errorf("failed to save the response body as artifact '%s': %w", art.Name, err)
+	}
+	logging.RequireLoggerFromContext(ctx).WithField("name", art.Name).Info(ctx, "Saved the response body as an artifact")
+	return art, nil
+}
+
+// GetSecret retrieves a secret value for an artifact driver
+func (ae *AgentExecutor) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := util.GetSecrets(ctx, ae.ClientSet, ae.Namespace, name, key)
+	return string(secret), err
+}
+
+// GetConfigMapKey retrieves a configmap value for an artifact driver
+func (ae *AgentExecutor) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	cm, err := ae.ClientSet.CoreV1().ConfigMaps(ae.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	value, ok := cm.Data[key]
+	if !ok {
+		return "", fmt.Errorf("configmap '%s' does not have the key '%s'", name, key)
+	}
+	return value, nil
 }
 
 var httpClientSkip = &http.Client{
@@ -352,14 +768,223 @@ func (ae *AgentExecutor) executeHTTPTemplateRequest(ctx context.Context, httpTem
 		}
 	}
 
-	response, err := httpClients[httpTemplate.InsecureSkipVerify].Do(request)
+	if auth := httpTemplate.Auth; auth != nil && auth.BasicAuth.UsernameSecret != nil && auth.BasicAuth.PasswordSecret != nil {
+		username, err := ae.GetSecret(ctx, auth.BasicAuth.UsernameSecret.Name, auth.BasicAuth.UsernameSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		password, err := ae.GetSecret(ctx, auth.BasicAuth.PasswordSecret.Name, auth.BasicAuth.PasswordSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		request.SetBasicAuth(username, password)
+	}
+
+	httpRetry := httpTemplate.Retry
+	if httpRetry == nil {
+		httpRetry = ae.httpRetry
+	}
+	client, err := ae.httpClient(ctx, httpTemplate)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return ae.doHTTPRequest(ctx, client, request, httpRetry)
+}
+
+// httpAuthSecrets are the values of the secrets of the TLS and authentication of an HTTP template
+type httpAuthSecrets struct {
+	CACert       string `json:"caCert,omitempty"`
+	ClientCert   string `json:"clientCert,omitempty"`
+	ClientKey    string `json:"clientKey,omitempty"`
+	ClientID     string `json:"clientID,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+	TokenURL     string `json:"tokenURL,omitempty"`
+}
+
+// getSecret sets the value to that of the key of the secret
+func (ae *AgentExecutor) getSecret(ctx context.Context, selector *apiv1.SecretKeySelector, value *string) error {
+	var err error
+	*value, err = ae.GetSecret(ctx, selector.Name, selector.Key)
+	return err
+}
+
+// httpClient returns the client for the TLS and authentication of the HTTP template. Clients are cached by the
+// contents of their secrets, so OAuth2 tokens are reused by the templates of the workflow until they expire, and a
+// rotated secret gets a new client.
+func (ae *AgentExecutor) httpClient(ctx context.Context, httpTemplate *wfv1.HTTP) (*http.Client, error) {
+	var clientCert *wfv1.ClientCertAuth
+	var oauth2Auth *wfv1.OAuth2Auth
+	if auth := httpTemplate.Auth; auth != nil {
+		if auth.ClientCert.ClientCertSecret != nil && auth.ClientCert.ClientKeySecret != nil {
+			clientCert = &auth.ClientCert
+		}
+		if auth.OAuth2.ClientIDSecret != nil && auth.OAuth2.ClientSecretSecret != nil && auth.OAuth2.TokenURLSecret != nil {
+			oauth2Auth = &auth.OAuth2
+		}
+	}
+	if clientCert == nil && oauth2Auth == nil && httpTemplate.CACertSecret == nil {
+		return httpClients[httpTemplate.InsecureSkipVerify], nil
+	}
+	var secrets httpAuthSecrets
+	if selector := httpTemplate.CACertSecret; selector != nil {
+		if err := ae.getSecret(ctx, selector, &secrets.CACert); err != nil {
+			return nil, err
+		}
+	}
+	if clientCert != nil {
+		if err := ae.getSecret(ctx, clientCert.ClientCertSecret, &secrets.ClientCert); err != nil {
+			return nil, err
+		}
+		if err := ae.getSecret(ctx, clientCert.ClientKeySecret, &secrets.ClientKey); err != nil {
+			return nil, err
+		}
+	}
+	var scopes []string
+	var endpointParams []wfv1.OAuth2EndpointParam
+	if oauth2Auth != nil {
+		if err := ae.getSecret(ctx, oauth2Auth.ClientIDSecret, &secrets.ClientID); err != nil {
+			return nil, err
+		}
+		if err := ae.getSecret(ctx, oauth2Auth.ClientSecretSecret, &secrets.ClientSecret); err != nil {
+			return nil, err
+		}
+		if err := ae.getSecret(ctx, oauth2Auth.TokenURLSecret, &secrets.TokenURL); err != nil {
+			return nil, err
+		}
+		scopes, endpointParams = oauth2Auth.Scopes, oauth2Auth.EndpointParams
+	}
+	// The key is a digest, so the cache does not hold the secrets in plain text
+	digest := sha256.Sum256([]byte(wfv1.MustMarshallJSON([]any{secrets, scopes, endpointParams, httpTemplate.InsecureSkipVerify})))
+	key := hex.EncodeToString(digest[:])
+	if client, ok := ae.httpAuthClients.Load(key); ok {
+		return client.(*http.Client), nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: httpTemplate.InsecureSkipVerify}
+	if selector := httpTemplate.CACertSecret; selector != nil {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(secrets.CACert)) {
+			return nil, fmt.Errorf("secret '%s' key '%s' does not have any PEM certificates", selector.Name, selector.Key)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if clientCert != nil {
+		cert, err := tls.X509KeyPair([]byte(secrets.ClientCert), []byte(secrets.ClientKey))
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client := &http.Client{Transport: transport}
+	if oauth2Auth != nil {
+		// The client outlives the task, and requests tokens, and the API, with the TLS configuration
+		tokenCtx := context.WithValue(context.WithoutCancel(ctx), oauth2.HTTPClient, client)
+		client = httpartifact.CreateOauth2Client(tokenCtx, secrets.ClientID, secrets.ClientSecret, secrets.TokenURL, scopes, endpointParams)
+	}
+	actual, _ := ae.httpAuthClients.LoadOrStore(key, client)
+	return actual.(*http.Client), nil
+}
+
+// doHTTPRequest sends the request within the rate limit of its host, and retries it as the retry allows. The result
+// is that of the last attempt.
+func (ae *AgentExecutor) doHTTPRequest(ctx context.Context, client *http.Client, request *http.Request, httpRetry *wfv1.HTTPRetry) (*http.Response, error) {
+	var backoff *httpBackoff
+	limit := 0
+	if httpRetry != nil {
+		var err error
+		backoff, err = newHTTPBackoff(httpRetry.Backoff, time.Second, 2, wfv1.DefaultHTTPRetryMaxDuration)
+		if err != nil {
+			return nil, err
+		}
+		limit = 3
+		if httpRetry.Limit != nil {
+			l, err := intstr.Int(httpRetry.Limit)
+			if err != nil {
+				return nil, err
+			}
+			limit = *l
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+		if err := ae.waitForHost(ctx, request.URL.Hostname()); err != nil {
+			return nil, err
+		}
+		response, err := client.Do(request)
+		if attempt >= limit {
+			return response, err
+		}
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if !httpRetry.RetriesNetworkErrors(request.Method) || ctx.Err() != nil {
+				return nil, err
+			}
+			delay = backoff.next()
+		case httpRetry.RetriesStatusCode(response.StatusCode):
+			delay = backoff.next()
+			if after := retryAfter(response.Header.Get("Retry-After")); after > 0 {
+				delay = backoff.capped(after)
+			}
+		default:
+			return response, nil
+		}
+		if !backoff.deadline.IsZero() && time.Now().Add(delay).After(backoff.deadline) {
+			return response, err
+		}
+		logger := logging.RequireLoggerFromContext(ctx).WithField("url", request.URL.String()).WithField("delay", delay)
+		if err != nil {
+			logger = logger.WithError(err)
+		} else {
+			logger = logger.WithField("statusCode", response.StatusCode)
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+		logger.Info(ctx, "Retrying HTTP request")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
 }
 
-func (ae *AgentExecutor) executePluginTemplate(ctx context.Context, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error) {
+// retryAfter returns the delay requested by a Retry-After header, which is either a number of seconds or a date
+func retryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// waitForHost waits until the rate limit of the host allows another request
+func (ae *AgentExecutor) waitForHost(ctx context.Context, host string) error {
+	if ae.httpRateLimit == nil {
+		return nil
+	}
+	limiter, ok := ae.hostLimiters.Load(host)
+	if !ok {
+		limit := ae.httpRateLimit.GetLimit(host)
+		limiter, _ = ae.hostLimiters.LoadOrStore(host, rate.NewLimiter(rate.Limit(limit.Limit), max(limit.Burst, 1)))
+	}
+	return limiter.(*rate.Limiter).Wait(ctx)
+}
+
+func (ae *AgentExecutor) executePluginTemplate(ctx context.Context, _ string, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error) {
 	args := executorplugins.ExecuteTemplateArgs{
 		Workflow: &executorplugins.Workflow{
 			ObjectMeta: executorplugins.ObjectMeta{
@@ -372,6 +997,10 @@ func (ae *AgentExecutor) executePluginTemplate(ctx context.Context, tmpl wfv1.Te
 	}
 	reply := &executorplugins.ExecuteTemplateReply{}
 	for _, plug := range ae.plugins {
+		// plugins are sidecars, so their calls are within the rate limit of localhost
+		if err := ae.waitForHost(ctx, "localhost"); err != nil {
+			return 0, err
+		}
 		if err := plug.ExecuteTemplate(ctx, args, reply); err != nil {
 			return 0, err
 		} else if reply.Node != nil {
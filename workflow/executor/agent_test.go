@@ -2,7 +2,18 @@ package executor
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -10,8 +21,13 @@ import (
 	"github.com/argoproj/argo-workflows/v4/util/logging"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
 
+	argoConfig "github.com/argoproj/argo-workflows/v4/config"
 	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	executorplugins "github.com/argoproj/argo-workflows/v4/pkg/plugins/executor"
 )
@@ -76,7 +92,7 @@ func TestAgentPluginExecuteTaskSet(t *testing.T) {
 				consideredTasks: &sync.Map{},
 				plugins:         []executorplugins.TemplateExecutor{tc.plugin},
 			}
-			_, requeue, err := ae.processTask(ctx, *tc.template)
+			_, requeue, err := ae.processTask(ctx, "", *tc.template)
 			if err != nil {
 				t.Errorf("expect nil, but got %v", err)
 			}
@@ -98,3 +114,469 @@ func (a alwaysSucceededPlugin) ExecuteTemplate(_ context.Context, _ executorplug
 	reply.Requeue = &metav1.Duration{Duration: a.requeue}
 	return nil
 }
+
+func TestExecuteHTTPTemplateOutputs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Operation", "op-1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "abc", "items": [1, 2]}`))
+	}))
+	defer server.Close()
+	ctx := logging.TestContext(t.Context())
+	ae := &AgentExecutor{}
+	tmpl := v1alpha1.Template{
+		HTTP: &v1alpha1.HTTP{URL: server.URL},
+		Outputs: v1alpha1.Outputs{
+			Parameters: []v1alpha1.Parameter{
+				{Name: "id", ValueFrom: &v1alpha1.ValueFrom{JSONPath: "$.id"}},
+				{Name: "items", ValueFrom: &v1alpha1.ValueFrom{JQFilter: ".items"}},
+				{Name: "status", ValueFrom: &v1alpha1.ValueFrom{Expression: "response.statusCode"}},
+				{Name: "operation", ValueFrom: &v1alpha1.ValueFrom{Expression: `response.headers["X-Operation"][0]`}},
+				{Name: "missing", ValueFrom: &v1alpha1.ValueFrom{JSONPath: "$.missing", Default: v1alpha1.AnyStringPtr("none")}},
+			},
+		},
+	}
+
+	result, _, err := ae.processTask(ctx, "", tmpl)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase)
+	assert.JSONEq(t, `{"id": "abc", "items": [1, 2]}`, *result.Outputs.Result)
+	values := map[string]string{}
+	for _, param := range result.Outputs.Parameters {
+		values[param.Name] = param.Value.String()
+	}
+	assert.Equal(t, map[string]string{
+		"id":        "abc",
+		"items":     "[1,2]",
+		"status":    "201",
+		"operation": "op-1",
+		"missing":   "none",
+	}, values)
+
+	t.Run("ExtractionFailure", func(t *testing.T) {
+		tmpl := tmpl.DeepCopy()
+		tmpl.Outputs.Parameters = []v1alpha1.Parameter{{Name: "missing", ValueFrom: &v1alpha1.ValueFrom{JSONPath: "$.missing"}}}
+		result, _, err := ae.processTask(ctx, "", *tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Contains(t, result.Message, "failed to extract output parameter 'missing' from the response")
+	})
+}
+
+func TestExecuteHTTPTemplateBodyArtifact(t *testing.T) {
+	var saved []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Length", "11")
+			_, _ = w.Write([]byte("large-body!"))
+		case http.MethodPut:
+			assert.Equal(t, "/artifacts/report", r.URL.Path)
+			saved, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+	ctx := logging.TestContext(t.Context())
+	ae := &AgentExecutor{
+		Namespace: "argo",
+		ClientSet: fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "artifactory", Namespace: "argo"},
+			Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("password")},
+		}),
+	}
+	tmpl := v1alpha1.Template{
+		HTTP: &v1alpha1.HTTP{URL: server.URL},
+		Outputs: v1alpha1.Outputs{
+			Parameters: []v1alpha1.Parameter{{Name: "length", ValueFrom: &v1alpha1.ValueFrom{Expression: `response.headers["Content-Length"][0]`}}},
+			Artifacts:  v1alpha1.Artifacts{{Name: "report"}},
+		},
+		ArchiveLocation: &v1alpha1.ArtifactLocation{Artifactory: &v1alpha1.ArtifactoryArtifact{
+			URL: server.URL + "/artifacts",
+			ArtifactoryAuth: v1alpha1.ArtifactoryAuth{
+				UsernameSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "artifactory"}, Key: "username"},
+				PasswordSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "artifactory"}, Key: "password"},
+			},
+		}},
+	}
+
+	result, _, err := ae.processTask(ctx, "", tmpl)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+	assert.Equal(t, "large-body!", string(saved))
+	// The body is not kept in the node status
+	assert.Nil(t, result.Outputs.Result)
+	require.Len(t, result.Outputs.Artifacts, 1)
+	art := result.Outputs.Artifacts[0]
+	key, err := art.GetKey()
+	require.NoError(t, err)
+	assert.Equal(t, "/artifacts/report", key)
+	assert.NotEmpty(t, art.Digest)
+	assert.NotNil(t, art.Archive.None)
+	require.Len(t, result.Outputs.Parameters, 1)
+	assert.Equal(t, "11", result.Outputs.Parameters[0].Value.String())
+}
+
+func TestExecuteHTTPTemplatePoll(t *testing.T) {
+	var starts, polls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jobs":
+			assert.Equal(t, http.MethodPost, r.Method)
+			starts++
+			w.Header().Set("Location", "/jobs/1")
+			w.WriteHeader(http.StatusAccepted)
+		case "/jobs/1":
+			assert.Equal(t, http.MethodGet, r.Method)
+			polls++
+			if polls < 2 {
+				_, _ = w.Write([]byte(`{"status": "running"}`))
+			} else {
+				_, _ = w.Write([]byte(`{"status": "done", "id": "abc"}`))
+			}
+		case "/jobs/2":
+			_, _ = w.Write([]byte(`{"status": "error"}`))
+		}
+	}))
+	defer server.Close()
+	ctx := logging.TestContext(t.Context())
+	tmpl := v1alpha1.Template{
+		HTTP: &v1alpha1.HTTP{
+			URL:    server.URL + "/jobs",
+			Method: http.MethodPost,
+			Poll: &v1alpha1.HTTPPoll{
+				URLExpression:       `response.headers["Location"][0]`,
+				CompletionCondition: `jsonpath(response.body, "$.status") == "done"`,
+				FailureCondition:    `jsonpath(response.body, "$.status") == "error"`,
+				Backoff:             &v1alpha1.Backoff{Duration: "1s", Factor: new(intstr.FromInt32(2)), Cap: "3s"},
+			},
+		},
+		Outputs: v1alpha1.Outputs{
+			Parameters: []v1alpha1.Parameter{{Name: "id", ValueFrom: &v1alpha1.ValueFrom{JSONPath: "$.id"}}},
+		},
+	}
+
+	t.Run("Completed", func(t *testing.T) {
+		ae := &AgentExecutor{}
+		result, requeue, err := ae.processTask(ctx, "node", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeRunning, result.Phase)
+		assert.Equal(t, "polling "+server.URL+"/jobs/1", result.Message)
+		assert.Equal(t, time.Second, requeue)
+		require.NotNil(t, result.HTTPPoll)
+		assert.Equal(t, server.URL+"/jobs/1", result.HTTPPoll.URL)
+		assert.Equal(t, "2s", result.HTTPPoll.Delay)
+
+		result, requeue, err = ae.processTask(ctx, "node", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeRunning, result.Phase)
+		assert.Equal(t, 2*time.Second, requeue)
+
+		result, requeue, err = ae.processTask(ctx, "node", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+		assert.Zero(t, requeue)
+		require.Len(t, result.Outputs.Parameters, 1)
+		assert.Equal(t, "abc", result.Outputs.Parameters[0].Value.String())
+		_, polling := ae.httpPolls.Load("node")
+		assert.False(t, polling)
+	})
+
+	t.Run("Resumed", func(t *testing.T) {
+		starts, polls = 0, 1
+		ae := &AgentExecutor{consideredTasks: &sync.Map{}}
+		deadline := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+		ae.resumeHTTPPoll(ctx, "node", tmpl, v1alpha1.NodeResult{
+			Phase:    v1alpha1.NodeRunning,
+			HTTPPoll: &v1alpha1.HTTPPollStatus{URL: server.URL + "/jobs/1", Delay: "3s", Deadline: deadline},
+		})
+		value, polling := ae.httpPolls.Load("node")
+		require.True(t, polling)
+		poll := value.(*httpPoll)
+		assert.Equal(t, 3*time.Second, poll.delay)
+		assert.Equal(t, deadline.Time, poll.deadline)
+
+		result, _, err := ae.processTask(ctx, "node", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+		assert.Zero(t, starts, "the request which started the operation is not sent again")
+	})
+
+	t.Run("NotResumed", func(t *testing.T) {
+		ae := &AgentExecutor{consideredTasks: &sync.Map{}}
+		status := &v1alpha1.HTTPPollStatus{URL: server.URL + "/jobs/1"}
+		ae.resumeHTTPPoll(ctx, "completed", tmpl, v1alpha1.NodeResult{Phase: v1alpha1.NodeSucceeded, HTTPPoll: status})
+		ae.consideredTasks.Store("considered", true)
+		ae.resumeHTTPPoll(ctx, "considered", tmpl, v1alpha1.NodeResult{Phase: v1alpha1.NodeRunning, HTTPPoll: status})
+		_, polling := ae.httpPolls.Load("completed")
+		assert.False(t, polling)
+		_, polling = ae.httpPolls.Load("considered")
+		assert.False(t, polling)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		ae := &AgentExecutor{}
+		ae.httpPolls.Store("node", &httpPoll{url: server.URL + "/jobs/2", httpBackoff: httpBackoff{deadline: time.Now().Add(time.Hour), delay: time.Second, factor: 1}})
+		result, requeue, err := ae.processTask(ctx, "node", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Contains(t, result.Message, "failureCondition")
+		assert.Zero(t, requeue)
+	})
+
+	t.Run("TimedOut", func(t *testing.T) {
+		polls = 0
+		ae := &AgentExecutor{}
+		ae.httpPolls.Store("node", &httpPoll{url: server.URL + "/jobs/1", httpBackoff: httpBackoff{deadline: time.Now().Add(-time.Second), delay: time.Second, factor: 1}})
+		result, requeue, err := ae.processTask(ctx, "node", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Contains(t, result.Message, "did not complete")
+		assert.Zero(t, requeue)
+	})
+}
+
+func TestExecuteHTTPTemplateRetry(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body))
+		switch r.URL.Path {
+		case "/unavailable":
+			if requests < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/retry-after":
+			if requests < 2 {
+				w.Header().Set("Retry-After", "3600")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		case "/reset":
+			if requests < 2 {
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				_ = conn.Close()
+				return
+			}
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	ctx := logging.TestContext(t.Context())
+	httpRetry := &v1alpha1.HTTPRetry{Limit: new(intstr.FromInt32(2)), Backoff: &v1alpha1.Backoff{Duration: "1ms"}}
+	tmpl := func(path string) v1alpha1.Template {
+		return v1alpha1.Template{HTTP: &v1alpha1.HTTP{URL: server.URL + path, Method: http.MethodPost, Body: "payload", Retry: httpRetry}}
+	}
+
+	t.Run("StatusCode", func(t *testing.T) {
+		requests = 0
+		result, _, err := (&AgentExecutor{}).processTask(ctx, "", tmpl("/unavailable"))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+		assert.Equal(t, 3, requests)
+	})
+	t.Run("RetryAfterCapped", func(t *testing.T) {
+		requests = 0
+		tmpl := tmpl("/retry-after")
+		tmpl.HTTP.Retry = &v1alpha1.HTTPRetry{Backoff: &v1alpha1.Backoff{Duration: "1ms", Cap: "1ms"}}
+		result, _, err := (&AgentExecutor{}).processTask(ctx, "", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("NetworkError", func(t *testing.T) {
+		requests = 0
+		tmpl := tmpl("/reset")
+		tmpl.HTTP.Method = http.MethodPut
+		result, _, err := (&AgentExecutor{}).processTask(ctx, "", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("NetworkErrorNotIdempotent", func(t *testing.T) {
+		requests = 0
+		result, _, err := (&AgentExecutor{}).processTask(ctx, "", tmpl("/reset"))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("NetworkErrorEnabled", func(t *testing.T) {
+		requests = 0
+		tmpl := tmpl("/reset")
+		tmpl.HTTP.Retry = &v1alpha1.HTTPRetry{NetworkErrors: new(true), Backoff: &v1alpha1.Backoff{Duration: "1ms"}}
+		result, _, err := (&AgentExecutor{}).processTask(ctx, "", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("NotRetried", func(t *testing.T) {
+		requests = 0
+		result, _, err := (&AgentExecutor{}).processTask(ctx, "", tmpl("/bad"))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("LimitReached", func(t *testing.T) {
+		requests = 0
+		tmpl := tmpl("/unavailable")
+		tmpl.HTTP.Retry = &v1alpha1.HTTPRetry{Limit: new(intstr.FromInt32(1)), Backoff: &v1alpha1.Backoff{Duration: "1ms"}}
+		result, _, err := (&AgentExecutor{}).processTask(ctx, "", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Equal(t, "received non-2xx response code: 503", result.Message)
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("ControllerDefault", func(t *testing.T) {
+		requests = 0
+		tmpl := tmpl("/unavailable")
+		tmpl.HTTP.Retry = nil
+		result, _, err := (&AgentExecutor{httpRetry: httpRetry}).processTask(ctx, "", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+		assert.Equal(t, 3, requests)
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, 2*time.Second, retryAfter("2"))
+	assert.Zero(t, retryAfter(""))
+	assert.Zero(t, retryAfter("soon"))
+	delay := retryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.Greater(t, delay, 55*time.Second)
+	assert.LessOrEqual(t, delay, time.Minute)
+}
+
+func TestWaitForHost(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	ae := &AgentExecutor{httpRateLimit: &argoConfig.HTTPRateLimit{
+		Limit: 0.001,
+		Burst: 1,
+		Hosts: map[string]argoConfig.ResourceRateLimit{"fast.example.com": {Limit: 1000, Burst: 10}},
+	}}
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, ae.waitForHost(waitCtx, "slow.example.com"))
+	// The bucket is empty, and will not refill before the deadline
+	require.Error(t, ae.waitForHost(waitCtx, "slow.example.com"))
+	// Other hosts have their own buckets
+	require.NoError(t, ae.waitForHost(waitCtx, "other.example.com"))
+	for range 10 {
+		require.NoError(t, ae.waitForHost(waitCtx, "fast.example.com"))
+	}
+}
+
+// generateClientCert returns the PEMs of a self-signed client certificate and its key
+func generateClientCert(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "agent"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestExecuteHTTPTemplateAuth(t *testing.T) {
+	var tokenRequests int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.NotEmpty(t, r.TLS.PeerCertificates) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "agent", r.TLS.PeerCertificates[0].Subject.CommonName)
+		switch r.URL.Path {
+		case "/token":
+			tokenRequests++
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token": "token-1", "token_type": "bearer", "expires_in": 3600}`))
+		case "/api":
+			if r.Header.Get("Authorization") != "Bearer token-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	certPEM, keyPEM := generateClientCert(t)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	ctx := logging.TestContext(t.Context())
+	ae := &AgentExecutor{
+		Namespace: "argo",
+		ClientSet: fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "argo"},
+			Data: map[string][]byte{
+				"ca.crt":        caPEM,
+				"tls.crt":       certPEM,
+				"tls.key":       keyPEM,
+				"client-id":     []byte("agent"),
+				"client-secret": []byte("secret"),
+				"token-url":     []byte(server.URL + "/token"),
+			},
+		}),
+	}
+	selector := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: key}
+	}
+	tmpl := v1alpha1.Template{
+		HTTP: &v1alpha1.HTTP{
+			URL:          server.URL + "/api",
+			CACertSecret: selector("ca.crt"),
+			Auth: &v1alpha1.HTTPAuth{
+				ClientCert: v1alpha1.ClientCertAuth{ClientCertSecret: selector("tls.crt"), ClientKeySecret: selector("tls.key")},
+				OAuth2: v1alpha1.OAuth2Auth{
+					ClientIDSecret:     selector("client-id"),
+					ClientSecretSecret: selector("client-secret"),
+					TokenURLSecret:     selector("token-url"),
+				},
+			},
+		},
+	}
+
+	for range 2 {
+		result, _, err := ae.processTask(ctx, "", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+	}
+	// The token is cached
+	assert.Equal(t, 1, tokenRequests)
+
+	t.Run("RotatedSecret", func(t *testing.T) {
+		secret, err := ae.ClientSet.CoreV1().Secrets("argo").Get(ctx, "api", metav1.GetOptions{})
+		require.NoError(t, err)
+		secret.Data["client-secret"] = []byte("rotated")
+		_, err = ae.ClientSet.CoreV1().Secrets("argo").Update(ctx, secret, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		result, _, err := ae.processTask(ctx, "", tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase, result.Message)
+		// The client of the old secret is not reused
+		assert.Equal(t, 2, tokenRequests)
+	})
+
+	t.Run("UntrustedServer", func(t *testing.T) {
+		tmpl := tmpl.DeepCopy()
+		tmpl.HTTP.CACertSecret = nil
+		result, _, err := ae.processTask(ctx, "", *tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+		assert.Contains(t, result.Message, "certificate")
+	})
+}
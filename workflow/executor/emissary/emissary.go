@@ -43,6 +43,8 @@ If the container is named `main` it also copies base-layer artifacts to the shar
 * `/var/run/argo/outputs/parameters/${path}` All output parameters are copied here, e.g. `/tmp/message` is moved to `/var/run/argo/outputs/parameters/tmp/message`.
 * `/var/run/argo/outputs/artifacts/${path}.tgz` All output artifacts are copied here, e.g. `/tmp/message` is moved to /var/run/argo/outputs/artifacts/tmp/message.tgz`.
 
+Any other container of a container set which has outputs of its own copies them under `/var/run/argo/ctr/${containerName}/outputs` instead, e.g. `/var/run/argo/ctr/${containerName}/outputs/parameters/tmp/message`.
+
 The auxiliary container (`wait` in the legacy layout, `supervisor` in the init-less layout) can create one file itself, used for terminating the sub-process:
 
 * `/var/run/argo/ctr/${containerName}/signal` The emissary binary listens to changes in this file, and signals the sub-process with the value found in this file.
@@ -72,8 +74,8 @@ func (e emissary) WriteTemplate(t wfv1.Template) error {
 	return os.WriteFile(common.VarRunArgoPath+"/template", data, 0o444) // chmod -r--r--r--
 }
 
-func (e emissary) GetFileContents(_ string, sourcePath string) (string, error) {
-	data, err := os.ReadFile(filepath.Clean(filepath.Join(common.VarRunArgoPath, "outputs", "parameters", sourcePath)))
+func (e emissary) GetFileContents(containerName string, sourcePath string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(filepath.Join(common.VarRunArgoPath, common.OutputsDir(containerName), "parameters", sourcePath)))
 	return string(data), err
 }
 
@@ -81,7 +83,7 @@ func (e emissary) CopyFile(ctx context.Context, containerName string, sourcePath
 	// this implementation is very different, because we expect the emissary binary has already compressed the file
 	// so no compression can or needs to be implemented here
 	// TODO - warn the user we ignored compression?
-	sourceFile := filepath.Join(common.VarRunArgoPath, "outputs", "artifacts", strings.TrimSuffix(sourcePath, "/")+".tgz")
+	sourceFile := filepath.Join(common.VarRunArgoPath, common.OutputsDir(containerName), "artifacts", strings.TrimSuffix(sourcePath, "/")+".tgz")
 	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"source": sourceFile, "dest": destPath}).Info(ctx, "Copying file")
 	src, err := os.Open(filepath.Clean(sourceFile))
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"encoding/json"
@@ -325,8 +326,8 @@ func (we *WorkflowExecutor) unarchiveArtifact(ctx context.Context, art wfv1.Arti
 		// explicitly not a tar
 		isTar = false
 		isZip = false
-	case art.GetArchive().Tar != nil:
-		// explicitly a tar
+	case art.GetArchive().Tar != nil, art.GetArchive().Zstd != nil:
+		// explicitly a tar, its compression is detected when untarring
 		isTar = true
 	case art.GetArchive().Zip != nil:
 		// explicitly a zip
@@ -446,6 +447,10 @@ func (we *WorkflowExecutor) saveArtifact(ctx context.Context, containerName stri
 	}
 	ctx, span := we.Tracing.StartSaveArtifact(ctx)
 	defer span.End()
+	if art.Deduplicate {
+		// archives of the same files have the same digest, so they are deduplicated
+		ctx = archive.WithNormalizedHeaders(ctx)
+	}
 	fileName, localArtPath, err := we.stageArchiveFile(ctx, containerName, art)
 	if err != nil {
 		if art.Optional && argoerrs.IsCode(argoerrs.CodeNotFound, err) {
@@ -468,7 +473,8 @@ func (we *WorkflowExecutor) saveArtifact(ctx context.Context, containerName stri
 
 // fileBase is probably path.Base(filePath), but can be something else
 func (we *WorkflowExecutor) saveArtifactFromFile(ctx context.Context, art *wfv1.Artifact, fileName, localArtPath string) error {
-	if !art.HasKey() {
+	hasKey := art.HasKey()
+	if !hasKey {
 		key, err := we.Template.ArchiveLocation.GetKey()
 		if err != nil {
 			return err
@@ -484,6 +490,14 @@ func (we *WorkflowExecutor) saveArtifactFromFile(ctx context.Context, art *wfv1.
 			return err
 		}
 	}
+	if err := setDigest(art, localArtPath); err != nil {
+		return err
+	}
+	if art.Deduplicate {
+		if err := setContentAddressedKey(ctx, art, hasKey, fileName); err != nil {
+			return err
+		}
+	}
 	driverArt, err := we.newDriverArt(art)
 	if err != nil {
 		return err
@@ -492,7 +506,15 @@ func (we *WorkflowExecutor) saveArtifactFromFile(ctx context.Context, art *wfv1.
 	if err != nil {
 		return err
 	}
-	err = artDriver.Save(ctx, localArtPath, driverArt)
+	if art.Deduplicate && artifactExists(ctx, artDriver, driverArt) {
+		we.maybeDeleteLocalArtPath(ctx, localArtPath)
+		logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"path": localArtPath, "digest": art.Digest}).Info(ctx, "Skipped saving file, an artifact with the same digest already exists")
+		return nil
+	}
+	err = artDriver.Save(artifactcommon.WithUploadStateDir(ctx, common.ArtifactUploadStatePath), localArtPath, driverArt)
+	if err != nil && !art.HasLocation() {
+		err = we.saveArtifactToFallbacks(ctx, art, fileName, localArtPath, !hasKey && !art.Deduplicate, err)
+	}
 	if err != nil {
 		return err
 	}
@@ -501,6 +523,114 @@ func (we *WorkflowExecutor) saveArtifactFromFile(ctx context.Context, art *wfv1.
 	return nil
 }
 
+// saveArtifactToFallbacks saves the artifact to the fallback locations of the artifact repository, in order, once
+// saving it to the repository has failed with saveErr. The artifact is given the location it is saved to, so it is
+// read from there. Its key is under the key format of the fallback if it was under that of the repository, and is kept
+// otherwise.
+func (we *WorkflowExecutor) saveArtifactToFallbacks(ctx context.Context, art *wfv1.Artifact, fileName, localArtPath string, archiveKey bool, saveErr error) error {
+	value := os.Getenv(common.EnvVarArtifactFallbacks)
+	if value == "" {
+		return saveErr
+	}
+	var fallbacks []wfv1.ArtifactLocation
+	if err := json.Unmarshal([]byte(value), &fallbacks); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", common.EnvVarArtifactFallbacks, err)
+	}
+	logger := logging.RequireLoggerFromContext(ctx).WithField("artifact", art.Name)
+	logger.WithError(saveErr).Warn(ctx, "Failed to save artifact to the artifact repository, saving it to the fallbacks")
+	key, err := art.GetKey()
+	if err != nil {
+		return err
+	}
+	for i, fallback := range fallbacks {
+		fallbackArt := art.DeepCopy()
+		fallbackArt.ArtifactLocation = fallback
+		fallbackKey := key
+		if archiveKey {
+			prefix, err := fallback.GetKey()
+			if err != nil {
+				return err
+			}
+			fallbackKey = path.Join(prefix, fileName)
+		}
+		if err := fallbackArt.SetKey(fallbackKey); err != nil {
+			return err
+		}
+		if !fallbackArt.HasLocation() {
+			logger.WithField("fallback", i).Warn(ctx, "Skipping incomplete artifact repository fallback")
+			continue
+		}
+		driver, err := we.InitDriver(ctx, fallbackArt)
+		if err == nil {
+			err = driver.Save(ctx, localArtPath, fallbackArt)
+		}
+		if err != nil {
+			logger.WithField("fallback", i).WithError(err).Warn(ctx, "Failed to save artifact to artifact repository fallback")
+			continue
+		}
+		logger.WithFields(logging.Fields{"fallback": i, "key": fallbackKey}).Info(ctx, "Saved artifact to artifact repository fallback")
+		art.ArtifactLocation = fallbackArt.ArtifactLocation
+		return nil
+	}
+	return saveErr
+}
+
+// setDigest records the digest of the staged file on the artifact, so it can be verified when the artifact is loaded.
+// Directories which are not archived have no digest.
+func setDigest(art *wfv1.Artifact, localArtPath string) error {
+	isDir, err := file.IsDirectory(localArtPath)
+	if err != nil {
+		return err
+	}
+	if isDir {
+		art.Digest = ""
+		return nil
+	}
+	digest, err := artifactcommon.FileDigest(localArtPath)
+	if err != nil {
+		return fmt.Errorf("failed to compute the digest of %s: %w", localArtPath, err)
+	}
+	art.Digest = digest
+	return nil
+}
+
+// setContentAddressedKey sets the key of the artifact to the content-addressed key of its digest, and the extension of
+// its file name, under the key prefix of the repository. Deduplication is turned off for artifacts it does not apply to, so they are garbage collected as
+// usual.
+func setContentAddressedKey(ctx context.Context, art *wfv1.Artifact, hasKey bool, fileName string) error {
+	logger := logging.RequireLoggerFromContext(ctx).WithField("name", art.Name)
+	switch {
+	case hasKey:
+		logger.Warn(ctx, "Not deduplicating artifact, its key is set explicitly")
+		art.Deduplicate = false
+		return nil
+	case art.Digest == "":
+		logger.Warn(ctx, "Not deduplicating artifact, it is a directory which is not archived")
+		art.Deduplicate = false
+		return nil
+	case art.S3 == nil && art.GCS == nil && art.Azure == nil && art.OSS == nil:
+		logger.Warn(ctx, "Not deduplicating artifact, deduplication is only supported by S3, GCS, Azure and OSS repositories")
+		art.Deduplicate = false
+		return nil
+	}
+	key, err := artifactcommon.ContentAddressedKey(os.Getenv(common.EnvVarArtifactKeyPrefix), art.Digest, artifactcommon.FileExtension(fileName))
+	if err != nil {
+		return err
+	}
+	return art.SetKey(key)
+}
+
+// artifactExists returns whether the artifact exists in the repository. It returns false if that cannot be determined,
+// so the artifact is saved again rather than lost.
+func artifactExists(ctx context.Context, artDriver artifactcommon.ArtifactDriver, art *wfv1.Artifact) bool {
+	exists, err := artifactcommon.Exists(ctx, artDriver, art)
+	if err != nil {
+		logging.RequireLoggerFromContext(ctx).WithField("name", art.Name).WithError(err).Warn(ctx, "Failed to check if a deduplicated artifact exists, saving it")
+		return false
+	}
+	return exists
+}
+
 func (we *WorkflowExecutor) maybeDeleteLocalArtPath(ctx context.Context, localArtPath string) {
 	if os.Getenv("REMOVE_LOCAL_ART_PATH") == "true" {
 		logger := logging.RequireLoggerFromContext(ctx)
@@ -556,30 +686,7 @@ func (we *WorkflowExecutor) stageArchiveFile(ctx context.Context, containerName
 			}
 			return fileName, mountedArtPath, nil
 		}
-		if strategy.Zip != nil {
-			fileName := fmt.Sprintf("%s.zip", art.Name)
-			localArtPath := filepath.Join(tempOutArtDir, fileName)
-			f, err := os.Create(localArtPath)
-			if err != nil {
-				return "", "", argoerrs.InternalWrapError(err)
-			}
-			zw := zip.NewWriter(f)
-			defer zw.Close()
-			err = archive.ZipToWriter(ctx, mountedArtPath, zw)
-			if err != nil {
-				return "", "", err
-			}
-			logger.WithFields(logging.Fields{"path": art.Path, "mountedArtPath": mountedArtPath}).Info(ctx, "Successfully staged from mirrored volume mount")
-			return fileName, localArtPath, nil
-		}
-		fileName := fmt.Sprintf("%s.tgz", art.Name)
-		localArtPath := filepath.Join(tempOutArtDir, fileName)
-		f, err := os.Create(localArtPath)
-		if err != nil {
-			return "", "", argoerrs.InternalWrapError(err)
-		}
-		w := bufio.NewWriter(f)
-		err = archive.TarGzToWriter(ctx, mountedArtPath, compressionLevel, w)
+		fileName, localArtPath, err := archiveToFile(ctx, art.Name, mountedArtPath, strategy, compressionLevel)
 		if err != nil {
 			return "", "", err
 		}
@@ -587,7 +694,12 @@ func (we *WorkflowExecutor) stageArchiveFile(ctx context.Context, containerName
 		return fileName, localArtPath, nil
 	}
 
-	fileName := fmt.Sprintf("%s.tgz", art.Name)
+	// the tarball copied from the container is only gzipped for the gzip tar strategy, so it is named after the
+	// codec it actually has. It is untarred or recompressed below for any other strategy
+	fileName := art.Name + ".tar"
+	if strategy.Tar != nil && !strategy.Tar.IsUncompressed() {
+		fileName = art.Name + ".tgz"
+	}
 	localArtPath := filepath.Join(tempOutArtDir, fileName)
 	logger.WithFields(logging.Fields{"path": art.Path, "localArtPath": localArtPath}).Info(ctx, "Copying from container base image layer")
 
@@ -596,10 +708,23 @@ func (we *WorkflowExecutor) stageArchiveFile(ctx context.Context, containerName
 		return "", "", err
 	}
 	if strategy.Tar != nil {
-		// NOTE we already tar gzip the file in the executor. So this is a noop.
+		// NOTE we already tar the file as required by the strategy in the executor. So this is a noop.
 		return fileName, localArtPath, nil
 	}
-	// localArtPath now points to a .tgz file, and the archive strategy is *not* tar. We need to untar it
+	if strategy.Zstd != nil {
+		// a tarball only needs compressing, so there is no need to untar it first
+		logger.WithField("path", localArtPath).Info(ctx, "Compressing archive with zstd before upload")
+		zstdFileName := art.Name + ".tar.zst"
+		zstdArtPath := filepath.Join(tempOutArtDir, zstdFileName)
+		if err := zstdTarball(localArtPath, zstdArtPath, strategy.Zstd); err != nil {
+			return "", "", err
+		}
+		if err := os.Remove(localArtPath); err != nil {
+			return "", "", argoerrs.InternalWrapError(err)
+		}
+		return zstdFileName, zstdArtPath, nil
+	}
+	// localArtPath now points to a tarball, and the archive strategy is *not* tar. We need to untar it
 	logger.WithField("path", localArtPath).Info(ctx, "Untarring archive before upload")
 	unarchivedArtPath := path.Join(filepath.Dir(localArtPath), art.Name)
 	err = untar(localArtPath, unarchivedArtPath)
@@ -628,18 +753,9 @@ func (we *WorkflowExecutor) stageArchiveFile(ctx context.Context, containerName
 			return "", "", argoerrs.InternalWrapError(err)
 		}
 	}
-	// In the future, if we were to support other compression formats (e.g. bzip2) or options
-	// the logic would go here, and compression would be moved out of the executors
 	if strategy.Zip != nil {
-		fileName = fmt.Sprintf("%s.zip", art.Name)
-		localArtPath = filepath.Join(tempOutArtDir, fileName)
-		f, err := os.Create(localArtPath)
-		if err != nil {
-			return "", "", argoerrs.InternalWrapError(err)
-		}
-		zw := zip.NewWriter(f)
-		defer zw.Close()
-		err = archive.ZipToWriter(ctx, unarchivedArtPath, zw)
+		unarchivedArtPath = localArtPath
+		fileName, localArtPath, err = archiveToFile(ctx, art.Name, unarchivedArtPath, strategy, compressionLevel)
 		if err != nil {
 			return "", "", err
 		}
@@ -649,6 +765,87 @@ func (we *WorkflowExecutor) stageArchiveFile(ctx context.Context, containerName
 	return fileName, localArtPath, nil
 }
 
+// archiveToFile archives the source path with the archive strategy into the staging directory.
+// Returns the file name and the local path of the archive.
+func archiveToFile(ctx context.Context, artName, sourcePath string, strategy *wfv1.ArchiveStrategy, compressionLevel int) (string, string, error) {
+	var fileName string
+	switch {
+	case strategy.Zip != nil:
+		fileName = artName + ".zip"
+	case strategy.Zstd != nil:
+		fileName = artName + ".tar.zst"
+	case strategy.Tar.IsUncompressed():
+		fileName = artName + ".tar"
+	default:
+		fileName = artName + ".tgz"
+	}
+	localArtPath := filepath.Join(tempOutArtDir, fileName)
+	f, err := os.Create(localArtPath)
+	if err != nil {
+		return "", "", argoerrs.InternalWrapError(err)
+	}
+	defer func() { _ = f.Close() }()
+	w := bufio.NewWriter(f)
+	switch {
+	case strategy.Zip != nil:
+		zw := zip.NewWriter(w)
+		err = archive.ZipToWriter(ctx, sourcePath, zw)
+		if err == nil {
+			err = zw.Close()
+		}
+	case strategy.Zstd != nil:
+		err = archive.TarZstdToWriter(ctx, sourcePath, strategy.Zstd.GetCompressionLevel(), strategy.Zstd.GetConcurrency(), w)
+	case strategy.Tar.IsUncompressed():
+		err = archive.TarToWriter(ctx, sourcePath, w)
+	default:
+		err = archive.TarGzToWriter(ctx, sourcePath, compressionLevel, w)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if err := w.Flush(); err != nil {
+		return "", "", argoerrs.InternalWrapError(err)
+	}
+	if err := f.Close(); err != nil {
+		return "", "", argoerrs.InternalWrapError(err)
+	}
+	return fileName, localArtPath, nil
+}
+
+// zstdTarball compresses a tarball, which may already be compressed, with zstd
+func zstdTarball(srcPath, destPath string, strategy *wfv1.ZstdStrategy) error {
+	src, err := os.Open(filepath.Clean(srcPath))
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	defer func() { _ = src.Close() }()
+	r, _, err := file.GetDecompressingReader(src)
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	defer func() { _ = r.Close() }()
+	dst, err := os.Create(destPath)
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	defer func() { _ = dst.Close() }()
+	zw, err := archive.NewZstdWriter(dst, strategy.GetCompressionLevel(), strategy.GetConcurrency())
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	if _, err := io.Copy(zw, r); err != nil {
+		_ = zw.Close()
+		return argoerrs.InternalWrapError(err)
+	}
+	if err := zw.Close(); err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	if err := dst.Close(); err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	return nil
+}
+
 // isBaseImagePath checks if the given artifact path resides in the base image layer of the container
 // versus a shared volume mount between the wait and main container
 func (we *WorkflowExecutor) isBaseImagePath(path string) bool {
@@ -696,49 +893,88 @@ func (we *WorkflowExecutor) SaveParameters(ctx context.Context) error {
 		return nil
 	}
 	logger.Info(ctx, "Saving output parameters")
-	for i, param := range we.Template.Outputs.Parameters {
-		logger.WithField("name", param.Name).Info(ctx, "Saving path output parameter")
-		// Determine the file path of where to find the parameter
-		if param.ValueFrom == nil || param.ValueFrom.Path == "" {
-			continue
+	for i := range we.Template.Outputs.Parameters {
+		if err := we.saveParameter(ctx, common.MainContainerName, &we.Template.Outputs.Parameters[i]); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		var output *wfv1.AnyString
-		if we.isBaseImagePath(param.ValueFrom.Path) {
-			logger.WithField("path", param.ValueFrom.Path).Info(ctx, "Copying from base image layer")
-			fileContents, err := we.RuntimeExecutor.GetFileContents(common.MainContainerName, param.ValueFrom.Path)
-			if err != nil {
-				// We have a default value to use instead of returning an error
-				if param.ValueFrom.Default == nil {
-					return err
-				}
-				output = param.ValueFrom.Default
-			} else {
-				output = wfv1.AnyStringPtr(fileContents)
+// saveParameter saves the content of the path of the output parameter of the container as its value
+func (we *WorkflowExecutor) saveParameter(ctx context.Context, containerName string, param *wfv1.Parameter) error {
+	logger := logging.RequireLoggerFromContext(ctx)
+	logger.WithField("name", param.Name).Info(ctx, "Saving path output parameter")
+	// Determine the file path of where to find the parameter
+	if param.ValueFrom == nil || param.ValueFrom.Path == "" {
+		return nil
+	}
+
+	var output *wfv1.AnyString
+	if we.isBaseImagePath(param.ValueFrom.Path) {
+		logger.WithField("path", param.ValueFrom.Path).Info(ctx, "Copying from base image layer")
+		fileContents, err := we.RuntimeExecutor.GetFileContents(containerName, param.ValueFrom.Path)
+		if err != nil {
+			// We have a default value to use instead of returning an error
+			if param.ValueFrom.Default == nil {
+				return err
 			}
+			output = param.ValueFrom.Default
 		} else {
-			logger.WithField("path", param.ValueFrom.Path).Info(ctx, "Copying from volume mount")
-			mountedPath := filepath.Join(common.ExecutorMainFilesystemDir, param.ValueFrom.Path)
-			data, err := os.ReadFile(filepath.Clean(mountedPath))
-			if err != nil {
-				// We have a default value to use instead of returning an error
-				if param.ValueFrom.Default == nil {
-					return err
-				}
-				output = param.ValueFrom.Default
-			} else {
-				output = wfv1.AnyStringPtr(string(data))
+			output = wfv1.AnyStringPtr(fileContents)
+		}
+	} else {
+		logger.WithField("path", param.ValueFrom.Path).Info(ctx, "Copying from volume mount")
+		mountedPath := filepath.Join(common.ExecutorMainFilesystemDir, param.ValueFrom.Path)
+		data, err := os.ReadFile(filepath.Clean(mountedPath))
+		if err != nil {
+			// We have a default value to use instead of returning an error
+			if param.ValueFrom.Default == nil {
+				return err
 			}
+			output = param.ValueFrom.Default
+		} else {
+			output = wfv1.AnyStringPtr(string(data))
 		}
-
-		// Trims off a single newline for user convenience
-		output = wfv1.AnyStringPtr(strings.TrimSuffix(output.String(), "\n"))
-		we.Template.Outputs.Parameters[i].Value = output
-		logger.WithField("name", param.Name).Info(ctx, "Successfully saved output parameter")
 	}
+
+	// Trims off a single newline for user convenience
+	param.Value = wfv1.AnyStringPtr(strings.TrimSuffix(output.String(), "\n"))
+	logger.WithField("name", param.Name).Info(ctx, "Successfully saved output parameter")
 	return nil
 }
 
+// SaveContainerOutputs saves the output parameters and artifacts of the containers of a container set. The artifacts
+// of each container are replaced with those which were in fact saved.
+func (we *WorkflowExecutor) SaveContainerOutputs(ctx context.Context) error {
+	logger := logging.RequireLoggerFromContext(ctx)
+	var errs []error
+	for i := range we.Template.ContainerSet.GetGraph() {
+		ctr := &we.Template.ContainerSet.Containers[i]
+		if ctr.Outputs == nil || !ctr.Outputs.HasOutputs() {
+			continue
+		}
+		logger.WithField("containerName", ctr.Name).Info(ctx, "Saving container outputs")
+		for j := range ctr.Outputs.Parameters {
+			if err := we.saveParameter(ctx, ctr.Name, &ctr.Outputs.Parameters[j]); err != nil {
+				errs = append(errs, fmt.Errorf("container %s: %w", ctr.Name, err))
+			}
+		}
+		artifacts := wfv1.Artifacts{}
+		for _, art := range ctr.Outputs.Artifacts {
+			saved, err := we.saveArtifact(ctx, ctr.Name, &art)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("container %s: %w", ctr.Name, err))
+			}
+			if saved {
+				artifacts = append(artifacts, art)
+			}
+		}
+		ctr.Outputs.Artifacts = artifacts
+	}
+	return errors.Join(errs...)
+}
+
 func (we *WorkflowExecutor) SaveLogs(ctx context.Context) []wfv1.Artifact {
 	ctx, span := we.Tracing.StartSaveLogs(ctx)
 	defer span.End()
@@ -989,7 +1225,7 @@ func (we *WorkflowExecutor) InitializeOutput(ctx context.Context) {
 func (we *WorkflowExecutor) ReportOutputs(ctx context.Context, artifacts []wfv1.Artifact) error {
 	outputs := we.Template.Outputs.DeepCopy()
 	outputs.Artifacts = artifacts
-	return we.reportResult(ctx, wfv1.NodeResult{Outputs: outputs})
+	return we.reportResult(ctx, wfv1.NodeResult{Outputs: outputs, ContainerOutputs: we.Template.ContainerSet.GetContainerOutputs()})
 }
 
 // ReportOutputsLogs updates the WorkflowTaskResult log fields
@@ -1053,7 +1289,14 @@ func (we *WorkflowExecutor) AddAnnotation(ctx context.Context, key, value string
 	return err
 }
 
-// isTarball returns whether or not the file is a tarball
+const (
+	// tarHeaderSize is the size of the header of each file in a tarball
+	tarHeaderSize = 512
+	// tarMagicOffset is the offset of the magic in a tar header
+	tarMagicOffset = 257
+)
+
+// isTarball returns whether or not the file is a tarball, either uncompressed or compressed with gzip or zstd
 func isTarball(ctx context.Context, filePath string) (bool, error) {
 	logger := logging.RequireLoggerFromContext(ctx)
 	logger.WithField("path", filePath).Info(ctx, "Detecting if file is a tarball")
@@ -1066,18 +1309,27 @@ func isTarball(ctx context.Context, filePath string) (bool, error) {
 			logger.WithFatal().WithField("path", filePath).WithError(closeErr).Error(ctx, "Error closing file")
 		}
 	}()
-	gzr, err := gzip.NewReader(f)
+	r, algorithm, err := file.GetDecompressingReader(f)
 	if err != nil {
 		return false, nil
 	}
-	defer gzr.Close()
-	tarr := tar.NewReader(gzr)
+	defer r.Close()
+	if algorithm == "" {
+		// any file can be read as a tar header, so an uncompressed tarball is detected by the ustar magic of its first
+		// header, which both POSIX and GNU tarballs have
+		header := make([]byte, tarHeaderSize)
+		if _, err := io.ReadFull(r, header); err != nil {
+			return false, nil
+		}
+		return bytes.HasPrefix(header[tarMagicOffset:], []byte("ustar")), nil
+	}
+	tarr := tar.NewReader(r)
 	_, err = tarr.Next()
 	return err == nil, nil
 }
 
-// untar extracts a tarball to a temporary directory,
-// renaming it to the desired location
+// untar extracts a tarball, either uncompressed or compressed with gzip or zstd,
+// to a temporary directory, renaming it to the desired location
 func untar(tarPath string, destPath string) error {
 	decompressor := func(src string, dest string) error {
 		f, err := os.Open(src)
@@ -1085,12 +1337,12 @@ func untar(tarPath string, destPath string) error {
 			return err
 		}
 		defer f.Close()
-		gzr, err := file.GetGzipReader(f)
+		r, _, err := file.GetDecompressingReader(f)
 		if err != nil {
 			return err
 		}
-		defer gzr.Close()
-		tr := tar.NewReader(gzr)
+		defer r.Close()
+		tr := tar.NewReader(r)
 		for {
 			header, err := tr.Next()
 			switch {
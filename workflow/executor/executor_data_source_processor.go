@@ -2,8 +2,19 @@ package executor
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	errorsutil "github.com/argoproj/argo-workflows/v4/util/errors"
+	"github.com/argoproj/argo-workflows/v4/util/file"
+	"github.com/argoproj/argo-workflows/v4/util/retry"
+	waitutil "github.com/argoproj/argo-workflows/v4/util/wait"
+	"github.com/argoproj/argo-workflows/v4/workflow/data"
 )
 
 type executorDataSourceProcessor struct {
@@ -34,3 +45,62 @@ func (ep *executorDataSourceProcessor) ProcessArtifactPaths(ctx context.Context,
 
 	return files, nil
 }
+
+func (ep *executorDataSourceProcessor) ProcessArtifactContents(ctx context.Context, source *wfv1.ArtifactContents) (any, error) {
+	contents, err := ep.loadArtifact(ctx, &source.Artifact)
+	if err != nil {
+		return nil, err
+	}
+	return data.ParseArtifactContents(contents, source)
+}
+
+func (ep *executorDataSourceProcessor) ProcessConfigMap(ctx context.Context, ref *apiv1.LocalObjectReference) (any, error) {
+	configMapsIf := ep.we.ClientSet.CoreV1().ConfigMaps(ep.we.Namespace)
+	var configMap *apiv1.ConfigMap
+	err := waitutil.Backoff(retry.DefaultRetry(ctx), func() (bool, error) {
+		var err error
+		configMap, err = configMapsIf.Get(ctx, ref.Name, metav1.GetOptions{})
+		return !errorsutil.IsTransientErr(ctx, err), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]any, len(configMap.Data))
+	for k, v := range configMap.Data {
+		values[k] = v
+	}
+	return values, nil
+}
+
+// loadArtifact loads the contents of a file artifact, unpacking it first if it is an archive
+func (ep *executorDataSourceProcessor) loadArtifact(ctx context.Context, art *wfv1.Artifact) ([]byte, error) {
+	driverArt, err := ep.we.newDriverArt(art)
+	if err != nil {
+		return nil, err
+	}
+	artDriver, err := ep.we.InitDriver(ctx, driverArt)
+	if err != nil {
+		return nil, err
+	}
+	tempDir, err := os.MkdirTemp("", "data-source-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+	tempArtPath := filepath.Join(tempDir, "artifact.tmp")
+	artPath := filepath.Join(tempDir, "artifact")
+	if err := artDriver.Load(ctx, driverArt, tempArtPath); err != nil {
+		return nil, fmt.Errorf("artifact %s failed to load: %w", art.Name, err)
+	}
+	if err := ep.we.unarchiveArtifact(ctx, *art, tempArtPath, artPath); err != nil {
+		return nil, err
+	}
+	isDir, err := file.IsDirectory(artPath)
+	if err != nil {
+		return nil, err
+	}
+	if isDir {
+		return nil, fmt.Errorf("artifact %s is a directory, not a file", art.Name)
+	}
+	return os.ReadFile(filepath.Clean(artPath))
+}
@@ -0,0 +1,32 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+func TestProcessConfigMap(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	we := &WorkflowExecutor{
+		Namespace: fakeNamespace,
+		ClientSet: fake.NewClientset(&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cm", Namespace: fakeNamespace},
+			Data:       map[string]string{"regions": `["eu", "us"]`},
+		}),
+	}
+	ep := newExecutorDataSourceProcessor(we)
+
+	values, err := ep.ProcessConfigMap(ctx, &apiv1.LocalObjectReference{Name: "my-cm"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"regions": `["eu", "us"]`}, values)
+
+	_, err = ep.ProcessConfigMap(ctx, &apiv1.LocalObjectReference{Name: "not-found"})
+	require.Error(t, err)
+}
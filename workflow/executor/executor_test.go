@@ -4,8 +4,11 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -23,6 +26,7 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	argofake "github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v4/util/archive"
 	"github.com/argoproj/argo-workflows/v4/util/logging"
 	"github.com/argoproj/argo-workflows/v4/workflow/common"
 	"github.com/argoproj/argo-workflows/v4/workflow/executor/mocks"
@@ -124,6 +128,40 @@ func TestSaveParameters(t *testing.T) {
 	assert.Equal(t, "has a newline", we.Template.Outputs.Parameters[0].Value.String())
 }
 
+func TestSaveContainerOutputs(t *testing.T) {
+	mockRuntimeExecutor := mocks.ContainerRuntimeExecutor{}
+	ctx := logging.TestContext(t.Context())
+	tracing, err := tracing.New(ctx, `argoexec`)
+	require.NoError(t, err)
+	mockTaskResultClient := argofake.NewClientset().ArgoprojV1alpha1().WorkflowTaskResults(fakeNamespace)
+	we := WorkflowExecutor{
+		Template: wfv1.Template{
+			ContainerSet: &wfv1.ContainerSetTemplate{
+				Containers: []wfv1.ContainerNode{
+					{Container: corev1.Container{Name: "build"}, Outputs: &wfv1.Outputs{
+						Parameters: []wfv1.Parameter{{Name: "version", ValueFrom: &wfv1.ValueFrom{Path: "/tmp/version"}}},
+					}},
+					{Container: corev1.Container{Name: "main"}, Dependencies: []string{"build"}},
+				},
+			},
+		},
+		RuntimeExecutor:  &mockRuntimeExecutor,
+		taskResultClient: mockTaskResultClient,
+		Tracing:          tracing,
+		nodeID:           "my-node",
+	}
+	mockRuntimeExecutor.On("GetFileContents", "build", "/tmp/version").Return("1.2.3\n", nil)
+
+	require.NoError(t, we.SaveContainerOutputs(ctx))
+	require.NoError(t, we.ReportOutputs(ctx, nil))
+
+	result, err := mockTaskResultClient.Get(ctx, "my-node", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Contains(t, result.ContainerOutputs, "build")
+	assert.Equal(t, "1.2.3", result.ContainerOutputs["build"].Parameters[0].Value.String())
+	assert.NotContains(t, result.ContainerOutputs, "main")
+}
+
 // TestIsBaseImagePath tests logic of isBaseImagePath which determines if a path is coming from a
 // base image layer versus a shared volumeMount.
 func TestIsBaseImagePath(t *testing.T) {
@@ -279,6 +317,161 @@ func TestStageArchiveFileInitlessOverlap(t *testing.T) {
 	})
 }
 
+func TestArchiveToFile(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	require.NoError(t, os.MkdirAll(tempOutArtDir, 0o755))
+	sourcePath := filepath.Join(t.TempDir(), "my-dir")
+	require.NoError(t, os.MkdirAll(sourcePath, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sourcePath, "my-file"), []byte("my-content"), 0o600))
+
+	tests := []struct {
+		name     string
+		strategy *wfv1.ArchiveStrategy
+		fileName string
+		load     func(ctx context.Context, src, dest string) error
+	}{
+		{"Tar", &wfv1.ArchiveStrategy{Tar: &wfv1.TarStrategy{}}, "my-art.tgz", func(_ context.Context, src, dest string) error { return untar(src, dest) }},
+		{"TarUncompressed", &wfv1.ArchiveStrategy{Tar: &wfv1.TarStrategy{Compression: wfv1.TarCompressionNone}}, "my-art.tar", func(_ context.Context, src, dest string) error { return untar(src, dest) }},
+		{"Zstd", &wfv1.ArchiveStrategy{Zstd: &wfv1.ZstdStrategy{}}, "my-art.tar.zst", func(_ context.Context, src, dest string) error { return untar(src, dest) }},
+		{"Zip", &wfv1.ArchiveStrategy{Zip: &wfv1.ZipStrategy{}}, "my-art.zip", unzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileName, localArtPath, err := archiveToFile(ctx, "my-art", sourcePath, tt.strategy, gzip.DefaultCompression)
+			require.NoError(t, err)
+			defer func() { _ = os.Remove(localArtPath) }()
+			assert.Equal(t, tt.fileName, fileName)
+			assert.Equal(t, filepath.Join(tempOutArtDir, tt.fileName), localArtPath)
+
+			destPath := filepath.Join(t.TempDir(), "loaded")
+			require.NoError(t, tt.load(ctx, localArtPath, destPath))
+			data, err := os.ReadFile(filepath.Join(destPath, "my-file"))
+			require.NoError(t, err)
+			assert.Equal(t, "my-content", string(data))
+		})
+	}
+}
+
+func TestStageArchiveFileZstd(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	require.NoError(t, os.MkdirAll(tempOutArtDir, 0o755))
+	tr, err := tracing.New(ctx, "argoexec")
+	require.NoError(t, err)
+	tarball, err := os.ReadFile("testdata/file.tar.gz")
+	require.NoError(t, err)
+	rt := &mocks.ContainerRuntimeExecutor{}
+	// the tarball copied from the container is not gzipped, so it is staged with a .tar extension
+	rt.On("CopyFile", mock.Anything, common.MainContainerName, "/my-dir", filepath.Join(tempOutArtDir, "my-zstd-art.tar"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, os.WriteFile(args.String(3), tarball, 0o600))
+		}).
+		Return(nil)
+	we := &WorkflowExecutor{RuntimeExecutor: rt, Tracing: tr}
+	art := wfv1.Artifact{Name: "my-zstd-art", Path: "/my-dir", Archive: &wfv1.ArchiveStrategy{Zstd: &wfv1.ZstdStrategy{}}}
+
+	fileName, localArtPath, err := we.stageArchiveFile(ctx, common.MainContainerName, &art)
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(localArtPath) }()
+	assert.Equal(t, "my-zstd-art.tar.zst", fileName)
+	assert.NoFileExists(t, filepath.Join(tempOutArtDir, "my-zstd-art.tar"))
+	ok, err := isTarball(ctx, localArtPath)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestSetDigest(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "hello.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("hello world"), 0o600))
+
+	art := &wfv1.Artifact{Name: "hello"}
+	require.NoError(t, setDigest(art, filePath))
+	assert.Equal(t, "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", art.Digest)
+
+	art = &wfv1.Artifact{Name: "hello", Digest: "sha256:stale"}
+	require.NoError(t, setDigest(art, dir))
+	assert.Empty(t, art.Digest)
+}
+
+func TestSetContentAddressedKey(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	const digest = "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	s3Location := func(key string) wfv1.ArtifactLocation {
+		return wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, Key: key}}
+	}
+
+	t.Run("File", func(t *testing.T) {
+		art := &wfv1.Artifact{Name: "hello", Deduplicate: true, Digest: digest, ArtifactLocation: s3Location("my-wf/my-pod/hello.txt")}
+		require.NoError(t, setContentAddressedKey(ctx, art, false, "hello.txt"))
+		assert.True(t, art.Deduplicate)
+		assert.Equal(t, "sha256/b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9.txt", art.S3.Key)
+	})
+	t.Run("KeyPrefix", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactKeyPrefix, "team-a")
+		art := &wfv1.Artifact{Name: "hello", Deduplicate: true, Digest: digest, ArtifactLocation: s3Location("team-a/my-wf/my-pod/hello.txt")}
+		require.NoError(t, setContentAddressedKey(ctx, art, false, "hello.txt"))
+		assert.True(t, art.Deduplicate)
+		assert.Equal(t, "team-a/sha256/b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9.txt", art.S3.Key)
+	})
+	t.Run("ExplicitKey", func(t *testing.T) {
+		art := &wfv1.Artifact{Name: "hello", Deduplicate: true, Digest: digest, ArtifactLocation: s3Location("my-key.txt")}
+		require.NoError(t, setContentAddressedKey(ctx, art, true, "hello.txt"))
+		assert.False(t, art.Deduplicate)
+		assert.Equal(t, "my-key.txt", art.S3.Key)
+	})
+	t.Run("Directory", func(t *testing.T) {
+		art := &wfv1.Artifact{Name: "hello", Deduplicate: true, ArtifactLocation: s3Location("my-wf/my-pod/hello")}
+		require.NoError(t, setContentAddressedKey(ctx, art, false, "hello"))
+		assert.False(t, art.Deduplicate)
+		assert.Equal(t, "my-wf/my-pod/hello", art.S3.Key)
+	})
+	t.Run("UnsupportedRepository", func(t *testing.T) {
+		art := &wfv1.Artifact{Name: "hello", Deduplicate: true, Digest: digest, ArtifactLocation: wfv1.ArtifactLocation{Artifactory: &wfv1.ArtifactoryArtifact{URL: "https://example.com/hello.txt"}}}
+		require.NoError(t, setContentAddressedKey(ctx, art, false, "hello.txt"))
+		assert.False(t, art.Deduplicate)
+	})
+}
+
+func TestSaveArtifactToFallbacks(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	var puts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if strings.HasPrefix(r.URL.Path, "/primary/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		puts = append(puts, r.URL.Path)
+		w.Header().Set("ETag", `"my-etag"`)
+	}))
+	defer server.Close()
+	s3Location := func(endpoint, bucket, key string) wfv1.ArtifactLocation {
+		return wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{
+			S3Bucket: wfv1.S3Bucket{Endpoint: endpoint, Bucket: bucket, Region: "us-east-1", Insecure: new(true), UseSDKCreds: true},
+			Key:      key,
+		}}
+	}
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+	t.Setenv("AWS_ACCESS_KEY_ID", "my-access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "my-secret-key")
+	t.Setenv(common.EnvVarArtifactFallbacks, wfv1.MustMarshallJSON([]wfv1.ArtifactLocation{
+		s3Location("", "incomplete", "incomplete/my-wf/my-pod"),
+		s3Location(endpoint, "fallback", "fallback/my-wf/my-pod"),
+	}))
+	primary := s3Location(endpoint, "primary", "my-wf/my-pod")
+	we := &WorkflowExecutor{Template: wfv1.Template{ArchiveLocation: &primary}}
+	filePath := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("hello world"), 0o600))
+
+	art := &wfv1.Artifact{Name: "hello"}
+	require.NoError(t, we.saveArtifactFromFile(ctx, art, "hello.txt", filePath))
+	assert.Equal(t, []string{"/fallback/fallback/my-wf/my-pod/hello.txt"}, puts)
+	require.NotNil(t, art.S3)
+	assert.True(t, art.HasLocation())
+	assert.Equal(t, "fallback", art.S3.Bucket)
+	assert.Equal(t, "fallback/my-wf/my-pod/hello.txt", art.S3.Key)
+}
+
 func TestDefaultParameters(t *testing.T) {
 	fakeClientset := fake.NewClientset()
 	mockRuntimeExecutor := mocks.ContainerRuntimeExecutor{}
@@ -350,10 +543,11 @@ func TestIsTarball(t *testing.T) {
 	}{
 		{"testdata/file", false, false},
 		{"testdata/file.zip", false, false},
-		{"testdata/file.tar", false, false},
+		{"testdata/file.tar", true, false},
 		{"testdata/file.gz", false, false},
 		{"testdata/file.tar.gz", true, false},
 		{"testdata/file.tgz", true, false},
+		{"testdata/file.tar.zst", true, false},
 		{"testdata/not-found", false, true},
 	}
 
@@ -368,6 +562,28 @@ func TestIsTarball(t *testing.T) {
 	}
 }
 
+func TestUnarchiveArtifactUncompressedTarball(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	tr, err := tracing.New(ctx, "argoexec")
+	require.NoError(t, err)
+	we := &WorkflowExecutor{Tracing: tr}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "my-file"), []byte("hello world"), 0o600))
+	tarPath := filepath.Join(t.TempDir(), "my-art.tmp")
+	f, err := os.Create(tarPath)
+	require.NoError(t, err)
+	require.NoError(t, archive.TarToWriter(ctx, filepath.Join(dir, "my-file"), f))
+	require.NoError(t, f.Close())
+
+	// the artifact has no archive strategy, so the tarball is detected
+	artPath := filepath.Join(t.TempDir(), "my-art")
+	require.NoError(t, we.unarchiveArtifact(ctx, wfv1.Artifact{Name: "my-art"}, tarPath, artPath))
+	data, err := os.ReadFile(artPath)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+	assert.NoFileExists(t, tarPath)
+}
+
 func TestUnzip(t *testing.T) {
 	ctx := logging.TestContext(t.Context())
 	zipPath := "testdata/file.zip"
@@ -388,43 +604,57 @@ func TestUnzip(t *testing.T) {
 }
 
 func TestUntar(t *testing.T) {
-	tarPath := "testdata/file.tar.gz"
-	destPath := "testdata/untarredDir"
-	filePath := "testdata/untarredDir/file"
-	linkPath := "testdata/untarredDir/link"
-	emptyDirPath := "testdata/untarredDir/empty-dir"
+	for _, tarPath := range []string{"testdata/file.tar.gz", "testdata/file.tar.zst"} {
+		t.Run(tarPath, func(t *testing.T) {
+			destPath := "testdata/untarredDir"
+			filePath := "testdata/untarredDir/file"
+			linkPath := "testdata/untarredDir/link"
+			emptyDirPath := "testdata/untarredDir/empty-dir"
+
+			// test
+			err := untar(tarPath, destPath)
+			require.NoError(t, err)
 
-	// test
-	err := untar(tarPath, destPath)
+			// check untarred contents
+			fileInfo, err := os.Stat(destPath)
+			require.NoError(t, err)
+			assert.True(t, fileInfo.Mode().IsDir())
+			fileInfo, err = os.Stat(filePath)
+			require.NoError(t, err)
+			assert.True(t, fileInfo.Mode().IsRegular())
+			dirInfo, err := os.Stat(destPath)
+			require.NoError(t, err)
+			// check that the modification time of the file is retained
+			assert.True(t, fileInfo.ModTime().Before(dirInfo.ModTime()))
+			fileInfo, err = os.Stat(linkPath)
+			require.NoError(t, err)
+			assert.True(t, fileInfo.Mode().IsRegular())
+			fileInfo, err = os.Stat(emptyDirPath)
+			require.NoError(t, err)
+			assert.True(t, fileInfo.Mode().IsDir())
+
+			// cleanup
+			err = os.Remove(linkPath)
+			require.NoError(t, err)
+			err = os.Remove(filePath)
+			require.NoError(t, err)
+			err = os.Remove(emptyDirPath)
+			require.NoError(t, err)
+			err = os.Remove(destPath)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUntarUncompressed(t *testing.T) {
+	destPath := filepath.Join(t.TempDir(), "untarredFile")
+
+	err := untar("testdata/file.tar", destPath)
 	require.NoError(t, err)
 
-	// check untarred contents
 	fileInfo, err := os.Stat(destPath)
 	require.NoError(t, err)
-	assert.True(t, fileInfo.Mode().IsDir())
-	fileInfo, err = os.Stat(filePath)
-	require.NoError(t, err)
 	assert.True(t, fileInfo.Mode().IsRegular())
-	dirInfo, err := os.Stat(destPath)
-	require.NoError(t, err)
-	// check that the modification time of the file is retained
-	assert.True(t, fileInfo.ModTime().Before(dirInfo.ModTime()))
-	fileInfo, err = os.Stat(linkPath)
-	require.NoError(t, err)
-	assert.True(t, fileInfo.Mode().IsRegular())
-	fileInfo, err = os.Stat(emptyDirPath)
-	require.NoError(t, err)
-	assert.True(t, fileInfo.Mode().IsDir())
-
-	// cleanup
-	err = os.Remove(linkPath)
-	require.NoError(t, err)
-	err = os.Remove(filePath)
-	require.NoError(t, err)
-	err = os.Remove(emptyDirPath)
-	require.NoError(t, err)
-	err = os.Remove(destPath)
-	require.NoError(t, err)
 }
 
 func TestChmod(t *testing.T) {
@@ -6,19 +6,64 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/wait"
 
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	executorplugins "github.com/argoproj/argo-workflows/v4/pkg/plugins/executor"
+	"github.com/argoproj/argo-workflows/v4/util/intstr"
 	rpc "github.com/argoproj/argo-workflows/v4/workflow/util/plugin"
 )
 
 type plugin struct{ rpc.Client }
 
-func New(address, token string) executorplugins.TemplateExecutor {
-	return &plugin{Client: rpc.New(address, token, 30*time.Second, wait.Backoff{
+// New returns the executor plugin at the address. Transient errors are retried with the limit and backoff of the
+// agent's httpRetry, if it has one.
+func New(address, token string, httpRetry *wfv1.HTTPRetry) (executorplugins.TemplateExecutor, error) {
+	backoff, err := retryBackoff(httpRetry)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin{Client: rpc.New(address, token, 30*time.Second, backoff)}, nil
+}
+
+// retryBackoff returns the backoff of the calls for the retry
+func retryBackoff(httpRetry *wfv1.HTTPRetry) (wait.Backoff, error) {
+	backoff := wait.Backoff{
 		Duration: time.Second,
 		Jitter:   0.2,
 		Factor:   2,
 		Steps:    5,
-	})}
+	}
+	if httpRetry == nil {
+		return backoff, nil
+	}
+	backoff.Steps = 4
+	if httpRetry.Limit != nil {
+		limit, err := intstr.Int(httpRetry.Limit)
+		if err != nil {
+			return backoff, err
+		}
+		backoff.Steps = max(*limit, 0) + 1
+	}
+	if b := httpRetry.Backoff; b != nil {
+		var err error
+		if b.Duration != "" {
+			if backoff.Duration, err = wfv1.ParseStringToDuration(b.Duration); err != nil {
+				return backoff, err
+			}
+		}
+		if b.Factor != nil {
+			factor, err := intstr.Int(b.Factor)
+			if err != nil {
+				return backoff, err
+			}
+			backoff.Factor = float64(max(*factor, 1))
+		}
+		if b.Cap != "" {
+			if backoff.Cap, err = wfv1.ParseStringToDuration(b.Cap); err != nil {
+				return backoff, err
+			}
+		}
+	}
+	return backoff, nil
 }
 
 func (p *plugin) ExecuteTemplate(ctx context.Context, args executorplugins.ExecuteTemplateArgs, reply *executorplugins.ExecuteTemplateReply) error {
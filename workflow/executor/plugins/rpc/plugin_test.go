@@ -0,0 +1,33 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		backoff, err := retryBackoff(nil)
+		require.NoError(t, err)
+		assert.Equal(t, wait.Backoff{Duration: time.Second, Jitter: 0.2, Factor: 2, Steps: 5}, backoff)
+	})
+	t.Run("HTTPRetry", func(t *testing.T) {
+		backoff, err := retryBackoff(&wfv1.HTTPRetry{
+			Limit:   new(intstr.FromInt32(2)),
+			Backoff: &wfv1.Backoff{Duration: "2s", Factor: new(intstr.FromInt32(3)), Cap: "10s"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, wait.Backoff{Duration: 2 * time.Second, Jitter: 0.2, Factor: 3, Steps: 3, Cap: 10 * time.Second}, backoff)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := retryBackoff(&wfv1.HTTPRetry{Backoff: &wfv1.Backoff{Duration: "soon"}})
+		require.Error(t, err)
+	})
+}
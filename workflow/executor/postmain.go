@@ -8,7 +8,8 @@ import (
 
 // PostMain drives the phase after the user's main container has started:
 // wait for main to exit, then capture script result, output parameters,
-// output artifacts and logs, and report outputs. It is shared between
+// output artifacts, the outputs of the containers of a container set and
+// logs, and report outputs. It is shared between
 // the `argoexec wait` command (legacy pod layout) and the new
 // `argoexec supervisor` command (init-less pod layout).
 //
@@ -53,6 +54,10 @@ func (we *WorkflowExecutor) PostMain(ctx, bgCtx context.Context, preMainFailed b
 		if err != nil {
 			we.AddError(ctx, err)
 		}
+
+		if err := we.SaveContainerOutputs(bgCtx); err != nil {
+			we.AddError(ctx, err)
+		}
 	}
 
 	// Save log artifacts (still useful even when pre-main failed — main's
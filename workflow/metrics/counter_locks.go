@@ -7,7 +7,10 @@ import (
 )
 
 func addLocksTakenCounter(_ context.Context, m *Metrics) error {
-	return m.CreateBuiltinInstrument(telemetry.InstrumentLocksTakenTotal)
+	if err := m.CreateBuiltinInstrument(telemetry.InstrumentLocksTakenTotal); err != nil {
+		return err
+	}
+	return m.CreateBuiltinInstrument(telemetry.InstrumentLocksLeaseExpiredTotal)
 }
 
 // RecordLockTaken increments the counter of synchronization locks acquired.
@@ -17,3 +20,11 @@ func (m *Metrics) RecordLockTaken(ctx context.Context, lockType, storage, name,
 	}
 	m.AddLocksTakenTotal(ctx, 1, lockType, storage, name, namespace)
 }
+
+// RecordLockLeaseExpired increments the counter of synchronization locks revoked because their lease expired.
+func (m *Metrics) RecordLockLeaseExpired(ctx context.Context, lockType, storage, name, namespace string) {
+	if m == nil || m.Metrics == nil {
+		return
+	}
+	m.AddLocksLeaseExpiredTotal(ctx, 1, lockType, storage, name, namespace)
+}
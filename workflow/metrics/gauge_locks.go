@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"time"
 
 	"github.com/argoproj/argo-workflows/v4/util/telemetry"
 
@@ -16,6 +17,8 @@ type LockGaugeSample struct {
 	Namespace string // the lock's namespace
 	Held      int64  // holders currently holding the lock
 	Pending   int64  // requests currently waiting to acquire
+	// LongestWait is, per tenant, how long the longest waiting request has been waiting to acquire
+	LongestWait map[string]time.Duration
 }
 
 // LocksCallback is the function prototype that provides the lock gauges with the current
@@ -35,10 +38,13 @@ func addLocksGauges(_ context.Context, m *Metrics) error {
 	if err := m.CreateBuiltinInstrument(telemetry.InstrumentLocksHeld); err != nil {
 		return err
 	}
+	if err := m.CreateBuiltinInstrument(telemetry.InstrumentLocksLongestWait); err != nil {
+		return err
+	}
 	return m.CreateBuiltinInstrument(telemetry.InstrumentLocksPending)
 }
 
-// RegisterLockGauges wires the locks_held / locks_pending gauges to their data source. It is called
+// RegisterLockGauges wires the locks_held / locks_pending / locks_longest_wait gauges to their data source. It is called
 // by the sync Manager (from WithMetrics) once it exists, binding the observable callbacks directly
 // to the live snapshot function. A no-op if cb is nil.
 func (m *Metrics) RegisterLockGauges(cb LocksCallback) error {
@@ -62,7 +68,32 @@ func (m *Metrics) RegisterLockGauges(cb LocksCallback) error {
 		value:    func(s LockGaugeSample) int64 { return s.Pending },
 	}
 	if inst := m.GetInstrument(telemetry.InstrumentLocksPending.Name()); inst != nil {
-		return inst.RegisterCallback(m.Metrics, pending.update)
+		if err := inst.RegisterCallback(m.Metrics, pending.update); err != nil {
+			return err
+		}
+	}
+
+	longestWait := &locksWaitGauge{
+		callback: cb,
+		observe:  m.ObserveLocksLongestWait,
+	}
+	if inst := m.GetInstrument(telemetry.InstrumentLocksLongestWait.Name()); inst != nil {
+		return inst.RegisterCallback(m.Metrics, longestWait.update)
+	}
+	return nil
+}
+
+// locksWaitGauge observes one value per lock and tenant, rather than one per lock
+type locksWaitGauge struct {
+	callback LocksCallback
+	observe  func(ctx context.Context, o metric.Observer, val float64, lockType, storage, name, namespace, tenant string)
+}
+
+func (g *locksWaitGauge) update(ctx context.Context, o metric.Observer) error {
+	for _, s := range g.callback(ctx) {
+		for tenant, wait := range s.LongestWait {
+			g.observe(ctx, o, wait.Seconds(), s.Type, s.Storage, s.Name, s.Namespace, tenant)
+		}
 	}
 	return nil
 }
@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,7 +29,7 @@ func TestLocksGauges(t *testing.T) {
 	require.NoError(t, m.RegisterLockGauges(func(_ context.Context) []LockGaugeSample {
 		return []LockGaugeSample{
 			{Type: "mutex", Storage: "configmap", Name: "my-mutex", Namespace: "default", Held: 1, Pending: 2},
-			{Type: "semaphore", Storage: "database", Name: "my-sem", Namespace: "ns2", Held: 3, Pending: 0},
+			{Type: "semaphore", Storage: "database", Name: "my-sem", Namespace: "ns2", Held: 3, Pending: 0, LongestWait: map[string]time.Duration{"team-a": 90 * time.Second}},
 		}
 	}))
 
@@ -44,6 +45,11 @@ func TestLocksGauges(t *testing.T) {
 	held, err = te.GetInt64GaugeValue(ctx, telemetry.InstrumentLocksHeld.Name(), &semAttribs)
 	require.NoError(t, err)
 	assert.Equal(t, int64(3), held, "semaphore held")
+
+	waitAttribs := attribute.NewSet(append(semAttribs.ToSlice(), attribute.String("tenant", "team-a"))...)
+	wait, err := te.GetFloat64GaugeValue(ctx, telemetry.InstrumentLocksLongestWait.Name(), &waitAttribs)
+	require.NoError(t, err)
+	assert.InDelta(t, 90.0, wait, 0.001, "semaphore longest wait")
 }
 
 func TestRecordLockTaken(t *testing.T) {
@@ -59,3 +65,16 @@ func TestRecordLockTaken(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(2), val)
 }
+
+func TestRecordLockLeaseExpired(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	m, te, err := createTestMetrics(ctx, &telemetry.MetricsConfig{}, Callbacks{})
+	require.NoError(t, err)
+
+	m.RecordLockLeaseExpired(ctx, "mutex", "configmap", "my-mutex", "default")
+
+	attribs := lockAttribs("mutex", "configmap", "my-mutex", "default")
+	val, err := te.GetInt64CounterValue(ctx, telemetry.InstrumentLocksLeaseExpiredTotal.Name(), &attribs)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), val)
+}
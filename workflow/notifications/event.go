@@ -0,0 +1,133 @@
+package notifications
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// Event is a CloudEvent in the structured content mode, see https://github.com/cloudevents/spec
+type Event struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            EventData `json:"data"`
+}
+
+// EventData is the data of the event about the transition of a workflow, or of one of its nodes
+type EventData struct {
+	Namespace  string             `json:"namespace"`
+	Name       string             `json:"name"`
+	UID        string             `json:"uid"`
+	Labels     map[string]string  `json:"labels,omitempty"`
+	Phase      wfv1.WorkflowPhase `json:"phase"`
+	Message    string             `json:"message,omitempty"`
+	StartedAt  *metav1.Time       `json:"startedAt,omitempty"`
+	FinishedAt *metav1.Time       `json:"finishedAt,omitempty"`
+	Node       *NodeData          `json:"node,omitempty"`
+}
+
+// NodeData is the node which transitioned
+type NodeData struct {
+	ID           string         `json:"id"`
+	Name         string         `json:"name"`
+	DisplayName  string         `json:"displayName"`
+	Type         wfv1.NodeType  `json:"type"`
+	TemplateName string         `json:"templateName,omitempty"`
+	Phase        wfv1.NodePhase `json:"phase"`
+	Message      string         `json:"message,omitempty"`
+	StartedAt    *metav1.Time   `json:"startedAt,omitempty"`
+	FinishedAt   *metav1.Time   `json:"finishedAt,omitempty"`
+}
+
+// Type prefixes of the events
+const (
+	workflowEventTypePrefix = "io.argoproj.workflow."
+	nodeEventTypePrefix     = "io.argoproj.workflow.node."
+)
+
+// newWorkflowEvent returns the event for the transition of the workflow to its phase
+func newWorkflowEvent(wf *wfv1.Workflow, now time.Time) Event {
+	return Event{
+		SpecVersion:     "1.0",
+		ID:              eventID(string(wf.UID), string(wf.Status.Phase), wf.Status.StartedAt.Time),
+		Source:          source(wf),
+		Type:            workflowEventTypePrefix + strings.ToLower(string(wf.Status.Phase)),
+		Time:            now,
+		DataContentType: "application/json",
+		Data:            workflowData(wf),
+	}
+}
+
+// newNodeEvent returns the event for the transition of the node of the workflow to its phase
+func newNodeEvent(wf *wfv1.Workflow, node wfv1.NodeStatus, now time.Time) Event {
+	data := workflowData(wf)
+	data.Node = &NodeData{
+		ID:           node.ID,
+		Name:         node.Name,
+		DisplayName:  node.DisplayName,
+		Type:         node.Type,
+		TemplateName: node.TemplateName,
+		Phase:        node.Phase,
+		Message:      node.Message,
+		StartedAt:    optionalTime(node.StartedAt),
+		FinishedAt:   optionalTime(node.FinishedAt),
+	}
+	return Event{
+		SpecVersion:     "1.0",
+		ID:              eventID(string(wf.UID), node.ID, string(node.Phase), node.StartedAt.Time),
+		Source:          source(wf),
+		Type:            nodeEventTypePrefix + strings.ToLower(string(node.Phase)),
+		Subject:         node.Name,
+		Time:            now,
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+func workflowData(wf *wfv1.Workflow) EventData {
+	return EventData{
+		Namespace:  wf.Namespace,
+		Name:       wf.Name,
+		UID:        string(wf.UID),
+		Labels:     wf.Labels,
+		Phase:      wf.Status.Phase,
+		Message:    wf.Status.Message,
+		StartedAt:  optionalTime(wf.Status.StartedAt),
+		FinishedAt: optionalTime(wf.Status.FinishedAt),
+	}
+}
+
+func source(wf *wfv1.Workflow) string {
+	return fmt.Sprintf("/apis/argoproj.io/v1alpha1/namespaces/%s/workflows/%s", wf.Namespace, wf.Name)
+}
+
+// eventID is the same for each delivery of the same transition, so that sinks can drop duplicates. The start time
+// tells apart the transitions of retried and resubmitted workflows and nodes.
+func eventID(parts ...any) string {
+	h := sha256.New()
+	for _, part := range parts {
+		if t, ok := part.(time.Time); ok {
+			part = t.UnixNano()
+		}
+		_, _ = fmt.Fprintf(h, "%v/", part)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+func optionalTime(t metav1.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,334 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v4/config"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/intstr"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+)
+
+// defaultQueueSize is how many events can wait to be delivered before further ones are dead-lettered
+const defaultQueueSize = 1000
+
+// secretTTL is how long the secrets of the headers are cached, so rotated secrets are used within it
+const secretTTL = time.Minute
+
+// Notifier publishes the phase transitions of workflows, and of their nodes, as CloudEvents to the configured sinks.
+// Events are queued and delivered by workers, so that slow sinks never hold up the operation of workflows.
+type Notifier struct {
+	kubeclientset kubernetes.Interface
+	// namespace is the namespace of the controller, where the secrets of the sinks are
+	namespace string
+	client    *http.Client
+	queue     chan delivery
+	// secrets are the data of the secrets of the headers, by their name, so they are not read for every delivery
+	secrets   map[string]cachedSecret
+	secretsMu sync.Mutex
+}
+
+// cachedSecret is the data of a secret, until it expires
+type cachedSecret struct {
+	data    map[string][]byte
+	expires time.Time
+}
+
+// delivery is an event to deliver to a sink
+type delivery struct {
+	sink  config.NotificationSink
+	event Event
+}
+
+// NewNotifier returns a notifier which reads the secrets of the sinks from the namespace
+func NewNotifier(kubeclientset kubernetes.Interface, namespace string, queueSize int) *Notifier {
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	return &Notifier{
+		kubeclientset: kubeclientset,
+		namespace:     namespace,
+		client:        &http.Client{},
+		queue:         make(chan delivery, queueSize),
+		secrets:       map[string]cachedSecret{},
+	}
+}
+
+// Run delivers the queued events with the workers until the context is done
+func (n *Notifier) Run(ctx context.Context, workers int) {
+	defer runtimeutil.HandleCrashWithContext(ctx, runtimeutil.PanicHandlers...)
+
+	logger := logging.RequireLoggerFromContext(ctx)
+	ctx, _ = logger.WithField("component", "notifications").InContext(ctx)
+	for range workers {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case d := <-n.queue:
+					n.deliver(ctx, d)
+				}
+			}
+		}()
+	}
+	<-ctx.Done()
+}
+
+// Notify queues the events for the transitions of the workflow, and of its nodes, since old for the sinks which
+// select them. These are the configured sinks and those the workflow adds, if the configuration allows them.
+func (n *Notifier) Notify(ctx context.Context, cfg *config.Notifications, old, wf *wfv1.Workflow) {
+	if cfg == nil {
+		return
+	}
+	deliveries := n.sinks(ctx, cfg, wf)
+	if len(deliveries) == 0 {
+		return
+	}
+	now := time.Now().UTC()
+	var events []Event
+	if wf.Status.Phase != "" && wf.Status.Phase != old.Status.Phase {
+		events = append(events, newWorkflowEvent(wf, now))
+	}
+	wantsNodes := slices.ContainsFunc(deliveries, func(d delivery) bool { return d.sink.Filter.Nodes })
+	for _, node := range wf.Status.Nodes {
+		if !wantsNodes {
+			break
+		}
+		if oldNode, ok := old.Status.Nodes[node.ID]; node.Phase != "" && (!ok || oldNode.Phase != node.Phase) {
+			events = append(events, newNodeEvent(wf, node, now))
+		}
+	}
+	logger := logging.RequireLoggerFromContext(ctx)
+	for _, event := range events {
+		isNode := event.Data.Node != nil
+		phase := string(event.Data.Phase)
+		if isNode {
+			phase = string(event.Data.Node.Phase)
+		}
+		for _, d := range deliveries {
+			matches, err := d.sink.Filter.Matches(wf.Namespace, wf.Labels, phase, isNode)
+			if err != nil {
+				logger.WithError(err).WithField("sink", d.sink.GetName()).Warn(ctx, "Invalid notification filter")
+				continue
+			}
+			if !matches {
+				continue
+			}
+			d.event = event
+			select {
+			case n.queue <- d:
+			default:
+				deadLetter(ctx, d, fmt.Errorf("the notification queue is full"))
+			}
+		}
+	}
+}
+
+// sinks returns the configured sinks, and the sinks the workflow adds which the configuration allows
+func (n *Notifier) sinks(ctx context.Context, cfg *config.Notifications, wf *wfv1.Workflow) []delivery {
+	var deliveries []delivery
+	for _, sink := range cfg.Sinks {
+		deliveries = append(deliveries, delivery{sink: sink})
+	}
+	value, ok := wf.Annotations[common.AnnotationKeyNotificationSinks]
+	if !ok {
+		return deliveries
+	}
+	logger := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name})
+	var sinks []config.NotificationSink
+	if err := json.Unmarshal([]byte(value), &sinks); err != nil {
+		logger.WithError(err).Warn(ctx, "Invalid notification sinks annotation")
+		return deliveries
+	}
+	for _, sink := range sinks {
+		if !cfg.AllowsWorkflowSink(sink) {
+			logger.WithField("url", sink.URL).Warn(ctx, "Notification sink of workflow is not allowed")
+			continue
+		}
+		deliveries = append(deliveries, delivery{sink: sink})
+	}
+	return deliveries
+}
+
+// deliver POSTs the event to the sink, retrying as the sink allows, and dead-letters it if it cannot be delivered
+func (n *Notifier) deliver(ctx context.Context, d delivery) {
+	logger := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"sink": d.sink.GetName(), "id": d.event.ID, "type": d.event.Type})
+	ctx = logging.WithLogger(ctx, logger)
+	body, err := json.Marshal(d.event)
+	if err != nil {
+		deadLetter(ctx, d, err)
+		return
+	}
+	headers, err := n.headers(ctx, d)
+	if err != nil {
+		deadLetter(ctx, d, err)
+		return
+	}
+	retry := d.sink.Retry
+	if retry == nil {
+		retry = &wfv1.HTTPRetry{}
+	}
+	limit := 3
+	if retry.Limit != nil {
+		l, err := intstr.Int(retry.Limit)
+		if err != nil {
+			deadLetter(ctx, d, err)
+			return
+		}
+		limit = *l
+	}
+	backoff, err := newBackoff(retry.Backoff)
+	if err != nil {
+		deadLetter(ctx, d, err)
+		return
+	}
+	for attempt := 0; ; attempt++ {
+		statusCode, err := n.post(ctx, d.sink, headers, body)
+		if err == nil {
+			logger.Debug(ctx, "Delivered notification")
+			return
+		}
+		// delivering an event again is idempotent, as sinks can drop duplicates by its id, like a PUT
+		retryable := statusCode == 0 && retry.RetriesNetworkErrors(http.MethodPut) || statusCode != 0 && retry.RetriesStatusCode(statusCode)
+		if !retryable || attempt >= limit || ctx.Err() != nil {
+			deadLetter(ctx, d, err)
+			return
+		}
+		delay := backoff.next()
+		logger.WithError(err).WithField("delay", delay).Info(ctx, "Retrying notification")
+		select {
+		case <-ctx.Done():
+			deadLetter(ctx, d, ctx.Err())
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// post sends the event, returning the status code of the response, if there was one, when it is not successful
+func (n *Notifier) post(ctx context.Context, sink config.NotificationSink, headers http.Header, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, sink.GetTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header = headers.Clone()
+	req.Header.Set("Content-Type", "application/cloudevents+json; charset=UTF-8")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%s responded %s", sink.URL, resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// headers returns the headers of the sink, with the values of their secrets in the namespace of the controller
+func (n *Notifier) headers(ctx context.Context, d delivery) (http.Header, error) {
+	headers := http.Header{}
+	for _, h := range d.sink.Headers {
+		value := h.Value
+		if h.SecretKeyRef != nil {
+			secret, err := n.secretData(ctx, h.SecretKeyRef.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get secret %q for header %q: %w", h.SecretKeyRef.Name, h.Name, err)
+			}
+			data, ok := secret[h.SecretKeyRef.Key]
+			if !ok {
+				return nil, fmt.Errorf("secret %q does not have the key %q for header %q", h.SecretKeyRef.Name, h.SecretKeyRef.Key, h.Name)
+			}
+			value = string(data)
+		}
+		headers.Add(h.Name, value)
+	}
+	return headers, nil
+}
+
+// secretData returns the data of the secret, reading it again once the cached data expires
+func (n *Notifier) secretData(ctx context.Context, name string) (map[string][]byte, error) {
+	n.secretsMu.Lock()
+	cached, ok := n.secrets[name]
+	n.secretsMu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.data, nil
+	}
+	secret, err := n.kubeclientset.CoreV1().Secrets(n.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	n.secretsMu.Lock()
+	n.secrets[name] = cachedSecret{data: secret.Data, expires: time.Now().Add(secretTTL)}
+	n.secretsMu.Unlock()
+	return secret.Data, nil
+}
+
+// deadLetter logs the event which could not be delivered, so that it can be recovered from the logs
+func deadLetter(ctx context.Context, d delivery, err error) {
+	event, _ := json.Marshal(d.event)
+	logging.RequireLoggerFromContext(ctx).WithError(err).WithFields(logging.Fields{
+		"sink":       d.sink.GetName(),
+		"deadLetter": true,
+		"event":      string(event),
+	}).Error(ctx, "Failed to deliver notification")
+}
+
+// backoff is the delay between the retries of a delivery, 1s doubling each retry by default
+type backoff struct {
+	delay  time.Duration
+	factor int
+	cap    time.Duration
+}
+
+func newBackoff(b *wfv1.Backoff) (*backoff, error) {
+	result := &backoff{delay: time.Second, factor: 2}
+	if b == nil {
+		return result, nil
+	}
+	var err error
+	if b.Duration != "" {
+		if result.delay, err = wfv1.ParseStringToDuration(b.Duration); err != nil {
+			return nil, err
+		}
+	}
+	if b.Factor != nil {
+		factor, err := intstr.Int(b.Factor)
+		if err != nil {
+			return nil, err
+		}
+		result.factor = max(*factor, 1)
+	}
+	if b.Cap != "" {
+		if result.cap, err = wfv1.ParseStringToDuration(b.Cap); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// next returns the delay until the next retry, and backs off the one after it
+func (b *backoff) next() time.Duration {
+	delay := b.delay
+	b.delay *= time.Duration(b.factor)
+	if b.cap > 0 && b.delay > b.cap {
+		b.delay = b.cap
+	}
+	return delay
+}
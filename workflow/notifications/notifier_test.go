@@ -0,0 +1,178 @@
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v4/config"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+)
+
+// fakeSink records the events POSTed to it, failing the first requests with failures
+type fakeSink struct {
+	mu       sync.Mutex
+	events   []Event
+	headers  []http.Header
+	failures int
+}
+
+func (s *fakeSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var event Event
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.events = append(s.events, event)
+	s.headers = append(s.headers, r.Header)
+}
+
+func (s *fakeSink) received() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Event(nil), s.events...)
+}
+
+func TestNotifier(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	sink := &fakeSink{failures: 1}
+	server := httptest.NewServer(sink)
+	defer server.Close()
+	kube := fake.NewClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "argo"},
+		Data:       map[string][]byte{"token": []byte("Bearer my-token")},
+	})
+	n := NewNotifier(kube, "argo", 0)
+	go n.Run(ctx, 1)
+
+	cfg := &config.Notifications{
+		Sinks: []config.NotificationSink{{
+			Name: "my-sink",
+			URL:  server.URL + "/all",
+			Headers: []config.NotificationHeader{{
+				Name:         "Authorization",
+				SecretKeyRef: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-secret"}, Key: "token"},
+			}},
+			Filter: config.NotificationFilter{Nodes: true, Phases: []string{"Succeeded"}},
+			Retry:  &wfv1.HTTPRetry{Backoff: &wfv1.Backoff{Duration: "10ms"}},
+		}},
+		WorkflowSinkURLPrefixes: []string{server.URL + "/team-a/"},
+	}
+	old := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-wf", UID: "my-uid"},
+		Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning, Nodes: wfv1.Nodes{
+			"my-wf":   {ID: "my-wf", Name: "my-wf", Phase: wfv1.NodeRunning},
+			"my-node": {ID: "my-node", Name: "my-wf.a", Phase: wfv1.NodeRunning},
+		}},
+	}
+	wf := old.DeepCopy()
+	wf.Annotations = map[string]string{common.AnnotationKeyNotificationSinks: `[
+		{"url": "` + server.URL + `/team-a/hook"},
+		{"url": "` + server.URL + `/team-b/hook"}
+	]`}
+	wf.Status.Phase = wfv1.WorkflowSucceeded
+	wf.Status.Nodes["my-wf"] = wfv1.NodeStatus{ID: "my-wf", Name: "my-wf", Phase: wfv1.NodeSucceeded}
+	n.Notify(ctx, cfg, old, wf)
+
+	require.Eventually(t, func() bool { return len(sink.received()) == 3 }, 5*time.Second, 10*time.Millisecond)
+	received := map[string]Event{}
+	for _, e := range sink.received() {
+		received[e.Type+"/"+e.Subject] = e
+	}
+	assert.Len(t, received, 2)
+	event := received["io.argoproj.workflow.succeeded/"]
+	assert.Equal(t, "1.0", event.SpecVersion)
+	assert.Equal(t, "/apis/argoproj.io/v1alpha1/namespaces/my-ns/workflows/my-wf", event.Source)
+	assert.Equal(t, wfv1.WorkflowSucceeded, event.Data.Phase)
+	assert.Nil(t, event.Data.Node)
+	assert.NotEmpty(t, event.ID)
+	node := received["io.argoproj.workflow.node.succeeded/my-wf"].Data.Node
+	require.NotNil(t, node)
+	assert.Equal(t, "my-wf", node.ID)
+	assert.Contains(t, sink.headers[0].Get("Content-Type"), "application/cloudevents+json")
+	assert.Contains(t, []string{sink.headers[0].Get("Authorization"), sink.headers[1].Get("Authorization"), sink.headers[2].Get("Authorization")}, "Bearer my-token")
+
+	t.Run("NoTransition", func(t *testing.T) {
+		n.Notify(ctx, cfg, wf, wf)
+		assert.Empty(t, n.queue)
+	})
+}
+
+func TestNotifierDeadLetter(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	sink := &fakeSink{failures: 2}
+	server := httptest.NewServer(sink)
+	defer server.Close()
+	n := NewNotifier(fake.NewClientset(), "argo", 0)
+	n.deliver(ctx, delivery{
+		sink:  config.NotificationSink{URL: server.URL, Retry: &wfv1.HTTPRetry{Limit: new(intstr.FromInt32(1)), Backoff: &wfv1.Backoff{Duration: "1ms"}}},
+		event: Event{ID: "my-id"},
+	})
+	assert.Empty(t, sink.received())
+	assert.Equal(t, 0, sink.failures)
+}
+
+func TestNotifierHeaders(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	kube := fake.NewClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "argo"},
+		Data:       map[string][]byte{"token": []byte("Bearer my-token")},
+	})
+	n := NewNotifier(kube, "argo", 0)
+	d := delivery{sink: config.NotificationSink{Headers: []config.NotificationHeader{
+		{Name: "Authorization", SecretKeyRef: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-secret"}, Key: "token"}},
+		{Name: "X-Team", Value: "a"},
+	}}}
+	gets := func() int {
+		count := 0
+		for _, action := range kube.Actions() {
+			if action.GetVerb() == "get" && action.GetResource().Resource == "secrets" {
+				count++
+			}
+		}
+		return count
+	}
+
+	for range 2 {
+		headers, err := n.headers(ctx, d)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer my-token", headers.Get("Authorization"))
+		assert.Equal(t, "a", headers.Get("X-Team"))
+	}
+	// The secret is cached
+	assert.Equal(t, 1, gets())
+
+	t.Run("Expired", func(t *testing.T) {
+		n.secrets["my-secret"] = cachedSecret{data: n.secrets["my-secret"].data, expires: time.Now()}
+		_, err := n.headers(ctx, d)
+		require.NoError(t, err)
+		assert.Equal(t, 2, gets())
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		d := delivery{sink: config.NotificationSink{Headers: []config.NotificationHeader{
+			{Name: "Authorization", SecretKeyRef: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-secret"}, Key: "other"}},
+		}}}
+		_, err := n.headers(ctx, d)
+		require.ErrorContains(t, err, `does not have the key "other"`)
+	})
+}
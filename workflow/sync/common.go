@@ -26,8 +26,12 @@ type semaphore interface {
 	checkAcquire(ctx context.Context, holderKey string, tx *sqldb.SessionProxy) (bool, bool, string)
 	tryAcquire(ctx context.Context, holderKey string, tx *sqldb.SessionProxy) (bool, string, error)
 	release(ctx context.Context, key string) bool
-	addToQueue(ctx context.Context, holderKey string, priority int32, creationTime time.Time) error
+	addToQueue(ctx context.Context, holderKey string, tenant string, priority int32, creationTime time.Time) error
 	removeFromQueue(ctx context.Context, holderKey string) error
+	// setLease records when the hold of holderKey expires, unless the hold already has a lease
+	setLease(ctx context.Context, holderKey string, expiry time.Time, tx *sqldb.SessionProxy) error
+	// expiredLeases returns the holders whose leases expired before now
+	expiredLeases(ctx context.Context, now time.Time) ([]string, error)
 	getCurrentHolders(ctx context.Context) ([]string, error)
 	getCurrentPending(ctx context.Context) ([]string, error)
 	getLimit(ctx context.Context) int // Testing only
@@ -37,8 +37,8 @@ func TestDatabaseMutexAcquireRelease(t *testing.T) {
 			defer deferfunc()
 
 			now := time.Now()
-			require.NoError(t, mutex.addToQueue(ctx, "default/workflow1", 0, now))
-			require.NoError(t, mutex.addToQueue(ctx, "default/workflow2", 0, now.Add(time.Second)))
+			require.NoError(t, mutex.addToQueue(ctx, "default/workflow1", "default", 0, now))
+			require.NoError(t, mutex.addToQueue(ctx, "default/workflow2", "default", 0, now.Add(time.Second)))
 
 			// First acquisition should succeed
 			acquired, _, err := mutex.tryAcquire(ctx, "default/workflow1", tx)
@@ -76,8 +76,8 @@ func TestDatabaseMutexQueueOrder(t *testing.T) {
 
 			// Add items to the queue
 			now := time.Now()
-			require.NoError(t, mutex.addToQueue(ctx, "default/workflow1", 0, now))
-			require.NoError(t, mutex.addToQueue(ctx, "default/workflow2", 0, now.Add(time.Second)))
+			require.NoError(t, mutex.addToQueue(ctx, "default/workflow1", "default", 0, now))
+			require.NoError(t, mutex.addToQueue(ctx, "default/workflow2", "default", 0, now.Add(time.Second)))
 
 			acquired, _, err := mutex.tryAcquire(ctx, "default/workflow2", tx)
 			require.NoError(t, err)
@@ -2,15 +2,33 @@ package sync
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
 	"github.com/argoproj/argo-workflows/v4/util/logging"
 	"github.com/argoproj/argo-workflows/v4/util/sqldb"
 	syncdb "github.com/argoproj/argo-workflows/v4/util/sync/db"
 )
 
+// errLockContended is returned when another controller holds the lock row of a semaphore
+var errLockContended = errors.New("semaphore is locked by another controller")
+
+// addToQueueBackoff bounds how long addToQueue waits for the lock row of a semaphore, which other controllers only
+// hold while they acquire it
+var addToQueueBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 10 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.5,
+	Cap:      600 * time.Millisecond,
+}
+
 type databaseSemaphore struct {
 	name         string
 	limitGetter  limitProvider
@@ -20,11 +38,12 @@ type databaseSemaphore struct {
 	info         syncdb.Info
 	queries      syncdb.SyncQueries
 	isMutex      bool
+	fairShare    *fairShare
 }
 
 var _ semaphore = &databaseSemaphore{}
 
-func newDatabaseSemaphore(ctx context.Context, name string, dbKey string, nextWorkflow NextWorkflow, info syncdb.Info, syncLimitCacheTTL time.Duration) (*databaseSemaphore, error) {
+func newDatabaseSemaphore(ctx context.Context, name string, dbKey string, nextWorkflow NextWorkflow, info syncdb.Info, syncLimitCacheTTL time.Duration, fairShare *fairShare) (*databaseSemaphore, error) {
 	logger := syncLogger{
 		name:     name,
 		lockType: lockTypeSemaphore,
@@ -38,6 +57,7 @@ func newDatabaseSemaphore(ctx context.Context, name string, dbKey string, nextWo
 		info:         info,
 		queries:      syncdb.NewSyncQueries(info.SessionProxy, info.Config),
 		isMutex:      false,
+		fairShare:    fairShare,
 	}
 	sem.limitGetter = newCachedLimit(sem.getLimitFromDB, syncLimitCacheTTL)
 	var err error
@@ -166,7 +186,36 @@ func (s *databaseSemaphore) queueOrdered(ctx context.Context, sessionProxy *sqld
 		logger.WithError(err).Error(ctx, "Failed to get ordered queue for semaphore notification")
 		return nil, err
 	}
-	return queue, nil
+	if s.fairShare == nil {
+		return queue, nil
+	}
+	// The queue is ordered in Go rather than SQL, as tags are redistributed over the waiters of each tenant
+	waiters := make([]queueEntry, len(queue))
+	byKey := make(map[string][]syncdb.StateRecord, len(queue))
+	for i, record := range queue {
+		waiters[i] = queueEntry{key: record.Key, tenant: record.Tenant, priority: record.Priority, creationTime: record.Time, tag: record.FairShareTag}
+		byKey[record.Key] = append(byKey[record.Key], record)
+	}
+	s.fairShare.order(waiters)
+	ordered := make([]syncdb.StateRecord, 0, len(queue))
+	for _, waiter := range waiters {
+		ordered = append(ordered, byKey[waiter.key][0])
+		byKey[waiter.key] = byKey[waiter.key][1:]
+	}
+	return ordered, nil
+}
+
+// nextFairShareTag returns the fair-share tag of a new waiter of the tenant
+func (s *databaseSemaphore) nextFairShareTag(ctx context.Context, tx *sqldb.SessionProxy, tenant string) (float64, error) {
+	states, err := s.queries.GetFairShareState(ctx, tx, s.longDBKey())
+	if err != nil {
+		return 0, err
+	}
+	entries := make([]queueEntry, len(states))
+	for i, state := range states {
+		entries[i] = queueEntry{key: state.Key, tenant: state.Tenant, tag: state.FairShareTag, held: state.Held}
+	}
+	return s.fairShare.nextTag(tenant, entries), nil
 }
 
 // notifyWaiters enqueues the next N workflows who are waiting for the semaphore to the workqueue,
@@ -204,8 +253,9 @@ func (s *databaseSemaphore) notifyWaiters(ctx context.Context) {
 }
 
 // addToQueue adds the holderkey into priority queue that maintains the priority order to acquire the lock.
-func (s *databaseSemaphore) addToQueue(ctx context.Context, holderKey string, priority int32, creationTime time.Time) error {
-	// Doesn't need a transaction, as no-one else should be inserting exactly this record ever
+// The fair-share tag of the holder depends on the rest of the queue, so the queue is read and the holder inserted in
+// one transaction, while holding the semaphore's lock row so that no other controller queues a holder in between.
+func (s *databaseSemaphore) addToQueue(ctx context.Context, holderKey string, tenant string, priority int32, creationTime time.Time) error {
 	states, err := s.queries.CheckQueueExists(ctx, s.longDBKey(), holderKey, s.info.Config.ControllerName)
 	if err != nil {
 		return err
@@ -213,6 +263,7 @@ func (s *databaseSemaphore) addToQueue(ctx context.Context, holderKey string, pr
 	if len(states) > 0 {
 		return nil
 	}
+	queuedTime := nowFn()
 	record := &syncdb.StateRecord{
 		Name:       s.longDBKey(),
 		Key:        holderKey,
@@ -220,9 +271,29 @@ func (s *databaseSemaphore) addToQueue(ctx context.Context, holderKey string, pr
 		Held:       false,
 		Priority:   priority,
 		Time:       creationTime,
-	}
-	err = s.queries.AddToQueue(ctx, record)
-	return err
+		Tenant:     tenant,
+		QueuedTime: &queuedTime,
+	}
+	if s.fairShare == nil {
+		// Doesn't need a transaction, as no-one else should be inserting exactly this record ever
+		return s.queries.AddToQueue(ctx, s.info.SessionProxy, record)
+	}
+	return retry.OnError(addToQueueBackoff, func(err error) bool {
+		return errors.Is(err, errLockContended) || isRetryableSyncError(err)
+	}, func() error {
+		if !s.lock(ctx) {
+			return fmt.Errorf("%w: %s", errLockContended, s.longDBKey())
+		}
+		defer s.unlock(ctx)
+		return s.info.SessionProxy.TxWith(ctx, func(tx *sqldb.SessionProxy) error {
+			var tagErr error
+			record.FairShareTag, tagErr = s.nextFairShareTag(ctx, tx, tenant)
+			if tagErr != nil {
+				return tagErr
+			}
+			return s.queries.AddToQueue(ctx, tx, record)
+		}, &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: false})
+	})
 }
 
 func (s *databaseSemaphore) removeFromQueue(ctx context.Context, holderKey string) error {
@@ -230,6 +301,22 @@ func (s *databaseSemaphore) removeFromQueue(ctx context.Context, holderKey strin
 	return err
 }
 
+func (s *databaseSemaphore) setLease(ctx context.Context, holderKey string, expiry time.Time, tx *sqldb.SessionProxy) error {
+	return s.queries.SetLeaseExpiry(ctx, tx, s.longDBKey(), holderKey, s.info.Config.ControllerName, expiry)
+}
+
+func (s *databaseSemaphore) expiredLeases(ctx context.Context, now time.Time) ([]string, error) {
+	states, err := s.queries.GetExpiredLeases(ctx, s.longDBKey(), s.info.Config.ControllerName, now)
+	if err != nil {
+		return nil, err
+	}
+	expired := make([]string, len(states))
+	for i, state := range states {
+		expired[i] = state.Key
+	}
+	return expired, nil
+}
+
 func (s *databaseSemaphore) checkAcquire(ctx context.Context, holderKey string, tx *sqldb.SessionProxy) (bool, bool, string) {
 	logger := s.logger(ctx)
 	if holderKey == "" {
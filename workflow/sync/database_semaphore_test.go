@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/upper/db/v4"
 
+	"github.com/argoproj/argo-workflows/v4/config"
 	"github.com/argoproj/argo-workflows/v4/util/logging"
 	"github.com/argoproj/argo-workflows/v4/util/sqldb"
 	syncdb "github.com/argoproj/argo-workflows/v4/util/sync/db"
@@ -49,8 +50,8 @@ func TestInactiveControllerDBSemaphore(t *testing.T) {
 
 			// Add items to the queue
 			now := time.Now()
-			require.NoError(t, s.addToQueue(ctx, "foo/wf-01", 0, now))
-			require.NoError(t, s.addToQueue(ctx, "foo/wf-02", 0, now.Add(time.Second)))
+			require.NoError(t, s.addToQueue(ctx, "foo/wf-01", "foo", 0, now))
+			require.NoError(t, s.addToQueue(ctx, "foo/wf-02", "foo", 0, now.Add(time.Second)))
 
 			// Try to acquire - this should fail because the controller is considered inactive
 			tx := info.SessionProxy
@@ -105,7 +106,7 @@ func TestOtherControllerDBSemaphore(t *testing.T) {
 
 			// Add our own item to the queue
 			now := time.Now()
-			require.NoError(t, s.addToQueue(ctx, "foo/our-wf-01", 0, now.Add(time.Second)))
+			require.NoError(t, s.addToQueue(ctx, "foo/our-wf-01", "foo", 0, now.Add(time.Second)))
 
 			// Try to acquire - this should fail because the other controller's item is first in line
 			tx := info.SessionProxy
@@ -167,7 +168,7 @@ func TestDifferentSemaphoreDBSemaphore(t *testing.T) {
 
 			// Add our own item to the queue
 			now := time.Now()
-			require.NoError(t, s.addToQueue(ctx, "foo/our-wf-01", 0, now.Add(time.Second)))
+			require.NoError(t, s.addToQueue(ctx, "foo/our-wf-01", "foo", 0, now.Add(time.Second)))
 
 			// Try to acquire - this should succeed because the other cluster's item is for a different semaphore
 			tx := info.SessionProxy
@@ -205,27 +206,27 @@ func TestMutexAndSemaphoreWithSameName(t *testing.T) {
 
 			// Mutex workflow 1
 			tx := info.SessionProxy
-			require.NoError(t, mutex.addToQueue(ctx, "foo/wf-mutex-1", 0, now))
+			require.NoError(t, mutex.addToQueue(ctx, "foo/wf-mutex-1", "foo", 0, now))
 			mutexAcquired1, _, _ := mutex.tryAcquire(ctx, "foo/wf-mutex-1", tx)
 			assert.True(t, mutexAcquired1, "Mutex should be acquired by first workflow")
 
 			// Semaphore workflow 1
-			require.NoError(t, semaphore.addToQueue(ctx, "foo/wf-sem-1", 0, now))
+			require.NoError(t, semaphore.addToQueue(ctx, "foo/wf-sem-1", "foo", 0, now))
 			semAcquired1, _, _ := semaphore.tryAcquire(ctx, "foo/wf-sem-1", tx)
 			assert.True(t, semAcquired1, "Semaphore should be acquired by first workflow")
 
 			// Verify the mutex can't be acquired again
-			require.NoError(t, mutex.addToQueue(ctx, "foo/wf-mutex-2", 0, now))
+			require.NoError(t, mutex.addToQueue(ctx, "foo/wf-mutex-2", "foo", 0, now))
 			mutexAcquired2, _, _ := mutex.tryAcquire(ctx, "foo/wf-mutex-2", tx)
 			assert.False(t, mutexAcquired2, "Mutex should not be acquired by second workflow")
 
 			// But the semaphore can still be acquired (limit=2)
-			require.NoError(t, semaphore.addToQueue(ctx, "foo/wf-sem-2", 0, now))
+			require.NoError(t, semaphore.addToQueue(ctx, "foo/wf-sem-2", "foo", 0, now))
 			semAcquired2, _, _ := semaphore.tryAcquire(ctx, "foo/wf-sem-2", tx)
 			assert.True(t, semAcquired2, "Semaphore should be acquired by second workflow")
 
 			// But not a third time (because limit=2)
-			require.NoError(t, semaphore.addToQueue(ctx, "foo/wf-sem-3", 0, now))
+			require.NoError(t, semaphore.addToQueue(ctx, "foo/wf-sem-3", "foo", 0, now))
 			semAcquired3, _, _ := semaphore.tryAcquire(ctx, "foo/wf-sem-3", tx)
 			assert.False(t, semAcquired3, "Semaphore should not be acquired by third workflow (at capacity)")
 
@@ -249,7 +250,7 @@ func TestMutexAndSemaphoreWithSameName(t *testing.T) {
 			assert.True(t, semAcquired3Again, "Semaphore should be acquired after release")
 
 			// But not a fourth time (still at capacity with 2 holders)
-			require.NoError(t, semaphore.addToQueue(ctx, "foo/wf-sem-4", 0, now))
+			require.NoError(t, semaphore.addToQueue(ctx, "foo/wf-sem-4", "foo", 0, now))
 			semAcquired4, _, _ := semaphore.tryAcquire(ctx, "foo/wf-sem-4", tx)
 			assert.False(t, semAcquired4, "Semaphore should not be acquired fourth time (at capacity again)")
 
@@ -370,3 +371,34 @@ func TestSyncLimitCacheDB(t *testing.T) {
 		})
 	}
 }
+
+// TestFairShareQueueLockRow tests that fair-share waiters are only queued while holding the semaphore's lock row
+func TestFairShareQueueLockRow(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	for _, dbType := range testDBTypes {
+		t.Run(string(dbType), func(t *testing.T) {
+			s, info, deferfunc := createTestDatabaseSemaphore(ctx, t, "bar", "foo", 1, 0, func(string) {}, dbType)
+			defer deferfunc()
+			s.fairShare = newFairShare(&config.FairShareConfig{})
+
+			// Another controller holds the lock row
+			require.NoError(t, s.queries.InsertLock(ctx, &syncdb.LockRecord{Name: s.longDBKey(), Controller: "other-controller", Time: time.Now()}))
+			err := s.addToQueue(ctx, "foo/wf-01", "foo", 0, time.Now())
+			require.ErrorIs(t, err, errLockContended)
+			pending, err := s.getCurrentPending(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, pending)
+
+			require.NoError(t, s.queries.DeleteLock(ctx, s.longDBKey()))
+			require.NoError(t, s.addToQueue(ctx, "foo/wf-01", "foo", 0, time.Now()))
+			pending, err = s.getCurrentPending(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"foo/wf-01"}, pending)
+
+			// The lock row is released once the waiter is queued
+			locks, err := s.queries.GetExistingLocks(ctx, s.longDBKey(), info.Config.ControllerName)
+			require.NoError(t, err)
+			assert.Empty(t, locks)
+		})
+	}
+}
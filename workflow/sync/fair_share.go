@@ -0,0 +1,123 @@
+package sync
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/config"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// fairShare orders the waiters of a semaphore so that tenants share it, rather than the tenant which
+// queued the most workflows being served first.
+//
+// It uses start-time fair queueing. Each waiter is given a tag when it is queued: 1/weight after the
+// latest tag of the other holders and waiters of its tenant, or the current virtual time if its tenant
+// has none. Waiters are served in tag order, so tenants take turns in proportion to their weights.
+// Tags are stored alongside the waiters, so every controller sharing a database semaphore orders its
+// waiters the same way.
+type fairShare struct {
+	tenantLabelKey string
+	weights        map[string]int
+}
+
+func newFairShare(cfg *config.FairShareConfig) *fairShare {
+	if cfg == nil {
+		return nil
+	}
+	return &fairShare{
+		tenantLabelKey: cfg.TenantLabelKey,
+		weights:        cfg.Weights,
+	}
+}
+
+// tenant returns the tenant of a workflow, which is its namespace unless a tenant label key is configured.
+// It is safe to call on a nil fairShare, as tenants are also used to label the wait time metrics.
+func (f *fairShare) tenant(wf *wfv1.Workflow) string {
+	if f == nil || f.tenantLabelKey == "" {
+		return wf.Namespace
+	}
+	return wf.Labels[f.tenantLabelKey]
+}
+
+// step is the distance between the tags of consecutive waiters of a tenant
+func (f *fairShare) step(tenant string) float64 {
+	if weight, ok := f.weights[tenant]; ok && weight > 0 {
+		return 1 / float64(weight)
+	}
+	return 1
+}
+
+// queueEntry is a holder or waiter of a semaphore
+type queueEntry struct {
+	key          string
+	tenant       string
+	priority     int32
+	creationTime time.Time
+	tag          float64
+	held         bool
+	queuedTime   time.Time
+}
+
+// nextTag returns the tag of a new waiter of the tenant, given the current holders and waiters of the semaphore
+func (f *fairShare) nextTag(tenant string, entries []queueEntry) float64 {
+	tag := virtualTime(entries)
+	for _, e := range entries {
+		if e.tenant == tenant {
+			tag = max(tag, e.tag+f.step(tenant))
+		}
+	}
+	return tag
+}
+
+// virtualTime is the lowest tag of the waiters, or if there are none, the highest tag of the holders
+func virtualTime(entries []queueEntry) float64 {
+	var lowestWaiting, highestHeld float64
+	waiting, held := false, false
+	for _, e := range entries {
+		switch {
+		case !e.held && (!waiting || e.tag < lowestWaiting):
+			lowestWaiting, waiting = e.tag, true
+		case e.held && (!held || e.tag > highestHeld):
+			highestHeld, held = e.tag, true
+		}
+	}
+	if waiting {
+		return lowestWaiting
+	}
+	return highestHeld
+}
+
+// order sorts the waiters into the order they are served in: by tag, then by priority and creation time.
+// The tags of each tenant are first redistributed over its waiters in priority order, so that priority
+// still orders the workflows of a tenant.
+func (f *fairShare) order(waiters []queueEntry) {
+	byTenant := make(map[string][]int)
+	for i, w := range waiters {
+		byTenant[w.tenant] = append(byTenant[w.tenant], i)
+	}
+	for _, indexes := range byTenant {
+		tags := make([]float64, len(indexes))
+		for i, index := range indexes {
+			tags[i] = waiters[index].tag
+		}
+		slices.Sort(tags)
+		slices.SortFunc(indexes, func(a, b int) int { return compareQueued(waiters[a], waiters[b]) })
+		for i, index := range indexes {
+			waiters[index].tag = tags[i]
+		}
+	}
+	slices.SortFunc(waiters, func(a, b queueEntry) int {
+		return cmp.Or(cmp.Compare(a.tag, b.tag), compareQueued(a, b))
+	})
+}
+
+// compareQueued orders entries by priority, highest first, then by creation time, oldest first
+func compareQueued(a, b queueEntry) int {
+	return cmp.Or(
+		cmp.Compare(b.priority, a.priority),
+		a.creationTime.Compare(b.creationTime),
+		cmp.Compare(a.key, b.key),
+	)
+}
@@ -0,0 +1,115 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v4/config"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+)
+
+func TestFairShareTenant(t *testing.T) {
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Labels: map[string]string{"team": "my-team"}}}
+	var disabled *fairShare
+	assert.Equal(t, "my-ns", disabled.tenant(wf))
+	assert.Equal(t, "my-ns", newFairShare(&config.FairShareConfig{}).tenant(wf))
+	assert.Equal(t, "my-team", newFairShare(&config.FairShareConfig{TenantLabelKey: "team"}).tenant(wf))
+}
+
+func TestFairShareNextTag(t *testing.T) {
+	f := newFairShare(&config.FairShareConfig{Weights: map[string]int{"a": 2}})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.InDelta(t, 0, f.nextTag("a", nil), 0)
+	})
+	t.Run("AfterPreviousOfTenant", func(t *testing.T) {
+		entries := []queueEntry{{tenant: "a", tag: 3, held: true}, {tenant: "a", tag: 4}, {tenant: "b", tag: 4}}
+		assert.InDelta(t, 4.5, f.nextTag("a", entries), 0)
+		assert.InDelta(t, 5, f.nextTag("b", entries), 0)
+	})
+	t.Run("NewTenantStartsAtVirtualTime", func(t *testing.T) {
+		assert.InDelta(t, 4, f.nextTag("c", []queueEntry{{tenant: "a", tag: 3, held: true}, {tenant: "a", tag: 4}, {tenant: "a", tag: 5}}), 0)
+		assert.InDelta(t, 3, f.nextTag("c", []queueEntry{{tenant: "a", tag: 2, held: true}, {tenant: "a", tag: 3, held: true}}), 0)
+	})
+}
+
+func TestFairShareOrder(t *testing.T) {
+	now := time.Now()
+	f := newFairShare(&config.FairShareConfig{})
+	waiters := []queueEntry{
+		{key: "a/wf-1", tenant: "a", tag: 0, creationTime: now},
+		{key: "a/wf-2", tenant: "a", tag: 1, creationTime: now.Add(time.Second)},
+		{key: "a/wf-3", tenant: "a", tag: 2, creationTime: now.Add(2 * time.Second), priority: 10},
+		{key: "b/wf-1", tenant: "b", tag: 0, creationTime: now.Add(3 * time.Second)},
+		{key: "b/wf-2", tenant: "b", tag: 1, creationTime: now.Add(4 * time.Second)},
+	}
+	f.order(waiters)
+	var keys []string
+	for _, w := range waiters {
+		keys = append(keys, w.key)
+	}
+	// tenants take turns, and priority orders the workflows of a tenant
+	assert.Equal(t, []string{"a/wf-3", "b/wf-1", "a/wf-1", "b/wf-2", "a/wf-2"}, keys)
+}
+
+// testFairShareSemaphore tests that a tenant which floods a semaphore does not starve another tenant
+func testFairShareSemaphore(t *testing.T, factory semaphoreFactory) {
+	t.Helper()
+	ctx := logging.TestContext(t.Context())
+	s, sessionProxy, cleanup := factory(ctx, t, "bar", "default", 1, func(string) {})
+	defer cleanup()
+	fs := newFairShare(&config.FairShareConfig{})
+	switch sem := s.(type) {
+	case *prioritySemaphore:
+		sem.fairShare = fs
+	case *databaseSemaphore:
+		sem.fairShare = fs
+	}
+
+	now := time.Now()
+	require.NoError(t, s.addToQueue(ctx, "a/wf-1", "a", 0, now))
+	require.NoError(t, s.addToQueue(ctx, "a/wf-2", "a", 0, now.Add(time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "a/wf-3", "a", 0, now.Add(2*time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "b/wf-1", "b", 0, now.Add(3*time.Second)))
+
+	for _, key := range []string{"a/wf-1", "b/wf-1", "a/wf-2", "a/wf-3"} {
+		acquired, _, err := s.tryAcquire(ctx, key, sessionProxy)
+		require.NoError(t, err)
+		require.True(t, acquired, "%s should be served next", key)
+		s.release(ctx, key)
+	}
+}
+
+// TestFairShareSemaphore runs the fair-share test for all semaphore implementations
+func TestFairShareSemaphore(t *testing.T) {
+	for name, factory := range semaphoreFactories {
+		t.Run(name, func(t *testing.T) {
+			testFairShareSemaphore(t, factory)
+		})
+	}
+}
+
+func TestLongestWaits(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	originalNowFn := nowFn
+	defer func() { nowFn = originalNowFn }()
+	now := time.Now()
+	nowFn = func() time.Time { return now }
+
+	s := newInternalMutex("bar", func(string) {})
+	require.NoError(t, s.addToQueue(ctx, "a/wf-1", "a", 0, now))
+	acquired, _, err := s.tryAcquire(ctx, "a/wf-1", nil)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.NoError(t, s.addToQueue(ctx, "a/wf-2", "a", 0, now))
+	nowFn = func() time.Time { return now.Add(time.Minute) }
+	require.NoError(t, s.addToQueue(ctx, "b/wf-1", "b", 0, now))
+	nowFn = func() time.Time { return now.Add(2 * time.Minute) }
+
+	assert.Equal(t, map[string]time.Duration{"a": 2 * time.Minute, "b": time.Minute}, s.longestWaits())
+}
@@ -1,6 +1,8 @@
 package sync
 
 import (
+	"time"
+
 	sema "golang.org/x/sync/semaphore"
 )
 
@@ -18,5 +20,7 @@ func newInternalMutex(name string, nextWorkflow NextWorkflow) *prioritySemaphore
 		lockHolder:   make(map[string]bool),
 		nextWorkflow: nextWorkflow,
 		logger:       logger.get,
+		queued:       make(map[string]queueEntry),
+		leases:       make(map[string]time.Time),
 	}
 }
@@ -65,12 +65,20 @@ func (p *poisonedLock) tryAcquire(_ context.Context, _ string, _ *sqldb.SessionP
 
 func (p *poisonedLock) release(_ context.Context, _ string) bool { return false }
 
-func (p *poisonedLock) addToQueue(_ context.Context, _ string, _ int32, _ time.Time) error {
+func (p *poisonedLock) addToQueue(_ context.Context, _ string, _ string, _ int32, _ time.Time) error {
 	return nil
 }
 
 func (p *poisonedLock) removeFromQueue(_ context.Context, _ string) error { return nil }
 
+func (p *poisonedLock) setLease(_ context.Context, _ string, _ time.Time, _ *sqldb.SessionProxy) error {
+	return nil
+}
+
+func (p *poisonedLock) expiredLeases(_ context.Context, _ time.Time) ([]string, error) {
+	return nil, nil
+}
+
 func (p *poisonedLock) getCurrentHolders(_ context.Context) ([]string, error) { return nil, nil }
 
 func (p *poisonedLock) getCurrentPending(_ context.Context) ([]string, error) { return nil, nil }
@@ -20,11 +20,16 @@ type prioritySemaphore struct {
 	lockHolder   map[string]bool
 	nextWorkflow NextWorkflow
 	logger       loggerFn
+	fairShare    *fairShare
+	// queued records the tenants, fair-share tags and queue times of the holders and waiters
+	queued map[string]queueEntry
+	// leases records when the holds of the holders with a lease duration expire
+	leases map[string]time.Time
 }
 
 var _ semaphore = &prioritySemaphore{}
 
-func newInternalSemaphore(ctx context.Context, name string, nextWorkflow NextWorkflow, configMapGetter GetSyncLimit, syncLimitCacheTTL time.Duration) (*prioritySemaphore, error) {
+func newInternalSemaphore(ctx context.Context, name string, nextWorkflow NextWorkflow, configMapGetter GetSyncLimit, syncLimitCacheTTL time.Duration, fairShare *fairShare) (*prioritySemaphore, error) {
 	logger := syncLogger{
 		name:     name,
 		lockType: lockTypeSemaphore,
@@ -37,6 +42,9 @@ func newInternalSemaphore(ctx context.Context, name string, nextWorkflow NextWor
 		lockHolder:   make(map[string]bool),
 		nextWorkflow: nextWorkflow,
 		logger:       logger.get,
+		fairShare:    fairShare,
+		queued:       make(map[string]queueEntry),
+		leases:       make(map[string]time.Time),
 	}
 	var err error
 	limit := sem.getLimit(ctx)
@@ -107,6 +115,8 @@ func (s *prioritySemaphore) release(ctx context.Context, key string) bool {
 	limit := s.getLimit(ctx)
 	if _, ok := s.lockHolder[key]; ok {
 		delete(s.lockHolder, key)
+		delete(s.queued, key)
+		delete(s.leases, key)
 		// When semaphore resized downward
 		// Remove the excess holders from map once the done.
 		if len(s.lockHolder) >= limit {
@@ -129,10 +139,10 @@ func (s *prioritySemaphore) release(ctx context.Context, key string) bool {
 // notifyWaiters enqueues the next N workflows who are waiting for the semaphore to the workqueue,
 // where N is the availability of the semaphore. If semaphore is out of capacity, this does nothing.
 func (s *prioritySemaphore) notifyWaiters(ctx context.Context) {
-	triggerCount := min(s.pending.Len(), s.getLimit(ctx)-len(s.lockHolder))
+	waiters := s.waiters()
+	triggerCount := min(len(waiters), s.getLimit(ctx)-len(s.lockHolder))
 	for idx := range triggerCount {
-		item := s.pending.items[idx]
-		wfKey := workflowKey(item.key)
+		wfKey := workflowKey(waiters[idx].key)
 		s.logger(ctx).WithField("workflow", wfKey).Debug(ctx, "Enqueue the workflow")
 		s.nextWorkflow(wfKey)
 	}
@@ -150,7 +160,7 @@ func workflowKey(key string) string {
 }
 
 // addToQueue adds the holderkey into priority queue that maintains the priority order to acquire the lock.
-func (s *prioritySemaphore) addToQueue(ctx context.Context, holderKey string, priority int32, creationTime time.Time) error {
+func (s *prioritySemaphore) addToQueue(ctx context.Context, holderKey string, tenant string, priority int32, creationTime time.Time) error {
 	logger := s.logger(ctx)
 
 	if _, ok := s.lockHolder[holderKey]; ok {
@@ -158,6 +168,13 @@ func (s *prioritySemaphore) addToQueue(ctx context.Context, holderKey string, pr
 		return nil
 	}
 
+	if _, ok := s.pending.itemByKey[holderKey]; !ok {
+		entry := queueEntry{key: holderKey, tenant: tenant, queuedTime: nowFn()}
+		if s.fairShare != nil {
+			entry.tag = s.fairShare.nextTag(tenant, s.queueEntries())
+		}
+		s.queued[holderKey] = entry
+	}
 	s.pending.add(holderKey, priority, creationTime)
 	logger.WithField("holderKey", holderKey).Debug(ctx, "Added into queue")
 	return nil
@@ -166,10 +183,33 @@ func (s *prioritySemaphore) addToQueue(ctx context.Context, holderKey string, pr
 func (s *prioritySemaphore) removeFromQueue(ctx context.Context, holderKey string) error {
 	logger := s.logger(ctx)
 	s.pending.remove(holderKey)
+	if _, held := s.lockHolder[holderKey]; !held {
+		delete(s.queued, holderKey)
+	}
 	logger.WithField("holderKey", holderKey).Debug(ctx, "Removed from queue")
 	return nil
 }
 
+func (s *prioritySemaphore) setLease(_ context.Context, holderKey string, expiry time.Time, _ *sqldb.SessionProxy) error {
+	if _, held := s.lockHolder[holderKey]; !held {
+		return fmt.Errorf("cannot set the lease of %s, as it does not hold %s", holderKey, s.name)
+	}
+	if _, ok := s.leases[holderKey]; !ok {
+		s.leases[holderKey] = expiry
+	}
+	return nil
+}
+
+func (s *prioritySemaphore) expiredLeases(_ context.Context, now time.Time) ([]string, error) {
+	var expired []string
+	for holderKey, expiry := range s.leases {
+		if expiry.Before(now) {
+			expired = append(expired, holderKey)
+		}
+	}
+	return expired, nil
+}
+
 func (s *prioritySemaphore) acquire(_ context.Context, holderKey string, _ *sqldb.SessionProxy) (bool, error) {
 	if s.semaphore.TryAcquire(1) {
 		s.lockHolder[holderKey] = true
@@ -236,11 +276,11 @@ func (s *prioritySemaphore) checkAcquire(ctx context.Context, holderKey string,
 	// If it is in front position, it will allow to acquire lock.
 	// If it is not a front key, it needs to wait for its turn.
 	if s.pending.Len() > 0 {
-		item := s.pending.peek()
-		if !isSameWorkflowNodeKeys(holderKey, item.key) {
+		front := s.front()
+		if !isSameWorkflowNodeKeys(holderKey, front) {
 			// Enqueue the front workflow if lock is available
 			if len(s.lockHolder) < limit {
-				s.nextWorkflow(workflowKey(item.key))
+				s.nextWorkflow(workflowKey(front))
 			}
 			logger.WithField("holderKey", holderKey).Info(ctx, "isn't at the front")
 			return false, false, waitingMsg
@@ -266,7 +306,9 @@ func (s *prioritySemaphore) tryAcquire(ctx context.Context, holderKey string, tx
 	}
 	acquired, _ := s.acquire(ctx, holderKey, tx)
 	if acquired {
-		s.pending.pop()
+		if s.pending.Len() > 0 {
+			s.pending.remove(s.front())
+		}
 		limit := s.getLimit(ctx)
 		logger.WithFields(logging.Fields{
 			"name":      s.name,
@@ -282,3 +324,49 @@ func (s *prioritySemaphore) tryAcquire(ctx context.Context, holderKey string, tx
 }
 
 func (s *prioritySemaphore) probeWaiting(_ context.Context) {}
+
+// front returns the key of the waiter which is served next
+func (s *prioritySemaphore) front() string {
+	if s.fairShare == nil {
+		return s.pending.peek().key
+	}
+	return s.waiters()[0].key
+}
+
+// waiters returns the waiters of the semaphore. If fair-share is enabled, they are in the order they are served in.
+func (s *prioritySemaphore) waiters() []queueEntry {
+	waiters := make([]queueEntry, 0, s.pending.Len())
+	for _, item := range s.pending.items {
+		entry := s.queued[item.key]
+		entry.key, entry.priority, entry.creationTime = item.key, item.priority, item.creationTime
+		waiters = append(waiters, entry)
+	}
+	if s.fairShare != nil {
+		s.fairShare.order(waiters)
+	}
+	return waiters
+}
+
+// queueEntries returns the holders and waiters of the semaphore
+func (s *prioritySemaphore) queueEntries() []queueEntry {
+	entries := s.waiters()
+	for key := range s.lockHolder {
+		// holders re-established at startup have no entry, and are treated as having the lowest tag
+		entry := s.queued[key]
+		entry.key, entry.held = key, true
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// longestWaits returns, per tenant, how long its longest waiting waiter has been waiting
+func (s *prioritySemaphore) longestWaits() map[string]time.Duration {
+	waits := make(map[string]time.Duration)
+	now := nowFn()
+	for _, item := range s.pending.items {
+		if entry, ok := s.queued[item.key]; ok {
+			waits[entry.tenant] = max(waits[entry.tenant], now.Sub(entry.queuedTime))
+		}
+	}
+	return waits
+}
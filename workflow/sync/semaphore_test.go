@@ -21,7 +21,7 @@ type semaphoreFactory func(ctx context.Context, t *testing.T, name, namespace st
 // createTestInternalSemaphore creates an in-memory semaphore for testing
 func createTestInternalSemaphore(ctx context.Context, t *testing.T, name, namespace string, limit int, nextWorkflow NextWorkflow) (semaphore, *sqldb.SessionProxy, func()) {
 	t.Helper()
-	sem, err := newInternalSemaphore(ctx, name, nextWorkflow, func(ctx context.Context, _ string) (int, error) { return limit, nil }, 0)
+	sem, err := newInternalSemaphore(ctx, name, nextWorkflow, func(ctx context.Context, _ string) (int, error) { return limit, nil }, 0, nil)
 	require.NoError(t, err)
 	return sem, nil, func() {}
 }
@@ -36,7 +36,7 @@ func createTestDatabaseSemaphore(ctx context.Context, t *testing.T, name, namesp
 	_, err = info.SessionProxy.Session().SQL().Exec("INSERT INTO sync_limit (name, sizelimit) VALUES (?, ?)", dbKey, limit)
 	require.NoError(t, err)
 
-	s, err := newDatabaseSemaphore(ctx, name, dbKey, nextWorkflow, info, cacheTTL)
+	s, err := newDatabaseSemaphore(ctx, name, dbKey, nextWorkflow, info, cacheTTL, nil)
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -102,10 +102,10 @@ func testTryAcquireSemaphore(t *testing.T, factory semaphoreFactory) {
 
 	now := time.Now()
 	tx := sessionProxy
-	require.NoError(t, s.addToQueue(ctx, "default/wf-01", 0, now))
-	require.NoError(t, s.addToQueue(ctx, "default/wf-02", 0, now.Add(time.Second)))
-	require.NoError(t, s.addToQueue(ctx, "default/wf-03", 0, now.Add(2*time.Second)))
-	require.NoError(t, s.addToQueue(ctx, "default/wf-04", 0, now.Add(3*time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "default/wf-01", "default", 0, now))
+	require.NoError(t, s.addToQueue(ctx, "default/wf-02", "default", 0, now.Add(time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "default/wf-03", "default", 0, now.Add(2*time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "default/wf-04", "default", 0, now.Add(3*time.Second)))
 	// verify only the first in line is allowed to acquired the semaphore
 	var acquired bool
 	acquired, _, _ = s.tryAcquire(ctx, "default/wf-04", tx)
@@ -148,11 +148,11 @@ func testNotifyWaitersAcquire(t *testing.T, factory semaphoreFactory) {
 
 	now := time.Now()
 	// The ordering here is important and perhaps counterintuitive.
-	require.NoError(t, s.addToQueue(ctx, "default/wf-04", 0, now.Add(3*time.Second)))
-	require.NoError(t, s.addToQueue(ctx, "default/wf-02", 0, now.Add(time.Second)))
-	require.NoError(t, s.addToQueue(ctx, "default/wf-01", 0, now))
-	require.NoError(t, s.addToQueue(ctx, "default/wf-05", 0, now.Add(4*time.Second)))
-	require.NoError(t, s.addToQueue(ctx, "default/wf-03", 0, now.Add(2*time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "default/wf-04", "default", 0, now.Add(3*time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "default/wf-02", "default", 0, now.Add(time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "default/wf-01", "default", 0, now))
+	require.NoError(t, s.addToQueue(ctx, "default/wf-05", "default", 0, now.Add(4*time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "default/wf-03", "default", 0, now.Add(2*time.Second)))
 
 	tx := sessionProxy
 	acquired, _, _ := s.tryAcquire(ctx, "default/wf-01", tx)
@@ -197,8 +197,8 @@ func testNotifyWorkflowFromTemplateSemaphore(t *testing.T, factory semaphoreFact
 	defer cleanup()
 
 	now := time.Now()
-	require.NoError(t, s.addToQueue(ctx, "foo/wf-01/nodeid-123", 0, now))
-	require.NoError(t, s.addToQueue(ctx, "foo/wf-02/nodeid-456", 0, now.Add(time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "foo/wf-01/nodeid-123", "foo", 0, now))
+	require.NoError(t, s.addToQueue(ctx, "foo/wf-02/nodeid-456", "foo", 0, now.Add(time.Second)))
 
 	tx := sessionProxy
 	acquired, _, _ := s.tryAcquire(ctx, "foo/wf-01/nodeid-123", tx)
@@ -232,8 +232,8 @@ func testCheckAcquireNotifiesCorrectKeyForTemplateSemaphore(t *testing.T, factor
 	defer cleanup()
 
 	now := time.Now()
-	require.NoError(t, s.addToQueue(ctx, "foo/wf-01/node-aaa", 0, now))
-	require.NoError(t, s.addToQueue(ctx, "foo/wf-02/node-bbb", 0, now.Add(time.Second)))
+	require.NoError(t, s.addToQueue(ctx, "foo/wf-01/node-aaa", "foo", 0, now))
+	require.NoError(t, s.addToQueue(ctx, "foo/wf-02/node-bbb", "foo", 0, now.Add(time.Second)))
 
 	tx := sessionProxy
 	// wf-02 is not first in queue, so checkAcquire should notify the front (wf-01)
@@ -271,10 +271,10 @@ func TestInternalSemaphoreReleaseWithLimitFetchFailure(t *testing.T) {
 	nextWorkflow := func(_ string) {}
 
 	// TTL 0 forces a live limit fetch on every getLimit call
-	sem, err := newInternalSemaphore(ctx, "default/ConfigMap/my-config/workflow", nextWorkflow, getter, 0)
+	sem, err := newInternalSemaphore(ctx, "default/ConfigMap/my-config/workflow", nextWorkflow, getter, 0, nil)
 	require.NoError(t, err)
 
-	require.NoError(t, sem.addToQueue(ctx, "default/wf-a", 0, time.Now()))
+	require.NoError(t, sem.addToQueue(ctx, "default/wf-a", "default", 0, time.Now()))
 	acquired, _, err := sem.tryAcquire(ctx, "default/wf-a", nil)
 	require.NoError(t, err)
 	require.True(t, acquired, "wf-a should acquire the only slot")
@@ -289,7 +289,7 @@ func TestInternalSemaphoreReleaseWithLimitFetchFailure(t *testing.T) {
 	assert.Empty(t, holders)
 
 	// wf-b must be able to acquire: limit is 1 and there are no holders.
-	require.NoError(t, sem.addToQueue(ctx, "default/wf-b", 0, time.Now()))
+	require.NoError(t, sem.addToQueue(ctx, "default/wf-b", "default", 0, time.Now()))
 	acquired, _, err = sem.tryAcquire(ctx, "default/wf-b", nil)
 	require.NoError(t, err)
 	assert.True(t, acquired, "wf-b should acquire the slot released by wf-a")
@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -40,8 +41,16 @@ type Manager struct {
 	queries           syncdb.SyncQueries
 	log               logging.Logger
 	metrics           syncMetrics
+	fairShare         *fairShare
+	// revoked records, by holder key, the lock each holder's expired lease was revoked from,
+	// until the holding node or workflow releases its locks
+	revoked map[string]string
 }
 
+// ErrLeaseExpired is returned by TryAcquire when a lock was revoked from its holder because
+// it was held for longer than its lease duration
+var ErrLeaseExpired = errors.New("synchronization lease expired")
+
 func (sm *Manager) WithMetrics(ctx context.Context, m *wfmetrics.Metrics) *Manager {
 	sm.metrics = m
 	if m != nil {
@@ -52,6 +61,12 @@ func (sm *Manager) WithMetrics(ctx context.Context, m *wfmetrics.Metrics) *Manag
 	return sm
 }
 
+// WithFairShare enables fair-share queueing for semaphores. It must be called before any semaphore is used.
+func (sm *Manager) WithFairShare(cfg *config.FairShareConfig) *Manager {
+	sm.fairShare = newFairShare(cfg)
+	return sm
+}
+
 type lockTypeName string
 
 const (
@@ -94,6 +109,7 @@ func createLockManager(ctx context.Context, sessionProxy *sqldb.SessionProxy, co
 		dbInfo:            dbInfo,
 		queries:           syncdb.NewSyncQueries(sessionProxy, dbInfo.Config),
 		log:               log,
+		revoked:           make(map[string]string),
 	}
 	log.WithField("dbConfigured", sm.dbInfo.SessionProxy != nil).Info(ctx, "Sync manager initialized")
 	sm.dbInfo.Migrate(ctx)
@@ -150,6 +166,32 @@ func (sm *Manager) CheckWorkflowExistence(ctx context.Context) {
 	}
 }
 
+// RevokeExpiredLeases revokes the locks which have been held for longer than their lease duration.
+// The holding workflows are requeued, and fail the holding node or workflow when they next try to
+// acquire the lock. Database-backed locks are only revoked by the controller which holds them.
+func (sm *Manager) RevokeExpiredLeases(ctx context.Context) {
+	defer runtimeutil.HandleCrashWithContext(ctx, runtimeutil.PanicHandlers...)
+
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	now := nowFn()
+	for lockKey, lock := range sm.syncLockMap {
+		expired, err := lock.expiredLeases(ctx, now)
+		if err != nil {
+			sm.log.WithField("lock", lockKey).WithError(err).Error(ctx, "failed to get expired leases")
+			continue
+		}
+		for _, holderKey := range expired {
+			sm.log.WithFields(logging.Fields{"holderKey": holderKey, "lock": lockKey}).Warn(ctx, "Lease expired, revoking lock")
+			lock.release(ctx, holderKey)
+			sm.revoked[holderKey] = lockKey
+			sm.recordLeaseExpiry(ctx, lockKey, lock)
+			sm.nextWorkflow(workflowKey(holderKey))
+		}
+	}
+}
+
 func getUpgradedKey(wf *wfv1.Workflow, key string, level LevelType) string {
 	if wfv1.CheckHolderKeyVersion(key) == wfv1.HoldingNameV1 {
 		if level == WorkflowLevel {
@@ -343,6 +385,17 @@ func (sm *Manager) reestablishHolder(ctx context.Context, wf *wfv1.Workflow, loc
 	// Re-read from the map: a previous holder of this same lock may have poisoned
 	// it, in which case reacquire is a no-op and we must not resurrect it.
 	lock := sm.syncLockMap[lockName]
+	// A hold whose recorded lease expired, whether or not it was revoked before the
+	// restart, is revoked rather than re-established, so the holder fails instead of
+	// running on with a fresh lease.
+	expiry, leased := wf.Status.Synchronization.GetLeaseExpiry(lockName, key)
+	if leased && !expiry.After(nowFn()) {
+		sm.log.WithFields(logging.Fields{"key": key, lockType: lockName}).Warn(ctx, "Lease expired before the controller started, revoking lock")
+		lock.release(ctx, key)
+		sm.revoked[key] = lockName
+		sm.recordLeaseExpiry(ctx, lockName, lock)
+		return "", nil
+	}
 	// For in-memory locks reacquire force-registers the hold ignoring the limit,
 	// so the recorded hold is always represented: dropping it would let a racer
 	// double-acquire a semaphore whose holders exceed a lowered limit, and
@@ -355,6 +408,11 @@ func (sm *Manager) reestablishHolder(ctx context.Context, wf *wfv1.Workflow, loc
 		sm.log.WithFields(logging.Fields{"key": key, lockType: lockName}).WithError(err).Warn(ctx, "could not re-establish recorded holder, failing the workflow")
 		return fmt.Sprintf("could not re-establish %s %q at controller startup: %v", lockType, lockName, err), nil
 	}
+	if leased {
+		if err := lock.setLease(ctx, key, expiry, sm.dbInfo.SessionProxy); err != nil {
+			sm.log.WithFields(logging.Fields{"key": key, lockType: lockName}).WithError(err).Warn(ctx, "could not restore the lease of the recorded holder")
+		}
+	}
 	sm.log.WithFields(logging.Fields{"key": key, lockType: lockName}).Info(ctx, "re-established recorded holder")
 	return "", nil
 }
@@ -468,8 +526,15 @@ func (sm *Manager) TryAcquire(ctx context.Context, wf *wfv1.Workflow, nodeName s
 		if lockNameErr != nil {
 			return false, false, "", failedLockName, fmt.Errorf("requested configuration is invalid: %w", lockNameErr)
 		}
+		lease, leaseErr := syncItem.leaseDuration()
+		if leaseErr != nil {
+			return false, false, "", failedLockName, fmt.Errorf("requested configuration is invalid: %w", leaseErr)
+		}
 		sm.log.WithField("syncLockName", syncLockName).Info(ctx, "TryAcquire")
 		lockKeys[i] = syncLockName.String(ctx)
+		if sm.revoked[holderKey] == lockKeys[i] {
+			return false, false, "", lockKeys[i], fmt.Errorf("%w: %s was held for longer than its lease duration of %s", ErrLeaseExpired, lockKeys[i], lease)
+		}
 	}
 
 	if ok, msg, prepLockName, prepErr := sm.prepAcquire(ctx, wf, holderKey, syncItems, lockKeys); !ok {
@@ -575,7 +640,7 @@ func (sm *Manager) prepAcquire(ctx context.Context, wf *wfv1.Workflow, holderKey
 		}
 		creationTime := wf.CreationTimestamp
 		ensureInit(wf, syncItems[i].getType())
-		if err := lock.addToQueue(ctx, holderKey, priority, creationTime.Time); err != nil {
+		if err := lock.addToQueue(ctx, holderKey, sm.fairShare.tenant(wf), priority, creationTime.Time); err != nil {
 			return false, fmt.Sprintf("Failed to add to queue: %v", err), lockKey, err
 		}
 	}
@@ -635,6 +700,18 @@ func (sm *Manager) tryAcquireImpl(ctx context.Context, wf *wfv1.Workflow, tx *sq
 			if !acquired {
 				return false, false, "", failedLockName, nil, fmt.Errorf("bug: failed to acquire something that should have been checked: %s", msg)
 			}
+			// The lease starts when the lock is first acquired, and is recorded in the workflow's status
+			// so that it is restored rather than restarted when the controller restarts.
+			if lease, _ := syncItems[i].leaseDuration(); lease > 0 {
+				expiry, recorded := wf.Status.Synchronization.GetLeaseExpiry(lockKey, holderKey)
+				if !recorded {
+					expiry = nowFn().Add(lease)
+					updated = wf.Status.Synchronization.LeaseAcquired(lockKey, holderKey, expiry) || updated
+				}
+				if err := lock.setLease(ctx, holderKey, expiry, tx); err != nil {
+					return false, false, "", failedLockName, nil, fmt.Errorf("failed to set lease: %w", err)
+				}
+			}
 			currentHolders, err := sm.getCurrentLockHolders(ctx, lockKey)
 			if err != nil {
 				return false, false, "", failedLockName, nil, fmt.Errorf("failed to get current lock holders: %w", err)
@@ -690,6 +767,7 @@ func (sm *Manager) Release(ctx context.Context, wf *wfv1.Workflow, nodeName stri
 
 	holderKey := getHolderKey(wf, nodeName)
 	sm.log.WithField("holderKey", holderKey).Info(ctx, "Release")
+	delete(sm.revoked, holderKey)
 	// Ignoring error here is as good as it's going to be, we shouldn't get here as we should
 	// should never have acquired anything if this errored
 	syncItems, _ := allSyncItems(syncRef)
@@ -707,6 +785,7 @@ func (sm *Manager) Release(ctx context.Context, wf *wfv1.Workflow, nodeName stri
 			lockKey := lockName
 			if wf.Status.Synchronization != nil {
 				wf.Status.Synchronization.GetStatus(syncItem.getType()).LockReleased(holderKey, lockKey.String(ctx))
+				wf.Status.Synchronization.LeaseReleased(lockKey.String(ctx), holderKey)
 			}
 		}
 	}
@@ -716,6 +795,13 @@ func (sm *Manager) ReleaseAll(ctx context.Context, wf *wfv1.Workflow) bool {
 	sm.lock.Lock()
 	defer sm.lock.Unlock()
 
+	wfKey := getHolderKey(wf, "")
+	for holderKey := range sm.revoked {
+		if workflowKey(holderKey) == wfKey {
+			delete(sm.revoked, holderKey)
+		}
+	}
+
 	if wf.Status.Synchronization == nil {
 		return true
 	}
@@ -830,12 +916,12 @@ func (sm *Manager) initializeSemaphore(ctx context.Context, semaphoreName string
 	}
 	switch lock.getKind() {
 	case lockKindConfigMap:
-		return newInternalSemaphore(ctx, semaphoreName, sm.nextWorkflow, sm.getSyncLimit, sm.syncLimitCacheTTL)
+		return newInternalSemaphore(ctx, semaphoreName, sm.nextWorkflow, sm.getSyncLimit, sm.syncLimitCacheTTL, sm.fairShare)
 	case lockKindDatabase:
 		if sm.dbInfo.SessionProxy == nil {
 			return nil, fmt.Errorf("database session is not available for semaphore %s", semaphoreName)
 		}
-		return newDatabaseSemaphore(ctx, semaphoreName, lock.getDBKey(), sm.nextWorkflow, sm.dbInfo, sm.syncLimitCacheTTL)
+		return newDatabaseSemaphore(ctx, semaphoreName, lock.getDBKey(), sm.nextWorkflow, sm.dbInfo, sm.syncLimitCacheTTL, sm.fairShare)
 	default:
 		return nil, fmt.Errorf("invalid lock kind %s when initializing semaphore", lock.getKind())
 	}
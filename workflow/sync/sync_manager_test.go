@@ -1981,7 +1981,7 @@ func TestBackgroundNotifierClearsExpiredLocks(t *testing.T) {
 			_, err = info.SessionProxy.Session().SQL().Exec("INSERT INTO sync_limit (name, sizelimit) VALUES (?, ?)", "foo/test-semaphore", 100)
 			require.NoError(t, err)
 			// Initialize a semaphore so it gets added to the syncLockMap
-			testsem, err := newDatabaseSemaphore(ctx, "test-semaphore", "foo/test-semaphore", func(key string) {}, info, 0)
+			testsem, err := newDatabaseSemaphore(ctx, "test-semaphore", "foo/test-semaphore", func(key string) {}, info, 0, nil)
 			require.NoError(t, err)
 			syncLockMap := make(map[string]semaphore)
 			syncLockMap["sem/test-semaphore"] = testsem
@@ -2325,3 +2325,178 @@ func TestDatabaseInitializeStaleHoldFailsWorkflow(t *testing.T) {
 		})
 	}
 }
+
+// testLeaseExpiry tests that a hold which outlasts its lease is revoked and handed on to the next waiter
+func testLeaseExpiry(t *testing.T, newManager func(nextWorkflow NextWorkflow) *Manager, database bool) {
+	t.Helper()
+	ctx := logging.TestContext(t.Context())
+	originalNowFn := nowFn
+	defer func() { nowFn = originalNowFn }()
+	now := time.Now()
+	nowFn = func() time.Time { return now }
+
+	var requeued []string
+	mgr := newManager(func(key string) { requeued = append(requeued, key) })
+	rec := newTestMetricsRecorder()
+	mgr.metrics = rec
+	newWF := func(name string) *wfv1.Workflow {
+		wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(now)}}
+		wf.Spec.Synchronization = &wfv1.Synchronization{Mutexes: []*wfv1.Mutex{{Name: "my-mutex", Database: database, LeaseDuration: "1m"}}}
+		return wf
+	}
+	holder, waiter := newWF("holder"), newWF("waiter")
+
+	acquired, _, _, _, err := mgr.TryAcquire(ctx, holder, "", holder.Spec.Synchronization)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	acquired, _, _, _, err = mgr.TryAcquire(ctx, waiter, "", waiter.Spec.Synchronization)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	// The holder keeps the lock while its lease lasts, even when it reacquires it
+	nowFn = func() time.Time { return now.Add(30 * time.Second) }
+	acquired, _, _, _, err = mgr.TryAcquire(ctx, holder, "", holder.Spec.Synchronization)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	mgr.RevokeExpiredLeases(ctx)
+	assert.Empty(t, rec.expired)
+
+	nowFn = func() time.Time { return now.Add(2 * time.Minute) }
+	requeued = nil
+	mgr.RevokeExpiredLeases(ctx)
+	assert.Contains(t, requeued, "default/holder", "the holder is requeued so that it fails")
+	require.Len(t, rec.expired, 1)
+	assert.Equal(t, "mutex", rec.expired[0].lockType)
+	assert.Equal(t, "my-mutex", rec.expired[0].name)
+
+	_, _, _, _, err = mgr.TryAcquire(ctx, holder, "", holder.Spec.Synchronization)
+	require.ErrorIs(t, err, ErrLeaseExpired)
+	assert.Contains(t, err.Error(), "lease duration of 1m0s")
+
+	acquired, _, _, _, err = mgr.TryAcquire(ctx, waiter, "", waiter.Spec.Synchronization)
+	require.NoError(t, err)
+	assert.True(t, acquired, "the waiter acquires the revoked lock")
+
+	mgr.ReleaseAll(ctx, holder)
+	assert.Empty(t, mgr.revoked)
+}
+
+func TestLeaseExpiry(t *testing.T) {
+	t.Run("Mutex", func(t *testing.T) {
+		testLeaseExpiry(t, func(nextWorkflow NextWorkflow) *Manager {
+			ctx := logging.TestContext(t.Context())
+			mgr, err := NewLockManager(ctx, fake.NewSimpleClientset(), "", nil, nil, nextWorkflow, WorkflowExistenceFunc, false)
+			require.NoError(t, err)
+			return mgr
+		}, false)
+	})
+	for _, dbType := range testDBTypes {
+		t.Run(string(dbType), func(t *testing.T) {
+			testLeaseExpiry(t, func(nextWorkflow NextWorkflow) *Manager {
+				ctx := logging.TestContext(t.Context())
+				info, cleanup, syncConfig, err := createTestDBSession(ctx, t, dbType)
+				require.NoError(t, err)
+				t.Cleanup(cleanup)
+				return createLockManager(ctx, info.SessionProxy, &syncConfig, nil, nextWorkflow, WorkflowExistenceFunc)
+			}, true)
+		})
+	}
+}
+
+// testLeaseExpiryAcrossRestart tests that a lease is restored rather than restarted when the controller restarts,
+// and that a hold whose lease expired before the restart stays revoked
+func testLeaseExpiryAcrossRestart(t *testing.T, newManagerFactory func(t *testing.T) func(nextWorkflow NextWorkflow) *Manager, database bool) {
+	t.Helper()
+	ctx := logging.TestContext(t.Context())
+	originalNowFn := nowFn
+	defer func() { nowFn = originalNowFn }()
+	now := time.Now()
+	setNow := func(d time.Duration) { nowFn = func() time.Time { return now.Add(d) } }
+	setNow(0)
+
+	newWF := func(name string) *wfv1.Workflow {
+		wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(now)}}
+		wf.Spec.Synchronization = &wfv1.Synchronization{Mutexes: []*wfv1.Mutex{{Name: "my-mutex", Database: database, LeaseDuration: "1m"}}}
+		return wf
+	}
+
+	t.Run("LeaseRestored", func(t *testing.T) {
+		newManager := newManagerFactory(t)
+		holder := newWF("holder")
+		acquired, updated, _, _, err := newManager(func(string) {}).TryAcquire(ctx, holder, "", holder.Spec.Synchronization)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		require.True(t, updated)
+		expiry, ok := holder.Status.Synchronization.GetLeaseExpiry("default/Mutex/my-mutex", "default/holder")
+		require.True(t, ok)
+		assert.Equal(t, now.Add(time.Minute).Unix(), expiry.Unix())
+
+		// The controller restarts with the hold recorded in the workflow's status
+		setNow(50 * time.Second)
+		var requeued []string
+		mgr := newManager(func(key string) { requeued = append(requeued, key) })
+		_, err = mgr.Initialize(ctx, []wfv1.Workflow{*holder})
+		require.NoError(t, err)
+		acquired, _, _, _, err = mgr.TryAcquire(ctx, holder, "", holder.Spec.Synchronization)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		// The lease expires a minute after the lock was first acquired, not a minute after the restart
+		setNow(70 * time.Second)
+		mgr.RevokeExpiredLeases(ctx)
+		assert.Contains(t, requeued, "default/holder")
+		_, _, _, _, err = mgr.TryAcquire(ctx, holder, "", holder.Spec.Synchronization)
+		require.ErrorIs(t, err, ErrLeaseExpired)
+	})
+
+	t.Run("RevokedBeforeRestart", func(t *testing.T) {
+		setNow(0)
+		newManager := newManagerFactory(t)
+		holder, waiter := newWF("holder"), newWF("waiter")
+		mgr := newManager(func(string) {})
+		acquired, _, _, _, err := mgr.TryAcquire(ctx, holder, "", holder.Spec.Synchronization)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		setNow(2 * time.Minute)
+		mgr.RevokeExpiredLeases(ctx)
+
+		// The controller restarts before the holder is failed, so its status still records the hold
+		mgr = newManager(func(string) {})
+		_, err = mgr.Initialize(ctx, []wfv1.Workflow{*holder})
+		require.NoError(t, err)
+		_, _, _, _, err = mgr.TryAcquire(ctx, holder, "", holder.Spec.Synchronization)
+		require.ErrorIs(t, err, ErrLeaseExpired)
+		acquired, _, _, _, err = mgr.TryAcquire(ctx, waiter, "", waiter.Spec.Synchronization)
+		require.NoError(t, err)
+		assert.True(t, acquired, "the waiter acquires the revoked lock")
+
+		mgr.Release(ctx, holder, "", holder.Spec.Synchronization)
+		assert.Empty(t, holder.Status.Synchronization.Leases)
+	})
+}
+
+func TestLeaseExpiryAcrossRestart(t *testing.T) {
+	t.Run("Mutex", func(t *testing.T) {
+		testLeaseExpiryAcrossRestart(t, func(t *testing.T) func(nextWorkflow NextWorkflow) *Manager {
+			clientset := fake.NewSimpleClientset()
+			return func(nextWorkflow NextWorkflow) *Manager {
+				mgr, err := NewLockManager(logging.TestContext(t.Context()), clientset, "", nil, nil, nextWorkflow, WorkflowExistenceFunc, false)
+				require.NoError(t, err)
+				return mgr
+			}
+		}, false)
+	})
+	for _, dbType := range testDBTypes {
+		t.Run(string(dbType), func(t *testing.T) {
+			testLeaseExpiryAcrossRestart(t, func(t *testing.T) func(nextWorkflow NextWorkflow) *Manager {
+				ctx := logging.TestContext(t.Context())
+				info, cleanup, syncConfig, err := createTestDBSession(ctx, t, dbType)
+				require.NoError(t, err)
+				t.Cleanup(cleanup)
+				return func(nextWorkflow NextWorkflow) *Manager {
+					return createLockManager(ctx, info.SessionProxy, &syncConfig, nil, nextWorkflow, WorkflowExistenceFunc)
+				}
+			}, true)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"strings"
+	"time"
 
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v4/util/logging"
@@ -14,6 +15,7 @@ import (
 // separately via Manager.LockMetrics at scrape time, so they are not part of this interface.
 type syncMetrics interface {
 	RecordLockTaken(ctx context.Context, lockType, storage, name, namespace string)
+	RecordLockLeaseExpired(ctx context.Context, lockType, storage, name, namespace string)
 }
 
 const (
@@ -53,6 +55,29 @@ func syncTypeLabel(t wfv1.SynchronizationType) string {
 	return string(lockTypeSemaphore)
 }
 
+// recordLeaseExpiry counts a lock revoked because its lease expired
+func (sm *Manager) recordLeaseExpiry(ctx context.Context, lockKey string, lock semaphore) {
+	if sm.metrics == nil {
+		return
+	}
+	namespace, name, storage, ok := parseLockKey(lockKey)
+	if !ok {
+		return
+	}
+	lockType := string(lockTypeSemaphore)
+	switch l := lock.(type) {
+	case *databaseSemaphore:
+		if l.isMutex {
+			lockType = string(lockTypeMutex)
+		}
+	default:
+		if strings.SplitN(lockKey, "/", 3)[1] == string(lockKindMutex) {
+			lockType = string(lockTypeMutex)
+		}
+	}
+	sm.metrics.RecordLockLeaseExpired(ctx, lockType, storage, name, namespace)
+}
+
 // parseDBStateName decodes a database state-table lock name (as produced by databaseSemaphore's
 // longDBKey, e.g. "sem/<namespace>/<resource>" or "mtx/<namespace>/<resource>") into metric labels.
 func parseDBStateName(dbName string) (lockType, name, namespace string, ok bool) {
@@ -120,14 +145,18 @@ func (sm *Manager) inMemoryLockSamples(ctx context.Context) []wfmetrics.LockGaug
 			sm.log.WithField("lockKey", key).WithError(err).Debug(ctx, "could not read lock pending for metrics")
 			continue
 		}
-		samples = append(samples, wfmetrics.LockGaugeSample{
+		sample := wfmetrics.LockGaugeSample{
 			Type:      lockType,
 			Storage:   storage,
 			Name:      name,
 			Namespace: namespace,
 			Held:      int64(len(holders)),
 			Pending:   int64(len(pending)),
-		})
+		}
+		if sem, ok := lock.(*prioritySemaphore); ok {
+			sample.LongestWait = sem.longestWaits()
+		}
+		samples = append(samples, sample)
 	}
 	return samples
 }
@@ -160,6 +189,22 @@ func (sm *Manager) databaseLockSamples(ctx context.Context) []wfmetrics.LockGaug
 			s.Pending += c.Count
 		}
 	}
+	waits, err := sm.queries.GetLongestWaitsByController(ctx, sm.dbInfo.Config.ControllerName)
+	if err != nil {
+		sm.log.WithError(err).Debug(ctx, "could not read database lock wait times for metrics")
+	}
+	now := nowFn()
+	for _, w := range waits {
+		// rows queued before the wait time was recorded have no queued time
+		s := byLock[w.Name]
+		if s == nil || w.QueuedTime == nil {
+			continue
+		}
+		if s.LongestWait == nil {
+			s.LongestWait = make(map[string]time.Duration)
+		}
+		s.LongestWait[w.Tenant] = now.Sub(*w.QueuedTime)
+	}
 	samples := make([]wfmetrics.LockGaugeSample, 0, len(byLock))
 	for _, s := range byLock {
 		samples = append(samples, *s)
@@ -22,10 +22,11 @@ type takenLabels struct {
 	lockType, storage, name, namespace string
 }
 
-// testMetricsRecorder records locks_taken_total increments for assertions. It implements the
-// syncMetrics interface. The held/pending gauges are asserted via Manager.LockMetrics directly.
+// testMetricsRecorder records locks_taken_total and locks_lease_expired_total increments for assertions.
+// It implements the syncMetrics interface. The held/pending gauges are asserted via Manager.LockMetrics directly.
 type testMetricsRecorder struct {
-	taken []takenLabels
+	taken   []takenLabels
+	expired []takenLabels
 }
 
 func newTestMetricsRecorder() *testMetricsRecorder {
@@ -36,6 +37,10 @@ func (t *testMetricsRecorder) RecordLockTaken(_ context.Context, lockType, stora
 	t.taken = append(t.taken, takenLabels{lockType, storage, name, namespace})
 }
 
+func (t *testMetricsRecorder) RecordLockLeaseExpired(_ context.Context, lockType, storage, name, namespace string) {
+	t.expired = append(t.expired, takenLabels{lockType, storage, name, namespace})
+}
+
 // total returns the number of recorded lock acquisitions across all labels.
 func (t *testMetricsRecorder) total() int { return len(t.taken) }
 
@@ -119,6 +124,7 @@ func TestSyncMetrics(t *testing.T) {
 		s := findLockSample(mgr.LockMetrics(ctx), "wait-mutex")
 		assert.Equal(t, int64(1), s.Held, "one holder")
 		assert.Equal(t, int64(1), s.Pending, "one waiter")
+		assert.Contains(t, s.LongestWait, "default", "the waiter's tenant is its namespace")
 		assert.Equal(t, 1, rec.total(), "only wf1 acquired, so 1 lock taken")
 	})
 
@@ -283,6 +289,7 @@ func TestLockMetricsDatabase(t *testing.T) {
 			assert.Equal(t, "default", s.Namespace)
 			assert.Equal(t, int64(2), s.Held, "two holders")
 			assert.Equal(t, int64(1), s.Pending, "one waiter")
+			assert.Contains(t, s.LongestWait, "default", "the waiter's tenant is its namespace")
 		})
 	}
 }
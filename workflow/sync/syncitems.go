@@ -3,6 +3,7 @@ package sync
 import (
 	"errors"
 	"reflect"
+	"time"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 )
@@ -44,3 +45,15 @@ func (i *syncItem) getType() v1alpha1.SynchronizationType {
 		return v1alpha1.SynchronizationTypeUnknown
 	}
 }
+
+// leaseDuration returns the longest time the lock may be held for, or zero if it may be held indefinitely
+func (i *syncItem) leaseDuration() (time.Duration, error) {
+	switch {
+	case i.semaphore != nil:
+		return i.semaphore.GetLeaseDuration()
+	case i.mutex != nil:
+		return i.mutex.GetLeaseDuration()
+	default:
+		return 0, nil
+	}
+}
@@ -29,6 +29,28 @@ func GetFailHosts(nodes wfv1.Nodes, retryNodeName string) []string {
 	return RemoveDuplicates(hostNames)
 }
 
+// CountFailedPods iterates over the node subtree and counts the pods in error or fail which match
+func CountFailedPods(nodes wfv1.Nodes, retryNodeName string, match func(node wfv1.NodeStatus) bool) int {
+	toVisit := []string{retryNodeName}
+	count := 0
+	for len(toVisit) > 0 {
+		n := len(toVisit) - 1
+		nodeToVisit := toVisit[n]
+		toVisit = toVisit[:n]
+		if x, ok := nodes[nodeToVisit]; ok {
+			if (x.Phase == wfv1.NodeFailed || x.Phase == wfv1.NodeError) && x.Type == wfv1.NodeTypePod && match(x) {
+				count++
+			}
+			for _, child := range x.Children {
+				if _, ok := nodes[child]; ok {
+					toVisit = append(toVisit, child)
+				}
+			}
+		}
+	}
+	return count
+}
+
 // RemoveDuplicates removes duplicate strings from slice
 func RemoveDuplicates(strSlice []string) []string {
 	keys := make(map[string]bool)
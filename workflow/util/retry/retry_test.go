@@ -80,6 +80,20 @@ func TestGetFailHosts(t *testing.T) {
 	})
 }
 
+func TestCountFailedPods(t *testing.T) {
+	nodes := wfv1.Nodes{
+		"retry": wfv1.NodeStatus{ID: "retry", Type: wfv1.NodeTypeRetry, Phase: wfv1.NodeRunning, Children: []string{"n1", "n2", "n3", "n4"}},
+		"n1":    wfv1.NodeStatus{ID: "n1", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, Message: "OOMKilled (exit code 137)"},
+		"n2":    wfv1.NodeStatus{ID: "n2", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, Message: "Error (exit code 1)"},
+		"n3":    wfv1.NodeStatus{ID: "n3", Type: wfv1.NodeTypePod, Phase: wfv1.NodeError, Message: "OOMKilled (exit code 137)"},
+		"n4":    wfv1.NodeStatus{ID: "n4", Type: wfv1.NodeTypePod, Phase: wfv1.NodeRunning},
+	}
+	oomKilled := func(node wfv1.NodeStatus) bool { return node.Message == "OOMKilled (exit code 137)" }
+	assert.Equal(t, 2, CountFailedPods(nodes, "retry", oomKilled))
+	assert.Equal(t, 0, CountFailedPods(nodes, "n4", oomKilled))
+	assert.Equal(t, 0, CountFailedPods(nodes, "not-exist-node", oomKilled))
+}
+
 func TestAddHostnamesToAffinity(t *testing.T) {
 	hostNames := []string{"hostnameA", "hostnameB", "hostnameC"}
 	hostSelector := "kubernetes.io/hostname"
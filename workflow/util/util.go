@@ -366,6 +366,8 @@ func ApplyOverridesToTemplateArtifacts(templateArtifacts []wfv1.Artifact, overri
 		}
 		consumed[tmplArt.Name] = true
 		artCopy := tmplArt.DeepCopy()
+		// a digest set on the template artifact is not the digest of the uploaded object
+		artCopy.Digest = ""
 		if err := artCopy.SetKey(newKey); err != nil {
 			return nil, fmt.Errorf("failed to set key for artifact %s: %w", tmplArt.Name, err)
 		}
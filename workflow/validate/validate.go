@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/robfig/cron/v3"
 	apiv1 "k8s.io/api/core/v1"
@@ -29,6 +30,7 @@ import (
 	varkeys "github.com/argoproj/argo-workflows/v4/util/variables/keys"
 	"github.com/argoproj/argo-workflows/v4/workflow/artifacts/hdfs"
 	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/argoproj/argo-workflows/v4/workflow/cron/exclusions"
 	"github.com/argoproj/argo-workflows/v4/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v4/workflow/templateresolution"
 )
@@ -139,13 +141,17 @@ func SubstituteResourceManifestExpressions(manifest string) string {
 
 // validateHooks takes an array of hooks to validate and the name of the
 // container they are in and generates an error for the first invalid hook
-// or nil if they are all valid
-func validateHooks(hooks wfv1.LifecycleHooks, hookBaseName string) error {
+// or nil if they are all valid. The hook run by the soft timeout of a timeout
+// policy, if any, does not need an expression.
+func validateHooks(hooks wfv1.LifecycleHooks, hookBaseName string, softTimeoutHook string) error {
 	for hookName, hook := range hooks {
-		if hookName != wfv1.ExitLifecycleEvent && hook.Expression == "" {
+		if hookName != wfv1.ExitLifecycleEvent && string(hookName) != softTimeoutHook && hook.Expression == "" {
 			return errors.Errorf(errors.CodeBadRequest, "%s.%s %s", hookBaseName, hookName, "Expression required")
 		}
 	}
+	if _, ok := hooks[wfv1.LifecycleEvent(softTimeoutHook)]; softTimeoutHook != "" && !ok {
+		return errors.Errorf(errors.CodeBadRequest, "%s.hooks.%s is required by the timeoutPolicy of its template", hookBaseName, softTimeoutHook)
+	}
 	return nil
 }
 
@@ -331,7 +337,7 @@ func Workflow(ctx context.Context, wftmplGetter templateresolution.WorkflowTempl
 			return err
 		}
 	}
-	err = validateHooks(wf.Spec.Hooks, "hooks")
+	err = validateHooks(wf.Spec.Hooks, "hooks", "")
 	if err != nil {
 		return err
 	}
@@ -347,6 +353,10 @@ func Workflow(ctx context.Context, wftmplGetter templateresolution.WorkflowTempl
 		return err
 	}
 
+	if err := validateSynchronization("spec.synchronization", wf.Spec.Synchronization); err != nil {
+		return err
+	}
+
 	// Check if all templates can be resolved.
 	// If the Workflow is using a WorkflowTemplateRef, then the templates of the referred WorkflowTemplate will be validated.
 	if hasWorkflowTemplateRef {
@@ -468,6 +478,31 @@ func CronWorkflow(ctx context.Context, wftmplGetter templateresolution.WorkflowT
 		return errors.Errorf(errors.CodeBadRequest, "startingDeadlineSeconds must be positive")
 	}
 
+	if policy := cronWf.Spec.CatchUpPolicy; policy != nil {
+		switch policy.Strategy {
+		case wfv1.CatchUpNone, wfv1.CatchUpLatest, wfv1.CatchUpAll:
+			// Do nothing
+		default:
+			return errors.Errorf(errors.CodeBadRequest, "'%s' is not a valid catchUpPolicy.strategy", policy.Strategy)
+		}
+		if policy.MaxCount != nil && *policy.MaxCount < 1 {
+			return errors.Errorf(errors.CodeBadRequest, "catchUpPolicy.maxCount must be at least 1")
+		}
+	}
+
+	if cronWf.Spec.Exclusions != nil {
+		for i, dateRange := range cronWf.Spec.Exclusions.DateRanges {
+			if err := exclusions.ValidateDateRange(dateRange); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "exclusions.dateRanges[%d] is invalid: %s", i, err)
+			}
+		}
+		for i, window := range cronWf.Spec.Exclusions.Windows {
+			if _, _, err := exclusions.ParseWindow(window); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "exclusions.windows[%d] is invalid: %s", i, err)
+			}
+		}
+	}
+
 	wf := common.ConvertCronWorkflowToWorkflow(cronWf)
 
 	err := Workflow(ctx, wftmplGetter, cwftmplGetter, wf, wfDefaults, Opts{})
@@ -503,6 +538,16 @@ func (tctx *templateValidationCtx) validateTemplate(ctx context.Context, tmpl *w
 		}
 	}
 
+	if tmpl.Memoize != nil {
+		if err := validateMemoizationCache(tmpl.Memoize.Cache); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.memoize.cache %s", tmpl.Name, err.Error())
+		}
+	}
+
+	if err := validateSynchronization(fmt.Sprintf("templates.%s.synchronization", tmpl.Name), tmpl.Synchronization); err != nil {
+		return err
+	}
+
 	scope, err := validateInputs(tmpl)
 	if err != nil {
 		return err
@@ -562,6 +607,10 @@ func (tctx *templateValidationCtx) validateTemplate(ctx context.Context, tmpl *w
 		return fmt.Errorf("%s template doesn't support pendingTimeout field", newTmpl.GetType())
 	}
 
+	if err := validateTimeoutPolicy(newTmpl); err != nil {
+		return err
+	}
+
 	templateScope := tmplCtx.GetTemplateScope()
 	tmplID := getTemplateID(tmpl)
 	_, ok := tctx.results[templateScope+tmplID]
@@ -589,6 +638,10 @@ func (tctx *templateValidationCtx) validateTemplate(ctx context.Context, tmpl *w
 	if err != nil {
 		return err
 	}
+	err = validateContainerSetOutputs(scope, tctx.globalParams, newTmpl, workflowTemplateValidation)
+	if err != nil {
+		return err
+	}
 	if newTmpl.ArchiveLocation != nil {
 		errPrefix := fmt.Sprintf("templates.%s.archiveLocation", newTmpl.Name)
 		err = validateArtifactLocation(errPrefix, *newTmpl.ArchiveLocation)
@@ -680,11 +733,96 @@ func (tctx *templateValidationCtx) validateTemplateHolder(ctx context.Context, t
 		default:
 			return nil, fmt.Errorf("%s is not a valid RetryPolicy", resolvedTmpl.RetryStrategy.RetryPolicy)
 		}
+		if err := validateResourceEscalation(resolvedTmpl.RetryStrategy.ResourceEscalation); err != nil {
+			return nil, err
+		}
+		if err := validateRetryRules(resolvedTmpl.RetryStrategy); err != nil {
+			return nil, err
+		}
 	}
 
 	return resolvedTmpl, tctx.validateTemplate(ctx, resolvedTmpl, tmplCtx, args, workflowTemplateValidation)
 }
 
+// validateResourceEscalation validates the factors of the resource escalation of a retry strategy
+func validateResourceEscalation(escalation *wfv1.ResourceEscalation) error {
+	if escalation == nil {
+		return nil
+	}
+	if escalation.Memory != nil {
+		if _, err := escalation.Memory.GetFactor(); err != nil {
+			return fmt.Errorf("retryStrategy.resourceEscalation.memory.factor %w", err)
+		}
+	}
+	if escalation.EphemeralStorage != nil {
+		if _, err := escalation.EphemeralStorage.GetFactor(); err != nil {
+			return fmt.Errorf("retryStrategy.resourceEscalation.ephemeralStorage.factor %w", err)
+		}
+	}
+	return nil
+}
+
+// validateRetryRules validates the rules of a retry strategy, which replace its limit, backoff and expression
+func validateRetryRules(retryStrategy *wfv1.RetryStrategy) error {
+	if len(retryStrategy.Rules) == 0 {
+		return nil
+	}
+	if retryStrategy.Limit != nil || retryStrategy.Backoff != nil || retryStrategy.Expression != "" {
+		return fmt.Errorf("retryStrategy.rules cannot be used with retryStrategy.limit, retryStrategy.backoff or retryStrategy.expression")
+	}
+	names := make(map[string]bool)
+	for i, rule := range retryStrategy.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("retryStrategy.rules[%d].name is required", i)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("retryStrategy.rules[%d].name %q is not unique", i, rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.Limit == nil {
+			return fmt.Errorf("retryStrategy.rules[%d].limit is required", i)
+		}
+		if rule.Backoff != nil && rule.Backoff.Duration == "" {
+			return fmt.Errorf("retryStrategy.rules[%d].backoff.duration is required", i)
+		}
+	}
+	return nil
+}
+
+// validateTimeoutPolicy validates the timeout policy of a template, which only container and script templates support
+func validateTimeoutPolicy(tmpl *wfv1.Template) error {
+	policy := tmpl.TimeoutPolicy
+	if policy == nil {
+		return nil
+	}
+	if tmpl.Container == nil && tmpl.Script == nil {
+		return fmt.Errorf("%s template doesn't support timeoutPolicy field", tmpl.GetType())
+	}
+	if _, err := policy.GetSoftTimeout(); err != nil {
+		return fmt.Errorf("%s has invalid duration format in timeoutPolicy.softTimeout: %w", tmpl.Name, err)
+	}
+	if _, err := policy.GetGracePeriod(); err != nil {
+		return fmt.Errorf("%s has invalid duration format in timeoutPolicy.gracePeriod: %w", tmpl.Name, err)
+	}
+	if policy.GracePeriod != "" && !policy.Terminate {
+		return fmt.Errorf("%s timeoutPolicy.gracePeriod requires timeoutPolicy.terminate", tmpl.Name)
+	}
+	switch policy.GetResult() {
+	case wfv1.TimeoutResultContinue, wfv1.TimeoutResultSucceeded, wfv1.TimeoutResultFailed:
+	default:
+		return fmt.Errorf("%s timeoutPolicy.result must be one of Continue, Succeeded or Failed: %q", tmpl.Name, policy.Result)
+	}
+	return nil
+}
+
+// softTimeoutHook returns the hook run by the soft timeout of the timeout policy of the template, if any
+func softTimeoutHook(tmpl *wfv1.Template) string {
+	if tmpl == nil || tmpl.TimeoutPolicy == nil {
+		return ""
+	}
+	return tmpl.TimeoutPolicy.Hook
+}
+
 // validatePodResources validates pod-level resources, which Kubernetes restricts to
 // cpu, memory and hugepages-*, with no claims. Anything else would pass submission
 // and then be rejected by the API server at pod creation, mid-workflow.
@@ -705,6 +843,24 @@ func validatePodResources(errPrefix string, r *apiv1.ResourceRequirements) error
 	return nil
 }
 
+// validateSynchronization checks the lease durations of the mutexes and semaphores
+func validateSynchronization(errPrefix string, sync *wfv1.Synchronization) error {
+	if sync == nil {
+		return nil
+	}
+	for i, semaphore := range sync.Semaphores {
+		if _, err := semaphore.GetLeaseDuration(); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s.semaphores[%d] %s", errPrefix, i, err.Error())
+		}
+	}
+	for i, mutex := range sync.Mutexes {
+		if _, err := mutex.GetLeaseDuration(); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s.mutexes[%d] %s", errPrefix, i, err.Error())
+		}
+	}
+	return nil
+}
+
 // validateTemplateType validates that only one template type is defined
 func validateTemplateType(tmpl *wfv1.Template) error {
 	numTypes := 0
@@ -765,6 +921,25 @@ func validateInputs(tmpl *wfv1.Template) (map[string]any, error) {
 	return scope, nil
 }
 
+func validateMemoizationCache(c *wfv1.Cache) error {
+	if c == nil {
+		return fmt.Errorf("is required")
+	}
+	count := 0
+	for _, set := range []bool{c.ConfigMap != nil, c.Database != nil, c.Artifact != nil} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return fmt.Errorf("must have exactly one of configMap, database or artifact set")
+	}
+	if c.GetName() == "" {
+		return fmt.Errorf("name is required")
+	}
+	return nil
+}
+
 func validateArtifactLocation(errPrefix string, art wfv1.ArtifactLocation) error {
 	if art.Git != nil {
 		if art.Git.Repo == "" {
@@ -963,6 +1138,22 @@ func (tctx *templateValidationCtx) validateLeaf(scope map[string]any, tmplCtx *t
 			}
 		}
 	}
+	if tmpl.Data != nil {
+		if err := validateDataSource(tmpl.Data.Source); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.data.source %s", tmpl.Name, err.Error())
+		}
+	}
+	if tmpl.HTTP != nil {
+		if tmpl.HTTP.Poll != nil && tmpl.HTTP.Poll.CompletionCondition == "" {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.http.poll.completionCondition is required", tmpl.Name)
+		}
+		if err := validateHTTPRetry(tmpl.HTTP.Retry); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.http.retry %s", tmpl.Name, err.Error())
+		}
+		if err := validateHTTPAuth(tmpl.HTTP.Auth); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.http.auth %s", tmpl.Name, err.Error())
+		}
+	}
 	// we don't validate tmpl.Plugin, because this is done by Plugin.UnmarshallJSON
 	if tmpl.ActiveDeadlineSeconds != nil {
 		if !intstr.IsValidIntOrArgoVariable(tmpl.ActiveDeadlineSeconds) && !placeholderGenerator.IsPlaceholder(tmpl.ActiveDeadlineSeconds.StrVal) {
@@ -978,6 +1169,68 @@ func (tctx *templateValidationCtx) validateLeaf(scope map[string]any, tmplCtx *t
 	return nil
 }
 
+// validateHTTPRetry verifies the limit and status codes of an HTTP template's retry
+func validateHTTPRetry(retry *wfv1.HTTPRetry) error {
+	if retry == nil {
+		return nil
+	}
+	if !intstr.IsValidIntOrArgoVariable(retry.Limit) && !placeholderGenerator.IsPlaceholder(retry.Limit.StrVal) {
+		return fmt.Errorf("limit must be an integer or an argo variable")
+	}
+	if i, err := intstr.Int(retry.Limit); err == nil && i != nil && *i < 0 {
+		return fmt.Errorf("limit must not be negative")
+	}
+	for _, statusCode := range retry.StatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf("statusCodes has invalid status code %d", statusCode)
+		}
+	}
+	return nil
+}
+
+// validateHTTPAuth verifies that each method of an HTTP template's auth has all of its secrets
+func validateHTTPAuth(auth *wfv1.HTTPAuth) error {
+	if auth == nil {
+		return nil
+	}
+	if (auth.ClientCert.ClientCertSecret == nil) != (auth.ClientCert.ClientKeySecret == nil) {
+		return fmt.Errorf("clientCert must have both clientCertSecret and clientKeySecret")
+	}
+	oauth2 := auth.OAuth2
+	if set := []bool{oauth2.ClientIDSecret != nil, oauth2.ClientSecretSecret != nil, oauth2.TokenURLSecret != nil}; slices.Contains(set, true) && slices.Contains(set, false) {
+		return fmt.Errorf("oauth2 must have clientIDSecret, clientSecretSecret and tokenURLSecret")
+	}
+	if (auth.BasicAuth.UsernameSecret == nil) != (auth.BasicAuth.PasswordSecret == nil) {
+		return fmt.Errorf("basicAuth must have both usernameSecret and passwordSecret")
+	}
+	return nil
+}
+
+// validateDataSource verifies that exactly one source is defined, with valid options
+func validateDataSource(source wfv1.DataSource) error {
+	count := 0
+	for _, set := range []bool{source.ArtifactPaths != nil, source.ArtifactContents != nil, source.ConfigMap != nil} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return fmt.Errorf("must have exactly one of artifactPaths, artifactContents or configMap")
+	}
+	if contents := source.ArtifactContents; contents != nil && contents.CSV != nil {
+		if !contents.IsCSV() {
+			return fmt.Errorf("artifactContents.csv requires format csv")
+		}
+		if utf8.RuneCountInString(contents.CSV.Delimiter) > 1 {
+			return fmt.Errorf("artifactContents.csv.delimiter must be a single character")
+		}
+	}
+	if source.ConfigMap != nil && source.ConfigMap.Name == "" {
+		return fmt.Errorf("configMap.name is required")
+	}
+	return nil
+}
+
 func validateArguments(prefix string, arguments wfv1.Arguments, allowEmptyValues bool) error {
 	err := validateArgumentsFieldNames(prefix, arguments)
 	if err != nil {
@@ -1086,7 +1339,7 @@ func (tctx *templateValidationCtx) validateSteps(ctx context.Context, scope map[
 			}
 			resolvedTemplates[step.Name] = resolvedTmpl
 
-			err = validateHooks(step.Hooks, fmt.Sprintf("templates.%s.steps[%d].%s", tmpl.Name, i, step.Name))
+			err = validateHooks(step.Hooks, fmt.Sprintf("templates.%s.steps[%d].%s", tmpl.Name, i, step.Name), softTimeoutHook(resolvedTmpl))
 			if err != nil {
 				return err
 			}
@@ -1212,6 +1465,17 @@ func (tctx *templateValidationCtx) addOutputsToScope(ctx context.Context, tmpl *
 			}
 		}
 	}
+	for _, ctr := range tmpl.ContainerSet.GetGraph() {
+		if ctr.Outputs == nil {
+			continue
+		}
+		for _, param := range ctr.Outputs.Parameters {
+			scope[ref.ContainerOutputsParameterByName.Concretize(name, ctr.Name, param.Name)] = true
+		}
+		for _, art := range ctr.Outputs.Artifacts {
+			scope[ref.ContainerOutputsArtifactByName.Concretize(name, ctr.Name, art.Name)] = true
+		}
+	}
 	if aggregate {
 		switch tmpl.GetType() {
 		// Not that we don't also include TemplateTypeContainer here, even though it uses `outputs.result` it uses
@@ -1249,7 +1513,15 @@ func validateOutputs(scope map[string]any, globalParams map[string]string, tmpl
 
 	for _, art := range tmpl.Outputs.Artifacts {
 		artRef := fmt.Sprintf("outputs.artifacts.%s", art.Name)
-		if tmpl.IsLeaf() {
+		if tmpl.GetType() == wfv1.TemplateTypeHTTP {
+			// The artifact of an HTTP template is its response body
+			if len(tmpl.Outputs.Artifacts) > 1 {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputs.artifacts can only have one artifact, the response body, in http templates", tmpl.Name)
+			}
+			if art.Path != "" {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.path is not valid in http templates", tmpl.Name, artRef)
+			}
+		} else if tmpl.IsLeaf() {
 			err = art.CleanPath()
 			if err != nil {
 				return errors.Errorf(errors.CodeBadRequest, "error in templates.%s.%s: %s", tmpl.Name, artRef, err.Error())
@@ -1263,6 +1535,11 @@ func validateOutputs(scope map[string]any, globalParams map[string]string, tmpl
 				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.globalName: %s", tmpl.Name, artRef, errs[0])
 			}
 		}
+		if art.Archive != nil {
+			if err := validateArchiveStrategy(art.Archive); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.archive %s", tmpl.Name, artRef, err.Error())
+			}
+		}
 	}
 	for _, param := range tmpl.Outputs.Parameters {
 		paramRef := fmt.Sprintf("templates.%s.outputs.parameters.%s", tmpl.Name, param.Name)
@@ -1281,6 +1558,10 @@ func validateOutputs(scope map[string]any, globalParams map[string]string, tmpl
 				if param.ValueFrom.JQFilter == "" && param.ValueFrom.JSONPath == "" {
 					return errors.Errorf(errors.CodeBadRequest, "%s .jqFilter or jsonPath must be specified for %s templates", paramRef, tmplType)
 				}
+			case wfv1.TemplateTypeHTTP:
+				if param.ValueFrom.Expression == "" && param.ValueFrom.JSONPath == "" && param.ValueFrom.JQFilter == "" {
+					return errors.Errorf(errors.CodeBadRequest, "%s.expression, jsonPath or jqFilter must be specified for %s templates", paramRef, tmplType)
+				}
 			case wfv1.TemplateTypeDAG, wfv1.TemplateTypeSteps:
 				if param.ValueFrom.Parameter == "" && param.ValueFrom.Expression == "" {
 					return errors.Errorf(errors.CodeBadRequest, "%s.parameter or expression must be specified for %s templates", paramRef, tmplType)
@@ -1300,6 +1581,100 @@ func validateOutputs(scope map[string]any, globalParams map[string]string, tmpl
 	return nil
 }
 
+// validateContainerSetOutputs validates the outputs of the containers of a container set. Their artifacts are saved
+// alongside those of the template, so their names must be unique across the template and its containers.
+func validateContainerSetOutputs(scope map[string]any, globalParams map[string]string, tmpl *wfv1.Template, workflowTemplateValidation bool) error {
+	artifactNames := make(map[string]bool)
+	for _, art := range tmpl.Outputs.Artifacts {
+		artifactNames[art.Name] = true
+	}
+	for _, ctr := range tmpl.ContainerSet.GetGraph() {
+		if ctr.Outputs == nil {
+			continue
+		}
+		ctrRef := fmt.Sprintf("templates.%s.containerSet.containers.%s.outputs", tmpl.Name, ctr.Name)
+		if err := validateWorkflowFieldNames(ctr.Outputs.Parameters); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s.parameters %s", ctrRef, err.Error())
+		}
+		if err := validateWorkflowFieldNames(ctr.Outputs.Artifacts); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s.artifacts %s", ctrRef, err.Error())
+		}
+		outputBytes, err := json.Marshal(ctr.Outputs)
+		if err != nil {
+			return errors.InternalWrapError(err)
+		}
+		if err := resolveAllVariables(scope, globalParams, string(outputBytes), workflowTemplateValidation); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s %s", ctrRef, err.Error())
+		}
+		if ctr.Outputs.Result != nil || ctr.Outputs.ExitCode != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s can only have parameters and artifacts", ctrRef)
+		}
+		for _, param := range ctr.Outputs.Parameters {
+			paramRef := fmt.Sprintf("%s.parameters.%s", ctrRef, param.Name)
+			if param.ValueFrom == nil || param.ValueFrom.Path == "" {
+				return errors.Errorf(errors.CodeBadRequest, "%s.valueFrom.path must be specified", paramRef)
+			}
+			if err := validateOutputParameter(paramRef, &param); err != nil {
+				return err
+			}
+			if param.GlobalName != "" {
+				return errors.Errorf(errors.CodeBadRequest, "%s.globalName is not supported for the outputs of containers", paramRef)
+			}
+		}
+		for _, art := range ctr.Outputs.Artifacts {
+			artRef := fmt.Sprintf("%s.artifacts.%s", ctrRef, art.Name)
+			if err := art.CleanPath(); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "error in %s: %s", artRef, err.Error())
+			}
+			if art.GlobalName != "" {
+				return errors.Errorf(errors.CodeBadRequest, "%s.globalName is not supported for the outputs of containers", artRef)
+			}
+			if art.Archive != nil {
+				if err := validateArchiveStrategy(art.Archive); err != nil {
+					return errors.Errorf(errors.CodeBadRequest, "%s.archive %s", artRef, err.Error())
+				}
+			}
+			if artifactNames[art.Name] {
+				return errors.Errorf(errors.CodeBadRequest, "%s is not unique within the template and its containers", artRef)
+			}
+			artifactNames[art.Name] = true
+		}
+	}
+	return nil
+}
+
+// validateArchiveStrategy verifies that at most one archive strategy is defined, with valid options
+func validateArchiveStrategy(strategy *wfv1.ArchiveStrategy) error {
+	count := 0
+	for _, set := range []bool{strategy.Tar != nil, strategy.None != nil, strategy.Zip != nil, strategy.Zstd != nil} {
+		if set {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("only one of tar, none, zip or zstd can be specified")
+	}
+	if strategy.Tar != nil {
+		switch strategy.Tar.Compression {
+		case "", wfv1.TarCompressionGzip, wfv1.TarCompressionNone:
+		default:
+			return fmt.Errorf("tar.compression must be one of gzip or none")
+		}
+		if strategy.Tar.IsUncompressed() && strategy.Tar.CompressionLevel != nil {
+			return fmt.Errorf("tar.compressionLevel cannot be specified for an uncompressed tarball")
+		}
+	}
+	if strategy.Zstd != nil {
+		if level := strategy.Zstd.GetCompressionLevel(); level < 1 || level > 22 {
+			return fmt.Errorf("zstd.compressionLevel must be between 1 and 22")
+		}
+		if strategy.Zstd.GetConcurrency() < 0 {
+			return fmt.Errorf("zstd.concurrency must not be negative")
+		}
+	}
+	return nil
+}
+
 // validateOutputParameter verifies that only one of valueFrom is defined in an output
 func validateOutputParameter(paramRef string, param *wfv1.Parameter) error {
 	if param.ValueFrom != nil && param.Value != nil {
@@ -1513,6 +1888,9 @@ func (tctx *templateValidationCtx) validateDAG(ctx context.Context, scope map[st
 		if task.Hooks != nil {
 			scope[varkeys.TasksNodeRef.Status.Concretize(task.Name)] = true
 		}
+		if err := validateHooks(task.Hooks, fmt.Sprintf("templates.%s.tasks.%s", tmpl.Name, task.Name), softTimeoutHook(resolvedTmpl)); err != nil {
+			return err
+		}
 		tctx.addOutputsToScope(ctx, resolvedTmpl, varkeys.TasksNodeRef, varkeys.TasksAggregate, task.Name, scope, false, false)
 		if task.HasExitHook() {
 			tctx.addOutputsToScope(ctx, resolvedTmpl, varkeys.TasksNodeRef, varkeys.TasksAggregate, task.Name, scope, false, false)
@@ -2,6 +2,7 @@ package validate
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -969,6 +970,84 @@ func TestInvalidOutputArtName(t *testing.T) {
 	require.Error(t, err, invalidErr)
 }
 
+var outputArtifactArchive = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: output-artifact-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+    outputs:
+      artifacts:
+      - name: checkpoint
+        path: /tmp/checkpoint
+        archive:
+%s
+`
+
+func TestOutputArtifactArchive(t *testing.T) {
+	for archive, wantErr := range map[string]string{
+		"          zstd: {compressionLevel: 19, concurrency: 4}":  "",
+		"          tar: {compression: none}":                      "",
+		"          zip: {}":                                       "",
+		"          zstd: {}\n          tar: {}":                   "only one of tar, none, zip or zstd can be specified",
+		"          zstd: {compressionLevel: 23}":                  "zstd.compressionLevel must be between 1 and 22",
+		"          zstd: {concurrency: -1}":                       "zstd.concurrency must not be negative",
+		"          tar: {compression: none, compressionLevel: 1}": "tar.compressionLevel cannot be specified for an uncompressed tarball",
+		"          tar: {compression: bzip2}":                     "tar.compression must be one of gzip or none",
+	} {
+		t.Run(archive, func(t *testing.T) {
+			err := validate(logging.TestContext(t.Context()), fmt.Sprintf(outputArtifactArchive, archive))
+			if wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, wantErr)
+			}
+		})
+	}
+}
+
+var dataSource = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: data-source-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    data:
+      source:
+%s
+      transformation:
+      - expression: "data"
+`
+
+func TestDataSource(t *testing.T) {
+	for source, wantErr := range map[string]string{
+		"        configMap: {name: my-cm}": "",
+		"        artifactContents: {format: csv, csv: {header: true, delimiter: ';'}, s3: {key: my.csv}}": "",
+		"        artifactContents: {s3: {key: my.json}}":                                                  "",
+		"        artifactContents: {format: csv, csv: {delimiter: ';;'}, s3: {key: my.csv}}":              "artifactContents.csv.delimiter must be a single character",
+		"        artifactContents: {format: yaml, csv: {header: true}, s3: {key: my.yaml}}":               "artifactContents.csv requires format csv",
+		"        configMap: {}": "configMap.name is required",
+		"        configMap: {name: my-cm}\n        artifactContents: {s3: {key: my.json}}": "must have exactly one of artifactPaths, artifactContents or configMap",
+	} {
+		t.Run(source, func(t *testing.T) {
+			err := validate(logging.TestContext(t.Context()), fmt.Sprintf(dataSource, source))
+			if wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, wantErr)
+			}
+		})
+	}
+}
+
 var invalidOutputParamNames = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -3024,6 +3103,71 @@ func TestCronWorkflowInvalidTimezoneRejected(t *testing.T) {
 	require.ErrorContains(t, err, `invalid timezone "Not/A_Real_Timezone"`)
 }
 
+func TestCronWorkflowInvalidCatchUpPolicyRejected(t *testing.T) {
+	cwf := &wfv1.CronWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cron-wf", Namespace: metav1.NamespaceDefault},
+		Spec: wfv1.CronWorkflowSpec{
+			Schedules: []string{"0 * * * *"},
+			WorkflowSpec: wfv1.WorkflowSpec{
+				Entrypoint: "whalesay",
+				Templates: []wfv1.Template{
+					{
+						Name: "whalesay",
+						Container: &corev1.Container{
+							Image:   "docker/whalesay:latest",
+							Command: []string{"cowsay"},
+						},
+					},
+				},
+			},
+		},
+	}
+	ctx := logging.TestContext(t.Context())
+
+	cwf.Spec.CatchUpPolicy = &wfv1.CatchUpPolicy{Strategy: wfv1.CatchUpAll, MaxCount: new(int32(5))}
+	require.NoError(t, CronWorkflow(ctx, wftmplGetter, cwftmplGetter, cwf, nil))
+
+	cwf.Spec.CatchUpPolicy = &wfv1.CatchUpPolicy{Strategy: "Some"}
+	require.ErrorContains(t, CronWorkflow(ctx, wftmplGetter, cwftmplGetter, cwf, nil), "'Some' is not a valid catchUpPolicy.strategy")
+
+	cwf.Spec.CatchUpPolicy = &wfv1.CatchUpPolicy{Strategy: wfv1.CatchUpAll, MaxCount: new(int32(0))}
+	require.ErrorContains(t, CronWorkflow(ctx, wftmplGetter, cwftmplGetter, cwf, nil), "catchUpPolicy.maxCount must be at least 1")
+}
+
+func TestCronWorkflowInvalidExclusionsRejected(t *testing.T) {
+	cwf := &wfv1.CronWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cron-wf", Namespace: metav1.NamespaceDefault},
+		Spec: wfv1.CronWorkflowSpec{
+			Schedules: []string{"0 * * * *"},
+			WorkflowSpec: wfv1.WorkflowSpec{
+				Entrypoint: "whalesay",
+				Templates: []wfv1.Template{
+					{
+						Name: "whalesay",
+						Container: &corev1.Container{
+							Image:   "docker/whalesay:latest",
+							Command: []string{"cowsay"},
+						},
+					},
+				},
+			},
+		},
+	}
+	ctx := logging.TestContext(t.Context())
+
+	cwf.Spec.Exclusions = &wfv1.CronWorkflowExclusions{
+		DateRanges: []wfv1.ExclusionDateRange{{Start: "2026-12-20", End: "2027-01-05"}},
+		Windows:    []wfv1.ExclusionWindow{{Schedule: "0 22 * * 5", Duration: "56h"}},
+	}
+	require.NoError(t, CronWorkflow(ctx, wftmplGetter, cwftmplGetter, cwf, nil))
+
+	cwf.Spec.Exclusions = &wfv1.CronWorkflowExclusions{DateRanges: []wfv1.ExclusionDateRange{{Start: "2026-12-20", End: "2026-12-01"}}}
+	require.ErrorContains(t, CronWorkflow(ctx, wftmplGetter, cwftmplGetter, cwf, nil), "exclusions.dateRanges[0] is invalid: date range end '2026-12-01' is before its start '2026-12-20'")
+
+	cwf.Spec.Exclusions = &wfv1.CronWorkflowExclusions{Windows: []wfv1.ExclusionWindow{{Schedule: "0 22 * * 5", Duration: "forever"}}}
+	require.ErrorContains(t, CronWorkflow(ctx, wftmplGetter, cwftmplGetter, cwf, nil), "exclusions.windows[0] is invalid: window duration 'forever' is invalid")
+}
+
 var invalidContainerSetDependencyNotFound = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -3372,6 +3516,76 @@ func TestStepsOutputParametersForContainerSet(t *testing.T) {
 	require.NoError(t, err)
 }
 
+var containerSetContainerOutputs = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: container-outputs-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      steps:
+        - - name: build
+            template: build
+        - - name: print
+            template: print
+            arguments:
+              parameters:
+                - name: version
+                  value: "{{steps.build.containers.compile.outputs.parameters.version}}"
+              artifacts:
+                - name: binary
+                  from: "{{steps.build.containers.compile.outputs.artifacts.binary}}"
+    - name: build
+      containerSet:
+        containers:
+          - name: compile
+            image: argoproj/argosay:v2
+            outputs:
+              parameters:
+                - name: version
+                  valueFrom:
+                    path: /tmp/version
+              artifacts:
+                - name: binary
+                  path: /tmp/binary
+          - name: main
+            image: argoproj/argosay:v2
+            dependencies: [compile]
+    - name: print
+      inputs:
+        parameters:
+          - name: version
+        artifacts:
+          - name: binary
+            path: /tmp/binary
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestContainerSetContainerOutputs(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	require.NoError(t, validate(ctx, containerSetContainerOutputs))
+
+	t.Run("MissingPath", func(t *testing.T) {
+		err := validate(ctx, strings.Replace(containerSetContainerOutputs, "path: /tmp/version", "default: x", 1))
+		require.ErrorContains(t, err, "templates.build.containerSet.containers.compile.outputs.parameters.version.valueFrom.path must be specified")
+	})
+	t.Run("GlobalName", func(t *testing.T) {
+		err := validate(ctx, strings.Replace(containerSetContainerOutputs, "- name: version\n                  valueFrom:", "- name: version\n                  globalName: version\n                  valueFrom:", 1))
+		require.ErrorContains(t, err, "globalName is not supported for the outputs of containers")
+	})
+	t.Run("DuplicateArtifact", func(t *testing.T) {
+		err := validate(ctx, strings.Replace(containerSetContainerOutputs, "dependencies: [compile]", "dependencies: [compile]\n      outputs:\n        artifacts:\n          - name: binary\n            path: /tmp/other", 1))
+		require.ErrorContains(t, err, "is not unique within the template and its containers")
+	})
+	t.Run("UnknownContainer", func(t *testing.T) {
+		err := validate(ctx, strings.Replace(containerSetContainerOutputs, "containers.compile.outputs.parameters.version", "containers.link.outputs.parameters.version", 1))
+		require.Error(t, err)
+	})
+}
+
 var globalAnnotationsAndLabels = `apiVersion: argoproj.io/v1alpha1
 kind: Workflow
 metadata:
@@ -3435,6 +3649,67 @@ func TestInitContainerHasName(t *testing.T) {
 	require.EqualError(t, err, "templates.main.tasks.spurious initContainers must all have container name")
 }
 
+var invalidResourceEscalation = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: resource-escalation-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    retryStrategy:
+      limit: 2
+      resourceEscalation:
+        memory:
+          factor: "%s"
+          max: 64Gi
+    container:
+      image: alpine:3.23
+      resources:
+        requests:
+          memory: 1Gi
+`
+
+func TestResourceEscalation(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	require.NoError(t, validate(ctx, fmt.Sprintf(invalidResourceEscalation, "1.5")))
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(invalidResourceEscalation, "0.5")), "retryStrategy.resourceEscalation.memory.factor must be at least 1")
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(invalidResourceEscalation, "double")), "retryStrategy.resourceEscalation.memory.factor must be a decimal")
+}
+
+var retryRules = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: retry-rules-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    retryStrategy:
+%s
+      rules:
+      - name: transient
+        expression: lastRetry.exitCode == "75"
+        limit: 10
+      - name: %s
+        expression: lastRetry.message matches "Evicted|preempt"
+        limit: 2
+        backoff:
+          duration: 1m
+    container:
+      image: alpine:3.23
+`
+
+func TestRetryRules(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	require.NoError(t, validate(ctx, fmt.Sprintf(retryRules, "", "evicted")))
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(retryRules, "      limit: 3", "evicted")), "retryStrategy.rules cannot be used with retryStrategy.limit")
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(retryRules, "", "transient")), `retryStrategy.rules[1].name "transient" is not unique`)
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(retryRules, "", `""`)), "retryStrategy.rules[1].name is required")
+}
+
 var nodeNamePlumbsCorrectly = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -4118,3 +4393,294 @@ func TestPodResourcesValidation(t *testing.T) {
 	err = validate(ctx, podResourcesValid)
 	require.NoError(t, err)
 }
+
+var memoizeCacheTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: memoize-cache-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    memoize:
+      key: my-key
+      cache:
+%s
+    container:
+      image: alpine:3.23
+`
+
+func TestMemoizeCacheValidation(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	for cache, expectedErr := range map[string]string{
+		"        configMap:\n          name: my-cache":                                             "",
+		"        database:\n          name: my-cache":                                              "",
+		"        artifact:\n          name: my-cache":                                              "",
+		"        database:\n          name: my-cache\n        artifact:\n          name: my-cache": "templates.main.memoize.cache must have exactly one of configMap, database or artifact set",
+		"        database:\n          name: \"\"":                                                  "templates.main.memoize.cache name is required",
+	} {
+		err := validate(ctx, fmt.Sprintf(memoizeCacheTemplate, cache))
+		if expectedErr == "" {
+			require.NoError(t, err)
+		} else {
+			require.ErrorContains(t, err, expectedErr)
+		}
+	}
+}
+
+var leaseDurationTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: lease-duration-
+spec:
+  entrypoint: main
+  synchronization:
+    mutexes:
+    - name: workflow
+      leaseDuration: 1h
+  templates:
+  - name: main
+    synchronization:
+      mutexes:
+      - name: my-mutex
+        leaseDuration: %s
+      semaphores:
+      - configMapKeyRef:
+          name: my-config
+          key: template
+        leaseDuration: %s
+    container:
+      image: alpine:3.23
+`
+
+func TestLeaseDurationValidation(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	tests := []struct {
+		mutexLease     string
+		semaphoreLease string
+		expectedErr    string
+	}{
+		{"30m", "10s", ""},
+		{"forever", "10s", `templates.main.synchronization.mutexes[0] invalid leaseDuration "forever"`},
+		{"30m", "0s", `templates.main.synchronization.semaphores[0] leaseDuration "0s" must be positive`},
+	}
+	for _, tt := range tests {
+		err := validate(ctx, fmt.Sprintf(leaseDurationTemplate, tt.mutexLease, tt.semaphoreLease))
+		if tt.expectedErr == "" {
+			require.NoError(t, err)
+		} else {
+			require.ErrorContains(t, err, tt.expectedErr)
+		}
+	}
+}
+
+var httpOutputsTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: http-outputs-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    http:
+      url: https://example.com/api
+    outputs:
+%s
+`
+
+func TestHTTPTemplateOutputs(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	tests := []struct {
+		name        string
+		outputs     string
+		expectedErr string
+	}{
+		{"Parameters", `
+      parameters:
+      - name: id
+        valueFrom:
+          jsonPath: $.id
+      - name: status
+        valueFrom:
+          expression: response.statusCode`, ""},
+		{"Artifact", `
+      artifacts:
+      - name: body`, ""},
+		{"ParameterPath", `
+      parameters:
+      - name: id
+        valueFrom:
+          path: /tmp/id`, "templates.main.outputs.parameters.id.expression, jsonPath or jqFilter must be specified for HTTP templates"},
+		{"ArtifactPath", `
+      artifacts:
+      - name: body
+        path: /tmp/body`, "templates.main.outputs.artifacts.body.path is not valid in http templates"},
+		{"TwoArtifacts", `
+      artifacts:
+      - name: body
+      - name: copy`, "templates.main.outputs.artifacts can only have one artifact, the response body, in http templates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(ctx, fmt.Sprintf(httpOutputsTemplate, tt.outputs))
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+var httpPollTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: http-poll-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    http:
+      url: https://example.com/api/jobs
+      method: POST
+      poll:
+        urlExpression: response.headers["Location"][0]
+%s
+`
+
+func TestHTTPTemplatePoll(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	err := validate(ctx, fmt.Sprintf(httpPollTemplate, `        completionCondition: response.statusCode == 200`))
+	require.NoError(t, err)
+	err = validate(ctx, fmt.Sprintf(httpPollTemplate, ""))
+	require.EqualError(t, err, "templates.main.http.poll.completionCondition is required")
+}
+
+var httpRetryTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: http-retry-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    http:
+      url: https://example.com/api
+      retry:
+%s
+`
+
+func TestHTTPTemplateRetry(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	err := validate(ctx, fmt.Sprintf(httpRetryTemplate, `        limit: 5
+        statusCodes: [429, 503]
+        backoff:
+          duration: 2s`))
+	require.NoError(t, err)
+	err = validate(ctx, fmt.Sprintf(httpRetryTemplate, `        limit: -1`))
+	require.EqualError(t, err, "templates.main.http.retry limit must not be negative")
+	err = validate(ctx, fmt.Sprintf(httpRetryTemplate, `        statusCodes: [5030]`))
+	require.EqualError(t, err, "templates.main.http.retry statusCodes has invalid status code 5030")
+}
+
+var httpAuthTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: http-auth-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    http:
+      url: https://example.com/api
+      caCertSecret:
+        name: api
+        key: ca.crt
+      auth:
+%s
+`
+
+func TestHTTPTemplateAuth(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	err := validate(ctx, fmt.Sprintf(httpAuthTemplate, `        clientCert:
+          clientCertSecret: {name: api, key: tls.crt}
+          clientKeySecret: {name: api, key: tls.key}
+        oauth2:
+          clientIDSecret: {name: api, key: client-id}
+          clientSecretSecret: {name: api, key: client-secret}
+          tokenURLSecret: {name: api, key: token-url}`))
+	require.NoError(t, err)
+	err = validate(ctx, fmt.Sprintf(httpAuthTemplate, `        clientCert:
+          clientCertSecret: {name: api, key: tls.crt}`))
+	require.EqualError(t, err, "templates.main.http.auth clientCert must have both clientCertSecret and clientKeySecret")
+	err = validate(ctx, fmt.Sprintf(httpAuthTemplate, `        oauth2:
+          clientIDSecret: {name: api, key: client-id}`))
+	require.EqualError(t, err, "templates.main.http.auth oauth2 must have clientIDSecret, clientSecretSecret and tokenURLSecret")
+}
+
+var timeoutPolicy = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: timeout-policy-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      steps:
+        - - name: train
+            template: train
+            hooks:
+              %s
+    - name: train
+      timeoutPolicy:
+        softTimeout: %s
+        hook: checkpoint
+        %s
+      container:
+        image: alpine:latest
+    - name: checkpoint
+      container:
+        image: alpine:latest
+`
+
+func TestTimeoutPolicy(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	require.NoError(t, validate(ctx, fmt.Sprintf(timeoutPolicy, "checkpoint: {template: checkpoint}", "1h", "terminate: true")))
+	require.NoError(t, validate(ctx, fmt.Sprintf(timeoutPolicy, "checkpoint: {template: checkpoint}", "1h", "result: Succeeded")))
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(timeoutPolicy, "checkpoint: {template: checkpoint}", "soon", "")), "train has invalid duration format in timeoutPolicy.softTimeout")
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(timeoutPolicy, "checkpoint: {template: checkpoint}", "1h", "gracePeriod: 1m")), "train timeoutPolicy.gracePeriod requires timeoutPolicy.terminate")
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(timeoutPolicy, "checkpoint: {template: checkpoint}", "1h", "result: Error")), "train timeoutPolicy.result must be one of Continue, Succeeded or Failed")
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(timeoutPolicy, "other: {template: checkpoint, expression: 'true'}", "1h", "")), "templates.main.steps[0].train.hooks.checkpoint is required by the timeoutPolicy of its template")
+	require.ErrorContains(t, validate(ctx, fmt.Sprintf(timeoutPolicy, "other: {template: checkpoint}", "1h", "")), "templates.main.steps[0].train.other Expression required")
+}
+
+var timeoutPolicyOnSteps = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: timeout-policy-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      timeoutPolicy:
+        softTimeout: 1h
+      steps:
+        - - name: train
+            template: train
+    - name: train
+      container:
+        image: alpine:latest
+`
+
+func TestTimeoutPolicyOnSteps(t *testing.T) {
+	err := validate(logging.TestContext(t.Context()), timeoutPolicyOnSteps)
+	require.ErrorContains(t, err, "Steps template doesn't support timeoutPolicy field")
+}